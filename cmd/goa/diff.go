@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"goa.design/goa/v3/codegen/diff"
+)
+
+// compareDesigns evaluates the designs in the packages with import paths from
+// and to and prints the changes made to the first design by the second that
+// break existing clients or servers. It returns true if there are any.
+func compareDesigns(from, to string, jsonOutput, debug bool) (bool, error) {
+	old, err := snapshotDesign(from, debug)
+	if err != nil {
+		return false, err
+	}
+	cur, err := snapshotDesign(to, debug)
+	if err != nil {
+		return false, err
+	}
+	changes := diff.Compare(old, cur)
+	if err := writeChanges(os.Stdout, changes, jsonOutput); err != nil {
+		return false, err
+	}
+	return len(changes) > 0, nil
+}
+
+// snapshotDesign evaluates the design in the package with the given import
+// path and returns its wire contract.
+func snapshotDesign(path string, debug bool) (*diff.Design, error) {
+	out, err := runDesign("diff", path, debug)
+	if err != nil {
+		return nil, err
+	}
+	var d diff.Design
+	if err := json.Unmarshal(out, &d); err != nil {
+		return nil, fmt.Errorf("%s: invalid design snapshot: %w", path, err)
+	}
+	return &d, nil
+}
+
+// writeChanges writes the given breaking changes to w, one per line or as a
+// JSON object if jsonOutput is true.
+func writeChanges(w io.Writer, changes []*diff.Change, jsonOutput bool) error {
+	if jsonOutput {
+		if changes == nil {
+			changes = []*diff.Change{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]any{"changes": changes})
+	}
+	for _, c := range changes {
+		if _, err := fmt.Fprintln(w, c.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
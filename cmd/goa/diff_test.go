@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen/diff"
+)
+
+func TestWriteChanges(t *testing.T) {
+	changes := []*diff.Change{
+		{Kind: diff.MethodRemoved, Service: "store", Method: "list", Message: "method removed"},
+		{Kind: diff.Required, Service: "store", Method: "show", Path: "payload.id", Message: "attribute became required"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeChanges(&buf, changes, false))
+	assert.Equal(t, "store.list: method removed (method_removed)\nstore.show: payload.id: attribute became required (required)\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeChanges(&buf, changes[1:], true))
+	assert.JSONEq(t, `{"changes": [{"kind": "required", "service": "store", "method": "show", "path": "payload.id", "message": "attribute became required"}]}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeChanges(&buf, nil, true))
+	assert.JSONEq(t, `{"changes": []}`, buf.String())
+}
@@ -18,6 +18,11 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
+// modeFlags lists the flags of the gen command that do not change the
+// generated code and are thus omitted from the command line recorded in the
+// generated file headers.
+var modeFlags = map[string]bool{"watch": true, "dry-run": true, "check": true}
+
 // Generator is the code generation management data structure.
 type Generator struct {
 	// Command is the name of the command to run.
@@ -33,6 +38,15 @@ type Generator struct {
 	// DesignVersion is either 2 or 3.
 	DesignVersion int
 
+	// Incremental causes the generator to only write the files whose
+	// content changed, see generator.GenerateChanged.
+	Incremental bool
+
+	// DryRun causes the generator to print the differences between the
+	// generated files and the files on disk as JSON instead of writing the
+	// files, see generator.Diff.
+	DryRun bool
+
 	// bin is the filename of the generated generator.
 	bin string
 
@@ -41,6 +55,10 @@ type Generator struct {
 
 	// hasVendorDirectory is a flag to indicate whether the project uses vendoring
 	hasVendorDirectory bool
+
+	// fetched is true once the dependencies of the generator have been
+	// added to go.sum.
+	fetched bool
 }
 
 // NewGenerator creates a Generator.
@@ -119,22 +137,30 @@ func (g *Generator) Write(_ bool) error {
 			"Command":       g.Command,
 			"CleanupDirs":   cleanupDirs(g.Command, g.Output),
 			"DesignVersion": g.DesignVersion,
+			"GenFlags":      g.Command == "gen" && g.DesignVersion > 2,
 		}
 		ver := ""
 		if g.DesignVersion > 2 {
 			ver = "v" + strconv.Itoa(g.DesignVersion) + "/"
 		}
 		imports := []*codegen.ImportSpec{
+			codegen.SimpleImport("bufio"),
+			codegen.SimpleImport("encoding/json"),
 			codegen.SimpleImport("flag"),
 			codegen.SimpleImport("fmt"),
 			codegen.SimpleImport("os"),
+			codegen.SimpleImport("os/signal"),
 			codegen.SimpleImport("path/filepath"),
 			codegen.SimpleImport("sort"),
 			codegen.SimpleImport("strconv"),
 			codegen.SimpleImport("strings"),
+			codegen.SimpleImport("syscall"),
 			codegen.SimpleImport("goa.design/goa/" + ver + "codegen"),
+			codegen.SimpleImport("goa.design/goa/" + ver + "codegen/diff"),
 			codegen.SimpleImport("goa.design/goa/" + ver + "codegen/generator"),
+			codegen.SimpleImport("goa.design/goa/" + ver + "codegen/lint"),
 			codegen.SimpleImport("goa.design/goa/" + ver + "eval"),
+			codegen.SimpleImport("goa.design/goa/" + ver + "expr"),
 			codegen.NewImport("goa", "goa.design/goa/"+ver+"pkg"),
 			codegen.NewImport("_", g.DesignPath),
 		}
@@ -153,7 +179,8 @@ func (g *Generator) Write(_ bool) error {
 	return err
 }
 
-// Compile compiles the generator.
+// Compile compiles the generator. Compile may be called again after the
+// design package changed to rebuild the generator.
 func (g *Generator) Compile() error {
 	// We first need to go get the generated package to make sure that all
 	// dependencies are added to go.sum prior to compiling.
@@ -164,10 +191,11 @@ func (g *Generator) Compile() error {
 	if len(pkgs) != 1 {
 		return fmt.Errorf("expected to find one package in %s", g.tmpDir)
 	}
-	if !g.hasVendorDirectory {
+	if !g.hasVendorDirectory && !g.fetched {
 		if err := g.runGoCmd("get", pkgs[0].PkgPath); err != nil {
 			return err
 		}
+		g.fetched = true
 	}
 
 	err = g.runGoCmd("build", "-o", g.bin)
@@ -184,23 +212,39 @@ func (g *Generator) Compile() error {
 
 // Run runs the compiled binary and return the output lines.
 func (g *Generator) Run() ([]string, error) {
+	cmd := exec.Command(filepath.Join(g.tmpDir, g.bin), g.args()...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, string(out))
+	}
+	res := strings.Split(string(out), "\n")
+	for (len(res) > 0) && (res[len(res)-1] == "") {
+		res = res[:len(res)-1]
+	}
+	return res, nil
+}
+
+// args returns the command line arguments of the compiled binary.
+func (g *Generator) args() []string {
 	var cmdl string
 	{
-		args := make([]string, len(os.Args)-1)
+		var args []string
 		gopaths := filepath.SplitList(os.Getenv("GOPATH"))
 		if len(gopaths) == 0 {
 			gopaths = []string{build.Default.GOPATH}
 		}
-		for i, a := range os.Args[1:] {
+		for _, a := range os.Args[1:] {
+			if modeFlags[strings.TrimLeft(a, "-")] {
+				// Keep the generated code identical in all modes.
+				continue
+			}
 			for _, p := range gopaths {
 				if strings.HasPrefix(a, p) {
-					args[i] = strings.Replace(a, p, "$(GOPATH)", 1)
+					a = strings.Replace(a, p, "$(GOPATH)", 1)
 					break
 				}
 			}
-			if args[i] == "" {
-				args[i] = a
-			}
+			args = append(args, a)
 		}
 		cmdl = " " + strings.Join(args, " ")
 		rawcmd := filepath.Base(os.Args[0])
@@ -211,16 +255,13 @@ func (g *Generator) Run() ([]string, error) {
 	}
 
 	args := []string{"--version=" + strconv.Itoa(g.DesignVersion), "--output=" + g.Output, "--cmd=" + cmdl}
-	cmd := exec.Command(filepath.Join(g.tmpDir, g.bin), args...)
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("%w\n%s", err, string(out))
+	if g.Incremental {
+		args = append(args, "--incremental")
 	}
-	res := strings.Split(string(out), "\n")
-	for (len(res) > 0) && (res[len(res)-1] == "") {
-		res = res[:len(res)-1]
+	if g.DryRun {
+		args = append(args, "--dry-run")
 	}
-	return res, nil
+	return args
 }
 
 // Remove deletes the package files.
@@ -291,6 +332,11 @@ const mainT = `func main() {
 		out     = flag.String("output", "", "")
 		version = flag.String("version", "", "")
 		cmdl    = flag.String("cmd", "", "")
+{{- if .GenFlags }}
+		incremental = flag.Bool("incremental", false, "")
+		dryRun      = flag.Bool("dry-run", false, "")
+		serve       = flag.Bool("serve", false, "")
+{{- end }}
 		ver int
 	)
 	{
@@ -320,6 +366,77 @@ const mainT = `func main() {
 	if err := eval.RunDSL(); err != nil {
 		fail(err.Error())
 	}
+{{- if eq .Command "diff" }}
+	if err := json.NewEncoder(os.Stdout).Encode(diff.Snapshot(expr.Root)); err != nil {
+		fail(err.Error())
+	}
+{{- else if eq .Command "lint" }}
+	rules := lint.Rules()
+	names := make([]string, len(rules))
+	for i, r := range rules {
+		names[i] = r.Name
+	}
+	report := map[string]any{"rules": names, "issues": lint.Run(expr.Root, rules)}
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		fail(err.Error())
+	}
+{{- else if .GenFlags }}
+	codegen.DesignVersion = ver
+	if *serve {
+		// The design cannot change while the generator runs so render the
+		// files once then update the output directory each time a line is
+		// read on stdin and write the result as JSON on stdout until stdin
+		// is closed. The goa command closes stdin when it exits.
+		signal.Ignore(os.Interrupt, syscall.SIGTERM)
+		rendered, rerr := generator.Render(*out, {{ printf "%q" .Command }})
+		if rerr == nil {
+			defer rendered.Remove()
+		}
+		enc := json.NewEncoder(os.Stdout)
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			res := make(map[string]any)
+			if rerr != nil {
+				res["error"] = rerr.Error()
+			} else if outputs, err := rendered.Sync(); err != nil {
+				res["error"] = err.Error()
+			} else {
+				res["files"] = outputs
+			}
+			if err := enc.Encode(res); err != nil {
+				fail(err.Error())
+			}
+		}
+		return
+	}
+	if *dryRun {
+		diffs, err := generator.Diff(*out, {{ printf "%q" .Command }})
+		if err != nil {
+			fail(err.Error())
+		}
+		if err := json.NewEncoder(os.Stdout).Encode(diffs); err != nil {
+			fail(err.Error())
+		}
+		return
+	}
+	if !*incremental {
+	{{- range .CleanupDirs }}
+		if err := os.RemoveAll({{ printf "%q" . }}); err != nil {
+			fail(err.Error())
+		}
+	{{- end }}
+	}
+	generate := generator.Generate
+	if *incremental {
+		generate = generator.GenerateChanged
+	}
+	outputs, err := generate(*out, {{ printf "%q" .Command }})
+	if err != nil {
+		fail(err.Error())
+	}
+
+	fmt.Println(strings.Join(outputs, "\n"))
+{{- else }}
 {{- range .CleanupDirs }}
 	if err := os.RemoveAll({{ printf "%q" . }}); err != nil {
 		fail(err.Error())
@@ -334,6 +451,7 @@ const mainT = `func main() {
 	}
 
 	fmt.Println(strings.Join(outputs, "\n"))
+{{- end }}
 }
 
 func fail(msg string, vals ...any) {
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"goa.design/goa/v3/codegen/importer"
+)
+
+// importDesign writes the design package produced from the API description
+// contained in file to the output directory. format is the description
+// format, e.g. "openapi" or "proto".
+func importDesign(format, file, output string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	dir, err := filepath.Abs(output)
+	if err != nil {
+		return err
+	}
+	pkg := packageName(filepath.Base(dir))
+
+	var src []byte
+	switch format {
+	case "openapi":
+		src, err = importer.OpenAPI(data, pkg, filepath.Base(file))
+	case "proto":
+		src, err = importer.Proto(data, pkg, filepath.Base(file), protoLoader(file))
+	default:
+		return fmt.Errorf("unsupported import format %q, supported formats are: openapi, proto", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(output, "design.go")
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	if err := os.MkdirAll(output, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
+
+// protoLoader returns a function that reads the files imported by the given
+// proto file. Import paths are resolved relative to the directory containing
+// the file and its parent directories, then relative to the current working
+// directory.
+func protoLoader(file string) func(string) ([]byte, error) {
+	return func(path string) ([]byte, error) {
+		dir, err := filepath.Abs(filepath.Dir(file))
+		if err != nil {
+			return nil, err
+		}
+		for {
+			if data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path))); err == nil {
+				return data, nil
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+		return os.ReadFile(filepath.FromSlash(path))
+	}
+}
+
+// packageName returns a valid Go package name derived from the given
+// directory name.
+func packageName(dir string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, dir)
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		return "design"
+	}
+	return name
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportDesign(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "spec.json")
+	require.NoError(t, os.WriteFile(spec, []byte(`{"openapi": "3.0.3", "info": {"title": "Calc", "version": "1.0"}, "paths": {}}`), 0644))
+	output := filepath.Join(dir, "calc-design")
+
+	require.NoError(t, importDesign("openapi", spec, output))
+
+	src, err := os.ReadFile(filepath.Join(output, "design.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "package calcdesign")
+	assert.Contains(t, string(src), `var _ = API("calc", func() {`)
+
+	err = importDesign("openapi", spec, output)
+	assert.ErrorContains(t, err, "already exists")
+
+	err = importDesign("raml", spec, filepath.Join(dir, "other"))
+	assert.ErrorContains(t, err, "unsupported import format")
+}
+
+func TestImportDesignProto(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "pets", "v1"), 0755))
+	file := filepath.Join(dir, "pets", "v1", "pets.proto")
+	require.NoError(t, os.WriteFile(file, []byte(`syntax = "proto3";
+package pets.v1;
+import "pets/v1/owner.proto";
+service Pets { rpc GetOwner(Owner) returns (Owner); }`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets", "v1", "owner.proto"), []byte(`syntax = "proto3";
+package pets.v1;
+message Owner { string name = 1; }`), 0644))
+	output := filepath.Join(dir, "design")
+
+	require.NoError(t, importDesign("proto", file, output))
+
+	src, err := os.ReadFile(filepath.Join(output, "design.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `var _ = Service("pets", func() {`)
+	assert.Contains(t, string(src), `var Owner = Type("Owner", func() {`)
+}
+
+func TestPackageName(t *testing.T) {
+	cases := map[string]string{
+		"design":   "design",
+		"Calc-API": "calcapi",
+		"2fa":      "design",
+		"---":      "design",
+	}
+	for dir, expected := range cases {
+		assert.Equal(t, expected, packageName(dir), dir)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"goa.design/goa/v3/codegen/lint"
+)
+
+type (
+	// lintConfig is the content of the lint command configuration file.
+	lintConfig struct {
+		// Enable lists the names of the rules to run, all the rules
+		// run if empty.
+		Enable []string `yaml:"enable"`
+		// Disable lists the names of the rules that must not run.
+		Disable []string `yaml:"disable"`
+	}
+
+	// lintReport is the output of the generator run by the lint command.
+	lintReport struct {
+		// Rules lists the names of the rules run on the design.
+		Rules []string `json:"rules"`
+		// Issues lists the issues found in the design.
+		Issues []*lint.Issue `json:"issues"`
+	}
+)
+
+// lintDesign evaluates the design in the package with the given import path,
+// runs the lint rules on it and prints the issues found by the rules enabled
+// by the configuration file config if not empty. It returns true if there are
+// any issues.
+func lintDesign(path, config string, jsonOutput, debug bool) (bool, error) {
+	var conf lintConfig
+	if config != "" {
+		data, err := os.ReadFile(config)
+		if err != nil {
+			return false, err
+		}
+		if err := yaml.Unmarshal(data, &conf); err != nil {
+			return false, fmt.Errorf("%s: %w", config, err)
+		}
+	}
+	out, err := runDesign("lint", path, debug)
+	if err != nil {
+		return false, err
+	}
+	var report lintReport
+	if err := json.Unmarshal(out, &report); err != nil {
+		return false, fmt.Errorf("%s: invalid lint report: %w", path, err)
+	}
+	issues, err := filterIssues(report, conf)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", config, err)
+	}
+	if err := writeIssues(os.Stdout, issues, jsonOutput); err != nil {
+		return false, err
+	}
+	return len(issues) > 0, nil
+}
+
+// filterIssues returns the issues found by the rules enabled by conf. It
+// returns an error if conf refers to unknown rules.
+func filterIssues(report lintReport, conf lintConfig) ([]*lint.Issue, error) {
+	for _, name := range append(conf.Enable, conf.Disable...) {
+		if !slices.Contains(report.Rules, name) {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+	}
+	var issues []*lint.Issue
+	for _, i := range report.Issues {
+		if len(conf.Enable) > 0 && !slices.Contains(conf.Enable, i.Rule) {
+			continue
+		}
+		if slices.Contains(conf.Disable, i.Rule) {
+			continue
+		}
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
+
+// writeIssues writes the given issues to w, one per line or as a JSON object
+// if jsonOutput is true.
+func writeIssues(w io.Writer, issues []*lint.Issue, jsonOutput bool) error {
+	if jsonOutput {
+		if issues == nil {
+			issues = []*lint.Issue{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]any{"issues": issues})
+	}
+	for _, i := range issues {
+		if _, err := fmt.Fprintln(w, i.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen/lint"
+)
+
+func TestFilterIssues(t *testing.T) {
+	report := lintReport{
+		Rules: []string{"description", "method-errors"},
+		Issues: []*lint.Issue{
+			{Rule: "description", Message: "no description"},
+			{Rule: "method-errors", Message: "no error"},
+		},
+	}
+	cases := []struct {
+		Name     string
+		Config   lintConfig
+		Expected []string
+		Error    string
+	}{
+		{"all", lintConfig{}, []string{"description", "method-errors"}, ""},
+		{"enable", lintConfig{Enable: []string{"method-errors"}}, []string{"method-errors"}, ""},
+		{"disable", lintConfig{Disable: []string{"method-errors"}}, []string{"description"}, ""},
+		{"unknown", lintConfig{Disable: []string{"unknown"}}, nil, `unknown lint rule "unknown"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			issues, err := filterIssues(report, c.Config)
+			if c.Error != "" {
+				assert.EqualError(t, err, c.Error)
+				return
+			}
+			require.NoError(t, err)
+			var rules []string
+			for _, i := range issues {
+				rules = append(rules, i.Rule)
+			}
+			assert.Equal(t, c.Expected, rules)
+		})
+	}
+}
+
+func TestWriteIssues(t *testing.T) {
+	issues := []*lint.Issue{
+		{Rule: "description", Message: `service "store" has no description`, File: "design/design.go", Line: 12},
+		{Rule: "method-errors", Message: `method "store.list" does not define any error`},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeIssues(&buf, issues, false))
+	assert.Equal(t, "design/design.go:12: service \"store\" has no description (description)\nmethod \"store.list\" does not define any error (method-errors)\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeIssues(&buf, issues[:1], true))
+	assert.JSONEq(t, `{"issues": [{"rule": "description", "message": "service \"store\" has no description", "file": "design/design.go", "line": 12}]}`, buf.String())
+}
@@ -15,7 +15,10 @@ func main() {
 	var (
 		cmd    string
 		path   string
+		other  string
+		format string
 		offset int
+		output = "."
 	)
 	if len(os.Args) == 1 {
 		usage()
@@ -34,14 +37,41 @@ func main() {
 		cmd = os.Args[1]
 		path = os.Args[2]
 		offset = 2
+	case "import":
+		if len(os.Args) < 4 {
+			usage()
+			return
+		}
+		cmd = os.Args[1]
+		format = os.Args[2]
+		path = os.Args[3]
+		offset = 3
+		output = "design"
+	case "lint":
+		if len(os.Args) == 2 {
+			usage()
+			return
+		}
+		cmd = os.Args[1]
+		path = os.Args[2]
+		offset = 2
+	case "diff":
+		if len(os.Args) < 4 {
+			usage()
+			return
+		}
+		cmd = os.Args[1]
+		path = os.Args[2]
+		other = os.Args[3]
+		offset = 3
 	default:
 		usage()
 		return
 	}
 
 	var (
-		output = "."
-		debug  bool
+		debug, jsonOutput, watch, dryRun, check bool
+		config                                  string
 	)
 	if len(os.Args) > offset+1 {
 		var (
@@ -50,6 +80,11 @@ func main() {
 			out  = fset.String("output", output, "output `directory`")
 		)
 		fset.BoolVar(&debug, "debug", false, "Print debug information")
+		fset.BoolVar(&jsonOutput, "json", false, "Print the breaking changes or lint issues as JSON")
+		fset.StringVar(&config, "config", "", "lint configuration `file`")
+		fset.BoolVar(&watch, "watch", false, "Regenerate the code when the design changes")
+		fset.BoolVar(&dryRun, "dry-run", false, "Print the changes to the generated code without writing it")
+		fset.BoolVar(&check, "check", false, "Exit with status 2 if the generated code is stale")
 
 		fset.Usage = usage
 		if err := fset.Parse(os.Args[offset+1:]); err != nil {
@@ -63,6 +98,66 @@ func main() {
 		}
 	}
 
+	if cmd == "import" {
+		if err := imp(format, path, output); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	if cmd == "lint" {
+		found, err := lnt(path, config, jsonOutput, debug)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		if found {
+			os.Exit(2)
+		}
+		return
+	}
+
+	if cmd == "diff" {
+		breaking, err := cmp(path, other, jsonOutput, debug)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		if breaking {
+			os.Exit(2)
+		}
+		return
+	}
+
+	if dryRun || check {
+		if cmd != "gen" || watch {
+			fmt.Fprintln(os.Stderr, "the dry-run and check flags are only supported by the gen command without watch")
+			os.Exit(1)
+		}
+		stale, err := prv(path, output, dryRun, debug)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		if stale && check {
+			os.Exit(2)
+		}
+		return
+	}
+
+	if watch {
+		if cmd != "gen" {
+			fmt.Fprintln(os.Stderr, "the watch flag is only supported by the gen command")
+			os.Exit(1)
+		}
+		if err := wch(path, output, debug); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := gen(cmd, path, output, debug); err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
@@ -73,6 +168,11 @@ func main() {
 var (
 	usage = help
 	gen   = generate
+	imp   = importDesign
+	cmp   = compareDesigns
+	lnt   = lintDesign
+	wch   = watchDesign
+	prv   = previewDesign
 )
 
 func generate(cmd, path, output string, debug bool) error {
@@ -112,13 +212,45 @@ fail:
 	return err
 }
 
+// runDesign compiles and runs a generator that evaluates the design in the
+// package with the given import path and executes the given command on it.
+// It returns the output of the generator. The design must use Goa v3.
+func runDesign(cmd, path string, debug bool) ([]byte, error) {
+	if _, err := build.Import(path, ".", 0); err != nil {
+		return nil, err
+	}
+	tmp := NewGenerator(cmd, path, ".")
+	if tmp.DesignVersion < 3 {
+		return nil, fmt.Errorf("%s: the %s command requires a Goa v3 design", path, cmd)
+	}
+	defer func() {
+		if !debug {
+			tmp.Remove()
+		}
+	}()
+	if err := tmp.Write(debug); err != nil {
+		return nil, err
+	}
+	if err := tmp.Compile(); err != nil {
+		return nil, err
+	}
+	lines, err := tmp.Run()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
 func help() {
 	fmt.Fprint(os.Stderr, `goa is the code generation tool for the Goa framework.
 Learn more at https://goa.design.
 
 Usage:
-  goa gen PACKAGE [--output DIRECTORY] [--debug]
+  goa gen PACKAGE [--output DIRECTORY] [--watch|--dry-run|--check] [--debug]
   goa example PACKAGE [--output DIRECTORY] [--debug]
+  goa import openapi|proto FILE [--output DIRECTORY]
+  goa diff OLD_PACKAGE NEW_PACKAGE [--json] [--debug]
+  goa lint PACKAGE [--config FILE] [--json] [--debug]
   goa version
 
 Commands:
@@ -126,16 +258,60 @@ Commands:
         Generate service interfaces, endpoints, transport code and OpenAPI spec.
   example
         Generate example server and client tool.
+  import
+        Generate a design package from an OpenAPI v3 specification or from
+        the services defined in a proto3 file.
+  diff
+        Report the changes made to the design in OLD_PACKAGE by the design in
+        NEW_PACKAGE that break existing clients or servers. Exits with status 2
+        if breaking changes are found.
+  lint
+        Check the design against the lint rules and report the issues with
+        the position of the DSL function calls. Exits with status 2 if issues
+        are found. Rules are disabled for a design element and its children
+        with Meta("lint:disable", RULE...).
   version
         Print version information.
 
 Args:
   PACKAGE
         Go import path to design package
+  FILE
+        Path to the OpenAPI JSON or YAML file or to the proto file to import
+  OLD_PACKAGE, NEW_PACKAGE
+        Go import paths to the design packages to compare
 
 Flags:
   -o, -output DIRECTORY
-        output directory, defaults to the current working directory
+        output directory, defaults to the current working directory or to
+        "design" for the import command
+
+  -config FILE
+        YAML file that configures the lint command, it may list the names of
+        the rules to run under "enable" and of the rules to skip under
+        "disable"
+
+  -watch
+        Keep running after generating the code and regenerate it each time
+        the Go files of the design package or of the packages of the same
+        module it imports change, only the files whose content changed are
+        written. The generator process stays alive between runs and is only
+        rebuilt when the design changes since the design is compiled into it,
+        changes made to the generated files are reverted without rebuilding
+        it (gen command only)
+
+  -dry-run
+        Print the unified diff between the generated code and the files on
+        disk instead of writing the files (gen command only)
+
+  -check
+        Print the paths to the generated files that differ from the files on
+        disk and exit with status 2 if there are any, use with -dry-run to
+        print the diffs instead (gen command only)
+
+  -json
+        Print the breaking changes found by the diff command or the issues
+        found by the lint command as JSON
 
   -debug
         Print debug information (mainly intended for Goa developers)
@@ -143,6 +319,12 @@ Flags:
 Example:
 
   goa gen goa.design/examples/cellar/design -o gendir
+  goa gen goa.design/examples/cellar/design --watch
+  goa gen goa.design/examples/cellar/design --check
+  goa import openapi openapi.yaml -o design
+  goa import proto protos/pets/v1/pets.proto -o design
+  goa diff goa.design/examples/cellar/design/v1 goa.design/examples/cellar/design/v2
+  goa lint goa.design/examples/cellar/design --config lint.yaml
 
 `)
 }
@@ -75,3 +75,253 @@ func TestCmdLine(t *testing.T) {
 		}
 	}
 }
+
+func TestImportCmdLine(t *testing.T) {
+	var (
+		usageCalled          bool
+		format, path, output string
+	)
+
+	usage = func() { usageCalled = true }
+	imp = func(f, p, o string) error { format, path, output = f, p, o; return nil }
+	defer func() {
+		usage = help
+		imp = importDesign
+	}()
+
+	cases := map[string]struct {
+		CmdLine        string
+		ExpectedUsage  bool
+		ExpectedFormat string
+		ExpectedPath   string
+		ExpectedOutput string
+	}{
+		"import":         {"import openapi spec.yaml", false, "openapi", "spec.yaml", "design"},
+		"output":         {"import openapi spec.yaml -output dir", false, "openapi", "spec.yaml", "dir"},
+		"output short":   {"import openapi spec.yaml -o dir", false, "openapi", "spec.yaml", "dir"},
+		"proto":          {"import proto pets.proto -o dir", false, "proto", "pets.proto", "dir"},
+		"missing file":   {"import openapi", true, "", "", ""},
+		"missing format": {"import", true, "", "", ""},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		usageCalled = false
+		format, path, output = "", "", ""
+
+		main()
+
+		if usageCalled != c.ExpectedUsage {
+			t.Errorf("%s: Expected usage to be %v but got %v", k, c.ExpectedUsage, usageCalled)
+		}
+		if format != c.ExpectedFormat {
+			t.Errorf("%s: Expected format to be %s but got %s", k, c.ExpectedFormat, format)
+		}
+		if path != c.ExpectedPath {
+			t.Errorf("%s: Expected path to be %s but got %s", k, c.ExpectedPath, path)
+		}
+		if output != c.ExpectedOutput {
+			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestDiffCmdLine(t *testing.T) {
+	var (
+		usageCalled    bool
+		from, to       string
+		jsonOut, debug bool
+	)
+
+	usage = func() { usageCalled = true }
+	cmp = func(f, o string, j, d bool) (bool, error) { from, to, jsonOut, debug = f, o, j, d; return false, nil }
+	defer func() {
+		usage = help
+		cmp = compareDesigns
+	}()
+
+	cases := map[string]struct {
+		CmdLine       string
+		ExpectedUsage bool
+		ExpectedFrom  string
+		ExpectedTo    string
+		ExpectedJSON  bool
+		ExpectedDebug bool
+	}{
+		"diff":        {"diff old new", false, "old", "new", false, false},
+		"json":        {"diff old new -json", false, "old", "new", true, false},
+		"debug":       {"diff old new -json -debug", false, "old", "new", true, true},
+		"missing new": {"diff old", true, "", "", false, false},
+		"missing old": {"diff", true, "", "", false, false},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		usageCalled = false
+		from, to, jsonOut, debug = "", "", false, false
+
+		main()
+
+		if usageCalled != c.ExpectedUsage {
+			t.Errorf("%s: Expected usage to be %v but got %v", k, c.ExpectedUsage, usageCalled)
+		}
+		if from != c.ExpectedFrom {
+			t.Errorf("%s: Expected old package to be %s but got %s", k, c.ExpectedFrom, from)
+		}
+		if to != c.ExpectedTo {
+			t.Errorf("%s: Expected new package to be %s but got %s", k, c.ExpectedTo, to)
+		}
+		if jsonOut != c.ExpectedJSON {
+			t.Errorf("%s: Expected json to be %v but got %v", k, c.ExpectedJSON, jsonOut)
+		}
+		if debug != c.ExpectedDebug {
+			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
+		}
+	}
+}
+
+func TestLintCmdLine(t *testing.T) {
+	var (
+		usageCalled    bool
+		path, config   string
+		jsonOut, debug bool
+	)
+
+	usage = func() { usageCalled = true }
+	lnt = func(p, c string, j, d bool) (bool, error) { path, config, jsonOut, debug = p, c, j, d; return false, nil }
+	defer func() {
+		usage = help
+		lnt = lintDesign
+	}()
+
+	cases := map[string]struct {
+		CmdLine        string
+		ExpectedUsage  bool
+		ExpectedPath   string
+		ExpectedConfig string
+		ExpectedJSON   bool
+		ExpectedDebug  bool
+	}{
+		"lint":            {"lint pkg", false, "pkg", "", false, false},
+		"config":          {"lint pkg -config lint.yaml", false, "pkg", "lint.yaml", false, false},
+		"json":            {"lint pkg -json -debug", false, "pkg", "", true, true},
+		"missing package": {"lint", true, "", "", false, false},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		usageCalled = false
+		path, config, jsonOut, debug = "", "", false, false
+
+		main()
+
+		if usageCalled != c.ExpectedUsage {
+			t.Errorf("%s: Expected usage to be %v but got %v", k, c.ExpectedUsage, usageCalled)
+		}
+		if path != c.ExpectedPath {
+			t.Errorf("%s: Expected path to be %s but got %s", k, c.ExpectedPath, path)
+		}
+		if config != c.ExpectedConfig {
+			t.Errorf("%s: Expected config to be %s but got %s", k, c.ExpectedConfig, config)
+		}
+		if jsonOut != c.ExpectedJSON {
+			t.Errorf("%s: Expected json to be %v but got %v", k, c.ExpectedJSON, jsonOut)
+		}
+		if debug != c.ExpectedDebug {
+			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
+		}
+	}
+}
+
+func TestWatchCmdLine(t *testing.T) {
+	var (
+		genCalled, watchCalled bool
+		path, output           string
+		debug                  bool
+	)
+
+	gen = func(string, string, string, bool) error { genCalled = true; return nil }
+	wch = func(p, o string, d bool) error { watchCalled = true; path, output, debug = p, o, d; return nil }
+	defer func() {
+		gen = generate
+		wch = watchDesign
+	}()
+
+	cases := map[string]struct {
+		CmdLine        string
+		ExpectedWatch  bool
+		ExpectedPath   string
+		ExpectedOutput string
+		ExpectedDebug  bool
+	}{
+		"no watch":     {"gen pkg", false, "", "", false},
+		"watch":        {"gen pkg -watch", true, "pkg", ".", false},
+		"watch output": {"gen pkg -watch -o out -debug", true, "pkg", "out", true},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		genCalled, watchCalled = false, false
+		path, output, debug = "", "", false
+
+		main()
+
+		if watchCalled != c.ExpectedWatch {
+			t.Errorf("%s: Expected watch to be called %v but got %v", k, c.ExpectedWatch, watchCalled)
+		}
+		if genCalled == c.ExpectedWatch {
+			t.Errorf("%s: Expected gen to be called %v but got %v", k, !c.ExpectedWatch, genCalled)
+		}
+		if path != c.ExpectedPath {
+			t.Errorf("%s: Expected path to be %s but got %s", k, c.ExpectedPath, path)
+		}
+		if output != c.ExpectedOutput {
+			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
+		}
+		if debug != c.ExpectedDebug {
+			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
+		}
+	}
+}
+
+func TestPreviewCmdLine(t *testing.T) {
+	var (
+		called       bool
+		path, output string
+		showDiff     bool
+	)
+
+	prv = func(p, o string, s, _ bool) (bool, error) { called = true; path, output, showDiff = p, o, s; return false, nil }
+	defer func() { prv = previewDesign }()
+
+	cases := map[string]struct {
+		CmdLine          string
+		ExpectedPath     string
+		ExpectedOutput   string
+		ExpectedShowDiff bool
+	}{
+		"dry-run":       {"gen pkg -dry-run", "pkg", ".", true},
+		"check":         {"gen pkg -check -o out", "pkg", "out", false},
+		"check dry-run": {"gen pkg -check -dry-run", "pkg", ".", true},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		called, path, output, showDiff = false, "", "", false
+
+		main()
+
+		if !called {
+			t.Errorf("%s: Expected preview to be called", k)
+		}
+		if path != c.ExpectedPath {
+			t.Errorf("%s: Expected path to be %s but got %s", k, c.ExpectedPath, path)
+		}
+		if output != c.ExpectedOutput {
+			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
+		}
+		if showDiff != c.ExpectedShowDiff {
+			t.Errorf("%s: Expected show diff to be %v but got %v", k, c.ExpectedShowDiff, showDiff)
+		}
+	}
+}
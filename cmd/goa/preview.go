@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"strings"
+
+	"goa.design/goa/v3/codegen/generator"
+)
+
+// previewDesign generates the code for the design in the package with the
+// given import path without writing it and prints the generated files that
+// differ from the files on disk. It prints the unified diffs of the files if
+// showDiff is true and their paths otherwise. It returns true if any file
+// differs. The design must use Goa v3.
+func previewDesign(path, output string, showDiff, debug bool) (bool, error) {
+	if _, err := build.Import(path, ".", 0); err != nil {
+		return false, err
+	}
+	tmp := NewGenerator("gen", path, output)
+	if tmp.DesignVersion < 3 {
+		return false, fmt.Errorf("%s: the dry-run and check flags require a Goa v3 design", path)
+	}
+	tmp.DryRun = true
+	defer func() {
+		if !debug {
+			tmp.Remove()
+		}
+	}()
+	if err := tmp.Write(debug); err != nil {
+		return false, err
+	}
+	if err := tmp.Compile(); err != nil {
+		return false, err
+	}
+	lines, err := tmp.Run()
+	if err != nil {
+		return false, err
+	}
+	var diffs []*generator.FileDiff
+	if err := json.Unmarshal([]byte(strings.Join(lines, "\n")), &diffs); err != nil {
+		return false, fmt.Errorf("%s: invalid generator output: %w", path, err)
+	}
+	if err := writeFileDiffs(os.Stdout, diffs, showDiff); err != nil {
+		return false, err
+	}
+	return len(diffs) > 0, nil
+}
+
+// writeFileDiffs writes the unified diffs of the given files to w if showDiff
+// is true or their paths, one per line, otherwise.
+func writeFileDiffs(w io.Writer, diffs []*generator.FileDiff, showDiff bool) error {
+	for _, d := range diffs {
+		s := d.Path + "\n"
+		if showDiff {
+			s = d.Diff
+		}
+		if _, err := io.WriteString(w, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen/generator"
+)
+
+func TestWriteFileDiffs(t *testing.T) {
+	diffs := []*generator.FileDiff{
+		{Path: "gen/svc/service.go", Diff: "--- gen/svc/service.go\n+++ gen/svc/service.go\n@@ -1 +1 @@\n-old\n+new\n"},
+		{Path: "gen/svc/client.go", Diff: "--- /dev/null\n+++ gen/svc/client.go\n@@ -0,0 +1 @@\n+added\n"},
+	}
+	cases := map[string]struct {
+		ShowDiff bool
+		Expected string
+	}{
+		"paths": {false, "gen/svc/service.go\ngen/svc/client.go\n"},
+		"diffs": {true, diffs[0].Diff + diffs[1].Diff},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeFileDiffs(&buf, diffs, c.ShowDiff))
+			assert.Equal(t, c.Expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"goa.design/goa/v3/codegen"
+	"golang.org/x/tools/go/packages"
+)
+
+// watchInterval is the interval at which the design files are checked for
+// changes.
+var watchInterval = 500 * time.Millisecond
+
+// fileState records the state of a watched file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// watchDesign generates the code for the design in the package with the given
+// import path then keeps the generator process running and regenerates the
+// code each time the Go files of the package or of the packages of the same
+// module it imports change until interrupted. Only the files whose content
+// changed are written. The design must use Goa v3.
+//
+// The generator runs in serve mode: it renders the files once when it starts
+// and updates the output directory with them each time it is asked to over
+// its standard input. The design DSL is Go code compiled into the generator so
+// a change to the design rebuilds the generator, the Go build cache keeps the
+// rebuilds incremental, and replaces the running process. Changes made to the
+// generated files, for example deleting the gen directory, are reverted by the
+// running generator without rebuilding it.
+func watchDesign(path, output string, debug bool) error {
+	if _, err := build.Import(path, ".", 0); err != nil {
+		return err
+	}
+	tmp := NewGenerator("gen", path, output)
+	if tmp.DesignVersion < 3 {
+		return fmt.Errorf("%s: watch mode requires a Goa v3 design", path)
+	}
+	tmp.Incremental = true
+
+	// Stop relaying the signals once the generator is removed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer func() {
+		if !debug {
+			tmp.Remove()
+		}
+	}()
+	if err := tmp.Write(debug); err != nil {
+		return err
+	}
+
+	var proc *generatorProcess
+	defer func() {
+		if proc != nil {
+			proc.Stop()
+		}
+	}()
+	rebuild := true
+	for {
+		design, err := watchedFiles(path)
+		if err != nil {
+			return err
+		}
+		if proc, err = regenerate(os.Stdout, tmp, proc, rebuild); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+		}
+		generated := generatedFiles(output)
+		fmt.Fprintf(os.Stderr, "watching %d design files for changes...\n", len(design))
+		if rebuild, err = waitForChange(ctx, design, generated); err != nil {
+			// Interrupted.
+			return nil
+		}
+	}
+}
+
+// regenerate asks the generator process proc to update the output directory
+// and writes the files it changed to w. regenerate first rebuilds the
+// generator and replaces proc with a new process if rebuild is true or proc is
+// nil. It returns the running generator process or nil if the generator
+// failed.
+func regenerate(w io.Writer, g *Generator, proc *generatorProcess, rebuild bool) (*generatorProcess, error) {
+	start := time.Now()
+	if rebuild || proc == nil {
+		if proc != nil {
+			proc.Stop()
+		}
+		if err := g.Compile(); err != nil {
+			return nil, err
+		}
+		p, err := startGenerator(g)
+		if err != nil {
+			return nil, err
+		}
+		proc = p
+	}
+	files, err := proc.Sync()
+	if err != nil {
+		proc.Stop()
+		return nil, err
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(w, "no change (%s)\n", time.Since(start).Round(time.Millisecond))
+		return proc, nil
+	}
+	fmt.Fprintln(w, strings.Join(files, "\n"))
+	fmt.Fprintf(w, "%d files changed (%s)\n", len(files), time.Since(start).Round(time.Millisecond))
+	return proc, nil
+}
+
+// generatorProcess is a compiled generator running in serve mode.
+type generatorProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *json.Decoder
+	stderr *bytes.Buffer
+}
+
+// startGenerator starts the compiled generator g in serve mode.
+func startGenerator(g *Generator) (*generatorProcess, error) {
+	cmd := exec.Command(filepath.Join(g.tmpDir, g.bin), append(g.args(), "--serve")...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &generatorProcess{cmd: cmd, stdin: stdin, stdout: json.NewDecoder(stdout), stderr: &stderr}, nil
+}
+
+// Sync asks the generator to update the output directory and returns the
+// paths to the files it wrote or deleted.
+func (p *generatorProcess) Sync() ([]string, error) {
+	if _, err := io.WriteString(p.stdin, "gen\n"); err != nil {
+		return nil, p.exitError(err)
+	}
+	var res struct {
+		Files []string `json:"files"`
+		Error string   `json:"error"`
+	}
+	if err := p.stdout.Decode(&res); err != nil {
+		return nil, p.exitError(err)
+	}
+	if res.Error != "" {
+		return nil, errors.New(res.Error)
+	}
+	return res.Files, nil
+}
+
+// Stop stops the generator and waits for it to exit.
+func (p *generatorProcess) Stop() {
+	p.stdin.Close()
+	p.cmd.Wait() // nolint: errcheck
+}
+
+// exitError waits for the generator to exit after it failed to handle a
+// request with err and returns the error it reported.
+func (p *generatorProcess) exitError(err error) error {
+	p.stdin.Close()
+	if werr := p.cmd.Wait(); werr != nil {
+		err = werr
+	}
+	if p.stderr.Len() > 0 {
+		return fmt.Errorf("%w\n%s", err, p.stderr.String())
+	}
+	return err
+}
+
+// watchedFiles returns the state of the Go files of the package with the given
+// import path and of the packages of the same module it imports, directly or
+// not. The files are listed by directory so that added files are detected.
+func watchedFiles(path string) (map[string]fileState, error) {
+	mode := packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedModule
+	pkgs, err := packages.Load(&packages.Config{Mode: mode}, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("%s: no package found", path)
+	}
+	dirs := make(map[string]struct{})
+	seen := make(map[string]bool)
+	var visit func(p *packages.Package)
+	visit = func(p *packages.Package) {
+		if seen[p.PkgPath] {
+			return
+		}
+		seen[p.PkgPath] = true
+		for _, f := range p.GoFiles {
+			dirs[filepath.Dir(f)] = struct{}{}
+		}
+		for _, imp := range p.Imports {
+			if sameModule(pkgs[0], imp) {
+				visit(imp)
+			}
+		}
+	}
+	visit(pkgs[0])
+
+	files := make(map[string]fileState)
+	for dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			if strings.HasSuffix(m, "_test.go") {
+				continue
+			}
+			fi, err := os.Stat(m)
+			if err != nil {
+				continue
+			}
+			files[m] = fileState{modTime: fi.ModTime(), size: fi.Size()}
+		}
+	}
+	return files, nil
+}
+
+// sameModule returns true if the package imp belongs to the module of the
+// design package.
+func sameModule(design, imp *packages.Package) bool {
+	if design.Module == nil || imp.Module == nil {
+		return false
+	}
+	return design.Module.Path == imp.Module.Path
+}
+
+// generatedFiles returns the state of the files in the gen directory of the
+// given output directory.
+func generatedFiles(output string) map[string]fileState {
+	files := make(map[string]fileState)
+	filepath.WalkDir(filepath.Join(output, codegen.Gendir), func(path string, d fs.DirEntry, err error) error { // nolint: errcheck
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if fi, err := d.Info(); err == nil {
+			files[path] = fileState{modTime: fi.ModTime(), size: fi.Size()}
+		}
+		return nil
+	})
+	return files
+}
+
+// waitForChange polls the given design and generated files until one of them
+// is modified or deleted or a Go file is added to the directories of the
+// design files. It returns true if a design file changed and false if only
+// generated files changed. It returns the context error if ctx is canceled
+// first.
+func waitForChange(ctx context.Context, design, generated map[string]fileState) (bool, error) {
+	dirs := make(map[string]int)
+	for f := range design {
+		dirs[filepath.Dir(f)]++
+	}
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-ticker.C:
+		}
+		if changed(design, dirs) {
+			return true, nil
+		}
+		if changed(generated, nil) {
+			return false, nil
+		}
+	}
+}
+
+// changed returns true if one of the given files was modified or deleted or
+// if the number of Go files in one of the given directories changed.
+func changed(files map[string]fileState, dirs map[string]int) bool {
+	for f, st := range files {
+		fi, err := os.Stat(f)
+		if err != nil || !fi.ModTime().Equal(st.modTime) || fi.Size() != st.size {
+			return true
+		}
+	}
+	for dir, count := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return true
+		}
+		n := 0
+		for _, m := range matches {
+			if !strings.HasSuffix(m, "_test.go") {
+				n++
+			}
+		}
+		if n != count {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanged(t *testing.T) {
+	cases := map[string]struct {
+		Change   func(t *testing.T, dir string)
+		Expected bool
+	}{
+		"none":     {func(*testing.T, string) {}, false},
+		"modified": {func(t *testing.T, dir string) { writeFile(t, dir, "design.go", "package design // changed") }, true},
+		"deleted":  {func(t *testing.T, dir string) { require.NoError(t, os.Remove(filepath.Join(dir, "types.go"))) }, true},
+		"added":    {func(t *testing.T, dir string) { writeFile(t, dir, "other.go", "package design") }, true},
+		"test":     {func(t *testing.T, dir string) { writeFile(t, dir, "design_test.go", "package design") }, false},
+		"other":    {func(t *testing.T, dir string) { writeFile(t, dir, "README.md", "design") }, false},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]fileState{
+				filepath.Join(dir, "design.go"): writeFile(t, dir, "design.go", "package design"),
+				filepath.Join(dir, "types.go"):  writeFile(t, dir, "types.go", "package design"),
+			}
+			c.Change(t, dir)
+			assert.Equal(t, c.Expected, changed(files, map[string]int{dir: 2}))
+		})
+	}
+}
+
+func TestWaitForChange(t *testing.T) {
+	defer func(d time.Duration) { watchInterval = d }(watchInterval)
+	watchInterval = time.Millisecond
+	dir, gen := t.TempDir(), t.TempDir()
+	design := map[string]fileState{filepath.Join(dir, "design.go"): writeFile(t, dir, "design.go", "package design")}
+	generated := map[string]fileState{filepath.Join(gen, "service.go"): writeFile(t, gen, "service.go", "package service")}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := waitForChange(ctx, design, generated)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	require.NoError(t, os.Remove(filepath.Join(gen, "service.go")))
+	rebuild, err := waitForChange(context.Background(), design, generated)
+	assert.NoError(t, err)
+	assert.False(t, rebuild)
+
+	writeFile(t, dir, "other.go", "package design")
+	rebuild, err = waitForChange(context.Background(), design, generated)
+	assert.NoError(t, err)
+	assert.True(t, rebuild)
+}
+
+func TestGeneratorProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a shell")
+	}
+	// The script replies like a generator running in serve mode: it
+	// reports one changed file, then an error, then exits.
+	g := &Generator{Command: "gen", Output: t.TempDir(), DesignVersion: 3, tmpDir: t.TempDir(), bin: "gen"}
+	script := `#!/bin/sh
+read line && echo '{"files":["gen/service/service.go"]}'
+read line && echo '{"error":"invalid design"}'
+read line && echo 'failed to evaluate design' >&2 && exit 1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(g.tmpDir, g.bin), []byte(script), 0700))
+
+	proc, err := startGenerator(g)
+	require.NoError(t, err)
+	defer proc.Stop()
+
+	files, err := proc.Sync()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gen/service/service.go"}, files)
+
+	_, err = proc.Sync()
+	assert.EqualError(t, err, "invalid design")
+
+	_, err = proc.Sync()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to evaluate design")
+}
+
+func TestGeneratedFiles(t *testing.T) {
+	output := t.TempDir()
+	assert.Empty(t, generatedFiles(output))
+
+	dir := filepath.Join(output, "gen", "http", "openapi")
+	require.NoError(t, os.MkdirAll(dir, 0750))
+	writeFile(t, dir, "openapi3.yaml", "openapi: 3.0.3")
+	files := generatedFiles(output)
+	assert.Len(t, files, 1)
+	assert.Contains(t, files, filepath.Join(dir, "openapi3.yaml"))
+}
+
+// writeFile writes a file with the given content in dir and returns its state.
+func writeFile(t *testing.T, dir, name, content string) fileState {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	return fileState{modTime: fi.ModTime(), size: fi.Size()}
+}
@@ -424,6 +424,12 @@ func flagType(tname string) string {
 		return strings.ToUpper(tname)
 	case bytesN:
 		return "STRING"
+	case datetimeN:
+		return "DATETIME"
+	case durationN:
+		return "DURATION"
+	case decimalN:
+		return "DECIMAL"
 	default: // Any, Array, Map, Object, User
 		return "JSON"
 	}
@@ -472,17 +478,20 @@ func jsonExample(v any) string {
 }
 
 var (
-	boolN    = codegen.GoNativeTypeName(expr.Boolean)
-	intN     = codegen.GoNativeTypeName(expr.Int)
-	int32N   = codegen.GoNativeTypeName(expr.Int32)
-	int64N   = codegen.GoNativeTypeName(expr.Int64)
-	uintN    = codegen.GoNativeTypeName(expr.UInt)
-	uint32N  = codegen.GoNativeTypeName(expr.UInt32)
-	uint64N  = codegen.GoNativeTypeName(expr.UInt64)
-	float32N = codegen.GoNativeTypeName(expr.Float32)
-	float64N = codegen.GoNativeTypeName(expr.Float64)
-	stringN  = codegen.GoNativeTypeName(expr.String)
-	bytesN   = codegen.GoNativeTypeName(expr.Bytes)
+	boolN     = codegen.GoNativeTypeName(expr.Boolean)
+	intN      = codegen.GoNativeTypeName(expr.Int)
+	int32N    = codegen.GoNativeTypeName(expr.Int32)
+	int64N    = codegen.GoNativeTypeName(expr.Int64)
+	uintN     = codegen.GoNativeTypeName(expr.UInt)
+	uint32N   = codegen.GoNativeTypeName(expr.UInt32)
+	uint64N   = codegen.GoNativeTypeName(expr.UInt64)
+	float32N  = codegen.GoNativeTypeName(expr.Float32)
+	float64N  = codegen.GoNativeTypeName(expr.Float64)
+	stringN   = codegen.GoNativeTypeName(expr.String)
+	bytesN    = codegen.GoNativeTypeName(expr.Bytes)
+	datetimeN = codegen.GoNativeTypeName(expr.DateTime)
+	durationN = codegen.GoNativeTypeName(expr.Duration)
+	decimalN  = codegen.GoNativeTypeName(expr.Decimal)
 )
 
 // conversionCode produces the code that converts the string contained in the
@@ -544,6 +553,15 @@ func conversionCode(from, to, typeName string, pointer bool) (string, bool, bool
 		parse = fmt.Sprintf("%s %s= []byte(%s)", target, decl, from)
 		declErr = false
 		checkErr = false
+	case datetimeN:
+		parse = fmt.Sprintf("%s, err %s= time.Parse(time.RFC3339, %s)", target, decl, from)
+		declErr = decl == ""
+	case durationN:
+		parse = fmt.Sprintf("var v int64\nv, err = strconv.ParseInt(%s, 10, 64)", from)
+		cast = fmt.Sprintf("%s %s= time.Duration(v)", target, decl)
+	case decimalN:
+		parse = fmt.Sprintf("%s, err %s= goa.ParseDecimal(%s)", target, decl, from)
+		declErr = decl == ""
 	default:
 		parse = fmt.Sprintf("err = json.Unmarshal([]byte(%s), &%s)", from, target)
 	}
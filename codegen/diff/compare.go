@@ -0,0 +1,373 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// Change is a breaking change between two versions of a design.
+	Change struct {
+		// Kind identifies the kind of change, see the Kind constants.
+		Kind Kind `json:"kind"`
+		// Service is the name of the service affected by the change.
+		Service string `json:"service"`
+		// Method is the name of the method affected by the change,
+		// empty if the change affects the whole service.
+		Method string `json:"method,omitempty"`
+		// Path locates the changed element in the method, e.g.
+		// "payload.items[].id" or "http.routes", empty if the change
+		// affects the whole method.
+		Path string `json:"path,omitempty"`
+		// Message describes the change.
+		Message string `json:"message"`
+	}
+
+	// Kind is the kind of a breaking change.
+	Kind string
+
+	// comparer compares the wire contracts of two designs.
+	comparer struct {
+		from, to *Design
+		changes  []*Change
+		// seen records the pairs of user types being compared to stop
+		// the recursion on recursive types.
+		seen map[string]bool
+		// service and method are the names of the service and method
+		// being compared.
+		service, method string
+	}
+
+	// direction is the direction in which values are sent.
+	direction int
+)
+
+const (
+	// ServiceRemoved indicates that a service was removed.
+	ServiceRemoved Kind = "service_removed"
+	// MethodRemoved indicates that a method was removed.
+	MethodRemoved Kind = "method_removed"
+	// RouteRemoved indicates that an HTTP route or a gRPC endpoint was
+	// removed.
+	RouteRemoved Kind = "route_removed"
+	// Required indicates that a request attribute became required.
+	Required Kind = "required"
+	// Optional indicates that a response attribute is no longer required
+	// or was removed.
+	Optional Kind = "optional"
+	// TypeChanged indicates that the type of a value changed.
+	TypeChanged Kind = "type_changed"
+	// Renamed indicates that the name of an attribute in JSON documents or
+	// the name of an HTTP parameter or header changed.
+	Renamed Kind = "renamed"
+	// TagChanged indicates that a protobuf field number changed.
+	TagChanged Kind = "tag_changed"
+	// EnumNarrowed indicates that a request value accepts fewer enum
+	// values.
+	EnumNarrowed Kind = "enum_narrowed"
+	// EnumWidened indicates that a response value may take enum values
+	// that the existing clients reject.
+	EnumWidened Kind = "enum_widened"
+	// ErrorRemoved indicates that an error was removed or that its HTTP
+	// status code or gRPC code changed.
+	ErrorRemoved Kind = "error_removed"
+)
+
+const (
+	// request is the direction of the values sent by clients.
+	request direction = iota
+	// response is the direction of the values sent by servers.
+	response
+)
+
+// Compare returns the changes made to design from that produced design to and
+// that break the clients or servers built from design from. The changes are
+// listed in the order of design from.
+func Compare(from, to *Design) []*Change {
+	c := &comparer{from: from, to: to, seen: make(map[string]bool)}
+	for _, fs := range from.Services {
+		c.service, c.method = fs.Name, ""
+		ts := findService(to, fs.Name)
+		if ts == nil {
+			c.report(ServiceRemoved, "", "service removed")
+			continue
+		}
+		for _, om := range fs.Methods {
+			c.method = om.Name
+			nm := findMethod(ts, om.Name)
+			if nm == nil {
+				c.report(MethodRemoved, "", "method removed")
+				continue
+			}
+			c.compareMethod(om, nm)
+		}
+	}
+	return c.changes
+}
+
+// String returns a human readable description of the change.
+func (c *Change) String() string {
+	loc := c.Service
+	if c.Method != "" {
+		loc += "." + c.Method
+	}
+	if c.Path != "" {
+		loc += ": " + c.Path
+	}
+	return fmt.Sprintf("%s: %s (%s)", loc, c.Message, c.Kind)
+}
+
+// compareMethod records the breaking changes between the given methods.
+func (c *comparer) compareMethod(om, nm *Method) {
+	if om.HTTP != nil {
+		var routes []string
+		if nm.HTTP != nil {
+			routes = nm.HTTP.Routes
+		}
+		for _, r := range om.HTTP.Routes {
+			if !slices.Contains(routes, r) {
+				c.report(RouteRemoved, "http.routes", fmt.Sprintf("route %q removed", r))
+			}
+		}
+		if nm.HTTP != nil {
+			c.compareMapping("http.params", "parameter", om.HTTP.Params, nm.HTTP.Params)
+			c.compareMapping("http.headers", "header", om.HTTP.Headers, nm.HTTP.Headers)
+		}
+	}
+	if om.GRPC && !nm.GRPC {
+		c.report(RouteRemoved, "grpc", "gRPC endpoint removed")
+	}
+	c.compareValue("payload", om.Payload, nm.Payload, request)
+	c.compareValue("result", om.Result, nm.Result, response)
+	for _, oe := range om.Errors {
+		path := "errors." + oe.Name
+		var ne *Error
+		for _, e := range nm.Errors {
+			if e.Name == oe.Name {
+				ne = e
+				break
+			}
+		}
+		if ne == nil {
+			c.report(ErrorRemoved, path, "error removed")
+			continue
+		}
+		if oe.HTTPStatus != 0 && oe.HTTPStatus != ne.HTTPStatus {
+			if ne.HTTPStatus == 0 {
+				c.report(ErrorRemoved, path, fmt.Sprintf("HTTP status %d removed", oe.HTTPStatus))
+			} else {
+				c.report(ErrorRemoved, path, fmt.Sprintf("HTTP status changed from %d to %d", oe.HTTPStatus, ne.HTTPStatus))
+			}
+		}
+		if oe.GRPCCode != "" && oe.GRPCCode != ne.GRPCCode {
+			if ne.GRPCCode == "" {
+				c.report(ErrorRemoved, path, fmt.Sprintf("gRPC code %s removed", oe.GRPCCode))
+			} else {
+				c.report(ErrorRemoved, path, fmt.Sprintf("gRPC code changed from %s to %s", oe.GRPCCode, ne.GRPCCode))
+			}
+		}
+		c.compareValue(path, oe.Type, ne.Type, response)
+	}
+}
+
+// compareMapping records the HTTP parameters or headers that were renamed.
+func (c *comparer) compareMapping(path, elem string, om, nm map[string]string) {
+	for _, att := range sortedKeys(om) {
+		if n, ok := nm[att]; ok && n != om[att] {
+			c.report(Renamed, path+"."+att, fmt.Sprintf("%s renamed from %q to %q", elem, om[att], n))
+		}
+	}
+}
+
+// compareValue records the breaking changes between the given values sent in
+// the given direction.
+func (c *comparer) compareValue(path string, oa, na *Attribute, dir direction) {
+	if oa == nil || na == nil {
+		if oa != na {
+			c.report(TypeChanged, path, fmt.Sprintf("changed from %s to %s", typeName(oa), typeName(na)))
+		}
+		return
+	}
+	ot, nt := c.resolve(oa, c.from), c.resolve(na, c.to)
+	if ot == nil || nt == nil || ot.Type != nt.Type {
+		c.report(TypeChanged, path, fmt.Sprintf("changed from %s to %s", typeName(oa), typeName(na)))
+		return
+	}
+	oe, ne := oa.Enum, na.Enum
+	if len(oe) == 0 {
+		oe = ot.Enum
+	}
+	if len(ne) == 0 {
+		ne = nt.Enum
+	}
+	c.compareEnum(path, oe, ne, dir)
+	if oa.Ref != "" && na.Ref != "" {
+		key := oa.Ref + "|" + na.Ref
+		if c.seen[key] {
+			return
+		}
+		c.seen[key] = true
+		defer delete(c.seen, key)
+	}
+	switch ot.Type {
+	case "array":
+		c.compareValue(path+"[]", ot.Elem, nt.Elem, dir)
+	case "map":
+		c.compareValue(path+"{key}", ot.Key, nt.Key, dir)
+		c.compareValue(path+"{}", ot.Elem, nt.Elem, dir)
+	case "object":
+		c.compareFields(path, ot.Fields, nt.Fields, dir)
+	case "union":
+		for _, of := range ot.Fields {
+			nf := findField(nt.Fields, of.Name)
+			if nf == nil {
+				c.report(TypeChanged, path, fmt.Sprintf("union type %q removed", of.Name))
+				continue
+			}
+			if of.Tag != "" && of.Tag != nf.Tag {
+				c.report(TagChanged, path+"."+of.Name, fmt.Sprintf("field number changed from %s to %s", of.Tag, nf.Tag))
+			}
+			c.compareValue(path+"."+of.Name, of.Attribute, nf.Attribute, dir)
+		}
+	}
+}
+
+// compareFields records the breaking changes between the given object
+// attributes sent in the given direction.
+func (c *comparer) compareFields(path string, ofs, nfs []*Field, dir direction) {
+	renamed := make(map[string]bool)
+	for _, of := range ofs {
+		fpath := path + "." + of.Name
+		nf := findField(nfs, of.Name)
+		if nf == nil {
+			if of.Tag != "" {
+				for _, f := range nfs {
+					if f.Tag == of.Tag && findField(ofs, f.Name) == nil {
+						nf = f
+						break
+					}
+				}
+			}
+			if nf == nil {
+				if dir == response && of.Required {
+					c.report(Optional, fpath, "required attribute removed")
+				}
+				continue
+			}
+			renamed[nf.Name] = true
+			c.report(Renamed, fpath, fmt.Sprintf("renamed to %q", nf.Name))
+		} else {
+			if of.JSON != nf.JSON {
+				c.report(Renamed, fpath, fmt.Sprintf("JSON name changed from %q to %q", of.JSON, nf.JSON))
+			}
+			if of.Tag != "" && of.Tag != nf.Tag {
+				c.report(TagChanged, fpath, fmt.Sprintf("field number changed from %s to %s", of.Tag, nf.Tag))
+			}
+		}
+		switch {
+		case dir == request && !of.Required && nf.Required:
+			c.report(Required, fpath, "attribute became required")
+		case dir == response && of.Required && !nf.Required:
+			c.report(Optional, fpath, "attribute is no longer required")
+		}
+		c.compareValue(fpath, of.Attribute, nf.Attribute, dir)
+	}
+	if dir != request {
+		return
+	}
+	for _, nf := range nfs {
+		if nf.Required && !renamed[nf.Name] && findField(ofs, nf.Name) == nil {
+			c.report(Required, path+"."+nf.Name, "required attribute added")
+		}
+	}
+}
+
+// compareEnum records the enum values of requests that are no longer accepted
+// and the enum values of responses that existing clients reject.
+func (c *comparer) compareEnum(path string, oe, ne []string, dir direction) {
+	if dir == request {
+		if removed := missing(oe, ne); len(ne) > 0 && (len(oe) == 0 || len(removed) > 0) {
+			c.report(EnumNarrowed, path, "enum values restricted"+list(removed))
+		}
+		return
+	}
+	if added := missing(ne, oe); len(oe) > 0 && (len(ne) == 0 || len(added) > 0) {
+		c.report(EnumWidened, path, "enum values extended"+list(added))
+	}
+}
+
+// resolve returns the attribute of the user type a refers to in d or a if it
+// does not refer to a user type.
+func (c *comparer) resolve(a *Attribute, d *Design) *Attribute {
+	for a != nil && a.Ref != "" {
+		a = d.Types[a.Ref]
+	}
+	return a
+}
+
+// report records a breaking change.
+func (c *comparer) report(kind Kind, path, msg string) {
+	c.changes = append(c.changes, &Change{Kind: kind, Service: c.service, Method: c.method, Path: path, Message: msg})
+}
+
+// findService returns the service with the given name, nil if there is none.
+func findService(d *Design, name string) *Service {
+	for _, s := range d.Services {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// findMethod returns the method with the given name, nil if there is none.
+func findMethod(s *Service, name string) *Method {
+	for _, m := range s.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// findField returns the field with the given name, nil if there is none.
+func findField(fs []*Field, name string) *Field {
+	for _, f := range fs {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// typeName returns the name of the type of a used in change messages.
+func typeName(a *Attribute) string {
+	switch {
+	case a == nil:
+		return "empty"
+	case a.Ref != "":
+		return a.Ref
+	default:
+		return a.Type
+	}
+}
+
+// missing returns the values of vals that are not in ref.
+func missing(vals, ref []string) []string {
+	var res []string
+	for _, v := range vals {
+		if !slices.Contains(ref, v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// list formats the given enum values for change messages.
+func list(vals []string) string {
+	if len(vals) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(vals, ", ")
+}
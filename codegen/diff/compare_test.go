@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/diff/testdata"
+)
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		Name     string
+		From     func()
+		To       func()
+		Expected []string
+	}{
+		{"unchanged", testdata.BaseDSL, testdata.UnchangedDSL, nil},
+		{"compatible", testdata.BaseDSL, testdata.CompatibleDSL, nil},
+		{"breaking", testdata.BaseDSL, testdata.BreakingDSL, []string{
+			`Store.Show: http.routes: route "GET /items/{id}" removed (route_removed)`,
+			`Store.Show: http.headers.verbose: header renamed from "X-Verbose" to "X-Debug" (renamed)`,
+			`Store.Show: grpc: gRPC endpoint removed (route_removed)`,
+			`Store.Show: payload.view: enum values restricted: tiny (enum_narrowed)`,
+			`Store.Show: payload.verbose: field number changed from 3 to 4 (tag_changed)`,
+			`Store.Show: payload.verbose: attribute became required (required)`,
+			`Store.Show: result.id: attribute is no longer required (optional)`,
+			`Store.Show: result.id: changed from string to int (type_changed)`,
+			`Store.Show: result.kind: enum values extended: c (enum_widened)`,
+			`Store.Show: errors.not_found: HTTP status changed from 404 to 410 (error_removed)`,
+			`Store.Show: errors.not_found: gRPC code NotFound removed (error_removed)`,
+			`Store.List: method removed (method_removed)`,
+		}},
+		{"renamed", testdata.BaseDSL, testdata.RenamedDSL, []string{
+			`Store.Show: http.params.view: parameter renamed from "view" to "v" (renamed)`,
+			`Store.Show: result.name: renamed to "title" (renamed)`,
+			`Store.Show: result.kind: JSON name changed from "kind" to "type" (renamed)`,
+			`Store.List: result[].name: renamed to "title" (renamed)`,
+			`Store.List: result[].kind: JSON name changed from "kind" to "type" (renamed)`,
+		}},
+		{"recursive", testdata.RecursiveDSL, testdata.RecursiveChangedDSL, []string{
+			`Tree.Get: result.value: changed from string to int (type_changed)`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			from := snapshot(t, c.From)
+			to := snapshot(t, c.To)
+			var changes []string
+			for _, ch := range Compare(from, to) {
+				changes = append(changes, ch.String())
+			}
+			assert.Equal(t, c.Expected, changes)
+		})
+	}
+}
+
+// snapshot evaluates the given DSL and returns its snapshot after a JSON
+// round trip as done by the goa diff command.
+func snapshot(t *testing.T, dsl func()) *Design {
+	t.Helper()
+	b, err := json.Marshal(Snapshot(codegen.RunDSL(t, dsl)))
+	require.NoError(t, err)
+	var d Design
+	require.NoError(t, json.Unmarshal(b, &d))
+	return &d
+}
@@ -0,0 +1,10 @@
+/*
+Package diff detects the changes between two versions of a design that break
+the existing clients or servers.
+
+A design is evaluated in the process of the generator compiled for its
+package, two designs cannot be evaluated by the same process. Snapshot thus
+records the wire contract of an evaluated design in a value that can be
+serialized to JSON and Compare compares the snapshots of the two versions.
+*/
+package diff
@@ -0,0 +1,258 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// Design is the wire contract of a design.
+	Design struct {
+		// Services lists the services in design order.
+		Services []*Service `json:"services"`
+		// Types indexes the user types by name.
+		Types map[string]*Attribute `json:"types,omitempty"`
+	}
+
+	// Service is the wire contract of a service.
+	Service struct {
+		// Name is the service name.
+		Name string `json:"name"`
+		// Methods lists the service methods in design order.
+		Methods []*Method `json:"methods"`
+	}
+
+	// Method is the wire contract of a method.
+	Method struct {
+		// Name is the method name.
+		Name string `json:"name"`
+		// Payload is the method payload, nil if the method has none.
+		Payload *Attribute `json:"payload,omitempty"`
+		// Result is the method result, nil if the method has none.
+		Result *Attribute `json:"result,omitempty"`
+		// Errors lists the method errors including the service errors.
+		Errors []*Error `json:"errors,omitempty"`
+		// HTTP is the HTTP endpoint of the method if any.
+		HTTP *HTTPEndpoint `json:"http,omitempty"`
+		// GRPC is true if the method has a gRPC endpoint.
+		GRPC bool `json:"grpc,omitempty"`
+	}
+
+	// Error is the wire contract of a method error.
+	Error struct {
+		// Name is the error name.
+		Name string `json:"name"`
+		// Type is the error type.
+		Type *Attribute `json:"type,omitempty"`
+		// HTTPStatus is the status code of the HTTP response, zero if
+		// the error has no HTTP response.
+		HTTPStatus int `json:"http_status,omitempty"`
+		// GRPCCode is the name of the gRPC status code, empty if the
+		// error has no gRPC response.
+		GRPCCode string `json:"grpc_code,omitempty"`
+	}
+
+	// HTTPEndpoint is the wire contract of an HTTP endpoint.
+	HTTPEndpoint struct {
+		// Routes lists the endpoint routes formatted as "METHOD path".
+		Routes []string `json:"routes"`
+		// Params maps the payload attribute names to the names of the
+		// path and query parameters.
+		Params map[string]string `json:"params,omitempty"`
+		// Headers maps the payload attribute names to the names of the
+		// request headers.
+		Headers map[string]string `json:"headers,omitempty"`
+	}
+
+	// Attribute is the wire contract of a value.
+	Attribute struct {
+		// Type is the name of the primitive type or one of "array",
+		// "map", "object" or "union", empty if Ref is set.
+		Type string `json:"type,omitempty"`
+		// Ref is the name of the user type of the value in the design
+		// types.
+		Ref string `json:"ref,omitempty"`
+		// Enum lists the values allowed by the design formatted with
+		// fmt.Sprint.
+		Enum []string `json:"enum,omitempty"`
+		// Elem is the type of the array or map elements.
+		Elem *Attribute `json:"elem,omitempty"`
+		// Key is the type of the map keys.
+		Key *Attribute `json:"key,omitempty"`
+		// Fields lists the object attributes or the union types.
+		Fields []*Field `json:"fields,omitempty"`
+	}
+
+	// Field is the wire contract of an object attribute or union type.
+	Field struct {
+		// Name is the attribute name.
+		Name string `json:"name"`
+		// JSON is the name of the attribute in JSON documents.
+		JSON string `json:"json,omitempty"`
+		// Tag is the protobuf field number.
+		Tag string `json:"tag,omitempty"`
+		// Required is true if the attribute is required.
+		Required bool `json:"required,omitempty"`
+		// Attribute is the attribute value.
+		Attribute *Attribute `json:"attribute"`
+	}
+)
+
+// Snapshot returns the wire contract of the evaluated design root.
+func Snapshot(root *expr.RootExpr) *Design {
+	d := &Design{Types: make(map[string]*Attribute)}
+	for _, svc := range root.Services {
+		s := &Service{Name: svc.Name}
+		for _, m := range svc.Methods {
+			s.Methods = append(s.Methods, d.method(root, svc, m))
+		}
+		d.Services = append(d.Services, s)
+	}
+	return d
+}
+
+// method returns the wire contract of the given method.
+func (d *Design) method(root *expr.RootExpr, svc *expr.ServiceExpr, m *expr.MethodExpr) *Method {
+	md := &Method{Name: m.Name, Payload: d.attribute(m.Payload), Result: d.attribute(m.Result)}
+	var (
+		he *expr.HTTPEndpointExpr
+		ge *expr.GRPCEndpointExpr
+	)
+	if root.API != nil && root.API.HTTP != nil {
+		if hs := root.API.HTTP.Service(svc.Name); hs != nil {
+			he = hs.Endpoint(m.Name)
+		}
+	}
+	if root.API != nil && root.API.GRPC != nil {
+		if gs := root.API.GRPC.Service(svc.Name); gs != nil {
+			ge = gs.Endpoint(m.Name)
+		}
+	}
+	if he != nil {
+		md.HTTP = &HTTPEndpoint{
+			Params:  mapping(he.Params),
+			Headers: mapping(he.Headers),
+		}
+		for _, r := range he.Routes {
+			for _, p := range r.FullPaths() {
+				md.HTTP.Routes = append(md.HTTP.Routes, r.Method+" "+p)
+			}
+		}
+	}
+	md.GRPC = ge != nil
+	for _, er := range m.Errors {
+		e := &Error{Name: er.Name, Type: d.attribute(er.AttributeExpr)}
+		if he != nil {
+			for _, hr := range he.HTTPErrors {
+				if hr.Name == er.Name {
+					e.HTTPStatus = hr.Response.StatusCode
+				}
+			}
+		}
+		if ge != nil {
+			for _, gr := range ge.GRPCErrors {
+				if gr.Name == er.Name {
+					e.GRPCCode = codes.Code(gr.Response.StatusCode).String() // nolint: gosec
+				}
+			}
+		}
+		md.Errors = append(md.Errors, e)
+	}
+	return md
+}
+
+// attribute returns the wire contract of the given attribute and records the
+// user types it refers to, nil if the attribute is empty.
+func (d *Design) attribute(att *expr.AttributeExpr) *Attribute {
+	if att == nil || att.Type == nil || att.Type == expr.Empty {
+		return nil
+	}
+	a := &Attribute{}
+	if v := att.Validation; v != nil {
+		for _, val := range v.Values {
+			a.Enum = append(a.Enum, fmt.Sprint(val))
+		}
+	}
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		a.Ref = actual.Name()
+		if _, ok := d.Types[a.Ref]; !ok {
+			// Record the type before computing its attribute to stop
+			// recursion on recursive types.
+			d.Types[a.Ref] = nil
+			d.Types[a.Ref] = d.attribute(actual.Attribute())
+		}
+	case *expr.Array:
+		a.Type = "array"
+		a.Elem = d.attribute(actual.ElemType)
+	case *expr.Map:
+		a.Type = "map"
+		a.Key = d.attribute(actual.KeyType)
+		a.Elem = d.attribute(actual.ElemType)
+	case *expr.Object:
+		a.Type = "object"
+		for _, nat := range *actual {
+			a.Fields = append(a.Fields, &Field{
+				Name:      nat.Name,
+				JSON:      jsonName(nat),
+				Tag:       tag(nat.Attribute),
+				Required:  att.IsRequired(nat.Name),
+				Attribute: d.attribute(nat.Attribute),
+			})
+		}
+	case *expr.Union:
+		a.Type = "union"
+		for _, nat := range actual.Values {
+			a.Fields = append(a.Fields, &Field{
+				Name:      nat.Name,
+				Tag:       tag(nat.Attribute),
+				Attribute: d.attribute(nat.Attribute),
+			})
+		}
+	default:
+		a.Type = actual.Name()
+	}
+	return a
+}
+
+// mapping returns the names of the HTTP elements indexed by the names of the
+// attributes they map to.
+func mapping(ma *expr.MappedAttributeExpr) map[string]string {
+	if ma == nil || ma.IsEmpty() {
+		return nil
+	}
+	m := make(map[string]string)
+	for _, nat := range *expr.AsObject(ma.Type) {
+		m[nat.Name] = ma.ElemName(nat.Name)
+	}
+	return m
+}
+
+// jsonName returns the name of the given attribute in JSON documents.
+func jsonName(nat *expr.NamedAttributeExpr) string {
+	if tags, ok := nat.Attribute.Meta["struct:tag:json"]; ok && len(tags) > 0 && tags[0] != "" {
+		return tags[0]
+	}
+	return nat.Name
+}
+
+// tag returns the protobuf field number of the given attribute if set in the
+// design.
+func tag(att *expr.AttributeExpr) string {
+	t, _ := att.Meta.Last("rpc:tag")
+	return t
+}
+
+// sortedKeys returns the keys of m in lexical order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,207 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var BaseDSL = func() {
+	var Item = Type("Item", func() {
+		Field(1, "id", String)
+		Field(2, "name", String)
+		Field(3, "kind", String, func() {
+			Enum("a", "b")
+		})
+		Required("id")
+	})
+	var NotFound = Type("NotFound", func() {
+		Attribute("id", String)
+	})
+	Service("Store", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Field(1, "id", String)
+				Field(2, "view", String, func() {
+					Enum("default", "tiny")
+				})
+				Field(3, "verbose", Boolean)
+			})
+			Result(Item)
+			Error("not_found", NotFound)
+			HTTP(func() {
+				GET("/items/{id}")
+				Param("view")
+				Header("verbose:X-Verbose")
+				Response("not_found", StatusNotFound)
+			})
+			GRPC(func() {
+				Response("not_found", CodeNotFound)
+			})
+		})
+		Method("List", func() {
+			Result(ArrayOf(Item))
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
+
+var UnchangedDSL = BaseDSL
+
+var CompatibleDSL = func() {
+	var Item = Type("Item", func() {
+		Field(1, "id", String)
+		Field(2, "name", String)
+		Field(3, "kind", String, func() {
+			Enum("a", "b")
+		})
+		Field(4, "price", Float64)
+		Required("id", "name")
+	})
+	var NotFound = Type("NotFound", func() {
+		Attribute("id", String)
+	})
+	Service("Store", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Field(1, "id", String)
+				Field(2, "view", String, func() {
+					Enum("default", "tiny", "full")
+				})
+				Field(3, "verbose", Boolean)
+				Field(4, "lang", String)
+			})
+			Result(Item)
+			Error("not_found", NotFound)
+			HTTP(func() {
+				GET("/items/{id}")
+				GET("/products/{id}")
+				Param("view")
+				Param("lang")
+				Header("verbose:X-Verbose")
+				Response("not_found", StatusNotFound)
+			})
+			GRPC(func() {
+				Response("not_found", CodeNotFound)
+			})
+		})
+		Method("List", func() {
+			Result(ArrayOf(Item))
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+		Method("Add", func() {
+			Payload(Item)
+			HTTP(func() {
+				POST("/items")
+			})
+		})
+	})
+}
+
+var BreakingDSL = func() {
+	var Item = Type("Item", func() {
+		Field(1, "id", Int)
+		Field(5, "title", String)
+		Field(3, "kind", String, func() {
+			Enum("a", "b", "c")
+		})
+	})
+	var NotFound = Type("NotFound", func() {
+		Attribute("id", String)
+	})
+	Service("Store", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Field(1, "id", String)
+				Field(2, "view", String, func() {
+					Enum("default")
+				})
+				Field(4, "verbose", Boolean)
+				Required("verbose")
+			})
+			Result(Item)
+			Error("not_found", NotFound)
+			HTTP(func() {
+				GET("/item/{id}")
+				Param("view")
+				Header("verbose:X-Debug")
+				Response("not_found", StatusGone)
+			})
+		})
+	})
+}
+
+var RenamedDSL = func() {
+	var Item = Type("Item", func() {
+		Field(1, "id", String)
+		Field(2, "title", String)
+		Field(3, "kind", String, func() {
+			Enum("a", "b")
+			Meta("struct:tag:json", "type")
+		})
+		Required("id")
+	})
+	var NotFound = Type("NotFound", func() {
+		Attribute("id", String)
+	})
+	Service("Store", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Field(1, "id", String)
+				Field(2, "view", String, func() {
+					Enum("default", "tiny")
+				})
+				Field(3, "verbose", Boolean)
+			})
+			Result(Item)
+			Error("not_found", NotFound)
+			HTTP(func() {
+				GET("/items/{id}")
+				Param("view:v")
+				Header("verbose:X-Verbose")
+				Response("not_found", StatusNotFound)
+			})
+			GRPC(func() {
+				Response("not_found", CodeNotFound)
+			})
+		})
+		Method("List", func() {
+			Result(ArrayOf(Item))
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
+
+var RecursiveDSL = func() {
+	var Node = Type("Node", func() {
+		Attribute("value", String)
+		Attribute("children", ArrayOf("Node"))
+	})
+	Service("Tree", func() {
+		Method("Get", func() {
+			Result(Node)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var RecursiveChangedDSL = func() {
+	var Node = Type("Node", func() {
+		Attribute("value", Int)
+		Attribute("children", ArrayOf("Node"))
+	})
+	Service("Tree", func() {
+		Method("Get", func() {
+			Result(Node)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
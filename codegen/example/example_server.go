@@ -51,6 +51,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 	svcData := make([]*service.Data, len(svr.Services))
 	scope := codegen.NewNameScope()
 	hasInterceptors := false
+	hasRateLimits := false
 	for i, svc := range svr.Services {
 		sd := service.Services.Get(svc)
 		svcData[i] = sd
@@ -59,6 +60,10 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Name: scope.Unique(sd.PkgName, "svc"),
 		})
 		hasInterceptors = hasInterceptors || len(sd.ServerInterceptors) > 0
+		hasRateLimits = hasRateLimits || isRateLimited(sd)
+	}
+	if hasRateLimits {
+		specs = append(specs, codegen.GoaImport("ratelimit"))
 	}
 	interPkg := scope.Unique("interceptors", "ex")
 
@@ -127,6 +132,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			},
 			FuncMap: map[string]any{
 				"mustInitServices": mustInitServices,
+				"isRateLimited":    isRateLimited,
 			},
 		}, {
 			Name:   "server-main-interrupts",
@@ -163,3 +169,19 @@ func mustInitServices(data []*service.Data) bool {
 	}
 	return false
 }
+
+// isRateLimited returns true if at least one of the service methods defines a
+// rate limit. It is used by the template to apply the rate limits to the
+// service endpoints.
+func isRateLimited(data *service.Data) bool {
+	svc := expr.Root.Service(data.Name)
+	if svc == nil {
+		return false
+	}
+	for _, m := range svc.Methods {
+		if m.EffectiveRateLimit() != nil {
+			return true
+		}
+	}
+	return false
+}
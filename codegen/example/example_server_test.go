@@ -57,6 +57,7 @@ func TestExampleServerFiles(t *testing.T) {
 		{"service-for-only-http", testdata.ServiceForOnlyHTTPDSL},
 		{"sercice-for-only-grpc", testdata.ServiceForOnlyGRPCDSL},
 		{"service-for-http-and-part-of-grpc", testdata.ServiceForHTTPAndPartOfGRPCDSL},
+		{"rate-limited-service", testdata.RateLimitedServiceDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -14,6 +14,9 @@
 			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc{{ if .ServerInterceptors }}, {{ .VarName }}Interceptors{{ end }})
 			{{ .VarName }}Endpoints.Use(debug.LogPayloads())
 			{{ .VarName }}Endpoints.Use(log.Endpoint)
+			{{- if isRateLimited . }}
+			{{ .PkgName }}.ApplyRateLimits({{ .VarName }}Endpoints, ratelimit.NewTokenBucket())
+			{{- end }}
 		{{- end }}
 	{{- end }}
 	}
@@ -1,6 +1,10 @@
 package testdata
 
-import . "goa.design/goa/v3/dsl"
+import (
+	"time"
+
+	. "goa.design/goa/v3/dsl"
+)
 
 var NoServerDSL = func() {
 	Service("Service", func() {
@@ -47,6 +51,17 @@ var SingleServerSingleHostDSL = func() {
 	})
 }
 
+var RateLimitedServiceDSL = func() {
+	Service("Service", func() {
+		RateLimit(100, time.Minute)
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var SingleServerSingleHostWithVariablesDSL = func() {
 	API("SingleServerSingleHostWithVariables", func() {
 		Server("SingleHost", func() {
@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// AsyncAPI iterates through the roots and returns the files needed to render
+// the AsyncAPI document of the service streaming methods. It produces a
+// document only if the roots define HTTP streaming methods.
+func AsyncAPI(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return httpcodegen.AsyncAPIFiles(r)
+		}
+	}
+	return nil, nil
+}
@@ -1,6 +1,6 @@
 /*
 Package generator contains the code generation algorithms for a service server,
-client, and OpenAPI specification.
+client, OpenAPI and AsyncAPI specifications and TypeScript clients.
 
 # Server and Client
 
@@ -17,5 +17,19 @@ The code generated for the service server and client includes:
 
 The OpenAPI generator generates a OpenAPI v2 specification for the service
 REST endpoints. This generator requires the design to define the HTTP transport.
+
+# AsyncAPI
+
+The AsyncAPI generator generates a AsyncAPI v3 specification for the HTTP
+streaming endpoints, that is the endpoints that use websockets or server-sent
+events. No specification is generated if the design does not define any such
+endpoint.
+
+# TypeScript
+
+The TypeScript generator generates a typed TypeScript client for each HTTP
+service that sets the "typescript:generate" meta to "true", either directly or
+via the API. The clients are written under gen/http/typescript together with
+the runtime module they share.
 */
 package generator
@@ -11,7 +11,18 @@ import (
 )
 
 // Generate runs the code generation algorithms.
-func Generate(dir, cmd string) (outputs []string, err1 error) {
+func Generate(dir, cmd string) ([]string, error) {
+	written, err := generate(dir, dir, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return relativePaths(written), nil
+}
+
+// generate runs the code generation algorithms for the output directory dir
+// and renders the files in the directory out. It returns the absolute paths
+// to the rendered files.
+func generate(dir, out, cmd string) (written map[string]struct{}, err1 error) {
 	// 1. Compute design roots.
 	var roots []eval.Root
 	{
@@ -41,7 +52,7 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 		}
 		defer func() {
 			if err := os.Remove(dummy.Name()); err != nil {
-				outputs = nil
+				written = nil
 				err1 = err
 			}
 		}()
@@ -92,9 +103,14 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 	}
 
 	// 7. Write the files.
-	written := make(map[string]struct{})
+	written = make(map[string]struct{})
 	for _, f := range genfiles {
-		filename, err := f.Render(dir)
+		if out != dir && f.SkipExist {
+			if _, err := os.Stat(filepath.Join(dir, f.Path)); err == nil {
+				continue
+			}
+		}
+		filename, err := f.Render(out)
 		if err != nil {
 			return nil, err
 		}
@@ -103,24 +119,24 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 		}
 	}
 
-	// 8. Compute all output filenames.
-	{
-		outputs = make([]string, len(written))
-		cwd, err := os.Getwd()
+	return written, nil
+}
+
+// relativePaths returns the given paths relative to the current working
+// directory sorted in lexical order.
+func relativePaths(paths map[string]struct{}) []string {
+	outputs := make([]string, 0, len(paths))
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = "."
+	}
+	for o := range paths {
+		rel, err := filepath.Rel(cwd, o)
 		if err != nil {
-			cwd = "."
-		}
-		i := 0
-		for o := range written {
-			rel, err := filepath.Rel(cwd, o)
-			if err != nil {
-				rel = o
-			}
-			outputs[i] = rel
-			i++
+			rel = o
 		}
+		outputs = append(outputs, rel)
 	}
 	sort.Strings(outputs)
-
-	return outputs, nil
+	return outputs
 }
@@ -0,0 +1,313 @@
+package generator
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// FileDiff is the difference between a generated file and the file on
+	// disk.
+	FileDiff struct {
+		// Path is the path to the file relative to the current working
+		// directory.
+		Path string `json:"path"`
+		// Diff is the unified diff between the file on disk and the
+		// generated file.
+		Diff string `json:"diff"`
+	}
+
+	// Rendered holds the files generated for an output directory so that the
+	// directory can be updated repeatedly without running the code
+	// generation algorithms again, see Render.
+	Rendered struct {
+		// dir is the absolute path to the output directory.
+		dir string
+		// staging is the directory containing the rendered files.
+		staging string
+		// files lists the absolute paths to the files in staging.
+		files map[string]struct{}
+	}
+
+	// fileChange is a file to write or delete to update the generated code.
+	fileChange struct {
+		// path is the absolute path to the file on disk.
+		path string
+		// current is the content of the file on disk, nil if the file
+		// does not exist.
+		current []byte
+		// content is the content of the generated file, nil if the file
+		// must be deleted.
+		content []byte
+		// mode is the file mode of the generated file.
+		mode fs.FileMode
+	}
+)
+
+// GenerateChanged runs the code generation algorithms like Generate but only
+// writes the files whose content changed. The files are first rendered in a
+// temporary directory then copied to dir if they differ from the existing
+// files so that the modification times of the files that did not change are
+// preserved. The files present in the subdirectories of the gen directory that
+// are no longer generated are deleted. GenerateChanged returns the paths to
+// the files written or deleted.
+func GenerateChanged(dir, cmd string) ([]string, error) {
+	r, err := Render(dir, cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Remove()
+	return r.Sync()
+}
+
+// Render runs the code generation algorithms for the output directory dir and
+// keeps the rendered files in a temporary directory until Remove is called.
+// The code generation algorithms modify the design so Render may only be
+// called once per process.
+func Render(dir, cmd string) (*Rendered, error) {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	staging, err := os.MkdirTemp("", "goagen")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := generate(dir, staging, cmd); err != nil {
+		os.RemoveAll(staging)
+		return nil, err
+	}
+	// The finalizers of the generated files may write additional files
+	// (e.g. protoc writes the protocol buffer Go files) so list the files
+	// present in the staging directory rather than the rendered files.
+	files, err := stagedFiles(staging)
+	if err != nil {
+		os.RemoveAll(staging)
+		return nil, err
+	}
+	return &Rendered{dir: base, staging: staging, files: files}, nil
+}
+
+// Sync writes the rendered files whose content differs from the files in the
+// output directory and deletes the files present in the subdirectories of the
+// gen directory that were not rendered, see GenerateChanged. Sync may be
+// called again to revert the changes made to the output directory since. It
+// returns the paths to the files written or deleted.
+func (r *Rendered) Sync() ([]string, error) {
+	changes, err := r.changes()
+	if err != nil {
+		return nil, err
+	}
+	changed, err := apply(r.dir, changes)
+	if err != nil {
+		return nil, err
+	}
+	return relativePaths(changed), nil
+}
+
+// Remove deletes the rendered files.
+func (r *Rendered) Remove() {
+	os.RemoveAll(r.staging)
+}
+
+// changes returns the changes needed to update the output directory.
+func (r *Rendered) changes() ([]*fileChange, error) {
+	return compareDir(r.staging, r.dir, r.files)
+}
+
+// Diff runs the code generation algorithms like GenerateChanged but does not
+// modify dir. Instead it returns the unified diffs between the files on disk
+// and the generated files for the files that GenerateChanged would write or
+// delete, sorted by path. Diff returns no diff if the generated code is up to
+// date.
+func Diff(dir, cmd string) ([]*FileDiff, error) {
+	r, err := Render(dir, cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Remove()
+	changes, err := r.changes()
+	if err != nil {
+		return nil, err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = "."
+	}
+	diffs := make([]*FileDiff, len(changes))
+	for i, c := range changes {
+		path, err := filepath.Rel(cwd, c.path)
+		if err != nil {
+			path = c.path
+		}
+		from, to := filepath.ToSlash(path), filepath.ToSlash(path)
+		if c.current == nil {
+			from = "/dev/null"
+		}
+		if c.content == nil {
+			to = "/dev/null"
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(c.current)),
+			B:        difflib.SplitLines(string(c.content)),
+			FromFile: from,
+			ToFile:   to,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+		diffs[i] = &FileDiff{Path: path, Diff: diff}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs, nil
+}
+
+// stagedFiles returns the absolute paths to the files in the staging
+// directory.
+func stagedFiles(staging string) (map[string]struct{}, error) {
+	files := make(map[string]struct{})
+	err := filepath.WalkDir(staging, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			files[path] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// compareDir compares the files rendered in the staging directory with the
+// files in dir. It returns the changes needed to update dir: the rendered
+// files that differ from the files on disk and the files of the
+// subdirectories of the gen directory of dir that were not rendered.
+func compareDir(staging, dir string, rendered map[string]struct{}) ([]*fileChange, error) {
+	var changes []*fileChange
+	keep := make(map[string]struct{}, len(rendered))
+	for src := range rendered {
+		rel, err := filepath.Rel(staging, src)
+		if err != nil {
+			return nil, err
+		}
+		dst := filepath.Join(dir, rel)
+		keep[dst] = struct{}{}
+		content, err := os.ReadFile(src)
+		if err != nil {
+			return nil, err
+		}
+		current, err := os.ReadFile(dst)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if current != nil && bytes.Equal(current, content) {
+			continue
+		}
+		fi, err := os.Stat(src)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &fileChange{path: dst, current: current, content: content, mode: fi.Mode().Perm()})
+	}
+
+	err := walkGenSubdirs(dir, func(path string, d fs.DirEntry) error {
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := keep[path]; ok {
+			return nil
+		}
+		current, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, &fileChange{path: path, current: current})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// apply writes and deletes the files listed in changes then deletes the
+// subdirectories of the gen directory of dir left empty. It returns the
+// absolute paths to the files written or deleted.
+func apply(dir string, changes []*fileChange) (map[string]struct{}, error) {
+	changed := make(map[string]struct{}, len(changes))
+	for _, c := range changes {
+		if c.content == nil {
+			if err := os.Remove(c.path); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := os.MkdirAll(filepath.Dir(c.path), 0750); err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(c.path, c.content, c.mode); err != nil {
+				return nil, err
+			}
+		}
+		changed[c.path] = struct{}{}
+	}
+
+	var dirs []string
+	err := walkGenSubdirs(dir, func(path string, d fs.DirEntry) error {
+		if d.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Delete the directories left empty, innermost first.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			if err := os.Remove(dirs[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return changed, nil
+}
+
+// walkGenSubdirs calls fn with the files and directories of the
+// subdirectories of the gen directory of dir, the files at the root of the gen
+// directory are not visited.
+func walkGenSubdirs(dir string, fn func(path string, d fs.DirEntry) error) error {
+	gendir := filepath.Join(dir, codegen.Gendir)
+	entries, err := os.ReadDir(gendir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		err := filepath.WalkDir(filepath.Join(gendir, e.Name()), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			return fn(path, d)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/dsl"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
+)
+
+func TestSyncDir(t *testing.T) {
+	staging, dir := t.TempDir(), t.TempDir()
+	write := func(root, path, content string) string {
+		p := filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0750))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+		return p
+	}
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for path, content := range map[string]string{
+		"gen/svc/service.go":        "unchanged",
+		"gen/svc/endpoints.go":      "old",
+		"gen/grpc/svc/pb/svc.pb.go": "unchanged",
+		"gen/removed/removed.go":    "removed",
+		"gen/http/removed/types.go": "removed",
+		"gen/doc.go":                "not generated",
+	} {
+		require.NoError(t, os.Chtimes(write(dir, path, content), old, old))
+	}
+	for path, content := range map[string]string{
+		"gen/svc/service.go":        "unchanged",
+		"gen/svc/endpoints.go":      "new",
+		"gen/svc/client.go":         "added",
+		"gen/grpc/svc/pb/svc.proto": "added",
+		"gen/grpc/svc/pb/svc.pb.go": "unchanged",
+	} {
+		write(staging, path, content)
+	}
+
+	rendered, err := stagedFiles(staging)
+	require.NoError(t, err)
+	changes, err := compareDir(staging, dir, rendered)
+	require.NoError(t, err)
+	changed, err := apply(dir, changes)
+	require.NoError(t, err)
+
+	var got []string
+	for p := range changed {
+		rel, err := filepath.Rel(dir, p)
+		require.NoError(t, err)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	assert.ElementsMatch(t, []string{"gen/svc/endpoints.go", "gen/svc/client.go", "gen/grpc/svc/pb/svc.proto", "gen/removed/removed.go", "gen/http/removed/types.go"}, got)
+
+	for _, path := range []string{"gen/svc/service.go", "gen/grpc/svc/pb/svc.pb.go"} {
+		fi, err := os.Stat(filepath.Join(dir, path))
+		require.NoError(t, err)
+		assert.True(t, fi.ModTime().Equal(old), "unchanged file %s was rewritten", path)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "gen", "svc", "endpoints.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+	assert.FileExists(t, filepath.Join(dir, "gen", "svc", "client.go"))
+	assert.FileExists(t, filepath.Join(dir, "gen", "doc.go"))
+	assert.NoDirExists(t, filepath.Join(dir, "gen", "removed"))
+	assert.NoDirExists(t, filepath.Join(dir, "gen", "http"))
+}
+
+func TestCompareAndApply(t *testing.T) {
+	staging, dir := t.TempDir(), t.TempDir()
+	write := func(root, path, content string) string {
+		p := filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0750))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+		return p
+	}
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for path, content := range map[string]string{
+		"gen/svc/service.go":        "unchanged",
+		"gen/svc/endpoints.go":      "old",
+		"gen/removed/removed.go":    "removed",
+		"gen/http/removed/types.go": "removed",
+		"gen/doc.go":                "not generated",
+	} {
+		require.NoError(t, os.Chtimes(write(dir, path, content), old, old))
+	}
+	rendered := make(map[string]struct{})
+	for path, content := range map[string]string{
+		"gen/svc/service.go":   "unchanged",
+		"gen/svc/endpoints.go": "new",
+		"gen/svc/client.go":    "added",
+	} {
+		rendered[write(staging, path, content)] = struct{}{}
+	}
+
+	changes, err := compareDir(staging, dir, rendered)
+	require.NoError(t, err)
+	summary := make(map[string]string)
+	for _, c := range changes {
+		rel, err := filepath.Rel(dir, c.path)
+		require.NoError(t, err)
+		summary[filepath.ToSlash(rel)] = string(c.current) + " -> " + string(c.content)
+	}
+	assert.Equal(t, map[string]string{
+		"gen/svc/endpoints.go":      "old -> new",
+		"gen/svc/client.go":         " -> added",
+		"gen/removed/removed.go":    "removed -> ",
+		"gen/http/removed/types.go": "removed -> ",
+	}, summary)
+
+	changed, err := apply(dir, changes)
+	require.NoError(t, err)
+	assert.Len(t, changed, 4)
+
+	fi, err := os.Stat(filepath.Join(dir, "gen", "svc", "service.go"))
+	require.NoError(t, err)
+	assert.True(t, fi.ModTime().Equal(old), "unchanged file was rewritten")
+	content, err := os.ReadFile(filepath.Join(dir, "gen", "svc", "endpoints.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+	assert.FileExists(t, filepath.Join(dir, "gen", "svc", "client.go"))
+	assert.FileExists(t, filepath.Join(dir, "gen", "doc.go"))
+	assert.NoDirExists(t, filepath.Join(dir, "gen", "removed"))
+	assert.NoDirExists(t, filepath.Join(dir, "gen", "http"))
+}
+
+func TestGenerateChangedProtoc(t *testing.T) {
+	dir, design := grpcProject(t)
+	pbFiles := []string{
+		filepath.Join("gen", "grpc", "calc", "pb", "goagen_calc_calc.pb.go"),
+		filepath.Join("gen", "grpc", "calc", "pb", "goagen_calc_calc_grpc.pb.go"),
+	}
+
+	grpccodegen.RunGRPCDSL(t, design)
+	changed, err := GenerateChanged(dir, "gen")
+	require.NoError(t, err)
+	assert.Subset(t, changed, pbFiles)
+	for _, f := range pbFiles {
+		assert.FileExists(t, filepath.Join(dir, f))
+	}
+
+	// Generating again must neither rewrite nor delete the files written
+	// by protoc.
+	grpccodegen.RunGRPCDSL(t, design)
+	changed, err = GenerateChanged(dir, "gen")
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	for _, f := range pbFiles {
+		assert.FileExists(t, filepath.Join(dir, f))
+	}
+}
+
+// grpcProject builds a fake protoc, creates a Go module in a temporary
+// directory and makes it the current working directory. It returns the module
+// directory and the design of a gRPC service whose proto files are compiled
+// with the fake protoc.
+func grpcProject(t *testing.T) (string, func()) {
+	t.Helper()
+	protoc := filepath.Join(t.TempDir(), "protoc")
+	if runtime.GOOS == "windows" {
+		protoc += ".exe"
+	}
+	out, err := exec.Command("go", "build", "-o", protoc, "./testdata/protoc").CombinedOutput()
+	require.NoError(t, err, "compile fake protoc: %s", out)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/calc\n\ngo 1.22\n"), 0600))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { assert.NoError(t, os.Chdir(wd)) })
+
+	return dir, func() {
+		dsl.API("calc", func() {
+			dsl.Meta("protoc:cmd", protoc)
+		})
+		dsl.Service("calc", func() {
+			dsl.Method("add", func() {
+				dsl.Payload(func() {
+					dsl.Field(1, "a", dsl.Int)
+					dsl.Field(2, "b", dsl.Int)
+				})
+				dsl.Result(dsl.Int)
+				dsl.GRPC(func() {})
+			})
+		})
+	}
+}
+
+func TestDiffProtoc(t *testing.T) {
+	dir, design := grpcProject(t)
+	pbFile := filepath.Join("gen", "grpc", "calc", "pb", "goagen_calc_calc.pb.go")
+
+	grpccodegen.RunGRPCDSL(t, design)
+	diffs, err := Diff(dir, "gen")
+	require.NoError(t, err)
+	var found bool
+	for _, d := range diffs {
+		if d.Path == pbFile {
+			found = true
+			assert.Contains(t, d.Diff, "--- /dev/null\n")
+		}
+	}
+	assert.True(t, found, "missing diff of %s", pbFile)
+
+	// The generated code is up to date once generated so that the check
+	// mode succeeds.
+	grpccodegen.RunGRPCDSL(t, design)
+	_, err = GenerateChanged(dir, "gen")
+	require.NoError(t, err)
+	grpccodegen.RunGRPCDSL(t, design)
+	diffs, err = Diff(dir, "gen")
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+	assert.FileExists(t, filepath.Join(dir, pbFile))
+}
+
+func TestRenderedSync(t *testing.T) {
+	dir, design := grpcProject(t)
+	service := filepath.Join("gen", "calc", "service.go")
+	pbFile := filepath.Join("gen", "grpc", "calc", "pb", "goagen_calc_calc.pb.go")
+
+	grpccodegen.RunGRPCDSL(t, design)
+	r, err := Render(dir, "gen")
+	require.NoError(t, err)
+	defer r.Remove()
+	changed, err := r.Sync()
+	require.NoError(t, err)
+	assert.Subset(t, changed, []string{service, pbFile})
+
+	// Syncing again reverts the changes made to the generated files
+	// without running the code generation algorithms.
+	require.NoError(t, os.Remove(filepath.Join(dir, service)))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, pbFile), []byte("package pb"), 0600))
+	changed, err = r.Sync()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{service, pbFile}, changed)
+
+	changed, err = r.Sync()
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
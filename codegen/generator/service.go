@@ -27,6 +27,10 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 			if f := service.ViewsFile(genpkg, s); f != nil {
 				files = append(files, f)
 			}
+			if f := service.RateLimitFile(genpkg, s); f != nil {
+				files = append(files, f)
+			}
+			files = append(files, service.MockFile(genpkg, s))
 			for _, f := range files {
 				if len(f.SectionTemplates) > 0 {
 					service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
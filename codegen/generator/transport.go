@@ -26,6 +26,7 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		files = append(files, httpcodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, httpcodegen.PathFiles(r)...)
 		files = append(files, httpcodegen.ClientCLIFiles(genpkg, r)...)
+		files = append(files, httpcodegen.HarnessFiles(genpkg, r)...)
 
 		// GRPC
 		files = append(files, grpccodegen.ProtoFiles(genpkg, r)...)
@@ -34,6 +35,7 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		files = append(files, grpccodegen.ServerTypeFiles(genpkg, r)...)
 		files = append(files, grpccodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, grpccodegen.ClientCLIFiles(genpkg, r)...)
+		files = append(files, grpccodegen.HarnessFiles(genpkg, r)...)
 
 		for _, f := range files {
 			if len(f.SectionTemplates) > 0 {
@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// TypeScript iterates through the roots and returns the files needed to
+// render the TypeScript clients of the HTTP services. It produces clients only
+// for the services that opt in with the "typescript:generate" meta.
+func TypeScript(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return httpcodegen.TypeScriptFiles(r)
+		}
+	}
+	return nil, nil
+}
@@ -119,7 +119,8 @@ func GetMetaType(att *expr.AttributeExpr) (typeName string, importS *ImportSpec)
 	return
 }
 
-// GetMetaTypeImports parses the attribute for all user defined imports
+// GetMetaTypeImports parses the attribute for all user defined imports and
+// for the imports required by the Go types of the primitives it uses.
 func GetMetaTypeImports(att *expr.AttributeExpr) []*ImportSpec {
 	return safelyGetMetaTypeImports(att, nil)
 }
@@ -171,11 +172,24 @@ func safelyGetMetaTypeImports(att *expr.AttributeExpr, seen map[string]struct{})
 				}
 			}
 		}
+	case *expr.Union:
+		for _, nat := range t.Values {
+			for _, im := range safelyGetMetaTypeImports(nat.Attribute, seen) {
+				if im != nil {
+					uniqueImports[*im] = struct{}{}
+				}
+			}
+		}
 	}
-	_, im := GetMetaType(att)
+	typeName, im := GetMetaType(att)
 	if im != nil {
 		uniqueImports[*im] = struct{}{}
 	}
+	if typeName == "" {
+		if im := GoNativeTypeImport(att.Type); im != nil {
+			uniqueImports[*im] = struct{}{}
+		}
+	}
 	for imp := range uniqueImports {
 		// Copy loop variable into body so next iteration doesn't overwrite its address https://stackoverflow.com/questions/27610039/golang-appending-leaves-only-last-element
 		cp := imp
@@ -0,0 +1,10 @@
+/*
+Package importer produces Goa designs from existing API descriptions. The
+importers return the Go source code of a design package that makes use of the
+Goa DSL so that services described with other tools can be migrated to Goa.
+
+The produced designs are a starting point: constructs that have no Goa
+equivalent are either approximated or omitted, the corresponding package
+functions document how.
+*/
+package importer
@@ -0,0 +1,1072 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// openapiImporter produces a design from an OpenAPI v3 document.
+	openapiImporter struct {
+		doc *openapi3.T
+		// schemas indexes the types created for the component schemas by
+		// reference.
+		schemas map[string]*designType
+		// types lists the types in definition order, the types created for
+		// inline objects are appended as they are encountered.
+		types []*designType
+		// used records the Go identifiers in use.
+		used map[string]bool
+		// names records the type names in use.
+		names map[string]bool
+		// deps records the references between the type variables.
+		deps varDeps
+		// current is the variable of the type being written, empty while
+		// writing the services.
+		current string
+		// strict is true while writing type expressions evaluated during
+		// package initialization, these cannot refer to types by name.
+		strict bool
+	}
+
+	// designType is a user type of the design.
+	designType struct {
+		// name is the type name.
+		name string
+		// varName is the name of the Go variable holding the type.
+		varName string
+		// schema is the type schema.
+		schema *openapi3.SchemaRef
+	}
+
+	// openapiService groups the operations of a service.
+	openapiService struct {
+		name        string
+		description string
+		methods     []*openapiMethod
+		// methodNames records the method names in use.
+		methodNames map[string]bool
+	}
+
+	// openapiMethod is an operation mapped to a service method.
+	openapiMethod struct {
+		name   string
+		verb   string
+		path   string
+		op     *openapi3.Operation
+		params []*openapi3.Parameter
+	}
+
+	// payloadParam is a payload attribute mapped to a request parameter.
+	payloadParam struct {
+		attr  string
+		param *openapi3.Parameter
+	}
+
+	// kind is the kind of data described by a schema.
+	kind int
+)
+
+const (
+	kindAny kind = iota
+	kindObject
+	kindMap
+	kindArray
+	kindString
+	kindBytes
+	kindInteger
+	kindNumber
+	kindBoolean
+)
+
+// schemaPrefix is the prefix of the component schema references.
+const schemaPrefix = "#/components/schemas/"
+
+var (
+	// verbs lists the HTTP methods in the order used to write the methods.
+	verbs = []string{
+		http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+	}
+
+	// formats maps the OpenAPI string formats to the DSL constants.
+	formats = map[string]string{
+		"date":      "FormatDate",
+		"date-time": "FormatDateTime",
+		"uuid":      "FormatUUID",
+		"email":     "FormatEmail",
+		"hostname":  "FormatHostname",
+		"ipv4":      "FormatIPv4",
+		"ipv6":      "FormatIPv6",
+		"ip":        "FormatIP",
+		"uri":       "FormatURI",
+		"mac":       "FormatMAC",
+		"cidr":      "FormatCIDR",
+		"regexp":    "FormatRegexp",
+		"regex":     "FormatRegexp",
+		"json":      "FormatJSON",
+		"rfc1123":   "FormatRFC1123",
+	}
+
+	// statuses maps the HTTP status codes to the DSL constants.
+	statuses = map[int]string{
+		http.StatusContinue:                      "StatusContinue",
+		http.StatusSwitchingProtocols:            "StatusSwitchingProtocols",
+		http.StatusProcessing:                    "StatusProcessing",
+		http.StatusOK:                            "StatusOK",
+		http.StatusCreated:                       "StatusCreated",
+		http.StatusAccepted:                      "StatusAccepted",
+		http.StatusNonAuthoritativeInfo:          "StatusNonAuthoritativeInfo",
+		http.StatusNoContent:                     "StatusNoContent",
+		http.StatusResetContent:                  "StatusResetContent",
+		http.StatusPartialContent:                "StatusPartialContent",
+		http.StatusMultiStatus:                   "StatusMultiStatus",
+		http.StatusAlreadyReported:               "StatusAlreadyReported",
+		http.StatusIMUsed:                        "StatusIMUsed",
+		http.StatusMultipleChoices:               "StatusMultipleChoices",
+		http.StatusMovedPermanently:              "StatusMovedPermanently",
+		http.StatusFound:                         "StatusFound",
+		http.StatusSeeOther:                      "StatusSeeOther",
+		http.StatusNotModified:                   "StatusNotModified",
+		http.StatusUseProxy:                      "StatusUseProxy",
+		http.StatusTemporaryRedirect:             "StatusTemporaryRedirect",
+		http.StatusPermanentRedirect:             "StatusPermanentRedirect",
+		http.StatusBadRequest:                    "StatusBadRequest",
+		http.StatusUnauthorized:                  "StatusUnauthorized",
+		http.StatusPaymentRequired:               "StatusPaymentRequired",
+		http.StatusForbidden:                     "StatusForbidden",
+		http.StatusNotFound:                      "StatusNotFound",
+		http.StatusMethodNotAllowed:              "StatusMethodNotAllowed",
+		http.StatusNotAcceptable:                 "StatusNotAcceptable",
+		http.StatusProxyAuthRequired:             "StatusProxyAuthRequired",
+		http.StatusRequestTimeout:                "StatusRequestTimeout",
+		http.StatusConflict:                      "StatusConflict",
+		http.StatusGone:                          "StatusGone",
+		http.StatusLengthRequired:                "StatusLengthRequired",
+		http.StatusPreconditionFailed:            "StatusPreconditionFailed",
+		http.StatusRequestEntityTooLarge:         "StatusRequestEntityTooLarge",
+		http.StatusRequestURITooLong:             "StatusRequestURITooLong",
+		http.StatusUnsupportedMediaType:          "StatusUnsupportedMediaType",
+		http.StatusRequestedRangeNotSatisfiable:  "StatusRequestedRangeNotSatisfiable",
+		http.StatusExpectationFailed:             "StatusExpectationFailed",
+		http.StatusTeapot:                        "StatusTeapot",
+		http.StatusUnprocessableEntity:           "StatusUnprocessableEntity",
+		http.StatusLocked:                        "StatusLocked",
+		http.StatusFailedDependency:              "StatusFailedDependency",
+		http.StatusUpgradeRequired:               "StatusUpgradeRequired",
+		http.StatusPreconditionRequired:          "StatusPreconditionRequired",
+		http.StatusTooManyRequests:               "StatusTooManyRequests",
+		http.StatusRequestHeaderFieldsTooLarge:   "StatusRequestHeaderFieldsTooLarge",
+		http.StatusUnavailableForLegalReasons:    "StatusUnavailableForLegalReasons",
+		http.StatusInternalServerError:           "StatusInternalServerError",
+		http.StatusNotImplemented:                "StatusNotImplemented",
+		http.StatusBadGateway:                    "StatusBadGateway",
+		http.StatusServiceUnavailable:            "StatusServiceUnavailable",
+		http.StatusGatewayTimeout:                "StatusGatewayTimeout",
+		http.StatusHTTPVersionNotSupported:       "StatusHTTPVersionNotSupported",
+		http.StatusVariantAlsoNegotiates:         "StatusVariantAlsoNegotiates",
+		http.StatusInsufficientStorage:           "StatusInsufficientStorage",
+		http.StatusLoopDetected:                  "StatusLoopDetected",
+		http.StatusNotExtended:                   "StatusNotExtended",
+		http.StatusNetworkAuthenticationRequired: "StatusNetworkAuthenticationRequired",
+	}
+
+	// typeNameRegex matches the schema names that can be used as is as
+	// type names.
+	typeNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+	// pathParamsRegex matches the path parameters.
+	pathParamsRegex = regexp.MustCompile(`{([^}]+)}`)
+)
+
+// OpenAPI returns the source code of a design package named pkg that describes
+// the API defined by the given OpenAPI v3 document encoded in JSON or YAML.
+// source describes the origin of the document in the package comment.
+//
+// The component schemas are mapped to user types. The operations are mapped to
+// the methods of the service named by the operation ID if it has the form
+// "service#method" as produced by the Goa OpenAPI generator, by the first
+// operation tag otherwise and by the API if there is none. The request
+// parameters and body are mapped to the method payload, the first success
+// response to the method result and the error responses to method errors.
+//
+// The schemas that make use of oneOf or anyOf are mapped to Any. Security
+// requirements, callbacks, links and response headers are not imported.
+func OpenAPI(data []byte, pkg, source string) ([]byte, error) {
+	data, err := normalize(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, only version 3 documents can be imported", doc.OpenAPI)
+	}
+	im := &openapiImporter{
+		doc:     doc,
+		schemas: make(map[string]*designType),
+		used:    make(map[string]bool),
+		names:   make(map[string]bool),
+		deps:    make(varDeps),
+	}
+	return im.design(pkg, source)
+}
+
+// design returns the design source code.
+func (im *openapiImporter) design(pkg, source string) ([]byte, error) {
+	if im.doc.Components != nil {
+		schemas := im.doc.Components.Schemas
+		for _, n := range sortedKeys(schemas) {
+			t := im.newType(n, schemas[n])
+			im.schemas[schemaPrefix+n] = t
+		}
+	}
+	// Extend requires a variable, record these references first so that
+	// the other references break the cycles.
+	for _, t := range im.types {
+		im.extendDeps(t.varName, t.schema)
+	}
+
+	apiName := snake(im.doc.Info.Title)
+	if apiName == "" {
+		apiName = "api"
+	}
+	services := im.services(apiName)
+
+	var w writer
+	w.header(pkg, source)
+	im.writeAPI(&w, apiName)
+	for _, svc := range services {
+		im.writeService(&w, svc)
+	}
+	for i := 0; i < len(im.types); i++ {
+		im.writeType(&w, im.types[i])
+	}
+	return w.source()
+}
+
+// normalize returns the JSON encoding of the given OpenAPI document where the
+// numeric exclusiveMinimum and exclusiveMaximum keywords defined by JSON Schema
+// 2019-09 and used by OpenAPI 3.1 are converted to their OpenAPI 3.0 form.
+func normalize(data []byte) ([]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var walk func(any) any
+	walk = func(v any) any {
+		switch val := v.(type) {
+		case map[string]any:
+			for k, e := range val {
+				val[k] = walk(e)
+			}
+			for kw, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+				switch n := val[kw].(type) {
+				case int, float64:
+					val[bound] = n
+					val[kw] = true
+				}
+			}
+			return val
+		case map[any]any:
+			m := make(map[string]any, len(val))
+			for k, e := range val {
+				m[fmt.Sprint(k)] = e
+			}
+			return walk(m)
+		case []any:
+			for i, e := range val {
+				val[i] = walk(e)
+			}
+		}
+		return v
+	}
+	return json.Marshal(walk(doc))
+}
+
+// newType creates a new user type for the given schema.
+func (im *openapiImporter) newType(name string, schema *openapi3.SchemaRef) *designType {
+	tname := name
+	if !typeNameRegex.MatchString(tname) {
+		tname = codegen.Goify(name, true)
+	}
+	base := tname
+	for i := 2; im.names[tname]; i++ {
+		tname = base + strconv.Itoa(i)
+	}
+	im.names[tname] = true
+	t := &designType{name: tname, varName: identifier(name, im.used), schema: schema}
+	im.types = append(im.types, t)
+	return t
+}
+
+// extendDeps records the references made by the allOf schemas of ref.
+func (im *openapiImporter) extendDeps(from string, ref *openapi3.SchemaRef) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	for _, a := range ref.Value.AllOf {
+		if t, ok := im.schemas[a.Ref]; ok {
+			im.deps.add(from, t.varName)
+			continue
+		}
+		im.extendDeps(from, a)
+	}
+}
+
+// use returns the expression that refers to type t. Types that would create a
+// package initialization cycle are referred to by name.
+func (im *openapiImporter) use(t *designType) string {
+	if im.current == "" {
+		return t.varName
+	}
+	if !im.strict && im.deps.reaches(t.varName, im.current) {
+		return quote(t.name)
+	}
+	im.deps.add(im.current, t.varName)
+	return t.varName
+}
+
+// services groups the operations by service.
+func (im *openapiImporter) services(apiName string) []*openapiService {
+	var (
+		services []*openapiService
+		byName   = make(map[string]*openapiService)
+	)
+	service := func(name string) *openapiService {
+		if svc, ok := byName[name]; ok {
+			return svc
+		}
+		svc := &openapiService{name: name, methodNames: make(map[string]bool)}
+		for _, tag := range im.doc.Tags {
+			if tag.Name == name {
+				svc.description = tag.Description
+			}
+		}
+		byName[name] = svc
+		services = append(services, svc)
+		return svc
+	}
+	if im.doc.Paths == nil {
+		return nil
+	}
+	paths := im.doc.Paths.Map()
+	for _, path := range sortedKeys(paths) {
+		item := paths[path]
+		ops := item.Operations()
+		for _, verb := range verbs {
+			op, ok := ops[verb]
+			if !ok {
+				continue
+			}
+			svcName, methName := apiName, ""
+			switch {
+			case strings.Contains(op.OperationID, "#"):
+				parts := strings.SplitN(op.OperationID, "#", 2)
+				svcName, methName = parts[0], parts[1]
+			case len(op.Tags) > 0:
+				svcName, methName = op.Tags[0], op.OperationID
+			default:
+				methName = op.OperationID
+			}
+			if methName == "" {
+				methName = strings.ToLower(verb) + " " + pathParamsRegex.ReplaceAllString(path, "by $1")
+			}
+			svc := service(svcName)
+			methName = snake(methName)
+			base := methName
+			for i := 2; svc.methodNames[methName]; i++ {
+				methName = base + "_" + strconv.Itoa(i)
+			}
+			svc.methodNames[methName] = true
+			svc.methods = append(svc.methods, &openapiMethod{
+				name:   methName,
+				verb:   verb,
+				path:   path,
+				op:     op,
+				params: mergeParams(item.Parameters, op.Parameters),
+			})
+		}
+	}
+	return services
+}
+
+// writeAPI writes the API and server expressions.
+func (im *openapiImporter) writeAPI(w *writer, name string) {
+	info := im.doc.Info
+	w.line("")
+	w.line("var _ = API(%s, func() {", quote(name))
+	if info.Title != "" {
+		w.line("Title(%s)", quote(info.Title))
+	}
+	if info.Description != "" {
+		w.line("Description(%s)", quote(info.Description))
+	}
+	if info.Version != "" {
+		w.line("Version(%s)", quote(info.Version))
+	}
+	if info.TermsOfService != "" {
+		w.line("TermsOfService(%s)", quote(info.TermsOfService))
+	}
+	if c := info.Contact; c != nil {
+		w.line("Contact(func() {")
+		if c.Name != "" {
+			w.line("Name(%s)", quote(c.Name))
+		}
+		if c.Email != "" {
+			w.line("Email(%s)", quote(c.Email))
+		}
+		if c.URL != "" {
+			w.line("URL(%s)", quote(c.URL))
+		}
+		w.line("})")
+	}
+	if l := info.License; l != nil {
+		w.line("License(func() {")
+		w.line("Name(%s)", quote(l.Name))
+		if l.URL != "" {
+			w.line("URL(%s)", quote(l.URL))
+		}
+		w.line("})")
+	}
+	if d := im.doc.ExternalDocs; d != nil {
+		w.line("Docs(func() {")
+		if d.Description != "" {
+			w.line("Description(%s)", quote(d.Description))
+		}
+		w.line("URL(%s)", quote(d.URL))
+		w.line("})")
+	}
+	if len(im.doc.Servers) > 0 {
+		w.line("Server(%s, func() {", quote(name))
+		hosts := make(map[string]bool)
+		for _, srv := range im.doc.Servers {
+			u := srv.URL
+			if !strings.Contains(u, "://") {
+				u = "http://localhost" + u
+			}
+			host := "localhost"
+			if parsed, err := url.Parse(pathParamsRegex.ReplaceAllString(u, "$1")); err == nil && parsed.Hostname() != "" {
+				host = parsed.Hostname()
+			}
+			host = snake(host)
+			base := host
+			for i := 2; hosts[host]; i++ {
+				host = base + "_" + strconv.Itoa(i)
+			}
+			hosts[host] = true
+			w.line("Host(%s, func() {", quote(host))
+			if srv.Description != "" {
+				w.line("Description(%s)", quote(srv.Description))
+			}
+			w.line("URI(%s)", quote(u))
+			for _, vn := range sortedKeys(srv.Variables) {
+				v := srv.Variables[vn]
+				if v.Description != "" {
+					w.line("Variable(%s, String, %s, func() {", quote(vn), quote(v.Description))
+				} else {
+					w.line("Variable(%s, String, func() {", quote(vn))
+				}
+				if v.Default != "" {
+					w.line("Default(%s)", quote(v.Default))
+				}
+				if len(v.Enum) > 0 {
+					vals := make([]any, len(v.Enum))
+					for i, e := range v.Enum {
+						vals[i] = e
+					}
+					lits, _ := literals(vals, false)
+					w.line("Enum(%s)", lits)
+				}
+				w.line("})")
+			}
+			w.line("})")
+		}
+		w.line("})")
+	}
+	w.line("})")
+}
+
+// writeService writes the service expression.
+func (im *openapiImporter) writeService(w *writer, svc *openapiService) {
+	w.line("")
+	w.line("var _ = Service(%s, func() {", quote(svc.name))
+	if svc.description != "" {
+		w.line("Description(%s)", quote(svc.description))
+	}
+	for i, m := range svc.methods {
+		if i > 0 || svc.description != "" {
+			w.line("")
+		}
+		im.writeMethod(w, m)
+	}
+	w.line("})")
+}
+
+// writeMethod writes the method expression.
+func (im *openapiImporter) writeMethod(w *writer, m *openapiMethod) {
+	hint := codegen.Goify(m.name, true)
+	w.line("Method(%s, func() {", quote(m.name))
+	desc := m.op.Description
+	if desc == "" {
+		desc = m.op.Summary
+	}
+	if desc != "" {
+		w.line("Description(%s)", quote(desc))
+	}
+
+	// Payload
+	path := m.path
+	var params []*payloadParam
+	{
+		attrs := make(map[string]bool)
+		for _, p := range m.params {
+			attr := snake(p.Name)
+			if attr == "" || attrs[attr] {
+				continue
+			}
+			attrs[attr] = true
+			if p.In == openapi3.ParameterInPath {
+				path = strings.ReplaceAll(path, "{"+p.Name+"}", "{"+attr+"}")
+			}
+			params = append(params, &payloadParam{attr: attr, param: p})
+		}
+		for _, match := range pathParamsRegex.FindAllStringSubmatch(m.path, -1) {
+			if attr := snake(match[1]); !attrs[attr] {
+				attrs[attr] = true
+				path = strings.ReplaceAll(path, match[0], "{"+attr+"}")
+				params = append(params, &payloadParam{attr: attr, param: &openapi3.Parameter{
+					Name:     match[1],
+					In:       openapi3.ParameterInPath,
+					Required: true,
+					Schema:   openapi3.NewStringSchema().NewRef(),
+				}})
+			}
+		}
+	}
+	var (
+		body         *openapi3.SchemaRef
+		bodyRequired bool
+	)
+	if rb := m.op.RequestBody; rb != nil && rb.Value != nil {
+		if mt := mediaType(rb.Value.Content); mt != nil && mt.Schema != nil {
+			body = mt.Schema
+			bodyRequired = rb.Value.Required
+		}
+	}
+	bodyAttr := ""
+	switch {
+	case len(params) == 0 && body == nil:
+	case len(params) == 0:
+		if body.Ref == "" && kindOf(body.Value) == kindObject {
+			w.line("Payload(func() {")
+			im.writeObject(w, body.Value, hint+"Payload")
+			w.line("})")
+		} else {
+			w.line("Payload(%s)", im.typeRef(body, hint+"Payload"))
+		}
+	default:
+		w.line("Payload(func() {")
+		var required []string
+		for _, p := range params {
+			im.writeAttribute(w, p.attr, p.param.Schema, p.param.Description, hint+codegen.Goify(p.attr, true))
+			if p.param.Required {
+				required = append(required, p.attr)
+			}
+		}
+		if body != nil {
+			if canMerge(body, params) {
+				im.writeObject(w, body.Value, hint+"Payload")
+			} else {
+				bodyAttr = "body"
+				im.writeAttribute(w, bodyAttr, body, "", hint+"Body")
+				if bodyRequired {
+					required = append(required, bodyAttr)
+				}
+			}
+		}
+		if len(required) > 0 {
+			w.line("Required(%s)", quoteAll(required))
+		}
+		w.line("})")
+	}
+
+	// Result and errors
+	success, successCode, errs := responses(m.op.Responses)
+	if success != nil {
+		if mt := mediaType(success.Content); mt != nil && mt.Schema != nil {
+			if mt.Schema.Ref == "" && kindOf(mt.Schema.Value) == kindObject {
+				w.line("Result(func() {")
+				im.writeObject(w, mt.Schema.Value, hint+"Result")
+				w.line("})")
+			} else {
+				w.line("Result(%s)", im.typeRef(mt.Schema, hint+"Result"))
+			}
+		}
+	}
+	errNames := make([]string, len(errs))
+	for i, code := range errs {
+		resp := m.op.Responses.Status(code).Value
+		name := snake(http.StatusText(code))
+		if name == "" {
+			name = "status_" + strconv.Itoa(code)
+		}
+		errNames[i] = name
+		args := []string{quote(name)}
+		hasDesc := resp.Description != nil && *resp.Description != ""
+		if mt := mediaType(resp.Content); mt != nil && mt.Schema != nil {
+			args = append(args, im.typeRef(mt.Schema, hint+codegen.Goify(name, true)))
+		} else if hasDesc {
+			args = append(args, "ErrorResult")
+		}
+		if hasDesc {
+			args = append(args, quote(*resp.Description))
+		}
+		w.line("Error(%s)", strings.Join(args, ", "))
+	}
+
+	// HTTP
+	w.line("HTTP(func() {")
+	w.line("%s(%s)", m.verb, quote(path))
+	for _, p := range params {
+		var fn string
+		switch p.param.In {
+		case openapi3.ParameterInQuery:
+			fn = "Param"
+		case openapi3.ParameterInHeader:
+			fn = "Header"
+		case openapi3.ParameterInCookie:
+			fn = "Cookie"
+		default:
+			continue
+		}
+		mapping := p.attr
+		if p.param.Name != p.attr {
+			mapping += ":" + p.param.Name
+		}
+		w.line("%s(%s)", fn, quote(mapping))
+	}
+	if bodyAttr != "" {
+		w.line("Body(%s)", quote(bodyAttr))
+	}
+	if success != nil {
+		w.line("Response(%s)", status(successCode))
+	}
+	for i, code := range errs {
+		w.line("Response(%s, %s)", quote(errNames[i]), status(code))
+	}
+	w.line("})")
+	w.line("})")
+}
+
+// writeType writes the type definition.
+func (im *openapiImporter) writeType(w *writer, t *designType) {
+	im.current = t.varName
+	defer func() { im.current = "" }()
+
+	w.line("")
+	s := t.schema.Value
+	if t.schema.Ref != "" {
+		if base, ok := im.schemas[t.schema.Ref]; ok && base != t {
+			// Alias of another component.
+			im.deps.add(t.varName, base.varName)
+			w.line("var %s = Type(%s, func() {", t.varName, quote(t.name))
+			w.line("Extend(%s)", base.varName)
+			w.line("})")
+			return
+		}
+	}
+	if s == nil {
+		w.line("var %s = Type(%s, Any)", t.varName, quote(t.name))
+		return
+	}
+	if kindOf(s) == kindObject {
+		w.line("var %s = Type(%s, func() {", t.varName, quote(t.name))
+		im.writeObject(w, s, t.varName)
+		w.line("})")
+		return
+	}
+	im.strict = true
+	base := im.typeExpr(s, t.varName)
+	im.strict = false
+	var b writer
+	im.writeValidations(&b, s)
+	if s.Description == "" && b.buf.Len() == 0 {
+		w.line("var %s = Type(%s, %s)", t.varName, quote(t.name), base)
+		return
+	}
+	w.line("var %s = Type(%s, %s, func() {", t.varName, quote(t.name), base)
+	if s.Description != "" {
+		w.line("Description(%s)", quote(s.Description))
+	}
+	w.buf.Write(b.buf.Bytes())
+	w.line("})")
+}
+
+// writeObject writes the description, attributes and required attributes of
+// the given object schema.
+func (im *openapiImporter) writeObject(w *writer, s *openapi3.Schema, hint string) {
+	if s.Description != "" {
+		w.line("Description(%s)", quote(s.Description))
+	}
+	required := im.writeAttributes(w, s, hint)
+	if len(required) > 0 {
+		w.line("Required(%s)", quoteAll(required))
+	}
+	if ex, ok := literal(s.Example, false); ok {
+		w.line("Example(%s)", ex)
+	}
+}
+
+// writeAttributes writes the attributes of the given object schema including
+// the ones defined by its allOf schemas and returns the required attributes.
+func (im *openapiImporter) writeAttributes(w *writer, s *openapi3.Schema, hint string) []string {
+	var required []string
+	for _, a := range s.AllOf {
+		if t, ok := im.schemas[a.Ref]; ok {
+			if im.current != "" {
+				im.deps.add(im.current, t.varName)
+			}
+			w.line("Extend(%s)", t.varName)
+			continue
+		}
+		if a.Value != nil {
+			required = append(required, im.writeAttributes(w, a.Value, hint)...)
+		}
+	}
+	for _, n := range sortedKeys(s.Properties) {
+		im.writeAttribute(w, n, s.Properties[n], "", hint+codegen.Goify(n, true))
+	}
+	for _, r := range s.Required {
+		if _, ok := s.Properties[r]; ok {
+			required = append(required, r)
+		}
+	}
+	return required
+}
+
+// writeAttribute writes the attribute with the given name and schema. desc
+// is used as description if the schema does not define one.
+func (im *openapiImporter) writeAttribute(w *writer, name string, ref *openapi3.SchemaRef, desc string, hint string) {
+	if ref == nil {
+		ref = openapi3.NewSchemaRef("", &openapi3.Schema{})
+	}
+	if _, ok := im.schemas[ref.Ref]; ok {
+		if desc != "" {
+			w.line("Attribute(%s, %s, %s)", quote(name), im.typeRef(ref, hint), quote(desc))
+		} else {
+			w.line("Attribute(%s, %s)", quote(name), im.typeRef(ref, hint))
+		}
+		return
+	}
+	s := ref.Value
+	if s.Description != "" {
+		desc = s.Description
+	}
+	if kindOf(s) == kindObject {
+		w.line("Attribute(%s, func() {", quote(name))
+		if desc != "" && s.Description == "" {
+			w.line("Description(%s)", quote(desc))
+		}
+		im.writeObject(w, s, hint)
+		w.line("})")
+		return
+	}
+	args := []string{quote(name), im.typeExpr(s, hint)}
+	if desc != "" {
+		args = append(args, quote(desc))
+	}
+	var b writer
+	im.writeValidations(&b, s)
+	if b.buf.Len() == 0 {
+		w.line("Attribute(%s)", strings.Join(args, ", "))
+		return
+	}
+	w.line("Attribute(%s, func() {", strings.Join(args, ", "))
+	w.buf.Write(b.buf.Bytes())
+	w.line("})")
+}
+
+// writeValidations writes the validations, default value and example of the
+// given non-object schema.
+func (im *openapiImporter) writeValidations(w *writer, s *openapi3.Schema) {
+	k := kindOf(s)
+	float := k == kindNumber
+	if k == kindString {
+		if f, ok := formats[s.Format]; ok {
+			w.line("Format(%s)", f)
+		}
+	}
+	if len(s.Enum) > 0 {
+		if lits, ok := literals(s.Enum, float); ok {
+			w.line("Enum(%s)", lits)
+		}
+	}
+	if s.Pattern != "" {
+		w.line("Pattern(%s)", quote(s.Pattern))
+	}
+	switch k {
+	case kindString:
+		if s.MinLength > 0 {
+			w.line("MinLength(%d)", s.MinLength)
+		}
+		if s.MaxLength != nil {
+			w.line("MaxLength(%d)", *s.MaxLength)
+		}
+	case kindArray:
+		if s.MinItems > 0 {
+			w.line("MinLength(%d)", s.MinItems)
+		}
+		if s.MaxItems != nil {
+			w.line("MaxLength(%d)", *s.MaxItems)
+		}
+	case kindInteger, kindNumber:
+		if s.Min != nil {
+			fn := "Minimum"
+			if s.ExclusiveMin {
+				fn = "ExclusiveMinimum"
+			}
+			lit, _ := literal(*s.Min, float)
+			w.line("%s(%s)", fn, lit)
+		}
+		if s.Max != nil {
+			fn := "Maximum"
+			if s.ExclusiveMax {
+				fn = "ExclusiveMaximum"
+			}
+			lit, _ := literal(*s.Max, float)
+			w.line("%s(%s)", fn, lit)
+		}
+	}
+	if k != kindBytes {
+		if def, ok := literal(s.Default, float); ok {
+			w.line("Default(%s)", def)
+		}
+		if ex, ok := literal(s.Example, float); ok {
+			w.line("Example(%s)", ex)
+		}
+	}
+	var elem *openapi3.SchemaRef
+	switch k {
+	case kindArray:
+		elem = s.Items
+	case kindMap:
+		elem = s.AdditionalProperties.Schema
+	}
+	if elem != nil && elem.Ref == "" && elem.Value != nil {
+		var b writer
+		im.writeValidations(&b, elem.Value)
+		if b.buf.Len() > 0 {
+			w.line("Elem(func() {")
+			w.buf.Write(b.buf.Bytes())
+			w.line("})")
+		}
+	}
+}
+
+// typeRef returns the expression of the type described by ref. hint is used
+// to name the types created for inline objects.
+func (im *openapiImporter) typeRef(ref *openapi3.SchemaRef, hint string) string {
+	if ref == nil {
+		return "Any"
+	}
+	if t, ok := im.schemas[ref.Ref]; ok {
+		return im.use(t)
+	}
+	if ref.Value == nil {
+		return "Any"
+	}
+	if kindOf(ref.Value) == kindObject {
+		return im.use(im.newType(hint, openapi3.NewSchemaRef("", ref.Value)))
+	}
+	return im.typeExpr(ref.Value, hint)
+}
+
+// typeExpr returns the expression of the type described by the given
+// non-object schema.
+func (im *openapiImporter) typeExpr(s *openapi3.Schema, hint string) string {
+	switch kindOf(s) {
+	case kindArray:
+		return "ArrayOf(" + im.typeRef(s.Items, hint+"Item") + ")"
+	case kindMap:
+		return "MapOf(String, " + im.typeRef(s.AdditionalProperties.Schema, hint+"Value") + ")"
+	case kindString:
+		return "String"
+	case kindBytes:
+		return "Bytes"
+	case kindInteger:
+		switch s.Format {
+		case "int32":
+			return "Int32"
+		case "int64":
+			return "Int64"
+		case "uint":
+			return "UInt"
+		case "uint32":
+			return "UInt32"
+		case "uint64":
+			return "UInt64"
+		}
+		return "Int"
+	case kindNumber:
+		if s.Format == "float" {
+			return "Float32"
+		}
+		return "Float64"
+	case kindBoolean:
+		return "Boolean"
+	}
+	return "Any"
+}
+
+// kindOf returns the kind of data described by s.
+func kindOf(s *openapi3.Schema) kind {
+	if s == nil || len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return kindAny
+	}
+	if len(s.AllOf) > 0 {
+		return kindObject
+	}
+	t := s.Type
+	switch {
+	case t.Includes(openapi3.TypeArray) || t == nil && s.Items != nil:
+		return kindArray
+	case t.Includes(openapi3.TypeObject) || t == nil && (len(s.Properties) > 0 || s.AdditionalProperties.Schema != nil):
+		if len(s.Properties) > 0 {
+			return kindObject
+		}
+		return kindMap
+	case t.Includes(openapi3.TypeString):
+		if s.Format == "byte" || s.Format == "binary" {
+			return kindBytes
+		}
+		return kindString
+	case t.Includes(openapi3.TypeInteger):
+		return kindInteger
+	case t.Includes(openapi3.TypeNumber):
+		return kindNumber
+	case t.Includes(openapi3.TypeBoolean):
+		return kindBoolean
+	}
+	return kindAny
+}
+
+// canMerge returns true if the attributes of the request body schema can be
+// added to the payload next to the parameter attributes.
+func canMerge(body *openapi3.SchemaRef, params []*payloadParam) bool {
+	if body.Ref != "" || kindOf(body.Value) != kindObject || len(body.Value.AllOf) > 0 {
+		return false
+	}
+	for _, p := range params {
+		if _, ok := body.Value.Properties[p.attr]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeParams returns the operation parameters including the path item
+// parameters it does not override sorted by location.
+func mergeParams(item, op openapi3.Parameters) []*openapi3.Parameter {
+	var params []*openapi3.Parameter
+	for _, p := range item {
+		if p.Value != nil && op.GetByInAndName(p.Value.In, p.Value.Name) == nil {
+			params = append(params, p.Value)
+		}
+	}
+	for _, p := range op {
+		if p.Value != nil {
+			params = append(params, p.Value)
+		}
+	}
+	res := make([]*openapi3.Parameter, 0, len(params))
+	for _, in := range []string{openapi3.ParameterInPath, openapi3.ParameterInQuery, openapi3.ParameterInHeader, openapi3.ParameterInCookie} {
+		for _, p := range params {
+			if p.In == in {
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+// responses returns the first success response and its status code and the
+// sorted status codes of the error responses.
+func responses(rs *openapi3.Responses) (*openapi3.Response, int, []int) {
+	if rs == nil {
+		return nil, 0, nil
+	}
+	var codes []int
+	for k := range rs.Map() {
+		if code, err := strconv.Atoi(k); err == nil {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+	var (
+		success     *openapi3.Response
+		successCode int
+		errs        []int
+	)
+	for _, code := range codes {
+		resp := rs.Status(code)
+		if resp == nil || resp.Value == nil {
+			continue
+		}
+		switch {
+		case code >= 200 && code < 300:
+			if success == nil {
+				success, successCode = resp.Value, code
+			}
+		case code >= 400:
+			errs = append(errs, code)
+		}
+	}
+	return success, successCode, errs
+}
+
+// mediaType returns the JSON media type of content if any, the first media
+// type otherwise.
+func mediaType(content openapi3.Content) *openapi3.MediaType {
+	if len(content) == 0 {
+		return nil
+	}
+	keys := sortedKeys(content)
+	for _, k := range keys {
+		if k == "application/json" || strings.HasSuffix(k, "+json") {
+			return content[k]
+		}
+	}
+	return content[keys[0]]
+}
+
+// status returns the expression of the given HTTP status code.
+func status(code int) string {
+	if s, ok := statuses[code]; ok {
+		return s
+	}
+	return strconv.Itoa(code)
+}
+
+// sortedKeys returns the sorted keys of m.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
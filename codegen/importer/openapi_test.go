@@ -0,0 +1,255 @@
+package importer
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+func TestOpenAPI(t *testing.T) {
+	cases := []string{"petstore.yaml", "calc.json"}
+	for _, file := range cases {
+		name := strings.TrimSuffix(file, filepath.Ext(file))
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", file))
+			require.NoError(t, err)
+
+			src, err := OpenAPI(data, "design", file)
+
+			require.NoError(t, err)
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, src, 0644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(src))
+		})
+	}
+}
+
+func TestOpenAPIErrors(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Data     string
+		Expected string
+	}{
+		{"invalid", "{", "failed to load OpenAPI document"},
+		{"v2", `{"swagger": "2.0", "info": {"title": "t", "version": "1"}, "paths": {}}`, "unsupported OpenAPI version"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := OpenAPI([]byte(c.Data), "design", "test")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), c.Expected)
+		})
+	}
+}
+
+func TestReserved(t *testing.T) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), filepath.Join("..", "..", "dsl"), nil, 0)
+	require.NoError(t, err)
+	for _, pkg := range pkgs {
+		for name, f := range pkg.Files {
+			if strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			for id, obj := range f.Scope.Objects {
+				if obj.Decl != nil && isExported(id) {
+					assert.True(t, reserved[id], "dsl identifier %q is missing from reserved", id)
+				}
+			}
+		}
+	}
+}
+
+func isExported(id string) bool {
+	return id != "" && strings.ToUpper(id[:1]) == id[:1]
+}
+
+// roundTripMain is the program that evaluates the imported design and prints
+// the OpenAPI v3 specification generated from it.
+const roundTripMain = `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	openapiv3 "goa.design/goa/v3/http/codegen/openapi/v3"
+)
+
+func main() {
+	if err := eval.RunDSL(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(openapiv3.New(expr.Root)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`
+
+func TestOpenAPIRoundTrip(t *testing.T) {
+	cases := []string{"petstore.yaml", "calc.json"}
+	for _, file := range cases {
+		name := strings.TrimSuffix(file, filepath.Ext(file))
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", file))
+			require.NoError(t, err)
+			src, err := OpenAPI(data, "main", file)
+			require.NoError(t, err)
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "design.go"), src, 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(roundTripMain), 0600))
+			cmd := exec.Command("go", "run", filepath.Join(dir, "main.go"), filepath.Join(dir, "design.go"))
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			out, err := cmd.Output()
+			require.NoError(t, err, "evaluate imported design: %s", stderr.String())
+
+			want := loadOperations(t, data)
+			got := loadOperations(t, out)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+// loadOperations loads the given OpenAPI document and returns a description of
+// its operations indexed by verb and path that ignores the details that do not
+// survive an import, e.g. the names of the path parameters, descriptions or
+// examples.
+func loadOperations(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	data, err := normalize(data)
+	require.NoError(t, err)
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+	ops := make(map[string]string)
+	for path, item := range doc.Paths.Map() {
+		for verb, op := range item.Operations() {
+			var b strings.Builder
+			for _, p := range mergeParams(item.Parameters, op.Parameters) {
+				name := p.Name
+				if p.In == openapi3.ParameterInPath {
+					name = ""
+				}
+				fmt.Fprintf(&b, "param %s %s required=%t %s\n", p.In, name, p.Required, shape(p.Schema, 0))
+			}
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				if mt := mediaType(op.RequestBody.Value.Content); mt != nil {
+					fmt.Fprintf(&b, "body %s\n", shape(mt.Schema, 0))
+				}
+			}
+			for _, code := range sortedKeys(op.Responses.Map()) {
+				fmt.Fprintf(&b, "response %s", code)
+				if code[0] == '2' {
+					if mt := mediaType(op.Responses.Value(code).Value.Content); mt != nil {
+						fmt.Fprintf(&b, " %s", shape(mt.Schema, 0))
+					}
+				}
+				b.WriteString("\n")
+			}
+			ops[verb+" "+pathParams.ReplaceAllString(path, "{}")] = b.String()
+		}
+	}
+	return ops
+}
+
+// pathParams matches the path parameters of an OpenAPI path.
+var pathParams = regexp.MustCompile(`\{[^}]*\}`)
+
+// shape describes the type and validations of the given schema. The depth
+// limits the description of recursive schemas.
+func shape(ref *openapi3.SchemaRef, depth int) string {
+	if ref == nil || ref.Value == nil {
+		return "any"
+	}
+	if depth > 2 {
+		return "..."
+	}
+	s := ref.Value
+	if len(s.AllOf) > 0 {
+		merged := &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeObject}, Properties: openapi3.Schemas{}}
+		for _, sub := range s.AllOf {
+			if sub.Value == nil {
+				continue
+			}
+			for n, p := range sub.Value.Properties {
+				merged.Properties[n] = p
+			}
+			merged.Required = append(merged.Required, sub.Value.Required...)
+		}
+		s = merged
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 || s.Type == nil {
+		return "any"
+	}
+	var b strings.Builder
+	b.WriteString(strings.Join(s.Type.Slice(), ","))
+	// Int and Float64 are rendered with explicit formats and Bytes with
+	// the binary format.
+	switch s.Format {
+	case "", "int64", "double":
+	case "binary":
+		b.WriteString(" format=byte")
+	default:
+		fmt.Fprintf(&b, " format=%s", s.Format)
+	}
+	if len(s.Enum) > 0 {
+		fmt.Fprintf(&b, " enum=%v", s.Enum)
+	}
+	if s.Default != nil {
+		fmt.Fprintf(&b, " default=%v", s.Default)
+	}
+	if s.Min != nil {
+		fmt.Fprintf(&b, " min=%v exclusive=%t", *s.Min, s.ExclusiveMin)
+	}
+	if s.Max != nil {
+		fmt.Fprintf(&b, " max=%v exclusive=%t", *s.Max, s.ExclusiveMax)
+	}
+	if s.MinLength > 0 {
+		fmt.Fprintf(&b, " minLength=%d", s.MinLength)
+	}
+	if s.MaxLength != nil {
+		fmt.Fprintf(&b, " maxLength=%d", *s.MaxLength)
+	}
+	if s.Pattern != "" {
+		fmt.Fprintf(&b, " pattern=%s", s.Pattern)
+	}
+	if s.Items != nil {
+		fmt.Fprintf(&b, " items=(%s)", shape(s.Items, depth+1))
+	}
+	if s.AdditionalProperties.Schema != nil {
+		fmt.Fprintf(&b, " values=(%s)", shape(s.AdditionalProperties.Schema, depth+1))
+	}
+	if len(s.Properties) > 0 {
+		required := append([]string(nil), s.Required...)
+		sort.Strings(required)
+		fmt.Fprintf(&b, " required=%v {", required)
+		for _, n := range sortedKeys(s.Properties) {
+			fmt.Fprintf(&b, " %s: %s;", n, shape(s.Properties[n], depth+1))
+		}
+		b.WriteString(" }")
+	}
+	return b.String()
+}
@@ -0,0 +1,505 @@
+package importer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// protoImporter produces a design from proto3 files.
+	protoImporter struct {
+		// load returns the content of imported files.
+		load func(string) ([]byte, error)
+		// files indexes the parsed files by import path.
+		files map[string]*protoFile
+		// missing lists the imported files that could not be loaded.
+		missing []string
+		// decls indexes the messages and enums by full name.
+		decls map[string]*protoDecl
+		// types lists the types in definition order.
+		types []*protoDecl
+		// used records the Go identifiers in use.
+		used map[string]bool
+		// names records the type names in use.
+		names map[string]bool
+		// deps records the references between the type variables.
+		deps varDeps
+		// current is the variable of the type being written, empty while
+		// writing the services.
+		current string
+	}
+
+	// protoDecl is a message or enum mapped to a user type.
+	protoDecl struct {
+		msg  *protoMessage
+		enum *protoEnum
+		// parent is the prefix of the type name derived from the enclosing
+		// messages if any.
+		parent string
+		// name is the type name, empty until the type is used.
+		name string
+		// varName is the name of the Go variable holding the type.
+		varName string
+		// external lists the "struct:field:proto" meta values that bind
+		// the type to an existing message, nil if the message is
+		// generated.
+		external []string
+	}
+)
+
+// wellKnownTypes lists the supported google.protobuf well-known types
+// indexed by import path. The message definitions are used to create the
+// design types, the fields that use them are bound to the existing messages.
+var wellKnownTypes = map[string]struct {
+	goPkg string
+	src   string
+}{
+	"google/protobuf/any.proto": {
+		"google.golang.org/protobuf/types/known/anypb",
+		"message Any { string type_url = 1; bytes value = 2; }",
+	},
+	"google/protobuf/duration.proto": {
+		"google.golang.org/protobuf/types/known/durationpb",
+		"message Duration { int64 seconds = 1; int32 nanos = 2; }",
+	},
+	"google/protobuf/empty.proto": {
+		"google.golang.org/protobuf/types/known/emptypb",
+		"message Empty {}",
+	},
+	"google/protobuf/field_mask.proto": {
+		"google.golang.org/protobuf/types/known/fieldmaskpb",
+		"message FieldMask { repeated string paths = 1; }",
+	},
+	"google/protobuf/struct.proto": {
+		"google.golang.org/protobuf/types/known/structpb",
+		`message Struct { map<string, Value> fields = 1; }
+		message Value {
+			oneof kind {
+				NullValue null_value = 1;
+				double number_value = 2;
+				string string_value = 3;
+				bool bool_value = 4;
+				Struct struct_value = 5;
+				ListValue list_value = 6;
+			}
+		}
+		enum NullValue { NULL_VALUE = 0; }
+		message ListValue { repeated Value values = 1; }`,
+	},
+	"google/protobuf/timestamp.proto": {
+		"google.golang.org/protobuf/types/known/timestamppb",
+		"message Timestamp { int64 seconds = 1; int32 nanos = 2; }",
+	},
+	"google/protobuf/wrappers.proto": {
+		"google.golang.org/protobuf/types/known/wrapperspb",
+		`message DoubleValue { double value = 1; }
+		message FloatValue { float value = 1; }
+		message Int64Value { int64 value = 1; }
+		message UInt64Value { uint64 value = 1; }
+		message Int32Value { int32 value = 1; }
+		message UInt32Value { uint32 value = 1; }
+		message BoolValue { bool value = 1; }
+		message StringValue { string value = 1; }
+		message BytesValue { bytes value = 1; }`,
+	},
+}
+
+// protoScalars maps the protobuf scalar types to the DSL primitive types and
+// to the protobuf type generated by Goa for these primitives.
+var protoScalars = map[string]struct{ dsl, goaProto string }{
+	"double":   {"Float64", "double"},
+	"float":    {"Float32", "float"},
+	"int32":    {"Int32", "sint32"},
+	"int64":    {"Int64", "sint64"},
+	"uint32":   {"UInt32", "uint32"},
+	"uint64":   {"UInt64", "uint64"},
+	"sint32":   {"Int32", "sint32"},
+	"sint64":   {"Int64", "sint64"},
+	"fixed32":  {"UInt32", "uint32"},
+	"fixed64":  {"UInt64", "uint64"},
+	"sfixed32": {"Int32", "sint32"},
+	"sfixed64": {"Int64", "sint64"},
+	"bool":     {"Boolean", "bool"},
+	"string":   {"String", "string"},
+	"bytes":    {"Bytes", "bytes"},
+}
+
+// emptyMessage is the full name of the google.protobuf.Empty message.
+const emptyMessage = "google.protobuf.Empty"
+
+// Proto returns the source code of a design package named pkg that describes
+// the services defined in the given proto3 file. source is the import path of
+// the file, load returns the content of the files it imports given their
+// import path. The google.protobuf well-known types are built-in and are not
+// loaded.
+//
+// The services and rpcs are mapped to services and methods that use the gRPC
+// transport and the proto package, the messages used by the services to types
+// whose fields keep their numbers, the oneofs to OneOf and the enums to Int32
+// types with an Enum validation. Fields whose protobuf type differs from the
+// one Goa generates for the corresponding primitive, e.g. int64 rather than
+// sint64, are given a "struct:field:proto" meta so that the wire format is
+// preserved. The fields that use well-known types are bound to the existing
+// messages the same way.
+//
+// Custom options, including HTTP annotations, are ignored. gRPC metadata is
+// not described by proto files and is thus not imported.
+func Proto(data []byte, pkg, source string, load func(string) ([]byte, error)) ([]byte, error) {
+	im := &protoImporter{
+		load:  load,
+		files: make(map[string]*protoFile),
+		decls: make(map[string]*protoDecl),
+		used:  make(map[string]bool),
+		names: map[string]bool{"Empty": true},
+		deps:  make(varDeps),
+	}
+	f, err := im.parse(source, data)
+	if err != nil {
+		return nil, err
+	}
+	return im.design(f, pkg, source)
+}
+
+// parse parses the given file and the files it imports and indexes their
+// messages and enums.
+func (im *protoImporter) parse(name string, data []byte) (*protoFile, error) {
+	external := ""
+	if wk, ok := wellKnownTypes[name]; ok {
+		data = []byte("syntax = \"proto3\";\npackage google.protobuf;\n" + wk.src)
+		external = wk.goPkg
+	}
+	f, err := parseProto(name, data)
+	if err != nil {
+		return nil, err
+	}
+	im.files[name] = f
+	var index func(parent string, msgs []*protoMessage, enums []*protoEnum)
+	index = func(parent string, msgs []*protoMessage, enums []*protoEnum) {
+		for _, e := range enums {
+			im.decls[e.fullName] = &protoDecl{enum: e, parent: parent}
+		}
+		for _, m := range msgs {
+			d := &protoDecl{msg: m, parent: parent}
+			if external != "" {
+				d.external = []string{m.fullName, name, m.name, external}
+			}
+			im.decls[m.fullName] = d
+			index(parent+codegen.Goify(m.name, true), m.messages, m.enums)
+		}
+	}
+	index("", f.messages, f.enums)
+	for _, imp := range f.imports {
+		if _, ok := im.files[imp]; ok {
+			continue
+		}
+		var data []byte
+		if _, ok := wellKnownTypes[imp]; !ok {
+			if data, err = im.load(imp); err != nil {
+				im.missing = append(im.missing, imp)
+				continue
+			}
+		}
+		if _, err := im.parse(imp, data); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// design returns the design source code.
+func (im *protoImporter) design(f *protoFile, pkg, source string) ([]byte, error) {
+	// Messages defined in the imported file come first, in order.
+	var declare func(msgs []*protoMessage, enums []*protoEnum)
+	declare = func(msgs []*protoMessage, enums []*protoEnum) {
+		for _, m := range msgs {
+			im.declare(im.decls[m.fullName])
+			declare(m.messages, m.enums)
+		}
+		for _, e := range enums {
+			im.declare(im.decls[e.fullName])
+		}
+	}
+	declare(f.messages, f.enums)
+
+	var w writer
+	w.header(pkg, source)
+	w.line("")
+	if f.pkg == "" {
+		w.line("var _ = API(%s, func() {})", quote("api"))
+	} else {
+		w.line("var _ = API(%s, func() {", quote(snake(strings.Split(f.pkg, ".")[0])))
+		w.line("Title(%s)", quote(fmt.Sprintf("%s services", f.pkg)))
+		w.line("})")
+	}
+	for _, svc := range f.services {
+		if err := im.writeService(&w, svc, f.pkg); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i < len(im.types); i++ {
+		if err := im.writeType(&w, im.types[i]); err != nil {
+			return nil, err
+		}
+	}
+	return w.source()
+}
+
+// declare assigns a type name and variable to d and schedules the writing of
+// its definition.
+func (im *protoImporter) declare(d *protoDecl) {
+	if d.name != "" {
+		return
+	}
+	var name string
+	if d.msg != nil {
+		name = d.msg.name
+	} else {
+		name = d.enum.name
+	}
+	name = d.parent + codegen.Goify(name, true)
+	base := name
+	for i := 2; im.names[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	im.names[name] = true
+	d.name = name
+	d.varName = identifier(name, im.used)
+	im.types = append(im.types, d)
+}
+
+// resolve returns the message or enum with the given name referenced from the
+// given scope using the protobuf scoping rules.
+func (im *protoImporter) resolve(name, scope string) (*protoDecl, error) {
+	if strings.HasPrefix(name, ".") {
+		if d, ok := im.decls[name[1:]]; ok {
+			return d, nil
+		}
+	} else {
+		for {
+			if d, ok := im.decls[qualify(scope, name)]; ok {
+				return d, nil
+			}
+			if scope == "" {
+				break
+			}
+			if i := strings.LastIndexByte(scope, '.'); i >= 0 {
+				scope = scope[:i]
+			} else {
+				scope = ""
+			}
+		}
+	}
+	if len(im.missing) > 0 {
+		return nil, fmt.Errorf("unknown type %q, the following imports could not be loaded: %s", name, strings.Join(im.missing, ", "))
+	}
+	return nil, fmt.Errorf("unknown type %q", name)
+}
+
+// use returns the expression that refers to the type of d. Types that would
+// create a package initialization cycle are referred to by name.
+func (im *protoImporter) use(d *protoDecl) string {
+	im.declare(d)
+	if im.current == "" {
+		return d.varName
+	}
+	if im.deps.reaches(d.varName, im.current) {
+		return quote(d.name)
+	}
+	im.deps.add(im.current, d.varName)
+	return d.varName
+}
+
+// writeService writes the service expression.
+func (im *protoImporter) writeService(w *writer, svc *protoService, pkg string) error {
+	w.line("")
+	w.line("var _ = Service(%s, func() {", quote(snake(svc.name)))
+	if svc.comment != "" {
+		w.line("Description(%s)", quote(svc.comment))
+	}
+	if pkg != "" {
+		w.line("GRPC(func() {")
+		w.line("Package(%s)", quote(pkg))
+		w.line("})")
+	}
+	for _, r := range svc.rpcs {
+		w.line("")
+		w.line("Method(%s, func() {", quote(snake(r.name)))
+		if r.comment != "" {
+			w.line("Description(%s)", quote(r.comment))
+		}
+		in, err := im.resolve(r.input, r.scope)
+		if err != nil {
+			return fmt.Errorf("rpc %s: %w", r.name, err)
+		}
+		out, err := im.resolve(r.output, r.scope)
+		if err != nil {
+			return fmt.Errorf("rpc %s: %w", r.name, err)
+		}
+		if in.msg == nil || out.msg == nil {
+			return fmt.Errorf("rpc %s: request and response types must be messages", r.name)
+		}
+		switch {
+		case r.clientStream:
+			w.line("StreamingPayload(%s)", im.use(in))
+		case in.msg.fullName != emptyMessage:
+			w.line("Payload(%s)", im.use(in))
+		}
+		switch {
+		case r.serverStream:
+			w.line("StreamingResult(%s)", im.use(out))
+		case out.msg.fullName != emptyMessage:
+			w.line("Result(%s)", im.use(out))
+		}
+		w.line("GRPC(func() {")
+		w.line("Response(CodeOK)")
+		w.line("})")
+		w.line("})")
+	}
+	w.line("})")
+	return nil
+}
+
+// writeType writes the type definition.
+func (im *protoImporter) writeType(w *writer, d *protoDecl) error {
+	im.current = d.varName
+	defer func() { im.current = "" }()
+
+	w.line("")
+	if e := d.enum; e != nil {
+		w.line("var %s = Type(%s, Int32, func() {", d.varName, quote(d.name))
+		values := make([]string, len(e.values))
+		numbers := make([]string, 0, len(e.values))
+		seen := make(map[int]bool)
+		for i, v := range e.values {
+			values[i] = fmt.Sprintf("%s (%d)", v.name, v.number)
+			if !seen[v.number] {
+				seen[v.number] = true
+				numbers = append(numbers, strconv.Itoa(v.number))
+			}
+		}
+		desc := "Values: " + strings.Join(values, ", ")
+		if e.comment != "" {
+			desc = e.comment + "\n\n" + desc
+		}
+		w.line("Description(%s)", quote(desc))
+		if len(numbers) > 0 {
+			w.line("Enum(%s)", strings.Join(numbers, ", "))
+		}
+		w.line("})")
+		return nil
+	}
+
+	m := d.msg
+	w.line("var %s = Type(%s, func() {", d.varName, quote(d.name))
+	if m.comment != "" {
+		w.line("Description(%s)", quote(m.comment))
+	}
+	written := make(map[*protoOneOf]bool)
+	for _, fd := range m.fields {
+		if fd.oneof == nil {
+			if err := im.writeField(w, fd); err != nil {
+				return fmt.Errorf("message %s: %w", m.fullName, err)
+			}
+			continue
+		}
+		if written[fd.oneof] {
+			continue
+		}
+		written[fd.oneof] = true
+		o := fd.oneof
+		if o.comment != "" {
+			w.line("OneOf(%s, %s, func() {", quote(o.name), quote(o.comment))
+		} else {
+			w.line("OneOf(%s, func() {", quote(o.name))
+		}
+		for _, ofd := range m.fields {
+			if ofd.oneof == o {
+				if err := im.writeField(w, ofd); err != nil {
+					return fmt.Errorf("message %s: %w", m.fullName, err)
+				}
+			}
+		}
+		w.line("})")
+	}
+	w.line("})")
+	return nil
+}
+
+// writeField writes the field expression.
+func (im *protoImporter) writeField(w *writer, fd *protoField) error {
+	typ, meta, err := im.fieldType(fd.typ, fd.scope)
+	if err != nil {
+		return err
+	}
+	switch {
+	case fd.keyType != "":
+		key, keyMeta, err := im.fieldType(fd.keyType, fd.scope)
+		if err != nil {
+			return err
+		}
+		if keyMeta == nil && meta == nil {
+			typ = fmt.Sprintf("MapOf(%s, %s)", key, typ)
+			break
+		}
+		var b writer
+		b.line("MapOf(%s, %s, func() {", key, typ)
+		if keyMeta != nil {
+			b.line("Key(func() {")
+			b.line("Meta(%s)", quoteAll(keyMeta))
+			b.line("})")
+		}
+		if meta != nil {
+			b.line("Elem(func() {")
+			b.line("Meta(%s)", quoteAll(meta))
+			b.line("})")
+		}
+		b.buf.WriteString("})")
+		typ = b.buf.String()
+	case fd.repeated:
+		if meta == nil {
+			typ = fmt.Sprintf("ArrayOf(%s)", typ)
+			break
+		}
+		typ = fmt.Sprintf("ArrayOf(%s, func() {\nMeta(%s)\n})", typ, quoteAll(meta))
+	}
+	args := []string{strconv.Itoa(fd.tag), quote(fd.name), typ}
+	if fd.comment != "" {
+		args = append(args, quote(fd.comment))
+	}
+	if fd.keyType != "" || fd.repeated || meta == nil {
+		w.line("Field(%s)", strings.Join(args, ", "))
+		return nil
+	}
+	w.line("Field(%s, func() {", strings.Join(args, ", "))
+	w.line("Meta(%s)", quoteAll(meta))
+	w.line("})")
+	return nil
+}
+
+// fieldType returns the DSL expression of the given protobuf type and the
+// "struct:field:proto" meta values required to preserve the protobuf type if
+// any.
+func (im *protoImporter) fieldType(typ, scope string) (string, []string, error) {
+	if s, ok := protoScalars[typ]; ok {
+		if s.goaProto != typ {
+			return s.dsl, []string{"struct:field:proto", typ}, nil
+		}
+		return s.dsl, nil, nil
+	}
+	d, err := im.resolve(typ, scope)
+	if err != nil {
+		return "", nil, err
+	}
+	ref := im.use(d)
+	switch {
+	case d.enum != nil:
+		// Enums are encoded as int32 values.
+		return ref, []string{"struct:field:proto", "int32"}, nil
+	case d.external != nil:
+		return ref, append([]string{"struct:field:proto"}, d.external...), nil
+	}
+	return ref, nil, nil
+}
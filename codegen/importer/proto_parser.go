@@ -0,0 +1,739 @@
+package importer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// protoFile is a parsed .proto file.
+	protoFile struct {
+		name     string
+		pkg      string
+		imports  []string
+		options  map[string]string
+		messages []*protoMessage
+		enums    []*protoEnum
+		services []*protoService
+	}
+
+	// protoMessage is a message definition.
+	protoMessage struct {
+		name     string
+		fullName string
+		comment  string
+		fields   []*protoField
+		messages []*protoMessage
+		enums    []*protoEnum
+	}
+
+	// protoField is a message field.
+	protoField struct {
+		name    string
+		comment string
+		tag     int
+		// typ is the field type or the map value type.
+		typ string
+		// keyType is the map key type, empty if the field is not a map.
+		keyType  string
+		repeated bool
+		// oneof is the oneof the field belongs to if any.
+		oneof *protoOneOf
+		// scope is the full name of the message that defines the field.
+		scope string
+	}
+
+	// protoOneOf is a oneof definition.
+	protoOneOf struct {
+		name    string
+		comment string
+	}
+
+	// protoEnum is an enum definition.
+	protoEnum struct {
+		name     string
+		fullName string
+		comment  string
+		values   []*protoEnumValue
+	}
+
+	// protoEnumValue is an enum value.
+	protoEnumValue struct {
+		name    string
+		number  int
+		comment string
+	}
+
+	// protoService is a service definition.
+	protoService struct {
+		name    string
+		comment string
+		rpcs    []*protoRPC
+	}
+
+	// protoRPC is a service method definition.
+	protoRPC struct {
+		name         string
+		comment      string
+		input        string
+		output       string
+		clientStream bool
+		serverStream bool
+		scope        string
+	}
+
+	// protoToken is a lexical token.
+	protoToken struct {
+		// kind is one of the token kinds below.
+		kind int
+		text string
+		line int
+		// comment is the comment that immediately precedes the token.
+		comment string
+	}
+
+	// protoParser parses proto3 files.
+	protoParser struct {
+		name    string
+		src     string
+		pos     int
+		line    int
+		tok     protoToken
+		pending []string
+	}
+)
+
+const (
+	tokEOF = iota
+	tokIdent
+	tokInt
+	tokFloat
+	tokString
+	tokSymbol
+)
+
+// parseProto parses the given proto3 file content. Options other than
+// go_package and the field options are parsed and ignored.
+func parseProto(name string, src []byte) (*protoFile, error) {
+	p := &protoParser{name: name, src: string(src), line: 1}
+	f := &protoFile{name: name, options: make(map[string]string)}
+	if err := p.parseFile(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *protoParser) parseFile(f *protoFile) error {
+	p.next()
+	proto3 := false
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.is("syntax"), p.is("edition"):
+			proto3 = true
+			p.next()
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			syntax := p.tok.text
+			if p.tok.kind != tokString {
+				return p.errorf("syntax string")
+			}
+			if syntax != "proto3" {
+				return fmt.Errorf("%s: unsupported syntax %q, only proto3 files can be imported", p.name, syntax)
+			}
+			p.next()
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case p.is("package"):
+			p.next()
+			f.pkg = p.tok.text
+			if err := p.expectKind(tokIdent, "package name"); err != nil {
+				return err
+			}
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case p.is("import"):
+			p.next()
+			if p.is("public") || p.is("weak") {
+				p.next()
+			}
+			f.imports = append(f.imports, p.tok.text)
+			if err := p.expectKind(tokString, "import path"); err != nil {
+				return err
+			}
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case p.is("option"):
+			name, val, err := p.parseOption()
+			if err != nil {
+				return err
+			}
+			f.options[name] = val
+		case p.is("message"):
+			m, err := p.parseMessage(f.pkg)
+			if err != nil {
+				return err
+			}
+			f.messages = append(f.messages, m)
+		case p.is("enum"):
+			e, err := p.parseEnum(f.pkg)
+			if err != nil {
+				return err
+			}
+			f.enums = append(f.enums, e)
+		case p.is("service"):
+			s, err := p.parseService(f.pkg)
+			if err != nil {
+				return err
+			}
+			f.services = append(f.services, s)
+		case p.is("extend"):
+			if err := p.skipDecl(); err != nil {
+				return err
+			}
+		case p.is(";"):
+			p.next()
+		default:
+			return p.errorf("top level definition")
+		}
+	}
+	if !proto3 {
+		// Files without syntax statement use proto2.
+		return fmt.Errorf("%s: missing syntax statement, only proto3 files can be imported", p.name)
+	}
+	return nil
+}
+
+// parseOption parses an option statement and returns the option name and
+// value, aggregate values are skipped.
+func (p *protoParser) parseOption() (string, string, error) {
+	p.next()
+	name, err := p.parseOptionName()
+	if err != nil {
+		return "", "", err
+	}
+	if err := p.expect("="); err != nil {
+		return "", "", err
+	}
+	val := p.tok.text
+	if p.is("{") {
+		if err := p.skipBlock(); err != nil {
+			return "", "", err
+		}
+		return name, "", p.expect(";")
+	}
+	if p.is("-") {
+		p.next()
+		val = "-" + p.tok.text
+	}
+	p.next()
+	return name, val, p.expect(";")
+}
+
+// parseOptionName parses an option name such as "deprecated" or
+// "(google.api.http).get".
+func (p *protoParser) parseOptionName() (string, error) {
+	var name string
+	for {
+		switch {
+		case p.is("("):
+			p.next()
+			name += "(" + p.tok.text + ")"
+			if err := p.expectKind(tokIdent, "option name"); err != nil {
+				return "", err
+			}
+			if err := p.expect(")"); err != nil {
+				return "", err
+			}
+		case p.tok.kind == tokIdent:
+			name += p.tok.text
+			p.next()
+		default:
+			return "", p.errorf("option name")
+		}
+		if p.tok.kind == tokIdent && strings.HasPrefix(p.tok.text, ".") {
+			continue
+		}
+		if !p.is(".") {
+			return name, nil
+		}
+		name += "."
+		p.next()
+	}
+}
+
+func (p *protoParser) parseMessage(scope string) (*protoMessage, error) {
+	comment := p.tok.comment
+	p.next()
+	m := &protoMessage{name: p.tok.text, comment: comment}
+	m.fullName = qualify(scope, m.name)
+	if err := p.expectKind(tokIdent, "message name"); err != nil {
+		return nil, err
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.is("}") {
+		switch {
+		case p.tok.kind == tokEOF:
+			return nil, p.errorf("}")
+		case p.is("message"):
+			nested, err := p.parseMessage(m.fullName)
+			if err != nil {
+				return nil, err
+			}
+			m.messages = append(m.messages, nested)
+		case p.is("enum"):
+			e, err := p.parseEnum(m.fullName)
+			if err != nil {
+				return nil, err
+			}
+			m.enums = append(m.enums, e)
+		case p.is("option"):
+			if _, _, err := p.parseOption(); err != nil {
+				return nil, err
+			}
+		case p.is("reserved"), p.is("extensions"), p.is("extend"):
+			if err := p.skipDecl(); err != nil {
+				return nil, err
+			}
+		case p.is("oneof"):
+			o := &protoOneOf{comment: p.tok.comment}
+			p.next()
+			o.name = p.tok.text
+			if err := p.expectKind(tokIdent, "oneof name"); err != nil {
+				return nil, err
+			}
+			if err := p.expect("{"); err != nil {
+				return nil, err
+			}
+			for !p.is("}") {
+				switch {
+				case p.tok.kind == tokEOF:
+					return nil, p.errorf("}")
+				case p.is("option"):
+					if _, _, err := p.parseOption(); err != nil {
+						return nil, err
+					}
+				case p.is(";"):
+					p.next()
+				default:
+					fd, err := p.parseField(m.fullName)
+					if err != nil {
+						return nil, err
+					}
+					fd.oneof = o
+					m.fields = append(m.fields, fd)
+				}
+			}
+			p.next()
+		case p.is(";"):
+			p.next()
+		default:
+			fd, err := p.parseField(m.fullName)
+			if err != nil {
+				return nil, err
+			}
+			m.fields = append(m.fields, fd)
+		}
+	}
+	p.next()
+	return m, nil
+}
+
+func (p *protoParser) parseField(scope string) (*protoField, error) {
+	fd := &protoField{comment: p.tok.comment, scope: scope}
+	switch {
+	case p.is("repeated"):
+		fd.repeated = true
+		p.next()
+	case p.is("optional"):
+		p.next()
+	case p.is("required"):
+		return nil, p.errorf("proto3 field")
+	}
+	if p.is("map") {
+		p.next()
+		if err := p.expect("<"); err != nil {
+			return nil, err
+		}
+		fd.keyType = p.tok.text
+		if err := p.expectKind(tokIdent, "map key type"); err != nil {
+			return nil, err
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		fd.typ = p.tok.text
+		if err := p.expectKind(tokIdent, "map value type"); err != nil {
+			return nil, err
+		}
+		if err := p.expect(">"); err != nil {
+			return nil, err
+		}
+	} else {
+		fd.typ = p.tok.text
+		if err := p.expectKind(tokIdent, "field type"); err != nil {
+			return nil, err
+		}
+	}
+	fd.name = p.tok.text
+	if err := p.expectKind(tokIdent, "field name"); err != nil {
+		return nil, err
+	}
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+	tag, err := strconv.ParseInt(p.tok.text, 0, 32)
+	if err != nil || p.tok.kind != tokInt {
+		return nil, p.errorf("field number")
+	}
+	fd.tag = int(tag)
+	p.next()
+	if p.is("[") {
+		if err := p.skipBlock(); err != nil {
+			return nil, err
+		}
+	}
+	return fd, p.expect(";")
+}
+
+func (p *protoParser) parseEnum(scope string) (*protoEnum, error) {
+	comment := p.tok.comment
+	p.next()
+	e := &protoEnum{name: p.tok.text, comment: comment}
+	e.fullName = qualify(scope, e.name)
+	if err := p.expectKind(tokIdent, "enum name"); err != nil {
+		return nil, err
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.is("}") {
+		switch {
+		case p.tok.kind == tokEOF:
+			return nil, p.errorf("}")
+		case p.is("option"):
+			if _, _, err := p.parseOption(); err != nil {
+				return nil, err
+			}
+		case p.is("reserved"):
+			if err := p.skipDecl(); err != nil {
+				return nil, err
+			}
+		case p.is(";"):
+			p.next()
+		default:
+			v := &protoEnumValue{name: p.tok.text, comment: p.tok.comment}
+			if err := p.expectKind(tokIdent, "enum value name"); err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			sign := ""
+			if p.is("-") {
+				sign = "-"
+				p.next()
+			}
+			n, err := strconv.ParseInt(sign+p.tok.text, 0, 32)
+			if err != nil || p.tok.kind != tokInt {
+				return nil, p.errorf("enum value number")
+			}
+			v.number = int(n)
+			p.next()
+			if p.is("[") {
+				if err := p.skipBlock(); err != nil {
+					return nil, err
+				}
+			}
+			if err := p.expect(";"); err != nil {
+				return nil, err
+			}
+			e.values = append(e.values, v)
+		}
+	}
+	p.next()
+	return e, nil
+}
+
+func (p *protoParser) parseService(scope string) (*protoService, error) {
+	comment := p.tok.comment
+	p.next()
+	s := &protoService{name: p.tok.text, comment: comment}
+	if err := p.expectKind(tokIdent, "service name"); err != nil {
+		return nil, err
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.is("}") {
+		switch {
+		case p.tok.kind == tokEOF:
+			return nil, p.errorf("}")
+		case p.is("option"):
+			if _, _, err := p.parseOption(); err != nil {
+				return nil, err
+			}
+		case p.is(";"):
+			p.next()
+		case p.is("rpc"):
+			r := &protoRPC{comment: p.tok.comment, scope: scope}
+			p.next()
+			r.name = p.tok.text
+			if err := p.expectKind(tokIdent, "rpc name"); err != nil {
+				return nil, err
+			}
+			var err error
+			if r.input, r.clientStream, err = p.parseRPCType(); err != nil {
+				return nil, err
+			}
+			if !p.is("returns") {
+				return nil, p.errorf("returns")
+			}
+			p.next()
+			if r.output, r.serverStream, err = p.parseRPCType(); err != nil {
+				return nil, err
+			}
+			if p.is("{") {
+				if err := p.skipBlock(); err != nil {
+					return nil, err
+				}
+				if p.is(";") {
+					p.next()
+				}
+			} else if err := p.expect(";"); err != nil {
+				return nil, err
+			}
+			s.rpcs = append(s.rpcs, r)
+		default:
+			return nil, p.errorf("rpc")
+		}
+	}
+	p.next()
+	return s, nil
+}
+
+// parseRPCType parses the parenthesized request or response type of a rpc.
+func (p *protoParser) parseRPCType() (string, bool, error) {
+	if err := p.expect("("); err != nil {
+		return "", false, err
+	}
+	var stream bool
+	if p.is("stream") {
+		p.next()
+		if p.tok.kind == tokIdent {
+			stream = true
+		} else {
+			// Message named "stream".
+			return "stream", false, p.expect(")")
+		}
+	}
+	typ := p.tok.text
+	if err := p.expectKind(tokIdent, "message type"); err != nil {
+		return "", false, err
+	}
+	return typ, stream, p.expect(")")
+}
+
+// skipDecl skips a declaration up to and including the terminating semicolon
+// or block.
+func (p *protoParser) skipDecl() error {
+	for !p.is(";") {
+		if p.tok.kind == tokEOF {
+			return p.errorf(";")
+		}
+		if p.is("{") {
+			return p.skipBlock()
+		}
+		p.next()
+	}
+	p.next()
+	return nil
+}
+
+// skipBlock skips a balanced block starting with the current token.
+func (p *protoParser) skipBlock() error {
+	depth := 0
+	for {
+		switch {
+		case p.tok.kind == tokEOF:
+			return p.errorf("end of block")
+		case p.is("{"), p.is("["), p.is("("):
+			depth++
+		case p.is("}"), p.is("]"), p.is(")"):
+			depth--
+		}
+		p.next()
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// is returns true if the current token is the given identifier or symbol.
+func (p *protoParser) is(text string) bool {
+	return (p.tok.kind == tokIdent || p.tok.kind == tokSymbol) && p.tok.text == text
+}
+
+// expect consumes the given identifier or symbol.
+func (p *protoParser) expect(text string) error {
+	if !p.is(text) {
+		return p.errorf(fmt.Sprintf("%q", text))
+	}
+	p.next()
+	return nil
+}
+
+// expectKind consumes a token of the given kind.
+func (p *protoParser) expectKind(kind int, what string) error {
+	if p.tok.kind != kind {
+		return p.errorf(what)
+	}
+	p.next()
+	return nil
+}
+
+func (p *protoParser) errorf(expected string) error {
+	got := p.tok.text
+	if p.tok.kind == tokEOF {
+		got = "end of file"
+	}
+	return fmt.Errorf("%s:%d: expected %s, got %q", p.name, p.tok.line, expected, got)
+}
+
+// next reads the next token.
+func (p *protoParser) next() {
+	p.skipSpace()
+	tok := protoToken{line: p.line, comment: strings.Join(p.pending, "\n")}
+	p.pending = nil
+	if p.pos >= len(p.src) {
+		tok.kind = tokEOF
+		p.tok = tok
+		return
+	}
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case isIdentStart(c) || c == '.' && p.pos+1 < len(p.src) && isIdentStart(p.src[p.pos+1]):
+		p.pos++
+		for p.pos < len(p.src) && (isIdentStart(p.src[p.pos]) || isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		tok.kind = tokIdent
+	case isDigit(c) || c == '.' && p.pos+1 < len(p.src) && isDigit(p.src[p.pos+1]):
+		tok.kind = tokInt
+		for p.pos < len(p.src) {
+			d := p.src[p.pos]
+			if d == '.' || ((d == 'e' || d == 'E') && !strings.HasPrefix(p.src[start:], "0x")) {
+				tok.kind = tokFloat
+			} else if (d == '+' || d == '-') && (p.src[p.pos-1] == 'e' || p.src[p.pos-1] == 'E') {
+				tok.kind = tokFloat
+			} else if !isIdentStart(d) && !isDigit(d) {
+				break
+			}
+			p.pos++
+		}
+	case c == '"' || c == '\'':
+		var sb strings.Builder
+		for c == '"' || c == '\'' {
+			p.pos++
+			s := p.pos
+			for p.pos < len(p.src) && p.src[p.pos] != c && p.src[p.pos] != '\n' {
+				if p.src[p.pos] == '\\' {
+					p.pos++
+				}
+				p.pos++
+			}
+			raw := p.src[s:min(p.pos, len(p.src))]
+			if v, err := strconv.Unquote(`"` + strings.ReplaceAll(raw, `"`, `\"`) + `"`); err == nil {
+				sb.WriteString(v)
+			} else {
+				sb.WriteString(raw)
+			}
+			p.pos++
+			p.skipSpace()
+			if p.pos >= len(p.src) {
+				break
+			}
+			c = p.src[p.pos]
+			if c == '"' || c == '\'' {
+				p.pending = nil
+			}
+		}
+		tok.kind = tokString
+		tok.text = sb.String()
+		p.tok = tok
+		return
+	default:
+		p.pos++
+		tok.kind = tokSymbol
+	}
+	tok.text = p.src[start:p.pos]
+	p.tok = tok
+}
+
+// skipSpace skips white space and comments and records the comments that
+// immediately precede the next token.
+func (p *protoParser) skipSpace() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\n':
+			p.line++
+			p.pos++
+			// A blank line detaches the previous comments.
+			if rest := strings.TrimLeft(p.src[p.pos:], " \t\r"); strings.HasPrefix(rest, "\n") {
+				p.pending = nil
+			}
+		case unicode.IsSpace(rune(c)):
+			p.pos++
+		case strings.HasPrefix(p.src[p.pos:], "//"):
+			end := strings.IndexByte(p.src[p.pos:], '\n')
+			if end < 0 {
+				end = len(p.src) - p.pos
+			}
+			text := strings.TrimPrefix(p.src[p.pos:p.pos+end], "//")
+			p.pending = append(p.pending, strings.TrimPrefix(strings.TrimRight(text, " \t\r"), " "))
+			p.pos += end
+		case strings.HasPrefix(p.src[p.pos:], "/*"):
+			end := strings.Index(p.src[p.pos+2:], "*/")
+			if end < 0 {
+				end = len(p.src) - p.pos - 2
+			}
+			text := p.src[p.pos+2 : p.pos+2+end]
+			p.line += strings.Count(text, "\n")
+			for _, l := range strings.Split(text, "\n") {
+				l = strings.TrimSpace(l)
+				l = strings.TrimSpace(strings.TrimPrefix(l, "*"))
+				if l != "" {
+					p.pending = append(p.pending, l)
+				}
+			}
+			p.pos = min(p.pos+4+end, len(p.src))
+		default:
+			return
+		}
+	}
+}
+
+// qualify returns the full name of the given element defined in scope.
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProto(t *testing.T) {
+	load := func(path string) ([]byte, error) {
+		return os.ReadFile(filepath.Join("testdata", "proto", filepath.FromSlash(path)))
+	}
+	data, err := os.ReadFile(filepath.Join("testdata", "proto", "pets", "v1", "pets.proto"))
+	require.NoError(t, err)
+
+	src, err := Proto(data, "design", "pets/v1/pets.proto", load)
+
+	require.NoError(t, err)
+	golden := filepath.Join("testdata", "pets_proto.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, src, 0644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(src))
+}
+
+func TestProtoErrors(t *testing.T) {
+	noImport := func(path string) ([]byte, error) { return nil, os.ErrNotExist }
+	cases := []struct {
+		Name     string
+		Data     string
+		Expected string
+	}{
+		{"proto2", `syntax = "proto2"; message M { optional string a = 1; }`, "only proto3"},
+		{"missing syntax", `message M { string a = 1; }`, "only proto3"},
+		{"invalid field", `syntax = "proto3"; message M { string a; }`, `expected "="`},
+		{"unknown type", `syntax = "proto3"; message M { Foo a = 1; }`, `unknown type "Foo"`},
+		{"missing import", `syntax = "proto3"; import "other.proto"; message M { Foo a = 1; }`, "imports could not be loaded: other.proto"},
+		{"enum rpc type", `syntax = "proto3"; enum E { A = 0; } service S { rpc M(E) returns (E); }`, "must be messages"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := Proto([]byte(c.Data), "design", "test.proto", noImport)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), c.Expected)
+		})
+	}
+}
+
+func TestParseProto(t *testing.T) {
+	src := `syntax = "proto3";
+package a.b;
+
+// Detached comment.
+
+/* M is a message. */
+message M {
+  message N { int32 x = 1; }
+  // Field comment.
+  N n = 1 [deprecated = true];
+  map<string, N> ns = 2;
+  oneof choice {
+    string s = 3;
+  }
+  option (custom.opt) = { a: 1 };
+}
+
+service S {
+  rpc Call(stream M) returns (stream .a.b.M.N) {}
+}`
+	f, err := parseProto("test.proto", []byte(src))
+
+	require.NoError(t, err)
+	assert.Equal(t, "a.b", f.pkg)
+	require.Len(t, f.messages, 1)
+	m := f.messages[0]
+	assert.Equal(t, "a.b.M", m.fullName)
+	assert.Equal(t, "M is a message.", m.comment)
+	require.Len(t, m.messages, 1)
+	assert.Equal(t, "a.b.M.N", m.messages[0].fullName)
+	require.Len(t, m.fields, 3)
+	assert.Equal(t, "Field comment.", m.fields[0].comment)
+	assert.Equal(t, "a.b.M", m.fields[0].scope)
+	assert.Equal(t, "string", m.fields[1].keyType)
+	require.NotNil(t, m.fields[2].oneof)
+	assert.Equal(t, "choice", m.fields[2].oneof.name)
+	require.Len(t, f.services, 1)
+	require.Len(t, f.services[0].rpcs, 1)
+	r := f.services[0].rpcs[0]
+	assert.True(t, r.clientStream)
+	assert.True(t, r.serverStream)
+	assert.Equal(t, ".a.b.M.N", r.output)
+}
@@ -0,0 +1,237 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"math"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// writer accumulates the source code of a design package.
+	writer struct {
+		buf bytes.Buffer
+	}
+
+	// varDeps records the references made by the initialization expressions
+	// of the type variables. The importers refer to types by name instead of
+	// by variable when doing otherwise would create a package initialization
+	// cycle.
+	varDeps map[string]map[string]bool
+)
+
+// reserved records the identifiers exported by the dsl package. The design
+// packages dot import the dsl package so these names cannot be used for the
+// type variables.
+var reserved = make(map[string]bool)
+
+func init() {
+	for _, id := range strings.Fields(dslIdentifiers) {
+		reserved[id] = true
+	}
+}
+
+// dslIdentifiers lists the identifiers exported by the dsl package.
+const dslIdentifiers = `
+	API APIKey APIKeyField APIKeySecurity AccessToken AccessTokenField Any
+	ArrayOf Attribute Attributes AuthErrors AuthorizationCodeFlow Backoff
+	BasicAuthSecurity Body Boolean Bytes CONNECT Cache CanonicalMethod
+	ClientCredentialsFlow ClientInterceptor Code CodeAborted CodeAlreadyExists
+	CodeCanceled CodeDataLoss CodeDeadlineExceeded CodeFailedPrecondition
+	CodeInternal CodeInvalidArgument CodeNotFound CodeOK CodeOutOfRange
+	CodePermissionDenied CodeResourceExhausted CodeUnauthenticated
+	CodeUnavailable CodeUnimplemented CodeUnknown CollectionOf Consumes Contact
+	ContentType ConvertTo Cookie CookieDomain CookieHTTPOnly CookieMaxAge
+	CookiePath CookieSameSite CookieSameSiteDefault CookieSameSiteLax
+	CookieSameSiteNone CookieSameSiteStrict CookieSecure CreateFrom DELETE
+	DateTime Decimal Default DefaultFieldSelectionAttribute DefaultProtoc
+	DefaultRetryInitialBackoff
+	DefaultRetryMaxBackoff Deprecated Description Docs Duration Elem Email
+	Empty Enum Error ErrorName
+	ETag ErrorResult ErrorResultIdentifier Example ExclusiveMaximum ExclusiveMinimum
+	Extend Fault Field FieldSelection Files Float32 Float64 Format FormatCIDR FormatDate
+	FormatDateTime FormatEmail FormatHostname FormatIP FormatIPv4 FormatIPv6
+	FormatJSON FormatMAC FormatRFC1123 FormatRegexp FormatURI FormatUUID GET
+	GRPC HEAD HTTP Header Headers Host Idempotent ImplicitFlow Int Int32 Int64
+	Interceptor InvalidEnumValue InvalidFieldType InvalidFormat InvalidLength
+	InvalidPattern InvalidRange JWTSecurity Key LastModified License MapOf MapParams
+	MaxLength Maximum Message Meta Metadata Method MinLength Minimum
+	MissingField MultipartRequest MutualTLSSecurity Name NoSecurity OAuth2Security
+	OPTIONS OneOf
+	PATCH POST PUT Package PageCursor PageItems PageOffset PageSize Paginated
+	Param Params Parent Password PasswordField
+	PasswordFlow Path Pattern Payload ProblemDetails Produces ProtoMessage
+	Randomizer RateLimit RateLimitByClientIP RateLimitByPrincipal ReadPayload ReadResult
+	Redirect Reference RemoveMeta Required Response Result ResultType Retry
+	SSEEventID SSEEventRetry SSEEventType SchemeScope Scope Security Server
+	ServerInterceptor ServerSentEvents Service Services
+	SkipRequestBodyEncodeDecode SkipResponseBodyEncodeDecode StatusAccepted
+	StatusAlreadyReported StatusBadGateway StatusBadRequest StatusConflict
+	StatusContinue StatusCreated StatusExpectationFailed StatusFailedDependency
+	StatusForbidden StatusFound StatusGatewayTimeout StatusGone
+	StatusHTTPVersionNotSupported StatusIMUsed StatusInsufficientStorage
+	StatusInternalServerError StatusLengthRequired StatusLocked
+	StatusLoopDetected StatusMethodNotAllowed StatusMovedPermanently
+	StatusMultiStatus StatusMultipleChoices StatusNetworkAuthenticationRequired
+	StatusNoContent StatusNonAuthoritativeInfo StatusNotAcceptable
+	StatusNotExtended StatusNotFound StatusNotImplemented StatusNotModified
+	StatusOK StatusPartialContent StatusPaymentRequired StatusPermanentRedirect
+	StatusPreconditionFailed StatusPreconditionRequired StatusProcessing
+	StatusProxyAuthRequired StatusRequestEntityTooLarge
+	StatusRequestHeaderFieldsTooLarge StatusRequestTimeout
+	StatusRequestURITooLong StatusRequestedRangeNotSatisfiable
+	StatusResetContent StatusSeeOther StatusServiceUnavailable
+	StatusSwitchingProtocols StatusTeapot StatusTemporaryRedirect
+	StatusTooManyRequests StatusUnauthorized StatusUnavailableForLegalReasons
+	StatusUnprocessableEntity StatusUnsupportedMediaType StatusUpgradeRequired
+	StatusUseProxy StatusVariantAlsoNegotiates StreamingPayload StreamingResult
+	String TRACE Tag Temporary TermsOfService Timeout Title Token TokenField
+	Trailers Type TypeName UInt UInt32 UInt64 URI URL Username UsernameField
+	Val Value Variable Version View WritePayload WriteResult
+`
+
+// line writes a line of code, the indentation is taken care of by gofmt.
+func (w *writer) line(format string, args ...any) {
+	fmt.Fprintf(&w.buf, format, args...)
+	w.buf.WriteByte('\n')
+}
+
+// source returns the formatted source code.
+func (w *writer) source() ([]byte, error) {
+	src, err := format.Source(w.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format design: %w\n%s", err, w.buf.String())
+	}
+	return src, nil
+}
+
+// header writes the package clause and the dsl import.
+func (w *writer) header(pkg, source string) {
+	w.line("// Package %s contains the Goa design imported from %s.", pkg, source)
+	w.line("package %s", pkg)
+	w.line("")
+	w.line("import (")
+	w.line(". %q", "goa.design/goa/v3/dsl")
+	w.line(")")
+}
+
+// identifier returns a unique Go identifier for the given name that does not
+// conflict with the dsl package identifiers. used records the identifiers
+// already in use.
+func identifier(name string, used map[string]bool) string {
+	id := codegen.Goify(name, true)
+	if id == "" {
+		id = "T"
+	}
+	if reserved[id] {
+		id += "Type"
+	}
+	base := id
+	for i := 2; used[id]; i++ {
+		id = base + strconv.Itoa(i)
+	}
+	used[id] = true
+	return id
+}
+
+// snake returns the snake case version of name.
+func snake(name string) string {
+	return codegen.SnakeCase(codegen.Goify(name, false))
+}
+
+// quote returns the Go string literal for s.
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+// quoteAll returns the comma separated Go string literals of vals.
+func quoteAll(vals []string) string {
+	qs := make([]string, len(vals))
+	for i, v := range vals {
+		qs[i] = quote(v)
+	}
+	return strings.Join(qs, ", ")
+}
+
+// literal returns the Go literal for the given value decoded from JSON or
+// YAML. float is true if the value is used with a floating point type. The
+// boolean is false if the value is not a scalar.
+func literal(v any, float bool) (string, bool) {
+	switch val := v.(type) {
+	case string:
+		return quote(val), true
+	case bool:
+		return strconv.FormatBool(val), true
+	case float64:
+		if !float && math.Abs(val) > 1<<53 {
+			// Integer values decoded from JSON may have lost precision
+			// and may overflow int.
+			return "", false
+		}
+		s := strconv.FormatFloat(val, 'f', -1, 64)
+		if float && val == math.Trunc(val) && !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s, true
+	case float32:
+		return literal(float64(val), float)
+	case int:
+		return literal(float64(val), float)
+	case int32:
+		return literal(float64(val), float)
+	case int64:
+		return literal(float64(val), float)
+	case uint64:
+		return literal(float64(val), float)
+	}
+	return "", false
+}
+
+// literals returns the comma separated Go literals of vals. The boolean is
+// false if any of the values is not a scalar.
+func literals(vals []any, float bool) (string, bool) {
+	lits := make([]string, len(vals))
+	for i, v := range vals {
+		l, ok := literal(v, float)
+		if !ok {
+			return "", false
+		}
+		lits[i] = l
+	}
+	return strings.Join(lits, ", "), true
+}
+
+// add records that the initialization of variable from refers to variable to.
+func (d varDeps) add(from, to string) {
+	if d[from] == nil {
+		d[from] = make(map[string]bool)
+	}
+	d[from][to] = true
+}
+
+// reaches returns true if the initialization of variable from refers to
+// variable to directly or indirectly.
+func (d varDeps) reaches(from, to string) bool {
+	seen := make(map[string]bool)
+	var visit func(string) bool
+	visit = func(v string) bool {
+		if v == to {
+			return true
+		}
+		if seen[v] {
+			return false
+		}
+		seen[v] = true
+		for dep := range d[v] {
+			if visit(dep) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(from)
+}
@@ -0,0 +1,17 @@
+/*
+Package lint checks evaluated designs against a set of rules that go beyond
+the validations done by the expr package, for example that all methods have a
+description or that mutating routes are secured.
+
+Rules are registered with Register, the package registers the built-in rules.
+A rule may be disabled for an expression and the expressions it contains by
+listing its name in the "lint:disable" meta of the expression:
+
+	Method("health", func() {
+	    Meta("lint:disable", "method-errors", "secure-mutations")
+	})
+
+The issues are reported with the location of the DSL function call that
+defined the expression when known.
+*/
+package lint
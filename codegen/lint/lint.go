@@ -0,0 +1,147 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// Rule is a design lint rule.
+	Rule struct {
+		// Name identifies the rule in the "lint:disable" meta and in the
+		// configuration files.
+		Name string
+		// Description describes what the rule checks.
+		Description string
+		// Check reports the issues found in the design root with l.
+		Check func(l *Linter, root *expr.RootExpr)
+	}
+
+	// Issue is a problem found in a design by a rule.
+	Issue struct {
+		// Rule is the name of the rule that found the issue.
+		Rule string `json:"rule"`
+		// Message describes the issue.
+		Message string `json:"message"`
+		// File is the path to the design file containing the DSL call
+		// that defines the expression with the issue if known.
+		File string `json:"file,omitempty"`
+		// Line is the line number of the DSL call if known.
+		Line int `json:"line,omitempty"`
+	}
+
+	// Linter collects the issues reported by the rules.
+	Linter struct {
+		// rule is the rule being run.
+		rule *Rule
+		// api is the design API expression.
+		api *expr.APIExpr
+		// issues lists the reported issues.
+		issues []*Issue
+	}
+)
+
+// DisableMeta is the key of the meta that lists the names of the rules that
+// must not report issues on the expression that defines it and the
+// expressions it contains.
+const DisableMeta = "lint:disable"
+
+var (
+	// rules lists the registered rules.
+	rules []*Rule
+	// rulesMu protects rules.
+	rulesMu sync.Mutex
+)
+
+// Register adds a rule to the rules run by the lint command. Design packages
+// may register their own rules in an init function. Register panics if a rule
+// with the same name is already registered.
+func Register(r *Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	for _, o := range rules {
+		if o.Name == r.Name {
+			panic(fmt.Sprintf("lint rule %q registered twice", r.Name)) // bug
+		}
+	}
+	rules = append(rules, r)
+}
+
+// Rules returns the registered rules in registration order.
+func Rules() []*Rule {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	return append([]*Rule(nil), rules...)
+}
+
+// Run runs the given rules on the evaluated design root and returns the
+// issues in rule order.
+func Run(root *expr.RootExpr, rules []*Rule) []*Issue {
+	l := &Linter{api: root.API}
+	for _, r := range rules {
+		l.rule = r
+		r.Check(l, root)
+	}
+	return l.issues
+}
+
+// Report records an issue found by the running rule in expression e. scope
+// lists the expressions that contain e starting with the innermost one. The
+// issue is not recorded if the "lint:disable" meta of e, of the expressions in
+// scope or of the API lists the rule. The position of the issue is the
+// location of the DSL call that defines e or else of the innermost expression
+// in scope with a known location.
+func (l *Linter) Report(e eval.Expression, msg string, scope ...eval.Expression) {
+	exprs := append([]eval.Expression{e}, scope...)
+	if l.api != nil {
+		exprs = append(exprs, l.api)
+	}
+	for _, x := range exprs {
+		if slices.Contains(metaOf(x)[DisableMeta], l.rule.Name) {
+			return
+		}
+	}
+	issue := &Issue{Rule: l.rule.Name, Message: msg}
+	for _, x := range exprs {
+		if loc, ok := eval.LocationOf(x); ok {
+			issue.File, issue.Line = loc.File, loc.Line
+			break
+		}
+	}
+	l.issues = append(l.issues, issue)
+}
+
+// String returns the issue formatted as "file:line: message (rule)".
+func (i *Issue) String() string {
+	if i.File == "" {
+		return fmt.Sprintf("%s (%s)", i.Message, i.Rule)
+	}
+	return fmt.Sprintf("%s:%d: %s (%s)", i.File, i.Line, i.Message, i.Rule)
+}
+
+// metaOf returns the meta of the given expression if it has any.
+func metaOf(e eval.Expression) expr.MetaExpr {
+	switch actual := e.(type) {
+	case *expr.APIExpr:
+		return actual.Meta
+	case *expr.ServiceExpr:
+		return actual.Meta
+	case *expr.MethodExpr:
+		return actual.Meta
+	case *expr.AttributeExpr:
+		return actual.Meta
+	case expr.UserType:
+		return actual.Attribute().Meta
+	case *expr.HTTPEndpointExpr:
+		return actual.Meta
+	case *expr.RouteExpr:
+		if actual.Endpoint != nil {
+			return actual.Endpoint.Meta
+		}
+	}
+	return nil
+}
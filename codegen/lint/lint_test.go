@@ -0,0 +1,51 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/lint/testdata"
+)
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected []string
+	}{
+		{"clean", testdata.CleanDSL, nil},
+		{"issues", testdata.IssuesDSL, []string{
+			`testdata/dsls.go:54: service "store" has no description (description)`,
+			`testdata/dsls.go:55: method "store.add" has no description (description)`,
+			`testdata/dsls.go:50: type "Item" has no description (description)`,
+			`testdata/dsls.go:55: method "store.add" does not define any error (method-errors)`,
+			`testdata/dsls.go:78: route "GET /itemList": segment "itemList" uses camel case but "item_list" uses snake case (path-casing)`,
+			`testdata/dsls.go:70: route "GET /item/{id}": collection segment "item" is not plural (plural-collections)`,
+			`testdata/dsls.go:78: route "GET /itemList": collection segment "itemList" is not plural (plural-collections)`,
+			`testdata/dsls.go:51: type "Item": string attribute "name" has no MaxLength validation (string-max-length)`,
+			`testdata/dsls.go:52: type "Item": string attribute "tags[]" has no MaxLength validation (string-max-length)`,
+			`testdata/dsls.go:55: method "store.add" payload has no example (payload-examples)`,
+			`testdata/dsls.go:61: method "store.show" payload has no example (payload-examples)`,
+			`testdata/dsls.go:58: route "POST /item_list" of method "store.add" has no security requirement (secure-mutations)`,
+		}},
+		{"disabled", testdata.DisabledDSL, []string{
+			`testdata/dsls.go:96: method "store.add" payload: string attribute "value" has no MaxLength validation (string-max-length)`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := codegen.RunDSL(t, c.DSL)
+			var issues []string
+			for _, i := range Run(root, Rules()) {
+				issues = append(issues, i.String())
+			}
+			assert.Equal(t, c.Expected, issues)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	assert.Panics(t, func() { Register(&Rule{Name: "description"}) })
+}
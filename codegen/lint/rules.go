@@ -0,0 +1,294 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// versionRegex matches path segments that identify API versions.
+var versionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+// mutatingMethods lists the HTTP methods of the routes that modify resources.
+var mutatingMethods = map[string]bool{"POST": true, "PUT": true, "PATCH": true, "DELETE": true}
+
+func init() {
+	Register(&Rule{
+		Name:        "description",
+		Description: "Services, methods and types have a description.",
+		Check:       checkDescriptions,
+	})
+	Register(&Rule{
+		Name:        "method-errors",
+		Description: "Methods define at least one error.",
+		Check:       checkMethodErrors,
+	})
+	Register(&Rule{
+		Name:        "path-casing",
+		Description: "Route path segments use the same case convention.",
+		Check:       checkPathCasing,
+	})
+	Register(&Rule{
+		Name:        "plural-collections",
+		Description: "Route path segments that name collections are plural.",
+		Check:       checkPluralCollections,
+	})
+	Register(&Rule{
+		Name:        "string-max-length",
+		Description: "Payload strings are bounded by a MaxLength validation.",
+		Check:       checkStringMaxLength,
+	})
+	Register(&Rule{
+		Name:        "payload-examples",
+		Description: "Method payloads define an example.",
+		Check:       checkPayloadExamples,
+	})
+	Register(&Rule{
+		Name:        "secure-mutations",
+		Description: "Routes that modify resources have security requirements.",
+		Check:       checkSecureMutations,
+	})
+}
+
+// checkDescriptions reports the services, methods and types defined without a
+// description.
+func checkDescriptions(l *Linter, root *expr.RootExpr) {
+	for _, svc := range root.Services {
+		if svc.Description == "" {
+			l.Report(svc, fmt.Sprintf("service %q has no description", svc.Name))
+		}
+		for _, m := range svc.Methods {
+			if m.Description == "" {
+				l.Report(m, fmt.Sprintf("method %q has no description", methodName(m)), svc)
+			}
+		}
+	}
+	for _, t := range root.Types {
+		if t.Attribute().Description == "" {
+			l.Report(t, fmt.Sprintf("type %q has no description", t.Name()))
+		}
+	}
+	for _, t := range root.ResultTypes {
+		if _, ok := eval.LocationOf(t); !ok {
+			// Result type created by Goa, e.g. with CollectionOf.
+			continue
+		}
+		if t.Attribute().Description == "" {
+			l.Report(t, fmt.Sprintf("result type %q has no description", t.Name()))
+		}
+	}
+}
+
+// checkMethodErrors reports the methods that do not define any error.
+func checkMethodErrors(l *Linter, root *expr.RootExpr) {
+	for _, svc := range root.Services {
+		for _, m := range svc.Methods {
+			if len(m.Errors) == 0 {
+				l.Report(m, fmt.Sprintf("method %q does not define any error", methodName(m)), svc)
+			}
+		}
+	}
+}
+
+// checkPathCasing reports the routes with path segments that use a different
+// case convention than the first segment of the API that uses one.
+func checkPathCasing(l *Linter, root *expr.RootExpr) {
+	var first, style string
+	walkRoutes(root, func(r *expr.RouteExpr, path string) {
+		for _, seg := range segments(path) {
+			s := segmentCase(seg)
+			if s == "" {
+				continue
+			}
+			if style == "" {
+				first, style = seg, s
+				continue
+			}
+			if s != style {
+				l.Report(r, fmt.Sprintf("route %q: segment %q uses %s case but %q uses %s case", r.Method+" "+path, seg, s, first, style), routeScope(r)...)
+				return
+			}
+		}
+	})
+}
+
+// checkPluralCollections reports the path segments that name collections but
+// are not plural. A segment names a collection if it is followed by a wildcard
+// or if it is the last segment of a GET route whose method returns an array.
+// Plurals are detected with a simple heuristic: they end with "s".
+func checkPluralCollections(l *Linter, root *expr.RootExpr) {
+	walkRoutes(root, func(r *expr.RouteExpr, path string) {
+		segs := segments(path)
+		for i, seg := range segs {
+			if isWildcard(seg) || versionRegex.MatchString(seg) || strings.HasSuffix(seg, "s") {
+				continue
+			}
+			collection := i+1 < len(segs) && isWildcard(segs[i+1])
+			if i == len(segs)-1 && r.Method == "GET" && expr.IsArray(r.Endpoint.MethodExpr.Result.Type) {
+				collection = true
+			}
+			if collection {
+				l.Report(r, fmt.Sprintf("route %q: collection segment %q is not plural", r.Method+" "+path, seg), routeScope(r)...)
+			}
+		}
+	})
+}
+
+// checkStringMaxLength reports the string attributes of method payloads that
+// are not bounded by a MaxLength, Enum or Format validation. The attributes of
+// user types are reported once.
+func checkStringMaxLength(l *Linter, root *expr.RootExpr) {
+	seen := make(map[string]bool)
+	for _, svc := range root.Services {
+		for _, m := range svc.Methods {
+			ctx := fmt.Sprintf("method %q payload", methodName(m))
+			checkString(l, m.Payload, ctx, "", false, seen, []eval.Expression{m, svc})
+		}
+	}
+}
+
+// checkString reports the unbounded strings in att. ctx describes the
+// expression containing att and path is the path to att in ctx. bounded is
+// true if a validation of the attribute that refers to att bounds its values.
+func checkString(l *Linter, att *expr.AttributeExpr, ctx, path string, bounded bool, seen map[string]bool, scope []eval.Expression) {
+	if att == nil || att.Type == nil {
+		return
+	}
+	if v := att.Validation; v != nil && (v.MaxLength != nil || len(v.Values) > 0 || v.Format != "") {
+		bounded = true
+	}
+	switch actual := att.Type.(type) {
+	case expr.Primitive:
+		if actual.Kind() != expr.StringKind || bounded {
+			return
+		}
+		msg := fmt.Sprintf("%s: string has no MaxLength validation", ctx)
+		if path != "" {
+			msg = fmt.Sprintf("%s: string attribute %q has no MaxLength validation", ctx, path)
+		}
+		l.Report(att, msg, scope...)
+	case expr.UserType:
+		if _, ok := eval.LocationOf(actual); ok {
+			if bounded || seen[actual.Name()] {
+				return
+			}
+			seen[actual.Name()] = true
+			ctx, path = fmt.Sprintf("type %q", actual.Name()), ""
+			scope = append([]eval.Expression{actual}, scope...)
+		}
+		checkString(l, actual.Attribute(), ctx, path, bounded, seen, scope)
+	case *expr.Array:
+		scope = append([]eval.Expression{att}, scope...)
+		checkString(l, actual.ElemType, ctx, path+"[]", false, seen, scope)
+	case *expr.Map:
+		scope = append([]eval.Expression{att}, scope...)
+		checkString(l, actual.KeyType, ctx, path+"{key}", false, seen, scope)
+		checkString(l, actual.ElemType, ctx, path+"{}", false, seen, scope)
+	case *expr.Object:
+		for _, nat := range *actual {
+			checkString(l, nat.Attribute, ctx, join(path, nat.Name), false, seen, scope)
+		}
+	case *expr.Union:
+		for _, nat := range actual.Values {
+			checkString(l, nat.Attribute, ctx, join(path, nat.Name), false, seen, scope)
+		}
+	}
+}
+
+// checkPayloadExamples reports the methods whose payload does not define an
+// example.
+func checkPayloadExamples(l *Linter, root *expr.RootExpr) {
+	for _, svc := range root.Services {
+		for _, m := range svc.Methods {
+			if m.Payload == nil || m.Payload.Type == expr.Empty || len(m.Payload.UserExamples) > 0 {
+				continue
+			}
+			if ut, ok := m.Payload.Type.(expr.UserType); ok && len(ut.Attribute().UserExamples) > 0 {
+				continue
+			}
+			l.Report(m, fmt.Sprintf("method %q payload has no example", methodName(m)), svc)
+		}
+	}
+}
+
+// checkSecureMutations reports the POST, PUT, PATCH and DELETE routes of
+// methods that have no security requirement.
+func checkSecureMutations(l *Linter, root *expr.RootExpr) {
+	walkRoutes(root, func(r *expr.RouteExpr, path string) {
+		if !mutatingMethods[r.Method] || len(r.Endpoint.Requirements) > 0 || len(r.Endpoint.MethodExpr.Requirements) > 0 {
+			return
+		}
+		l.Report(r, fmt.Sprintf("route %q of method %q has no security requirement", r.Method+" "+path, methodName(r.Endpoint.MethodExpr)), routeScope(r)...)
+	})
+}
+
+// walkRoutes calls fn with each full path of each route of the HTTP
+// endpoints.
+func walkRoutes(root *expr.RootExpr, fn func(r *expr.RouteExpr, path string)) {
+	if root.API == nil || root.API.HTTP == nil {
+		return
+	}
+	for _, svc := range root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					fn(r, p)
+				}
+			}
+		}
+	}
+}
+
+// routeScope returns the expressions that contain the given route.
+func routeScope(r *expr.RouteExpr) []eval.Expression {
+	return []eval.Expression{r.Endpoint.MethodExpr, r.Endpoint.MethodExpr.Service}
+}
+
+// segments returns the non-empty segments of the given path.
+func segments(path string) []string {
+	var segs []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segs = append(segs, s)
+		}
+	}
+	return segs
+}
+
+// isWildcard returns true if the given path segment is a wildcard.
+func isWildcard(seg string) bool {
+	return strings.HasPrefix(seg, "{")
+}
+
+// segmentCase returns the case convention used by the given path segment:
+// "kebab", "snake" or "camel", empty if the segment is a wildcard or is made
+// of a single lower case word.
+func segmentCase(seg string) string {
+	switch {
+	case isWildcard(seg):
+		return ""
+	case strings.Contains(seg, "-"):
+		return "kebab"
+	case strings.Contains(seg, "_"):
+		return "snake"
+	case seg != strings.ToLower(seg):
+		return "camel"
+	}
+	return ""
+}
+
+// methodName returns the qualified name of the given method.
+func methodName(m *expr.MethodExpr) string {
+	return m.Service.Name + "." + m.Name
+}
+
+// join appends name to the attribute path.
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
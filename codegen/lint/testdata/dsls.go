@@ -0,0 +1,103 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CleanDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	var Item = Type("Item", func() {
+		Description("Item is a store item.")
+		Attribute("name", String, func() {
+			MaxLength(100)
+		})
+		Attribute("kind", String, func() {
+			Enum("a", "b")
+		})
+		Attribute("id", String, func() {
+			Format(FormatUUID)
+		})
+		Example(map[string]any{"name": "foo", "kind": "a"})
+	})
+	Service("store", func() {
+		Description("The store service.")
+		Error("unavailable")
+		Method("add", func() {
+			Description("Add an item.")
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String, func() {
+					MaxLength(1000)
+				})
+				Extend(Item)
+				Example(map[string]any{"token": "foo", "name": "foo"})
+			})
+			HTTP(func() {
+				POST("/store-items")
+			})
+		})
+		Method("list", func() {
+			Description("List the items.")
+			Result(ArrayOf(Item))
+			HTTP(func() {
+				GET("/store-items")
+			})
+		})
+	})
+}
+
+var IssuesDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+		Attribute("tags", ArrayOf(String))
+	})
+	Service("store", func() {
+		Method("add", func() {
+			Payload(Item)
+			HTTP(func() {
+				POST("/item_list")
+			})
+		})
+		Method("show", func() {
+			Description("Show an item.")
+			Payload(func() {
+				Attribute("id", String, func() {
+					MaxLength(10)
+				})
+			})
+			Error("not_found")
+			HTTP(func() {
+				GET("/item/{id}")
+			})
+		})
+		Method("list", func() {
+			Description("List the items.")
+			Result(ArrayOf(Item))
+			Error("unavailable")
+			HTTP(func() {
+				GET("/itemList")
+			})
+		})
+	})
+}
+
+var DisabledDSL = func() {
+	API("test", func() {
+		Meta("lint:disable", "description")
+	})
+	Service("store", func() {
+		Meta("lint:disable", "method-errors")
+		Method("add", func() {
+			Meta("lint:disable", "payload-examples", "secure-mutations")
+			Payload(func() {
+				Attribute("name", String, func() {
+					Meta("lint:disable", "string-max-length")
+				})
+				Attribute("value", String)
+			})
+			HTTP(func() {
+				POST("/items")
+			})
+		})
+	})
+}
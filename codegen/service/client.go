@@ -24,7 +24,10 @@ func ClientFile(_ string, service *expr.ServiceExpr) *codegen.File {
 		imports := []*codegen.ImportSpec{
 			{Path: "context"},
 			{Path: "io"},
+			{Path: "iter"},
+			{Path: "time"},
 			codegen.GoaImport(""),
+			codegen.GoaImport("retry"),
 		}
 		imports = append(imports, svc.UserTypeImports...)
 		header := codegen.Header(service.Name+" client", svc.PkgName, imports)
@@ -45,6 +48,13 @@ func ClientFile(_ string, service *expr.ServiceExpr) *codegen.File {
 				Source: readTemplate("service_client_method"),
 				Data:   m,
 			})
+			if m.Pagination != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-pagination",
+					Source: readTemplate("service_client_pagination"),
+					Data:   m,
+				})
+			}
 		}
 	}
 
@@ -33,6 +33,8 @@ func TestClient(t *testing.T) {
 		{"client-bidirectional-streaming", testdata.BidirectionalStreamingMethodDSL, testdata.BidirectionalStreamingMethodClient},
 		{"client-bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodClient},
 		{"client-interceptor", testdata.EndpointWithClientInterceptorDSL, testdata.InterceptorClient},
+		{"client-retry", testdata.RetryEndpointDSL, testdata.RetryClient},
+		{"client-paginated", testdata.PaginatedEndpointDSL, testdata.PaginatedClient},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// ContractCaseData contains the data needed to render a contract test
+	// case that calls a method through the generated transport code and
+	// checks the outcome.
+	ContractCaseData struct {
+		// Name is the test case name.
+		Name string
+		// Method is the data of the mock method that handles the call.
+		Method *MockMethodData
+		// Payload is the Go expression of the payload sent by the client
+		// if the method has a payload.
+		Payload string
+		// Returns is the list of Go expressions returned by the mock
+		// method if the case does not exercise an error.
+		Returns string
+		// Err is the Go expression of the error returned by the mock
+		// method if the case exercises an error.
+		Err string
+		// Error is the name of the error exercised by the case if any.
+		Error string
+	}
+
+	// literalBuilder builds Go expressions of values of design types from
+	// example values.
+	literalBuilder struct {
+		svc *Data
+		gen *expr.ExampleGenerator
+	}
+)
+
+// maxLiteralDepth is the maximum depth of the values built by literalBuilder,
+// it prevents infinite recursion on recursive required attributes.
+const maxLiteralDepth = 16
+
+// ContractCase returns the data needed to render the contract test case that
+// calls the given method and makes the mock return the error er or a result
+// if er is nil. The payload and result values are built from the design
+// examples. The payload sets the required attributes and the attributes listed
+// in include, optional attributes are set with the generic function "ptr" that
+// the test file must define. ContractCase returns nil if the method streams
+// or bypasses the body encoding or if the values cannot be built from the
+// design.
+func ContractCase(svc *Data, m *expr.MethodExpr, er *expr.ErrorExpr, include ...string) *ContractCaseData {
+	md := svc.Method(m.Name)
+	if md == nil || m.IsStreaming() || md.SkipRequestBodyEncodeDecode || md.SkipResponseBodyEncodeDecode {
+		return nil
+	}
+	b := &literalBuilder{svc: svc, gen: expr.NewRandom(svc.Name + m.Name)}
+	data := &ContractCaseData{Name: m.Name, Method: MockMethod(svc, md)}
+	if m.Payload.Type != expr.Empty {
+		overrides := make(map[string]string)
+		for _, name := range include {
+			att := m.Payload.Find(name)
+			if att == nil || m.Payload.IsRequired(name) {
+				continue
+			}
+			lit := b.literal(att, nil, nil, 1)
+			if lit == "" {
+				return nil
+			}
+			if m.Payload.IsPrimitivePointer(name, true) {
+				lit = fmt.Sprintf("ptr[%s](%s)", fullTypeRef(svc, att), lit)
+			}
+			overrides[name] = lit
+		}
+		if data.Payload = b.literal(m.Payload, nil, overrides, 0); data.Payload == "" {
+			return nil
+		}
+	}
+	if er != nil {
+		data.Name = m.Name + " " + er.Name
+		data.Error = er.Name
+		if data.Err = b.errorLiteral(er); data.Err == "" {
+			return nil
+		}
+		return data
+	}
+	var returns []string
+	if m.Result.Type != expr.Empty {
+		res := b.literal(m.Result, nil, nil, 0)
+		if res == "" {
+			return nil
+		}
+		returns = append(returns, res)
+		if md.ViewedResult != nil && md.ViewedResult.ViewName == "" {
+			returns = append(returns, strconv.Quote(expr.DefaultView))
+		}
+	}
+	data.Returns = strings.Join(append(returns, "nil"), ", ")
+	return data
+}
+
+// errorLiteral returns the Go expression of a value of the given error, an
+// empty string if the transport would not be able to identify the error from
+// the value.
+func (b *literalBuilder) errorLiteral(er *expr.ErrorExpr) string {
+	if er.Type == expr.ErrorResult {
+		return fmt.Sprintf("%s.Make%s(errors.New(%q))", b.svc.PkgName, codegen.Goify(er.Name, true), er.Name)
+	}
+	ut, ok := er.Type.(expr.UserType)
+	if !ok || !expr.IsObject(ut) || expr.IsUnion(ut) {
+		return ""
+	}
+	for _, nat := range *expr.AsObject(ut) {
+		if _, ok := nat.Attribute.Meta["struct:error:name"]; ok {
+			if v := nat.Attribute.Validation; v != nil && len(v.Values) > 0 && !slices.Contains(v.Values, any(er.Name)) {
+				// The client would reject the error value.
+				return ""
+			}
+			return b.literal(er.AttributeExpr, nil, map[string]string{nat.Name: strconv.Quote(er.Name)}, 0)
+		}
+	}
+	if v, ok := ut.Attribute().Meta.Last("struct:error:name"); !ok || v != er.Name {
+		return ""
+	}
+	return b.literal(er.AttributeExpr, nil, nil, 0)
+}
+
+// literal returns the Go expression of a value of the type of att built from
+// the example value v or from a generated example if v is nil. overrides
+// contains the Go expressions of the object fields indexed by attribute names
+// that replace the example values. literal returns an empty string if the
+// value cannot be built.
+func (b *literalBuilder) literal(att *expr.AttributeExpr, v any, overrides map[string]string, depth int) string {
+	if depth > maxLiteralDepth {
+		return ""
+	}
+	if t, _ := codegen.GetMetaType(att); t != "" {
+		return ""
+	}
+	if v == nil {
+		if v = att.Example(b.gen); v == nil {
+			return ""
+		}
+	}
+	switch actual := att.Type.(type) {
+	case expr.Primitive:
+		return primitiveLiteral(actual, v)
+	case *expr.Array:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return ""
+		}
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			if elems[i] = b.literal(actual.ElemType, rv.Index(i).Interface(), nil, depth+1); elems[i] == "" {
+				return ""
+			}
+		}
+		return fullTypeRef(b.svc, att) + "{" + strings.Join(elems, ", ") + "}"
+	case *expr.Map:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return ""
+		}
+		entries := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			key := b.literal(actual.KeyType, k.Interface(), nil, depth+1)
+			val := b.literal(actual.ElemType, rv.MapIndex(k).Interface(), nil, depth+1)
+			if key == "" || val == "" {
+				return ""
+			}
+			entries = append(entries, key+": "+val)
+		}
+		sort.Strings(entries)
+		return fullTypeRef(b.svc, att) + "{" + strings.Join(entries, ", ") + "}"
+	case expr.UserType:
+		if expr.IsUnion(actual) {
+			return ""
+		}
+		if !expr.IsObject(actual) {
+			return b.literal(actual.Attribute(), v, nil, depth)
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return ""
+		}
+		var fields []string
+		for _, nat := range *expr.AsObject(actual) {
+			var lit string
+			switch {
+			case overrides[nat.Name] != "":
+				lit = overrides[nat.Name]
+			case actual.Attribute().IsRequired(nat.Name):
+				var fv any
+				if e := rv.MapIndex(reflect.ValueOf(nat.Name)); e.IsValid() {
+					fv = e.Interface()
+				}
+				if lit = b.literal(nat.Attribute, fv, nil, depth+1); lit == "" {
+					return ""
+				}
+			case nat.Attribute.DefaultValue != nil && !actual.Attribute().IsPrimitivePointer(nat.Name, true):
+				p, ok := nat.Attribute.Type.(expr.Primitive)
+				if !ok {
+					continue
+				}
+				if lit = primitiveLiteral(p, nat.Attribute.DefaultValue); lit == "" {
+					continue
+				}
+			default:
+				continue
+			}
+			fields = append(fields, codegen.GoifyAtt(nat.Attribute, nat.Name, true)+": "+lit)
+		}
+		return "&" + strings.TrimPrefix(fullTypeRef(b.svc, att), "*") + "{" + strings.Join(fields, ", ") + "}"
+	}
+	return ""
+}
+
+// primitiveLiteral returns the Go expression of the primitive value v, an
+// empty string if v is not a value of type p.
+func primitiveLiteral(p expr.Primitive, v any) string {
+	rv := reflect.ValueOf(v)
+	switch p.Kind() {
+	case expr.BooleanKind:
+		if rv.Kind() == reflect.Bool {
+			return strconv.FormatBool(rv.Bool())
+		}
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(rv.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(rv.Uint(), 10)
+		}
+	case expr.Float32Kind, expr.Float64Kind:
+		bits := 64
+		if p.Kind() == expr.Float32Kind {
+			bits = 32
+		}
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return strconv.FormatFloat(rv.Float(), 'g', -1, bits)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(rv.Int(), 10)
+		}
+	case expr.StringKind:
+		if rv.Kind() == reflect.String {
+			return strconv.Quote(rv.String())
+		}
+	case expr.BytesKind:
+		switch b := v.(type) {
+		case []byte:
+			return fmt.Sprintf("[]byte(%q)", b)
+		case string:
+			return fmt.Sprintf("[]byte(%q)", b)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestContractCase(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Error    string
+		Expected *ContractCaseData
+	}{
+		{"with-default", testdata.WithDefaultDSL, "", &ContractCaseData{
+			Name:    "A",
+			Payload: `&withdefault.APayload{IntField: 1, StringField: "foo", RequiredField: 0.19456877}`,
+			Returns: `&withdefault.AResult{IntField: 1, StringField: "foo", RequiredField: 0.6125805}, nil`,
+		}},
+		{"service-error", testdata.ServiceErrorDSL, "error", &ContractCaseData{
+			Name:  "A error",
+			Err:   `serviceerror.MakeError(errors.New("error"))`,
+			Error: "error",
+		}},
+		{"primitive-error", testdata.CustomErrorsDSL, "primitive", nil},
+		{"user-type-error", testdata.CustomErrorsDSL, "user_type", &ContractCaseData{
+			Name:  "A user_type",
+			Err:   `&customerrors.APayload{IntField: 2565943954280397889, StringField: "Laboriosam nemo voluptas voluptas praesentium.", BooleanField: true, BytesField: []byte("Delectus facere saepe sit voluptas quos cumque.")}`,
+			Error: "user_type",
+		}},
+		{"struct-error-name", testdata.CustomErrorsDSL, "struct_error_name", &ContractCaseData{
+			Name:  "A struct_error_name",
+			Err:   `&customerrors.Result{B: "struct_error_name"}`,
+			Error: "struct_error_name",
+		}},
+		{"views", testdata.MultipleMethodsResultMultipleViewsDSL, "", &ContractCaseData{
+			Name:    "A",
+			Payload: `&multiplemethodsresultmultipleviews.APayload{IntField: 8274601006397990817, StringField: "Impedit distinctio.", BooleanField: false, BytesField: []byte("Aut eveniet natus et aut recusandae non.")}`,
+			Returns: `&multiplemethodsresultmultipleviews.MultipleViews{}, "default", nil`,
+		}},
+		{"streaming", testdata.StreamingResultMethodDSL, "", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			runDSL(t, c.DSL)
+			require.Len(t, expr.Root.Services, 1)
+			svc := expr.Root.Services[0]
+			m := svc.Methods[0]
+			var er *expr.ErrorExpr
+			if c.Error != "" {
+				er = m.Error(c.Error)
+				require.NotNil(t, er)
+			}
+			data := ContractCase(Services.Get(svc.Name), m, er)
+			if c.Expected == nil {
+				assert.Nil(t, data)
+				return
+			}
+			require.NotNil(t, data)
+			assert.Equal(t, c.Expected.Name, data.Name)
+			assert.Equal(t, c.Expected.Payload, data.Payload)
+			assert.Equal(t, c.Expected.Returns, data.Returns)
+			assert.Equal(t, c.Expected.Err, data.Err)
+			assert.Equal(t, c.Expected.Error, data.Error)
+		})
+	}
+}
@@ -50,6 +50,81 @@ type (
 		ServiceName string
 		// ServiceVarName is the name of the owner service Go interface.
 		ServiceVarName string
+		// Retry describes the retry policy used by the client if any.
+		Retry *RetryData
+		// Pagination describes the client iterator if the method is
+		// paginated.
+		Pagination *PaginationData
+		// FieldSelection describes the field selection if the method
+		// supports it.
+		FieldSelection *FieldSelectionData
+		// RateLimitPrincipal describes the principal used to enforce the
+		// method rate limit if it is keyed by principal.
+		RateLimitPrincipal *RateLimitPrincipalData
+	}
+
+	// RetryData contains the data needed to render the retry policy of a
+	// client endpoint.
+	RetryData struct {
+		// MaxAttempts is the maximum number of attempts including the
+		// initial request.
+		MaxAttempts int
+		// InitialBackoff is the Go expression for the delay before the
+		// first retry.
+		InitialBackoff string
+		// MaxBackoff is the Go expression for the maximum delay between
+		// two attempts.
+		MaxBackoff string
+		// Idempotent is true if requests that fail with timeout errors
+		// may be retried.
+		Idempotent bool
+	}
+
+	// PaginationData contains the data needed to render the client
+	// iterator of a paginated method.
+	PaginationData struct {
+		// VarName is the name of the iterator method.
+		VarName string
+		// PayloadTypeRef is the reference to the Go type of the payload
+		// without the pointer, used to start from a zero payload when
+		// the iterator is called with nil.
+		PayloadTypeRef string
+		// ItemRef is the reference to the Go type of the items.
+		ItemRef string
+		// ItemZero is the Go expression for the zero value of the items
+		// type.
+		ItemZero string
+		// ItemsField is the name of the result field that lists the
+		// items of a page.
+		ItemsField string
+		// Cursor is true for cursor based pagination and false for
+		// offset based pagination.
+		Cursor bool
+		// TokenField is the name of the payload field that holds the
+		// token of the page to retrieve.
+		TokenField string
+		// TokenPointer is true if the token payload field is a pointer.
+		TokenPointer bool
+		// NextTokenField is the name of the result field that holds
+		// the token of the next page.
+		NextTokenField string
+		// NextTokenPointer is true if the next token result field is a
+		// pointer.
+		NextTokenPointer bool
+		// OffsetField is the name of the payload field that holds the
+		// index of the first item to retrieve.
+		OffsetField string
+		// OffsetPointer is true if the offset payload field is a
+		// pointer.
+		OffsetPointer bool
+		// OffsetTypeRef is the Go type of the offset payload field.
+		OffsetTypeRef string
+		// PageSizeField is the name of the payload field that holds the
+		// page size if any.
+		PageSizeField string
+		// PageSizePointer is true if the page size payload field is a
+		// pointer.
+		PageSizePointer bool
 	}
 )
 
@@ -77,6 +152,7 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			{Path: "io"},
 			{Path: "fmt"},
 			codegen.GoaImport(""),
+			codegen.GoaImport("ratelimit"),
 			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
 		}
@@ -139,11 +215,15 @@ func endpointData(svc *Data) *EndpointsData {
 	names := make([]string, len(svc.Methods))
 	for i, m := range svc.Methods {
 		methods[i] = &EndpointMethodData{
-			MethodData:     m,
-			ArgName:        codegen.Goify(m.VarName, false),
-			ServiceName:    svc.Name,
-			ServiceVarName: serviceInterfaceName,
-			ClientVarName:  clientStructName,
+			MethodData:         m,
+			ArgName:            codegen.Goify(m.VarName, false),
+			ServiceName:        svc.Name,
+			ServiceVarName:     serviceInterfaceName,
+			ClientVarName:      clientStructName,
+			Retry:              retryData(svc.Name, m),
+			Pagination:         paginationData(svc, m),
+			FieldSelection:     BuildFieldSelectionData(svc, m),
+			RateLimitPrincipal: rateLimitPrincipalData(svc.Name, m),
 		}
 		names[i] = codegen.Goify(m.VarName, false)
 	}
@@ -162,6 +242,95 @@ func endpointData(svc *Data) *EndpointsData {
 	}
 }
 
+// retryData returns the data needed to render the retry policy of the given
+// method client endpoint, nil if the method is not retried. Methods that skip
+// the request body encoding are not retried as the body cannot be replayed.
+func retryData(svcName string, m *MethodData) *RetryData {
+	if m.SkipRequestBodyEncodeDecode {
+		return nil
+	}
+	svc := expr.Root.Service(svcName)
+	if svc == nil {
+		return nil
+	}
+	me := svc.Method(m.Name)
+	if me == nil {
+		return nil
+	}
+	r := me.EffectiveRetry()
+	if r == nil || r.MaxAttempts < 2 {
+		return nil
+	}
+	return &RetryData{
+		MaxAttempts:    r.MaxAttempts,
+		InitialBackoff: durationLiteral(r.InitialBackoff),
+		MaxBackoff:     durationLiteral(r.MaxBackoff),
+		Idempotent:     r.Idempotent,
+	}
+}
+
+// paginationData returns the data needed to render the client iterator of the
+// given method, nil if the method is not paginated.
+func paginationData(svc *Data, m *MethodData) *PaginationData {
+	if m.PayloadRef == "" || m.ResultRef == "" {
+		return nil
+	}
+	se := expr.Root.Service(svc.Name)
+	if se == nil {
+		return nil
+	}
+	me := se.Method(m.Name)
+	if me == nil || me.Pagination == nil {
+		return nil
+	}
+	p := me.Pagination
+	items := me.Result.Find(p.Items)
+	arr := expr.AsArray(items.Type)
+	var loc *codegen.Location
+	if ut, ok := arr.ElemType.Type.(expr.UserType); ok {
+		loc = codegen.UserTypeLocation(ut)
+	}
+	itemRef := svc.Scope.GoFullTypeRef(arr.ElemType, loc.PackageName())
+	itemZero := "nil"
+	if expr.IsPrimitive(arr.ElemType.Type) {
+		switch arr.ElemType.Type {
+		case expr.Boolean:
+			itemZero = "false"
+		case expr.String:
+			itemZero = `""`
+		case expr.Int, expr.Int32, expr.Int64, expr.UInt, expr.UInt32, expr.UInt64, expr.Float32, expr.Float64:
+			itemZero = "0"
+		case expr.Bytes, expr.Any:
+		default:
+			itemZero = "*new(" + itemRef + ")"
+		}
+	}
+	data := &PaginationData{
+		VarName:        m.VarName + "All",
+		PayloadTypeRef: strings.TrimPrefix(m.PayloadRef, "*"),
+		ItemRef:        itemRef,
+		ItemZero:       itemZero,
+		ItemsField:     codegen.GoifyAtt(items, p.Items, true),
+		Cursor:         p.Kind == expr.CursorPaginationKind,
+	}
+	if data.Cursor {
+		data.TokenField = codegen.GoifyAtt(me.Payload.Find(p.Token), p.Token, true)
+		data.TokenPointer = me.Payload.IsPrimitivePointer(p.Token, true)
+		data.NextTokenField = codegen.GoifyAtt(me.Result.Find(p.NextToken), p.NextToken, true)
+		data.NextTokenPointer = me.Result.IsPrimitivePointer(p.NextToken, true)
+	} else {
+		offset := me.Payload.Find(p.Offset)
+		data.OffsetField = codegen.GoifyAtt(offset, p.Offset, true)
+		data.OffsetPointer = me.Payload.IsPrimitivePointer(p.Offset, true)
+		data.OffsetTypeRef = svc.Scope.GoTypeRef(offset)
+	}
+	if p.PageSize != "" {
+		data.PageSizeField = codegen.GoifyAtt(me.Payload.Find(p.PageSize), p.PageSize, true)
+		data.PageSizePointer = me.Payload.IsPrimitivePointer(p.PageSize, true)
+	}
+	return data
+}
+
 func payloadVar(e *EndpointMethodData) string {
 	if e.ServerStream != nil || e.SkipRequestBodyEncodeDecode {
 		return "ep.Payload"
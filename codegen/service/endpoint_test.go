@@ -36,6 +36,11 @@ func TestEndpoint(t *testing.T) {
 		{"endpoint-bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodEndpoint},
 		{"endpoint-with-server-interceptor", testdata.EndpointWithServerInterceptorDSL, testdata.EndpointWithServerInterceptor},
 		{"endpoint-with-multiple-interceptors", testdata.EndpointWithMultipleInterceptorsDSL, testdata.EndpointWithMultipleInterceptors},
+		{"endpoint-field-selection", testdata.FieldSelectionDSL, testdata.FieldSelectionEndpoint},
+		{"endpoint-field-selection-cache", testdata.FieldSelectionCacheDSL, testdata.FieldSelectionCacheEndpoint},
+		{"endpoint-rate-limit-principal", testdata.RateLimitPrincipalDSL, testdata.RateLimitPrincipalEndpoint},
+		{"endpoint-rate-limit-principal-mtls", testdata.RateLimitPrincipalMutualTLSDSL, testdata.RateLimitPrincipalMutualTLSEndpoint},
+		{"endpoint-rate-limit-principal-requirements", testdata.RateLimitPrincipalRequirementsDSL, testdata.RateLimitPrincipalRequirementsEndpoint},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -69,10 +69,15 @@ func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr,
 		{Path: "io"},
 		{Path: "context"},
 		{Path: "fmt"},
+		{Path: "os"},
 		{Path: "strings"},
 		{Path: path.Join(genpkg, svcName), Name: data.PkgName},
 		{Path: "goa.design/clue/log"},
 		{Path: "goa.design/goa/v3/security"},
+		{Path: "goa.design/goa/v3/pkg", Name: "goa"},
+	}
+	if data.Schemes.HasType("MutualTLS") {
+		specs = append(specs, &codegen.ImportSpec{Path: "crypto/x509"})
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", apipkg, specs),
@@ -2,6 +2,8 @@ package service
 
 import (
 	"bytes"
+	"go/format"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,3 +51,27 @@ func TestExampleServiceFiles(t *testing.T) {
 		}
 	})
 }
+
+func TestExampleServiceFilesSecurity(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"jwt_auth", testdata.JWTAuthDSL},
+		{"mutual_tls_auth", testdata.MutualTLSAuthDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := runDSL(t, c.DSL)
+			fs := ExampleServiceFiles("", root)
+			require.Len(t, fs, 1)
+			buf := new(bytes.Buffer)
+			for _, s := range fs[0].SectionTemplates[1:] {
+				require.NoError(t, s.Write(buf))
+			}
+			bs, err := format.Source(buf.Bytes())
+			require.NoError(t, err, buf.String())
+			compareOrUpdateGolden(t, string(bs), filepath.Join("testdata", "example_svc", c.Name+".golden"))
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// FieldSelectionData contains the data needed to render the code that
+	// validates the field selection of a method and projects its result.
+	FieldSelectionData struct {
+		// Name is the name of the payload attribute that holds the field
+		// selection.
+		Name string
+		// Field is the name of the payload field that holds the field
+		// selection.
+		Field string
+		// Kind is "string", "array" or "mask" depending on whether the
+		// selection is a comma separated list, an array of strings or
+		// an object that lists the paths.
+		Kind string
+		// Pointer is true if the payload field is a pointer.
+		Pointer bool
+		// PathsField is the name of the field that lists the paths when
+		// Kind is "mask".
+		PathsField string
+		// Validate is the name of the views package function that
+		// validates the selected paths.
+		Validate string
+		// Project is the name of the views package function that clears
+		// the fields of the projected result that are not selected.
+		Project string
+		// Validators lists the quoted names of the optional result
+		// attributes used as HTTP cache validators. They are added to
+		// the selection so that the validators are computed from the
+		// complete result.
+		Validators string
+	}
+
+	// projectedFieldsData contains the data needed to render the functions
+	// that validate a field selection against a projected type and that
+	// clear the fields not selected.
+	projectedFieldsData struct {
+		// VarName is the name of the projected type.
+		VarName string
+		// Ref is the reference to the projected type.
+		Ref string
+		// Elem is the name of the projected type of the collection
+		// elements if the projected type is a collection.
+		Elem string
+		// Fields lists the projected type fields.
+		Fields []*projectedFieldData
+		// Leaves lists the quoted names of the fields that do not have
+		// nested fields that may be selected.
+		Leaves string
+		// HasNested is true if at least one field has nested fields
+		// that may be selected.
+		HasNested bool
+	}
+
+	// projectedFieldData describes a single field of a projected type.
+	projectedFieldData struct {
+		// Name is the attribute name.
+		Name string
+		// FieldName is the name of the Go struct field.
+		FieldName string
+		// Required is true if the attribute is required in which case
+		// the field is never cleared.
+		Required bool
+		// Nested is the name of the projected type of the field or of
+		// its elements if nested fields may be selected.
+		Nested string
+		// Many is true if the field is an array or a map of Nested.
+		Many bool
+	}
+)
+
+// BuildFieldSelectionData returns the data needed to render the field
+// selection code of the given method endpoint, nil if the method does not
+// support field selection.
+func BuildFieldSelectionData(svc *Data, m *MethodData) *FieldSelectionData {
+	if m.ViewedResult == nil {
+		return nil
+	}
+	se := expr.Root.Service(svc.Name)
+	if se == nil {
+		return nil
+	}
+	me := se.Method(m.Name)
+	if me == nil || me.FieldSelection == nil {
+		return nil
+	}
+	name := me.FieldSelection.Attribute
+	att := me.Payload.Find(name)
+	projected := svc.ViewScope.GoTypeName(viewedProjectedAttribute(m.ViewedResult))
+	data := &FieldSelectionData{
+		Name:     name,
+		Field:    codegen.GoifyAtt(att, name, true),
+		Kind:     expr.FieldSelectionKind(att),
+		Pointer:  me.Payload.IsPrimitivePointer(name, true),
+		Validate: svc.ViewsPkg + ".Validate" + projected + "Fields",
+		Project:  svc.ViewsPkg + ".Project" + projected + "Fields",
+	}
+	if data.Kind == "mask" {
+		data.Pointer = true
+		data.PathsField = codegen.GoifyAtt(att.Find(expr.FieldMaskPathsAttribute), expr.FieldMaskPathsAttribute, true)
+	}
+	if hs := expr.Root.HTTPService(svc.Name); hs != nil {
+		if e := hs.Endpoint(m.Name); e != nil && e.Cache != nil {
+			var validators []string
+			for _, n := range []string{e.Cache.ETag, e.Cache.LastModified} {
+				if n != "" && !me.Result.IsRequired(n) {
+					validators = append(validators, strconv.Quote(n))
+				}
+			}
+			data.Validators = strings.Join(validators, ", ")
+		}
+	}
+	return data
+}
+
+// projectedFields returns the data needed to render the field selection
+// functions of the projected result types of the service methods that
+// support field selection and of all the projected types they contain.
+func projectedFields(svc *Data) []*projectedFieldsData {
+	se := expr.Root.Service(svc.Name)
+	if se == nil {
+		return nil
+	}
+	var (
+		data []*projectedFieldsData
+		seen = make(map[string]struct{})
+	)
+	var collect func(att *expr.AttributeExpr)
+	collect = func(att *expr.AttributeExpr) {
+		varname := svc.ViewScope.GoTypeName(att)
+		if _, ok := seen[varname]; ok {
+			return
+		}
+		seen[varname] = struct{}{}
+		pf := &projectedFieldsData{VarName: varname, Ref: svc.ViewScope.GoTypeRef(att)}
+		data = append(data, pf)
+		if arr := expr.AsArray(att.Type); arr != nil {
+			if isSelectable(arr.ElemType) {
+				pf.Elem = svc.ViewScope.GoTypeName(arr.ElemType)
+				collect(arr.ElemType)
+			}
+			return
+		}
+		var (
+			leaves []string
+			ut     = att.Type.(expr.UserType)
+		)
+		for _, nat := range *expr.AsObject(att.Type) {
+			f := &projectedFieldData{
+				Name:      nat.Name,
+				FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+				Required:  ut.Attribute().IsRequired(nat.Name),
+			}
+			nested := nat.Attribute
+			switch t := nat.Attribute.Type.(type) {
+			case *expr.Array:
+				nested, f.Many = t.ElemType, true
+			case *expr.Map:
+				nested, f.Many = t.ElemType, true
+			}
+			if isSelectable(nested) {
+				f.Nested = svc.ViewScope.GoTypeName(nested)
+				pf.HasNested = true
+				collect(nested)
+			}
+			pf.Fields = append(pf.Fields, f)
+			if f.Nested == "" {
+				leaves = append(leaves, strconv.Quote(nat.Name))
+			}
+		}
+		pf.Leaves = strings.Join(leaves, ", ")
+	}
+	for _, m := range svc.Methods {
+		if m.ViewedResult == nil {
+			continue
+		}
+		if me := se.Method(m.Name); me == nil || me.FieldSelection == nil {
+			continue
+		}
+		collect(viewedProjectedAttribute(m.ViewedResult))
+	}
+	return data
+}
+
+// viewedProjectedAttribute returns an attribute whose type is the projected
+// type of the given viewed result type.
+func viewedProjectedAttribute(vrt *ViewedResultTypeData) *expr.AttributeExpr {
+	return &expr.AttributeExpr{Type: vrt.Type.Attribute().Find("projected").Type}
+}
+
+// isSelectable returns true if the nested fields of the given projected
+// attribute may be selected, that is if it is a user type object.
+func isSelectable(att *expr.AttributeExpr) bool {
+	if _, ok := att.Type.(expr.UserType); !ok {
+		return false
+	}
+	if expr.IsArray(att.Type) {
+		return true
+	}
+	return expr.IsObject(att.Type) && !expr.IsUnion(att.Type)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// MockData contains the data needed to render the programmable mock
+	// implementation of a service.
+	MockData struct {
+		// Name is the service name.
+		Name string
+		// PkgName is the name of the service package.
+		PkgName string
+		// Methods lists the mock methods.
+		Methods []*MockMethodData
+		// Schemes lists the authorization functions implemented by the
+		// mock.
+		Schemes []*mockSchemeData
+	}
+
+	// MockMethodData contains the data needed to render a mock method.
+	MockMethodData struct {
+		*MethodData
+		// FuncName is the name of the function type that implements the
+		// method.
+		FuncName string
+		// FieldName is the prefix of the names of the mock struct fields
+		// that hold the method functions.
+		FieldName string
+		// Params is the list of the method parameters.
+		Params string
+		// Args is the list of the method arguments.
+		Args string
+		// Results is the list of the method named results.
+		Results string
+		// PayloadArg is the argument recorded as the call payload.
+		PayloadArg string
+	}
+
+	// mockSchemeData contains the data needed to render the mock
+	// authorization function of a security scheme.
+	mockSchemeData struct {
+		// Type is the security scheme type.
+		Type string
+		// FieldName is the name of the mock struct field that holds the
+		// authorization function.
+		FieldName string
+		// Params is the list of the authorization function parameters.
+		Params string
+		// Args is the list of the authorization function arguments.
+		Args string
+	}
+)
+
+// TestPkgName returns the name of the package that contains the test doubles
+// of the given service.
+func TestPkgName(svc *Data) string {
+	return svc.PkgName + "test"
+}
+
+// TestPkgPath returns the import path of the package that contains the test
+// doubles of the given service.
+func TestPkgPath(genpkg string, svc *Data) string {
+	return path.Join(genpkg, svc.PathName, svc.PathName+"test")
+}
+
+// MockFile returns the file that contains the programmable mock
+// implementation of the given service. Tests set the functions that implement
+// the service methods on the mock and inspect the recorded calls.
+func MockFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "fmt"},
+		{Path: "io"},
+		{Path: "sync"},
+		{Path: "testing"},
+		codegen.GoaImport("security"),
+		{Path: path.Join(genpkg, svc.PathName), Name: svc.PkgName},
+	}
+	if svc.Schemes.HasType("MutualTLS") {
+		imports = append(imports, &codegen.ImportSpec{Path: "crypto/x509"})
+	}
+	imports = append(imports, svc.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" service mock", TestPkgName(svc), imports),
+		{
+			Name:   "service-mock",
+			Source: readTemplate("service_mock"),
+			Data:   mockData(svc),
+		},
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, svc.PathName, svc.PathName+"test", "mock.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// MockMethod returns the data needed to render the mock implementation of the
+// given method.
+func MockMethod(svc *Data, m *MethodData) *MockMethodData {
+	var (
+		params  = []string{"ctx context.Context"}
+		args    = []string{"ctx"}
+		results []string
+		parg    = "nil"
+	)
+	me := expr.Root.Service(svc.Name).Method(m.Name)
+	if m.PayloadRef != "" {
+		params = append(params, "p "+fullTypeRef(svc, me.Payload))
+		args = append(args, "p")
+		parg = "p"
+	}
+	if m.ServerStream != nil {
+		params = append(params, "stream "+svc.PkgName+"."+m.ServerStream.Interface)
+		args = append(args, "stream")
+	} else {
+		if m.SkipRequestBodyEncodeDecode {
+			params = append(params, "req io.ReadCloser")
+			args = append(args, "req")
+		}
+		if m.ResultRef != "" {
+			results = append(results, "res "+fullTypeRef(svc, me.Result))
+		}
+		if m.SkipResponseBodyEncodeDecode {
+			results = append(results, "body io.ReadCloser")
+		}
+		if m.ResultRef != "" && m.ViewedResult != nil && m.ViewedResult.ViewName == "" {
+			results = append(results, "view string")
+		}
+	}
+	results = append(results, "err error")
+	return &MockMethodData{
+		MethodData: m,
+		FuncName:   m.VarName + "Func",
+		FieldName:  codegen.Goify(m.Name, false),
+		Params:     strings.Join(params, ", "),
+		Args:       strings.Join(args, ", "),
+		Results:    strings.Join(results, ", "),
+		PayloadArg: parg,
+	}
+}
+
+// mockData builds the data needed to render the mock of the given service.
+func mockData(svc *Data) *MockData {
+	methods := make([]*MockMethodData, len(svc.Methods))
+	for i, m := range svc.Methods {
+		methods[i] = MockMethod(svc, m)
+	}
+	schemes := make([]*mockSchemeData, len(svc.Schemes))
+	for i, s := range svc.Schemes {
+		args, typ, field := "token", "string", strings.ToLower(s.Type)
+		switch s.Type {
+		case "Basic":
+			args = "user, pass"
+		case "APIKey":
+			args, field = "key", "apiKey"
+		case "MutualTLS":
+			args, typ, field = "certs", "[]*x509.Certificate", "mutualTLS"
+		}
+		schemes[i] = &mockSchemeData{
+			Type:      s.Type,
+			FieldName: field + "Auth",
+			Params:    "ctx context.Context, " + args + " " + typ + ", schema *security." + s.Type + "Scheme",
+			Args:      "ctx, " + args + ", schema",
+		}
+	}
+	return &MockData{
+		Name:    svc.Name,
+		PkgName: svc.PkgName,
+		Methods: methods,
+		Schemes: schemes,
+	}
+}
+
+// fullTypeRef returns the reference to the type of the given attribute
+// qualified with the service package or with the package defined via Meta if
+// any.
+func fullTypeRef(svc *Data, att *expr.AttributeExpr) string {
+	pkg := svc.PkgName
+	if loc := codegen.UserTypeLocation(att.Type); loc != nil {
+		pkg = loc.PackageName()
+	}
+	return svc.Scope.GoFullTypeRef(att, pkg)
+}
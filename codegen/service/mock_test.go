@@ -0,0 +1,45 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestMock(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"empty", testdata.EmptyMethodDSL, testdata.MockEmptyCode},
+		{"views", testdata.MultipleMethodsResultMultipleViewsDSL, testdata.MockViewsCode},
+		{"streaming", testdata.StreamingResultMethodDSL, testdata.MockStreamingCode},
+		{"security", testdata.EndpointsWithRequirementsDSL, testdata.MockSecurityCode},
+		{"composed-security", testdata.EndpointWithComposedRequirementsDSL, testdata.MockComposedSecurityCode},
+		{"pkg-path", testdata.PkgPathDSL, testdata.MockPkgPathCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			runDSL(t, c.DSL)
+			require.Len(t, expr.Root.Services, 1)
+			f := MockFile("goa.design/goa/example", expr.Root.Services[0])
+			require.NotNil(t, f)
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				require.NoError(t, s.Write(buf))
+			}
+			bs, err := format.Source(buf.Bytes())
+			require.NoError(t, err, buf.String())
+			code := strings.ReplaceAll(string(bs), "\r\n", "\n")
+			assert.Equal(t, c.Code, code)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// RateLimitData contains the data needed to render the endpoint
+	// middleware that enforces the rate limit of a method.
+	RateLimitData struct {
+		// Method is the rate limited method.
+		Method *MethodData
+		// MiddlewareName is the name of the function that creates the
+		// middleware.
+		MiddlewareName string
+		// Requests is the maximum number of requests allowed during the
+		// period.
+		Requests int
+		// Period is the Go expression for the rate limit period.
+		Period string
+		// PeriodText is the human readable rate limit period used in
+		// comments.
+		PeriodText string
+		// Scope is the prefix of the limiter keys, methods that share the
+		// same scope share the same quota.
+		Scope string
+		// ClientIP is true if the key is the client IP address.
+		ClientIP bool
+		// Principal is true if the key is the authenticated principal in
+		// which case the endpoint enforces the quota once the request is
+		// authenticated.
+		Principal bool
+		// KeyField is the name of the payload field used to compute the
+		// key if any.
+		KeyField string
+		// KeyPointer is true if the payload key field is a pointer.
+		KeyPointer bool
+		// KeyString is true if the payload key field is a string.
+		KeyString bool
+	}
+
+	// RateLimitPrincipalData contains the data needed by the endpoint of a
+	// method rate limited by principal to enforce the quota once the
+	// request is authenticated.
+	RateLimitPrincipalData struct {
+		// Creds lists the credentials given to
+		// ratelimit.EnforcePrincipal indexed by security requirement.
+		// The endpoint uses the credential of the requirement that
+		// succeeded.
+		Creds []*RateLimitCredData
+	}
+
+	// RateLimitCredData describes the credential of a security requirement
+	// used to identify the principal.
+	RateLimitCredData struct {
+		// Field is the name of the payload field holding the verified
+		// credential, empty if the requirement schemes do not define
+		// one, e.g. mutual TLS, in which case ratelimit.EnforcePrincipal
+		// identifies the principal from the context only.
+		Field string
+		// Pointer is true if the credential payload field is a pointer.
+		Pointer bool
+	}
+)
+
+// RateLimitFile returns the file that contains the endpoint middlewares
+// enforcing the rate limits defined in the design for the given service. It
+// returns nil if none of the service methods is rate limited.
+func RateLimitFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	var data []*RateLimitData
+	for _, m := range service.Methods {
+		rl := m.EffectiveRateLimit()
+		if rl == nil {
+			continue
+		}
+		data = append(data, rateLimitData(svc.Method(m.Name), m, rl))
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "fmt"},
+		{Path: "time"},
+		codegen.GoaImport(""),
+		codegen.GoaImport("ratelimit"),
+	}
+	imports = append(imports, svc.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" rate limits", svc.PkgName, imports),
+		{
+			Name:   "rate-limit-apply",
+			Source: readTemplate("rate_limit_apply"),
+			Data:   map[string]any{"Name": svc.Name, "RateLimits": data},
+		},
+	}
+	for _, d := range data {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "rate-limit-middleware",
+			Source: readTemplate("rate_limit_middleware"),
+			Data:   d,
+		})
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, svc.PathName, "ratelimit.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// rateLimitData builds the data needed to render the rate limit middleware of
+// the given method.
+func rateLimitData(md *MethodData, m *expr.MethodExpr, rl *expr.RateLimitExpr) *RateLimitData {
+	data := &RateLimitData{
+		Method:         md,
+		MiddlewareName: fmt.Sprintf("New%sRateLimitMiddleware", md.VarName),
+		Requests:       rl.Requests,
+		Period:         durationLiteral(rl.Period),
+		PeriodText:     durationText(rl.Period),
+		Scope:          rl.Scope(),
+	}
+	switch rl.Key {
+	case "":
+	case expr.RateLimitKeyClientIP:
+		data.ClientIP = true
+	case expr.RateLimitKeyPrincipal:
+		data.Principal = true
+	default:
+		att := m.Payload.Find(rl.Key)
+		data.KeyField = codegen.GoifyAtt(att, rl.Key, true)
+		data.KeyPointer = m.Payload.IsPrimitivePointer(rl.Key, true)
+		data.KeyString = att.Type == expr.String
+	}
+	return data
+}
+
+// rateLimitPrincipalData returns the data needed by the endpoint of the given
+// method to enforce its rate limit once the request is authenticated, nil if
+// the method is not rate limited by principal. The credential of each
+// security requirement is the one of its first scheme that defines one.
+func rateLimitPrincipalData(svcName string, m *MethodData) *RateLimitPrincipalData {
+	svc := expr.Root.Service(svcName)
+	if svc == nil {
+		return nil
+	}
+	me := svc.Method(m.Name)
+	if me == nil {
+		return nil
+	}
+	rl := me.EffectiveRateLimit()
+	if rl == nil || rl.Key != expr.RateLimitKeyPrincipal {
+		return nil
+	}
+	data := &RateLimitPrincipalData{Creds: make([]*RateLimitCredData, len(m.Requirements))}
+	for i, r := range m.Requirements {
+		cred := &RateLimitCredData{}
+		for _, s := range r.Schemes {
+			if s.Type == "Basic" {
+				cred.Field, cred.Pointer = s.UsernameField, s.UsernamePointer
+			} else {
+				cred.Field, cred.Pointer = s.CredField, s.CredPointer
+			}
+			if cred.Field != "" {
+				break
+			}
+		}
+		data.Creds[i] = cred
+	}
+	return data
+}
+
+// durationText returns a human readable version of the given duration, e.g.
+// "minute" or "30 seconds".
+func durationText(d time.Duration) string {
+	for _, u := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+		{time.Second, "second"},
+	} {
+		if d%u.d == 0 {
+			if d == u.d {
+				return u.name
+			}
+			return fmt.Sprintf("%d %ss", d/u.d, u.name)
+		}
+	}
+	return d.String()
+}
+
+// durationLiteral returns the Go expression for the given duration using the
+// largest unit that divides it.
+func durationLiteral(d time.Duration) string {
+	for _, u := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	} {
+		if d%u.d == 0 {
+			if d == u.d {
+				return u.name
+			}
+			return fmt.Sprintf("%d * %s", d/u.d, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
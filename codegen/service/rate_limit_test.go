@@ -0,0 +1,51 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestRateLimit(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"service", testdata.RateLimitServiceDSL, testdata.RateLimitServiceCode},
+		{"client-ip", testdata.RateLimitClientIPDSL, testdata.RateLimitClientIPCode},
+		{"payload-attribute", testdata.RateLimitPayloadAttributeDSL, testdata.RateLimitPayloadAttributeCode},
+		{"principal", testdata.RateLimitPrincipalDSL, testdata.RateLimitPrincipalCode},
+		{"api", testdata.RateLimitAPIDSL, testdata.RateLimitAPICode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			require.Len(t, expr.Root.Services, 1)
+			f := RateLimitFile("goa.design/goa/example", expr.Root.Services[0])
+			require.NotNil(t, f)
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				require.NoError(t, s.Write(buf))
+			}
+			bs, err := format.Source(buf.Bytes())
+			require.NoError(t, err, buf.String())
+			code := strings.ReplaceAll(string(bs), "\r\n", "\n")
+			assert.Equal(t, c.Code, code)
+		})
+	}
+}
+
+func TestRateLimitNone(t *testing.T) {
+	codegen.RunDSL(t, testdata.SingleMethodDSL)
+	require.Len(t, expr.Root.Services, 1)
+	assert.Nil(t, RateLimitFile("goa.design/goa/example", expr.Root.Services[0]))
+}
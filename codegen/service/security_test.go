@@ -46,6 +46,7 @@ func TestSecureEndpoint(t *testing.T) {
 		{"with-optional-required-scopes", testdata.EndpointWithOptionalRequiredScopesDSL, testdata.EndpointWithOptionalRequiredScopesCode},
 		{"with-api-key-override", testdata.EndpointWithAPIKeyOverrideDSL, testdata.EndpointWithAPIKeyOverrideCode},
 		{"with-oauth2", testdata.EndpointWithOAuth2DSL, testdata.EndpointWithOAuth2Code},
+		{"with-composed-requirements", testdata.EndpointWithComposedRequirementsDSL, testdata.EndpointWithComposedRequirementsCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
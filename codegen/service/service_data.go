@@ -153,6 +153,10 @@ type (
 		// Requirements contains the security requirements for the
 		// method.
 		Requirements RequirementsData
+		// AuthErrors is true if the method returns the
+		// "unauthenticated" and "insufficient_scope" errors when the
+		// request does not satisfy the security requirements.
+		AuthErrors bool
 		// Schemes contains the security schemes types used by the
 		// method.
 		Schemes SchemesData
@@ -321,6 +325,9 @@ type (
 		Schemes []*SchemeData
 		// Scopes list the required scopes.
 		Scopes []string
+		// SchemeScopes lists the scopes required by a single scheme in
+		// addition to Scopes indexed by scheme name.
+		SchemeScopes map[string][]string
 	}
 
 	// UserTypeData contains the data describing a user-defined type.
@@ -355,8 +362,8 @@ type (
 
 	// SchemeData describes a single security scheme.
 	SchemeData struct {
-		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT"
-		// or "OAuth2".
+		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT",
+		// "OAuth2" or "MutualTLS".
 		Type string
 		// SchemeName is the name of the scheme.
 		SchemeName string
@@ -541,6 +548,17 @@ func (d *Data) Method(name string) *MethodData {
 	return nil
 }
 
+// HasAuthErrors returns true if any of the service methods returns the
+// "unauthenticated" and "insufficient_scope" errors, see dsl.AuthErrors.
+func (d *Data) HasAuthErrors() bool {
+	for _, m := range d.Methods {
+		if m.AuthErrors {
+			return true
+		}
+	}
+	return false
+}
+
 // initUserTypeImports sets the import paths for the user types defined in the
 // service.  User types may be declared in multiple packages when defined with
 // the Meta key "struct:pkg:path".
@@ -589,6 +607,12 @@ func (r RequirementsData) Scheme(name string) *SchemeData {
 	return nil
 }
 
+// RequiredScopes returns the scopes required by the requirement scheme with
+// the given name.
+func (r *RequirementData) RequiredScopes(scheme string) []string {
+	return (&expr.SecurityExpr{Scopes: r.Scopes, SchemeScopes: r.SchemeScopes}).RequiredScopes(scheme)
+}
+
 // Dup creates a copy of the scheme data.
 func (s *SchemeData) Dup() *SchemeData {
 	return &SchemeData{
@@ -628,6 +652,16 @@ func (s SchemesData) Append(d *SchemeData) SchemesData {
 	return append(s, d)
 }
 
+// HasType returns true if one of the schemes has the given type, e.g. "JWT".
+func (s SchemesData) HasType(typ string) bool {
+	for _, se := range s {
+		if se.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
 // analyze creates the data necessary to render the code of the given service.
 // It records the user types needed by the service definition in userTypes.
 func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
@@ -1079,7 +1113,7 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 			rs = rs.Append(sch)
 			schemes = schemes.Append(sch)
 		}
-		reqs = append(reqs, &RequirementData{Schemes: rs, Scopes: req.Scopes})
+		reqs = append(reqs, &RequirementData{Schemes: rs, Scopes: req.Scopes, SchemeScopes: req.SchemeScopes})
 	}
 	var httpMet *expr.HTTPEndpointExpr
 	if httpSvc := expr.Root.HTTPService(m.Service.Name); httpSvc != nil {
@@ -1105,6 +1139,7 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 		Errors:                       errors,
 		ErrorLocs:                    errorLocs,
 		Requirements:                 reqs,
+		AuthErrors:                   m.HasAuthErrors(),
 		Schemes:                      schemes,
 		StreamKind:                   m.Stream,
 		SkipRequestBodyEncodeDecode:  httpMet != nil && httpMet.SkipRequestBodyEncodeDecode,
@@ -1277,6 +1312,12 @@ func buildInterceptorMethodData(i *expr.InterceptorExpr, md *MethodData) *Method
 
 // BuildSchemeData builds the scheme data for the given scheme and method expr.
 func BuildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
+	if s.Kind == expr.MutualTLSKind {
+		return &SchemeData{
+			Type:       s.Kind.String(),
+			SchemeName: s.SchemeName,
+		}
+	}
 	if !expr.IsObject(m.Payload.Type) {
 		return nil
 	}
@@ -1,9 +1,38 @@
 {{ range .Schemes }}
+{{- if eq .Type "JWT" }}
+{{ printf "%sAuth implements the authorization logic for service %q for the %q security scheme. It verifies the token signature, claims and scopes and stores the token claims in the context, see security.JWTClaimsFromContext." .Type $.Name .SchemeName | comment }}
+func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, token string, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
+	//
+	// The verification returns an "unauthenticated" error if the token is
+	// invalid and an "insufficient_scope" error if it lacks the required
+	// scopes (HTTP 401 and 403, gRPC Unauthenticated and
+	// PermissionDenied). Return one of the generated error structs or an
+	// instance of goa.ServiceError to use a design error instead.
+	//
+	return s.jwtAuth(ctx, token, scheme)
+}
+{{- else }}
 {{ printf "%sAuth implements the authorization logic for service %q for the %q security scheme." .Type $.Name .SchemeName | comment }}
-func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
+func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass string{{ else if eq .Type "APIKey" }}key string{{ else if eq .Type "MutualTLS" }}certs []*x509.Certificate{{ else }}token string{{ end }}, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
 	//
 	// TBD: add authorization logic.
 	//
+{{- if $.HasAuthErrors }}
+	// Return the credential verification error as is if the credentials
+	// are invalid, the generated endpoint code maps it to an
+	// "unauthenticated" error (HTTP 401, gRPC Unauthenticated).
+{{- if ne .Type "MutualTLS" }} Validate
+	// the scopes granted to the credentials and return the error returned
+	// by scheme.Validate if they lack the required scopes, e.g.:
+	//
+	//    if err := scheme.Validate(granted); err != nil {
+	//        return ctx, err
+	//    }
+	//
+	// The generated endpoint code maps it to an "insufficient_scope" error
+	// (HTTP 403, gRPC PermissionDenied).
+{{- end }}
+{{- else }}
 	// In case of authorization failure this function should return
 	// one of the generated error structs, e.g.:
 	//
@@ -14,7 +43,9 @@ func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Typ
 	// the design error names, e.g:
 	//
 	//    return ctx, goa.PermanentError("unauthorized", "invalid token")
+{{- end }}
 	//
 	return ctx, fmt.Errorf("not implemented")
 }
 {{- end }}
+{{- end }}
@@ -1,4 +1,15 @@
 {{ printf "New%s returns the %s service implementation." .StructName .Name | comment }}
 func New{{ .StructName }}() {{ .PkgName }}.Service {
+{{- if .Schemes.HasType "JWT" }}
+	return &{{ .VarName }}srvc{
+		// Configure the JWT verification, e.g. with the expected issuer
+		// and audience. The verification keys are read from the JSON Web
+		// Key Set file given by the JWKS_FILE environment variable.
+		jwtAuth: security.NewJWTAuthFunc(&security.JWTConfig{
+			JWKSFile: os.Getenv("JWKS_FILE"),
+		}),
+	}
+{{- else }}
 	return &{{ .VarName }}srvc{}
+{{- end }}
 }
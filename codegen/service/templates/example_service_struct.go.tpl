@@ -1,2 +1,7 @@
 {{ printf "%s service example implementation.\nThe example methods log the requests and return zero values." .Name | comment }}
-type {{ .VarName }}srvc struct {}
+type {{ .VarName }}srvc struct {
+{{- if .Schemes.HasType "JWT" }}
+	// jwtAuth verifies the JWTs and their scopes, see JWTAuth.
+	jwtAuth security.AuthJWTFunc
+{{ end -}}
+}
@@ -0,0 +1,78 @@
+{{ printf "Validate%sFields returns an error if one of the given field paths is not defined in %s. name is the name of the payload attribute that holds the field selection." .VarName .VarName | comment }}
+func Validate{{ .VarName }}Fields(name string, paths []string) (err error) {
+{{- if .Elem }}
+	return Validate{{ .Elem }}Fields(name, paths)
+{{- else if not .Fields }}
+	for _, path := range paths {
+		err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+	}
+	return
+{{- else }}
+	for _, path := range paths {
+		field, {{ if .HasNested }}nested{{ else }}_{{ end }}, ok := strings.Cut(path, ".")
+		switch field {
+	{{- if .Leaves }}
+		case {{ .Leaves }}:
+			if ok {
+				err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+			}
+	{{- end }}
+	{{- range .Fields }}
+		{{- if .Nested }}
+		case {{ printf "%q" .Name }}:
+			if ok && Validate{{ .Nested }}Fields(name, []string{nested}) != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+			}
+		{{- end }}
+	{{- end }}
+		default:
+			err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+		}
+	}
+	return
+{{- end }}
+}
+
+{{ printf "Project%sFields clears the fields of result that are not selected by paths. Required fields are never cleared. result is left unchanged if paths is empty." .VarName | comment }}
+func Project{{ .VarName }}Fields(result {{ .Ref }}, paths []string) {
+{{- if .Elem }}
+	for _, item := range result {
+		Project{{ .Elem }}Fields(item, paths)
+	}
+{{- else }}
+	if result == nil || len(paths) == 0 {
+		return
+	}
+	{{- range .Fields }}
+		{{- if .Required }}
+			{{- if .Nested }}
+	if _, nested := goa.SelectField(paths, {{ printf "%q" .Name }}); len(nested) > 0 {
+				{{- if .Many }}
+		for _, v := range result.{{ .FieldName }} {
+			Project{{ .Nested }}Fields(v, nested)
+		}
+				{{- else }}
+		Project{{ .Nested }}Fields(result.{{ .FieldName }}, nested)
+				{{- end }}
+	}
+			{{- end }}
+		{{- else if .Nested }}
+	if ok, nested := goa.SelectField(paths, {{ printf "%q" .Name }}); !ok {
+		result.{{ .FieldName }} = nil
+	} else {
+			{{- if .Many }}
+		for _, v := range result.{{ .FieldName }} {
+			Project{{ .Nested }}Fields(v, nested)
+		}
+			{{- else }}
+		Project{{ .Nested }}Fields(result.{{ .FieldName }}, nested)
+			{{- end }}
+	}
+		{{- else }}
+	if ok, _ := goa.SelectField(paths, {{ printf "%q" .Name }}); !ok {
+		result.{{ .FieldName }} = nil
+	}
+		{{- end }}
+	{{- end }}
+{{- end }}
+}
@@ -0,0 +1,6 @@
+{{ printf "ApplyRateLimits wraps the %q service endpoints that define a rate limit in the design with middlewares that enforce the quotas using the given limiter." .Name | comment }}
+func ApplyRateLimits(e *Endpoints, l ratelimit.Limiter) {
+{{- range .RateLimits }}
+	e.{{ .Method.VarName }} = {{ .MiddlewareName }}(l)(e.{{ .Method.VarName }})
+{{- end }}
+}
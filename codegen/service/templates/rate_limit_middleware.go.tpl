@@ -0,0 +1,35 @@
+{{ printf "%s returns an endpoint middleware that limits the requests made to the %q endpoint to %d per %s." .MiddlewareName .Method.Name .Requests .PeriodText | comment }}
+func {{ .MiddlewareName }}(l ratelimit.Limiter) func(goa.Endpoint) goa.Endpoint {
+	limit := ratelimit.Limit{Requests: {{ .Requests }}, Period: {{ .Period }}}
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+	{{- if .Principal }}
+			return next(ratelimit.WithPrincipalQuota(ctx, l, {{ printf "%q" .Scope }}, limit), req)
+	{{- else }}
+			key := {{ printf "%q" .Scope }}
+		{{- if .ClientIP }}
+			key += ":" + ratelimit.ClientIP(ctx)
+		{{- else if .KeyField }}
+			{{- if .Method.ServerStream }}
+			p := req.(*{{ .Method.ServerStream.EndpointStruct }}).Payload
+			{{- else if .Method.SkipRequestBodyEncodeDecode }}
+			p := req.(*{{ .Method.RequestStruct }}).Payload
+			{{- else }}
+			p := req.({{ .Method.PayloadRef }})
+			{{- end }}
+			{{- if .KeyPointer }}
+			if p.{{ .KeyField }} != nil {
+				key += ":" + {{ if .KeyString }}*p.{{ .KeyField }}{{ else }}fmt.Sprint(*p.{{ .KeyField }}){{ end }}
+			}
+			{{- else }}
+			key += ":" + {{ if .KeyString }}p.{{ .KeyField }}{{ else }}fmt.Sprint(p.{{ .KeyField }}){{ end }}
+			{{- end }}
+		{{- end }}
+			if err := ratelimit.Enforce(ctx, l, key, limit); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+	{{- end }}
+		}
+	}
+}
@@ -31,7 +31,7 @@ type Service interface {
 type Auther interface {
 	{{- range .Schemes }}
 	{{ printf "%sAuth implements the authorization logic for the %s security scheme." .Type .Type | comment }}
-	{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, schema *security.{{ .Type }}Scheme) (context.Context, error)
+	{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass string{{ else if eq .Type "APIKey" }}key string{{ else if eq .Type "MutualTLS" }}certs []*x509.Certificate{{ else }}token string{{ end }}, schema *security.{{ .Type }}Scheme) (context.Context, error)
 	{{- end }}
 }
 {{- end }}
@@ -2,7 +2,7 @@
 func New{{ .ClientVarName }}({{ .ClientInitArgs }} goa.Endpoint{{ if .HasClientInterceptors }}, ci ClientInterceptors{{ end }}) *{{ .ClientVarName }} {
     return &{{ .ClientVarName }}{
     {{- range .Methods }}
-        {{ .VarName }}Endpoint: {{ if .ClientInterceptors }}Wrap{{ .VarName }}ClientEndpoint({{ end }}{{ .ArgName }}{{ if .ClientInterceptors }}, ci){{ end }},
+        {{ .VarName }}Endpoint: {{ if .ClientInterceptors }}Wrap{{ .VarName }}ClientEndpoint({{ end }}{{ if .Retry }}retry.Endpoint(retry.Policy{MaxAttempts: {{ .Retry.MaxAttempts }}, InitialBackoff: {{ .Retry.InitialBackoff }}, MaxBackoff: {{ .Retry.MaxBackoff }}{{ if .Retry.Idempotent }}, Idempotent: true{{ end }}}, {{ end }}{{ .ArgName }}{{ if .Retry }}){{ end }}{{ if .ClientInterceptors }}, ci){{ end }},
     {{- end }}
     }
 }
@@ -0,0 +1,58 @@
+{{ printf "%s returns an iterator over the items returned by the %q endpoint of the %q service. The iterator requests the next page once the items of the current page have been consumed and stops at the first error." .Pagination.VarName .Name .ServiceName | comment }}
+func (c *{{ .ClientVarName }}) {{ .Pagination.VarName }}(ctx context.Context, p {{ .PayloadRef }}) iter.Seq2[{{ .Pagination.ItemRef }}, error] {
+	return func(yield func({{ .Pagination.ItemRef }}, error) bool) {
+		var next {{ .Pagination.PayloadTypeRef }}
+		if p != nil {
+			next = *p
+		}
+		for {
+			res, err := c.{{ .VarName }}(ctx, &next)
+			if err != nil {
+				yield({{ .Pagination.ItemZero }}, err)
+				return
+			}
+			for _, item := range res.{{ .Pagination.ItemsField }} {
+				if !yield(item, nil) {
+					return
+				}
+			}
+{{- if .Pagination.Cursor }}
+	{{- if .Pagination.NextTokenPointer }}
+			if res.{{ .Pagination.NextTokenField }} == nil || *res.{{ .Pagination.NextTokenField }} == "" {
+				return
+			}
+			next.{{ .Pagination.TokenField }} = {{ if not .Pagination.TokenPointer }}*{{ end }}res.{{ .Pagination.NextTokenField }}
+	{{- else }}
+			if res.{{ .Pagination.NextTokenField }} == "" {
+				return
+			}
+			next.{{ .Pagination.TokenField }} = {{ if .Pagination.TokenPointer }}&{{ end }}res.{{ .Pagination.NextTokenField }}
+	{{- end }}
+{{- else }}
+			if len(res.{{ .Pagination.ItemsField }}) == 0 {
+				return
+			}
+	{{- if .Pagination.PageSizeField }}
+		{{- if .Pagination.PageSizePointer }}
+			if next.{{ .Pagination.PageSizeField }} != nil && len(res.{{ .Pagination.ItemsField }}) < int(*next.{{ .Pagination.PageSizeField }}) {
+				return
+			}
+		{{- else }}
+			if len(res.{{ .Pagination.ItemsField }}) < int(next.{{ .Pagination.PageSizeField }}) {
+				return
+			}
+		{{- end }}
+	{{- end }}
+	{{- if .Pagination.OffsetPointer }}
+			offset := {{ .Pagination.OffsetTypeRef }}(len(res.{{ .Pagination.ItemsField }}))
+			if next.{{ .Pagination.OffsetField }} != nil {
+				offset += *next.{{ .Pagination.OffsetField }}
+			}
+			next.{{ .Pagination.OffsetField }} = &offset
+	{{- else }}
+			next.{{ .Pagination.OffsetField }} += {{ .Pagination.OffsetTypeRef }}(len(res.{{ .Pagination.ItemsField }}))
+	{{- end }}
+{{- end }}
+		}
+	}
+}
@@ -13,9 +13,18 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 {{- $payload := payloadVar . }}
 {{- if .Requirements }}
 		var err error
+	{{- if and .AuthErrors (gt (len .Requirements) 1) }}
+		var errs []error
+	{{- end }}
+	{{- if and .RateLimitPrincipal (gt (len .Requirements) 1) }}
+		var cred string
+	{{- end }}
 	{{- range $ridx, $r := .Requirements }}
 		{{- if ne $ridx 0 }}
 		if err != nil {
+			{{- if $.AuthErrors }}
+			errs = append(errs, err)
+			{{- end }}
 		{{- end }}
 		{{- range $sidx, $s := .Schemes }}
 			{{- if ne $sidx 0 }}
@@ -25,7 +34,7 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				sc := security.BasicScheme{
 					Name: {{ printf "%q" .SchemeName }},
 					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
-					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.RequiredScopes .SchemeName }}{{ printf "%q" . }}, {{ end }} },
 				}
 				{{- if .UsernamePointer }}
 				var user string
@@ -46,7 +55,7 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				sc := security.APIKeyScheme{
 					Name: {{ printf "%q" .SchemeName }},
 					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
-					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.RequiredScopes .SchemeName }}{{ printf "%q" . }}, {{ end }} },
 				}
 				{{- if $s.CredPointer }}
 				var key string
@@ -60,7 +69,7 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				sc := security.JWTScheme{
 					Name: {{ printf "%q" .SchemeName }},
 					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
-					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.RequiredScopes .SchemeName }}{{ printf "%q" . }}, {{ end }} },
 				}
 				{{- if $s.CredPointer }}
 				var token string
@@ -74,7 +83,7 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				sc := security.OAuth2Scheme{
 					Name: {{ printf "%q" .SchemeName }},
 					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
-					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.RequiredScopes .SchemeName }}{{ printf "%q" . }}, {{ end }} },
 					{{- if .Flows }}
 					Flows: []*security.OAuthFlow{
 						{{- range .Flows }}
@@ -102,18 +111,91 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				{{- end }}
 				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}token{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
 
+			{{- else if eq .Type "MutualTLS" }}
+				sc := security.MutualTLSScheme{
+					Name: {{ printf "%q" .SchemeName }},
+				}
+				ctx, err = auth{{ .Type }}Fn(ctx, security.PeerCertificatesFromContext(ctx), &sc)
+
 			{{- end }}
 			{{- if ne $sidx 0 }}
 				}
 			{{- end }}
 		{{- end }}
+		{{- if and $.RateLimitPrincipal (gt (len $.Requirements) 1) }}
+			{{- with index $.RateLimitPrincipal.Creds $ridx }}
+				{{- if .Pointer }}
+			if err == nil && {{ $payload }}.{{ .Field }} != nil {
+				cred = *{{ $payload }}.{{ .Field }}
+			}
+				{{- else if .Field }}
+			if err == nil {
+				cred = {{ $payload }}.{{ .Field }}
+			}
+				{{- end }}
+			{{- end }}
+		{{- end }}
 		{{- if ne $ridx 0 }}
 		}
 		{{- end }}
 	{{- end }}
 		if err != nil {
+	{{- if .RateLimitPrincipal }}
+			if err := ratelimit.EnforceAnonymous(ctx); err != nil {
+				return nil, err
+			}
+	{{- end }}
+		{{- if .AuthErrors }}
+			return nil, security.AuthError({{ if gt (len .Requirements) 1 }}append(errs, err)...{{ else }}err{{ end }})
+		{{- else }}
+			return nil, err
+		{{- end }}
+		}
+	{{- if and .RateLimitPrincipal (gt (len .Requirements) 1) }}
+		if err := ratelimit.EnforcePrincipal(ctx, cred); err != nil {
+			return nil, err
+		}
+	{{- else if .RateLimitPrincipal }}
+		{{- with index .RateLimitPrincipal.Creds 0 }}
+		{{- if not .Field }}
+		if err := ratelimit.EnforcePrincipal(ctx, ""); err != nil {
+			return nil, err
+		}
+		{{- else if .Pointer }}
+		var cred string
+		if {{ $payload }}.{{ .Field }} != nil {
+			cred = *{{ $payload }}.{{ .Field }}
+		}
+		if err := ratelimit.EnforcePrincipal(ctx, cred); err != nil {
 			return nil, err
 		}
+		{{- else }}
+		if err := ratelimit.EnforcePrincipal(ctx, {{ $payload }}.{{ .Field }}); err != nil {
+			return nil, err
+		}
+		{{- end }}
+		{{- end }}
+	{{- end }}
+{{- end }}
+{{- with .FieldSelection }}
+	{{- if eq .Kind "array" }}
+		paths := goa.FieldPaths({{ $payload }}.{{ .Field }}...)
+	{{- else if and (eq .Kind "string") (not .Pointer) }}
+		paths := goa.FieldPaths({{ $payload }}.{{ .Field }})
+	{{- else }}
+		var paths []string
+		if {{ $payload }}.{{ .Field }} != nil {
+			paths = goa.FieldPaths({{ if eq .Kind "mask" }}{{ $payload }}.{{ .Field }}.{{ .PathsField }}...{{ else }}*{{ $payload }}.{{ .Field }}{{ end }})
+		}
+	{{- end }}
+		if err := {{ .Validate }}({{ printf "%q" .Name }}, paths); err != nil {
+			return nil, err
+		}
+	{{- if .Validators }}
+		if len(paths) > 0 {
+			paths = append(paths, {{ .Validators }})
+		}
+	{{- end }}
 {{- end }}
 {{- if .ServerStream }}
 	return nil, s.{{ .VarName }}(ctx, {{ if .PayloadRef }}{{ $payload }}, {{ end }}ep.Stream)
@@ -130,6 +212,9 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		return nil, err
 	}
 	vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else }}view{{ end }})
+	{{- if .FieldSelection }}
+	{{ .FieldSelection.Project }}(vres.Projected, paths)
+	{{- end }}
 	return vres, nil
 	{{- else }}
 	return {{ if not .ResultRef }}nil, {{ end }}s.{{ .VarName }}(ctx, {{ if .PayloadRef }}ep.Payload, {{ end }}ep.Body)
@@ -140,6 +225,9 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		return nil, err
 	}
 	vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else }}view{{ end }})
+	{{- if .FieldSelection }}
+	{{ .FieldSelection.Project }}(vres.Projected, paths)
+	{{- end }}
 	return vres, nil
 {{- else if .SkipResponseBodyEncodeDecode }}
 	{{ if .ResultRef }}res, {{ end }}body, err := s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload}}{{ end }})
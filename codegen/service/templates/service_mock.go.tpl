@@ -0,0 +1,118 @@
+{{ printf "Mock is a programmable implementation of the %q service. Tests queue the functions that handle the next calls to a method with the Expect methods and set the function that handles the calls once the queue is exhausted with the Set methods. Unexpected calls and expectations left unmet when the test completes cause the test to fail." .Name | comment }}
+type Mock struct {
+	t     testing.TB
+	mu    sync.Mutex
+	calls []*Call
+{{- range .Methods }}
+	{{ .FieldName }}Funcs   []{{ .FuncName }}
+	{{ .FieldName }}Default {{ .FuncName }}
+{{- end }}
+{{- range .Schemes }}
+	{{ .FieldName }} func({{ .Params }}) (context.Context, error)
+{{- end }}
+}
+
+// Call records a call made to the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload any
+}
+{{- range .Methods }}
+
+{{ printf "%s is the type of the functions that handle the calls to the %q method." .FuncName .Name | comment }}
+type {{ .FuncName }} func({{ .Params }}) ({{ .Results }})
+{{- end }}
+
+{{ printf "NewMock returns a mock of the %q service that reports unexpected calls and unmet expectations to t." .Name | comment }}
+func NewMock(t testing.TB) *Mock {
+	m := &Mock{t: t}
+	t.Cleanup(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	{{- range .Methods }}
+		if n := len(m.{{ .FieldName }}Funcs); n > 0 {
+			t.Errorf("{{ $.Name }}: %d expected call(s) to %q not made", n, {{ printf "%q" .Name }})
+		}
+	{{- end }}
+	})
+	return m
+}
+{{- range .Methods }}
+
+{{ printf "Expect%s queues f to handle the next call to %s that is not handled by previously queued functions." .VarName .VarName | comment }}
+func (m *Mock) Expect{{ .VarName }}(f {{ .FuncName }}) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.{{ .FieldName }}Funcs = append(m.{{ .FieldName }}Funcs, f)
+	return m
+}
+
+{{ printf "Set%s sets f to handle the calls to %s once the functions queued with Expect%s have been used." .VarName .VarName .VarName | comment }}
+func (m *Mock) Set{{ .VarName }}(f {{ .FuncName }}) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.{{ .FieldName }}Default = f
+	return m
+}
+
+{{ printf "%s implements the %q method." .VarName .Name | comment }}
+func (m *Mock) {{ .VarName }}({{ .Params }}) ({{ .Results }}) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: {{ printf "%q" .Name }}, Payload: {{ .PayloadArg }}})
+	f := m.{{ .FieldName }}Default
+	if len(m.{{ .FieldName }}Funcs) > 0 {
+		f, m.{{ .FieldName }}Funcs = m.{{ .FieldName }}Funcs[0], m.{{ .FieldName }}Funcs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("{{ $.Name }}: unexpected call to %q", {{ printf "%q" .Name }})
+		m.t.Error(err)
+		return
+	}
+	return f({{ .Args }})
+}
+{{- end }}
+{{- range .Schemes }}
+
+{{ printf "Set%sAuth sets the function that implements the authorization logic for the %s security scheme. The mock authorizes all requests by default." .Type .Type | comment }}
+func (m *Mock) Set{{ .Type }}Auth(f func({{ .Params }}) (context.Context, error)) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.{{ .FieldName }} = f
+	return m
+}
+
+{{ printf "%sAuth implements the authorization logic for the %s security scheme." .Type .Type | comment }}
+func (m *Mock) {{ .Type }}Auth({{ .Params }}) (context.Context, error) {
+	m.mu.Lock()
+	f := m.{{ .FieldName }}
+	m.mu.Unlock()
+	if f == nil {
+		return ctx, nil
+	}
+	return f({{ .Args }})
+}
+{{- end }}
+
+// Calls returns the calls made to the mock so far in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Called returns the number of calls made to the method with the given design
+// name.
+func (m *Mock) Called(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
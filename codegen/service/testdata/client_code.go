@@ -308,3 +308,152 @@ func (c *Client) Method(ctx context.Context, p string) (res string, err error) {
 	return ires.(string), nil
 }
 `
+
+const RetryClient = `// Client is the "Retry" service client.
+type Client struct {
+	InheritedEndpoint  goa.Endpoint
+	IdempotentEndpoint goa.Endpoint
+	DisabledEndpoint   goa.Endpoint
+	StreamingEndpoint  goa.Endpoint
+}
+
+// NewClient initializes a "Retry" service client given the endpoints.
+func NewClient(inherited, idempotent, disabled, streaming goa.Endpoint, ci ClientInterceptors) *Client {
+	return &Client{
+		InheritedEndpoint:  retry.Endpoint(retry.Policy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second}, inherited),
+		IdempotentEndpoint: WrapIdempotentClientEndpoint(retry.Endpoint(retry.Policy{MaxAttempts: 5, InitialBackoff: 50 * time.Millisecond, MaxBackoff: 2 * time.Second, Idempotent: true}, idempotent), ci),
+		DisabledEndpoint:   disabled,
+		StreamingEndpoint:  streaming,
+	}
+}
+
+// Inherited calls the "Inherited" endpoint of the "Retry" service.
+func (c *Client) Inherited(ctx context.Context, p string) (err error) {
+	_, err = c.InheritedEndpoint(ctx, p)
+	return
+}
+
+// Idempotent calls the "Idempotent" endpoint of the "Retry" service.
+func (c *Client) Idempotent(ctx context.Context) (res string, err error) {
+	var ires any
+	ires, err = c.IdempotentEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return ires.(string), nil
+}
+
+// Disabled calls the "Disabled" endpoint of the "Retry" service.
+func (c *Client) Disabled(ctx context.Context) (err error) {
+	_, err = c.DisabledEndpoint(ctx, nil)
+	return
+}
+
+// Streaming calls the "Streaming" endpoint of the "Retry" service.
+func (c *Client) Streaming(ctx context.Context) (res StreamingClientStream, err error) {
+	var ires any
+	ires, err = c.StreamingEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return ires.(StreamingClientStream), nil
+}
+`
+
+const PaginatedClient = `// Client is the "Paginated" service client.
+type Client struct {
+	ListEndpoint  goa.Endpoint
+	NamesEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "Paginated" service client given the endpoints.
+func NewClient(list, names goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint:  list,
+		NamesEndpoint: names,
+	}
+}
+
+// List calls the "List" endpoint of the "Paginated" service.
+func (c *Client) List(ctx context.Context, p *ListPayload) (res *ListResult, err error) {
+	var ires any
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListAll returns an iterator over the items returned by the "List" endpoint
+// of the "Paginated" service. The iterator requests the next page once the
+// items of the current page have been consumed and stops at the first error.
+func (c *Client) ListAll(ctx context.Context, p *ListPayload) iter.Seq2[*Item, error] {
+	return func(yield func(*Item, error) bool) {
+		var next ListPayload
+		if p != nil {
+			next = *p
+		}
+		for {
+			res, err := c.List(ctx, &next)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range res.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if res.NextPageToken == nil || *res.NextPageToken == "" {
+				return
+			}
+			next.PageToken = res.NextPageToken
+		}
+	}
+}
+
+// Names calls the "Names" endpoint of the "Paginated" service.
+func (c *Client) Names(ctx context.Context, p *NamesPayload) (res *NamesResult, err error) {
+	var ires any
+	ires, err = c.NamesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*NamesResult), nil
+}
+
+// NamesAll returns an iterator over the items returned by the "Names" endpoint
+// of the "Paginated" service. The iterator requests the next page once the
+// items of the current page have been consumed and stops at the first error.
+func (c *Client) NamesAll(ctx context.Context, p *NamesPayload) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		var next NamesPayload
+		if p != nil {
+			next = *p
+		}
+		for {
+			res, err := c.Names(ctx, &next)
+			if err != nil {
+				yield("", err)
+				return
+			}
+			for _, item := range res.Names {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(res.Names) == 0 {
+				return
+			}
+			if len(res.Names) < int(next.Limit) {
+				return
+			}
+			offset := int32(len(res.Names))
+			if next.Offset != nil {
+				offset += *next.Offset
+			}
+			next.Offset = &offset
+		}
+	}
+}
+`
@@ -611,3 +611,286 @@ func NewMethodEndpoint(s Service) goa.Endpoint {
 	}
 }
 `
+
+const FieldSelectionEndpoint = `// Endpoints wraps the "FieldSelectionService" service endpoints.
+type Endpoints struct {
+	Show goa.Endpoint
+	List goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "FieldSelectionService" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Show: NewShowEndpoint(s),
+		List: NewListEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "FieldSelectionService" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Show = m(e.Show)
+	e.List = m(e.List)
+}
+
+// NewShowEndpoint returns an endpoint function that calls the method "Show" of
+// service "FieldSelectionService".
+func NewShowEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ShowPayload)
+		var paths []string
+		if p.Fields != nil {
+			paths = goa.FieldPaths(*p.Fields)
+		}
+		if err := fieldselectionserviceviews.ValidateAccountViewFields("fields", paths); err != nil {
+			return nil, err
+		}
+		res, err := s.Show(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		vres := NewViewedAccount(res, "default")
+		fieldselectionserviceviews.ProjectAccountViewFields(vres.Projected, paths)
+		return vres, nil
+	}
+}
+
+// NewListEndpoint returns an endpoint function that calls the method "List" of
+// service "FieldSelectionService".
+func NewListEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ListPayload)
+		var paths []string
+		if p.Mask != nil {
+			paths = goa.FieldPaths(p.Mask.Paths...)
+		}
+		if err := fieldselectionserviceviews.ValidateAccountCollectionViewFields("mask", paths); err != nil {
+			return nil, err
+		}
+		res, err := s.List(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		vres := NewViewedAccountCollection(res, "default")
+		fieldselectionserviceviews.ProjectAccountCollectionViewFields(vres.Projected, paths)
+		return vres, nil
+	}
+}
+`
+
+const RateLimitPrincipalEndpoint = `// Endpoints wraps the "RateLimitPrincipal" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "RateLimitPrincipal" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		A: NewAEndpoint(s, a.APIKeyAuth),
+	}
+}
+
+// Use applies the given middleware to all the "RateLimitPrincipal" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "RateLimitPrincipal".
+func NewAEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*APayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "api_key",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		var key string
+		if p.Key != nil {
+			key = *p.Key
+		}
+		ctx, err = authAPIKeyFn(ctx, key, &sc)
+		if err != nil {
+			if err := ratelimit.EnforceAnonymous(ctx); err != nil {
+				return nil, err
+			}
+			return nil, err
+		}
+		var cred string
+		if p.Key != nil {
+			cred = *p.Key
+		}
+		if err := ratelimit.EnforcePrincipal(ctx, cred); err != nil {
+			return nil, err
+		}
+		return nil, s.A(ctx, p)
+	}
+}
+`
+
+const RateLimitPrincipalMutualTLSEndpoint = `// Endpoints wraps the "RateLimitPrincipalMutualTLS" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "RateLimitPrincipalMutualTLS" service
+// with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		A: NewAEndpoint(s, a.MutualTLSAuth),
+	}
+}
+
+// Use applies the given middleware to all the "RateLimitPrincipalMutualTLS"
+// service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "RateLimitPrincipalMutualTLS".
+func NewAEndpoint(s Service, authMutualTLSFn security.AuthMutualTLSFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		var err error
+		sc := security.MutualTLSScheme{
+			Name: "mtls",
+		}
+		ctx, err = authMutualTLSFn(ctx, security.PeerCertificatesFromContext(ctx), &sc)
+		if err != nil {
+			if err := ratelimit.EnforceAnonymous(ctx); err != nil {
+				return nil, err
+			}
+			return nil, err
+		}
+		if err := ratelimit.EnforcePrincipal(ctx, ""); err != nil {
+			return nil, err
+		}
+		return nil, s.A(ctx)
+	}
+}
+`
+
+const RateLimitPrincipalRequirementsEndpoint = `// Endpoints wraps the "RateLimitPrincipalRequirements" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "RateLimitPrincipalRequirements"
+// service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		A: NewAEndpoint(s, a.APIKeyAuth, a.JWTAuth, a.MutualTLSAuth),
+	}
+}
+
+// Use applies the given middleware to all the "RateLimitPrincipalRequirements"
+// service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "RateLimitPrincipalRequirements".
+func NewAEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc, authJWTFn security.AuthJWTFunc, authMutualTLSFn security.AuthMutualTLSFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*APayload)
+		var err error
+		var cred string
+		sc := security.APIKeyScheme{
+			Name:           "api_key",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		var key string
+		if p.Key != nil {
+			key = *p.Key
+		}
+		ctx, err = authAPIKeyFn(ctx, key, &sc)
+		if err == nil && p.Key != nil {
+			cred = *p.Key
+		}
+		if err != nil {
+			sc := security.JWTScheme{
+				Name:           "jwt",
+				Scopes:         []string{},
+				RequiredScopes: []string{},
+			}
+			ctx, err = authJWTFn(ctx, p.Token, &sc)
+			if err == nil {
+				cred = p.Token
+			}
+		}
+		if err != nil {
+			sc := security.MutualTLSScheme{
+				Name: "mtls",
+			}
+			ctx, err = authMutualTLSFn(ctx, security.PeerCertificatesFromContext(ctx), &sc)
+		}
+		if err != nil {
+			if err := ratelimit.EnforceAnonymous(ctx); err != nil {
+				return nil, err
+			}
+			return nil, err
+		}
+		if err := ratelimit.EnforcePrincipal(ctx, cred); err != nil {
+			return nil, err
+		}
+		return nil, s.A(ctx, p)
+	}
+}
+`
+
+const FieldSelectionCacheEndpoint = `// Endpoints wraps the "FieldSelectionCacheService" service endpoints.
+type Endpoints struct {
+	Show goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "FieldSelectionCacheService" service
+// with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Show: NewShowEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "FieldSelectionCacheService"
+// service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Show = m(e.Show)
+}
+
+// NewShowEndpoint returns an endpoint function that calls the method "Show" of
+// service "FieldSelectionCacheService".
+func NewShowEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ShowPayload)
+		var paths []string
+		if p.Fields != nil {
+			paths = goa.FieldPaths(*p.Fields)
+		}
+		if err := fieldselectioncacheserviceviews.ValidateItemViewFields("fields", paths); err != nil {
+			return nil, err
+		}
+		if len(paths) > 0 {
+			paths = append(paths, "version")
+		}
+		res, err := s.Show(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		vres := NewViewedItem(res, "default")
+		fieldselectioncacheserviceviews.ProjectItemViewFields(vres.Projected, paths)
+		return vres, nil
+	}
+}
+`
@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"time"
+
 	. "goa.design/goa/v3/dsl"
 )
 
@@ -225,3 +227,67 @@ var EndpointStreamingWithInterceptorDSL = func() {
 		})
 	})
 }
+
+var RetryEndpointDSL = func() {
+	Interceptor("tracing")
+	Service("Retry", func() {
+		Retry(3)
+		Method("Inherited", func() {
+			Payload(String)
+		})
+		Method("Idempotent", func() {
+			Retry(5, func() {
+				Backoff(50*time.Millisecond, 2*time.Second)
+				Idempotent()
+			})
+			ClientInterceptor("tracing")
+			Result(String)
+		})
+		Method("Disabled", func() {
+			Retry(1)
+		})
+		Method("Streaming", func() {
+			StreamingResult(String)
+		})
+	})
+}
+
+var PaginatedEndpointDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+	})
+	Service("Paginated", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("page_token", String)
+				Attribute("page_size", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(Item))
+				Attribute("next_page_token", String)
+			})
+			Paginated(func() {
+				PageItems("items")
+				PageCursor("page_token", "next_page_token")
+				PageSize("page_size", 100)
+			})
+		})
+		Method("Names", func() {
+			Payload(func() {
+				Attribute("offset", Int32)
+				Attribute("limit", Int, func() {
+					Default(20)
+				})
+			})
+			Result(func() {
+				Attribute("names", ArrayOf(String))
+				Required("names")
+			})
+			Paginated(func() {
+				PageItems("names")
+				PageOffset("offset")
+				PageSize("limit")
+			})
+		})
+	})
+}
@@ -21,3 +21,37 @@ var ConflictWithGoifiedAPINameAndServiceNamesDSL = func() {
 	var _ = Service("good-by-api", func() {})   // API name + 'api' suffix
 	var _ = Service("good-by-api-1", func() {}) // API name + 'api' suffix + sequential no.
 }
+
+var JWTAuthDSL = func() {
+	var JWT = JWTSecurity("jwt", func() {
+		Scope("api:read", "Read-only access")
+		Scope("api:write", "Read and write access")
+	})
+	var _ = Service("JWTAuth", func() {
+		AuthErrors()
+		Method("Show", func() {
+			Security(JWT, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+		Method("Login", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+		})
+	})
+}
+
+var MutualTLSAuthDSL = func() {
+	var MTLS = MutualTLSSecurity("mtls")
+	var _ = Service("MutualTLSAuth", func() {
+		Method("Show", func() {
+			Security(MTLS)
+		})
+	})
+}
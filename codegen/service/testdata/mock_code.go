@@ -0,0 +1,778 @@
+package testdata
+
+const MockEmptyCode = `// Mock is a programmable implementation of the "Empty" service. Tests queue
+// the functions that handle the next calls to a method with the Expect methods
+// and set the function that handles the calls once the queue is exhausted with
+// the Set methods. Unexpected calls and expectations left unmet when the test
+// completes cause the test to fail.
+type Mock struct {
+	t            testing.TB
+	mu           sync.Mutex
+	calls        []*Call
+	emptyFuncs   []EmptyFunc
+	emptyDefault EmptyFunc
+}
+
+// Call records a call made to the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload any
+}
+
+// EmptyFunc is the type of the functions that handle the calls to the "Empty"
+// method.
+type EmptyFunc func(ctx context.Context) (err error)
+
+// NewMock returns a mock of the "Empty" service that reports unexpected calls
+// and unmet expectations to t.
+func NewMock(t testing.TB) *Mock {
+	m := &Mock{t: t}
+	t.Cleanup(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if n := len(m.emptyFuncs); n > 0 {
+			t.Errorf("Empty: %d expected call(s) to %q not made", n, "Empty")
+		}
+	})
+	return m
+}
+
+// ExpectEmpty queues f to handle the next call to Empty that is not handled by
+// previously queued functions.
+func (m *Mock) ExpectEmpty(f EmptyFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.emptyFuncs = append(m.emptyFuncs, f)
+	return m
+}
+
+// SetEmpty sets f to handle the calls to Empty once the functions queued with
+// ExpectEmpty have been used.
+func (m *Mock) SetEmpty(f EmptyFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.emptyDefault = f
+	return m
+}
+
+// Empty implements the "Empty" method.
+func (m *Mock) Empty(ctx context.Context) (err error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: "Empty", Payload: nil})
+	f := m.emptyDefault
+	if len(m.emptyFuncs) > 0 {
+		f, m.emptyFuncs = m.emptyFuncs[0], m.emptyFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("Empty: unexpected call to %q", "Empty")
+		m.t.Error(err)
+		return
+	}
+	return f(ctx)
+}
+
+// Calls returns the calls made to the mock so far in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Called returns the number of calls made to the method with the given design
+// name.
+func (m *Mock) Called(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+`
+
+const MockViewsCode = `// Mock is a programmable implementation of the
+// "MultipleMethodsResultMultipleViews" service. Tests queue the functions that
+// handle the next calls to a method with the Expect methods and set the
+// function that handles the calls once the queue is exhausted with the Set
+// methods. Unexpected calls and expectations left unmet when the test
+// completes cause the test to fail.
+type Mock struct {
+	t        testing.TB
+	mu       sync.Mutex
+	calls    []*Call
+	aFuncs   []AFunc
+	aDefault AFunc
+	bFuncs   []BFunc
+	bDefault BFunc
+}
+
+// Call records a call made to the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload any
+}
+
+// AFunc is the type of the functions that handle the calls to the "A" method.
+type AFunc func(ctx context.Context, p *multiplemethodsresultmultipleviews.APayload) (res *multiplemethodsresultmultipleviews.MultipleViews, view string, err error)
+
+// BFunc is the type of the functions that handle the calls to the "B" method.
+type BFunc func(ctx context.Context) (res *multiplemethodsresultmultipleviews.SingleView, err error)
+
+// NewMock returns a mock of the "MultipleMethodsResultMultipleViews" service
+// that reports unexpected calls and unmet expectations to t.
+func NewMock(t testing.TB) *Mock {
+	m := &Mock{t: t}
+	t.Cleanup(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if n := len(m.aFuncs); n > 0 {
+			t.Errorf("MultipleMethodsResultMultipleViews: %d expected call(s) to %q not made", n, "A")
+		}
+		if n := len(m.bFuncs); n > 0 {
+			t.Errorf("MultipleMethodsResultMultipleViews: %d expected call(s) to %q not made", n, "B")
+		}
+	})
+	return m
+}
+
+// ExpectA queues f to handle the next call to A that is not handled by
+// previously queued functions.
+func (m *Mock) ExpectA(f AFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aFuncs = append(m.aFuncs, f)
+	return m
+}
+
+// SetA sets f to handle the calls to A once the functions queued with ExpectA
+// have been used.
+func (m *Mock) SetA(f AFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aDefault = f
+	return m
+}
+
+// A implements the "A" method.
+func (m *Mock) A(ctx context.Context, p *multiplemethodsresultmultipleviews.APayload) (res *multiplemethodsresultmultipleviews.MultipleViews, view string, err error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: "A", Payload: p})
+	f := m.aDefault
+	if len(m.aFuncs) > 0 {
+		f, m.aFuncs = m.aFuncs[0], m.aFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("MultipleMethodsResultMultipleViews: unexpected call to %q", "A")
+		m.t.Error(err)
+		return
+	}
+	return f(ctx, p)
+}
+
+// ExpectB queues f to handle the next call to B that is not handled by
+// previously queued functions.
+func (m *Mock) ExpectB(f BFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bFuncs = append(m.bFuncs, f)
+	return m
+}
+
+// SetB sets f to handle the calls to B once the functions queued with ExpectB
+// have been used.
+func (m *Mock) SetB(f BFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bDefault = f
+	return m
+}
+
+// B implements the "B" method.
+func (m *Mock) B(ctx context.Context) (res *multiplemethodsresultmultipleviews.SingleView, err error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: "B", Payload: nil})
+	f := m.bDefault
+	if len(m.bFuncs) > 0 {
+		f, m.bFuncs = m.bFuncs[0], m.bFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("MultipleMethodsResultMultipleViews: unexpected call to %q", "B")
+		m.t.Error(err)
+		return
+	}
+	return f(ctx)
+}
+
+// Calls returns the calls made to the mock so far in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Called returns the number of calls made to the method with the given design
+// name.
+func (m *Mock) Called(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+`
+
+const MockStreamingCode = `// Mock is a programmable implementation of the "StreamingResultService"
+// service. Tests queue the functions that handle the next calls to a method
+// with the Expect methods and set the function that handles the calls once the
+// queue is exhausted with the Set methods. Unexpected calls and expectations
+// left unmet when the test completes cause the test to fail.
+type Mock struct {
+	t                            testing.TB
+	mu                           sync.Mutex
+	calls                        []*Call
+	streamingResultMethodFuncs   []StreamingResultMethodFunc
+	streamingResultMethodDefault StreamingResultMethodFunc
+}
+
+// Call records a call made to the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload any
+}
+
+// StreamingResultMethodFunc is the type of the functions that handle the calls
+// to the "StreamingResultMethod" method.
+type StreamingResultMethodFunc func(ctx context.Context, p *streamingresultservice.APayload, stream streamingresultservice.StreamingResultMethodServerStream) (err error)
+
+// NewMock returns a mock of the "StreamingResultService" service that reports
+// unexpected calls and unmet expectations to t.
+func NewMock(t testing.TB) *Mock {
+	m := &Mock{t: t}
+	t.Cleanup(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if n := len(m.streamingResultMethodFuncs); n > 0 {
+			t.Errorf("StreamingResultService: %d expected call(s) to %q not made", n, "StreamingResultMethod")
+		}
+	})
+	return m
+}
+
+// ExpectStreamingResultMethod queues f to handle the next call to
+// StreamingResultMethod that is not handled by previously queued functions.
+func (m *Mock) ExpectStreamingResultMethod(f StreamingResultMethodFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.streamingResultMethodFuncs = append(m.streamingResultMethodFuncs, f)
+	return m
+}
+
+// SetStreamingResultMethod sets f to handle the calls to StreamingResultMethod
+// once the functions queued with ExpectStreamingResultMethod have been used.
+func (m *Mock) SetStreamingResultMethod(f StreamingResultMethodFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.streamingResultMethodDefault = f
+	return m
+}
+
+// StreamingResultMethod implements the "StreamingResultMethod" method.
+func (m *Mock) StreamingResultMethod(ctx context.Context, p *streamingresultservice.APayload, stream streamingresultservice.StreamingResultMethodServerStream) (err error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: "StreamingResultMethod", Payload: p})
+	f := m.streamingResultMethodDefault
+	if len(m.streamingResultMethodFuncs) > 0 {
+		f, m.streamingResultMethodFuncs = m.streamingResultMethodFuncs[0], m.streamingResultMethodFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("StreamingResultService: unexpected call to %q", "StreamingResultMethod")
+		m.t.Error(err)
+		return
+	}
+	return f(ctx, p, stream)
+}
+
+// Calls returns the calls made to the mock so far in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Called returns the number of calls made to the method with the given design
+// name.
+func (m *Mock) Called(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+`
+
+const MockSecurityCode = `// Mock is a programmable implementation of the "EndpointsWithRequirements"
+// service. Tests queue the functions that handle the next calls to a method
+// with the Expect methods and set the function that handles the calls once the
+// queue is exhausted with the Set methods. Unexpected calls and expectations
+// left unmet when the test completes cause the test to fail.
+type Mock struct {
+	t                                   testing.TB
+	mu                                  sync.Mutex
+	calls                               []*Call
+	secureWithRequirementsFuncs         []SecureWithRequirementsFunc
+	secureWithRequirementsDefault       SecureWithRequirementsFunc
+	doublySecureWithRequirementsFuncs   []DoublySecureWithRequirementsFunc
+	doublySecureWithRequirementsDefault DoublySecureWithRequirementsFunc
+	basicAuth                           func(ctx context.Context, user, pass string, schema *security.BasicScheme) (context.Context, error)
+	jwtAuth                             func(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error)
+}
+
+// Call records a call made to the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload any
+}
+
+// SecureWithRequirementsFunc is the type of the functions that handle the
+// calls to the "SecureWithRequirements" method.
+type SecureWithRequirementsFunc func(ctx context.Context, p *endpointswithrequirements.SecureWithRequirementsPayload) (err error)
+
+// DoublySecureWithRequirementsFunc is the type of the functions that handle
+// the calls to the "DoublySecureWithRequirements" method.
+type DoublySecureWithRequirementsFunc func(ctx context.Context, p *endpointswithrequirements.DoublySecureWithRequirementsPayload) (err error)
+
+// NewMock returns a mock of the "EndpointsWithRequirements" service that
+// reports unexpected calls and unmet expectations to t.
+func NewMock(t testing.TB) *Mock {
+	m := &Mock{t: t}
+	t.Cleanup(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if n := len(m.secureWithRequirementsFuncs); n > 0 {
+			t.Errorf("EndpointsWithRequirements: %d expected call(s) to %q not made", n, "SecureWithRequirements")
+		}
+		if n := len(m.doublySecureWithRequirementsFuncs); n > 0 {
+			t.Errorf("EndpointsWithRequirements: %d expected call(s) to %q not made", n, "DoublySecureWithRequirements")
+		}
+	})
+	return m
+}
+
+// ExpectSecureWithRequirements queues f to handle the next call to
+// SecureWithRequirements that is not handled by previously queued functions.
+func (m *Mock) ExpectSecureWithRequirements(f SecureWithRequirementsFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secureWithRequirementsFuncs = append(m.secureWithRequirementsFuncs, f)
+	return m
+}
+
+// SetSecureWithRequirements sets f to handle the calls to
+// SecureWithRequirements once the functions queued with
+// ExpectSecureWithRequirements have been used.
+func (m *Mock) SetSecureWithRequirements(f SecureWithRequirementsFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secureWithRequirementsDefault = f
+	return m
+}
+
+// SecureWithRequirements implements the "SecureWithRequirements" method.
+func (m *Mock) SecureWithRequirements(ctx context.Context, p *endpointswithrequirements.SecureWithRequirementsPayload) (err error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: "SecureWithRequirements", Payload: p})
+	f := m.secureWithRequirementsDefault
+	if len(m.secureWithRequirementsFuncs) > 0 {
+		f, m.secureWithRequirementsFuncs = m.secureWithRequirementsFuncs[0], m.secureWithRequirementsFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("EndpointsWithRequirements: unexpected call to %q", "SecureWithRequirements")
+		m.t.Error(err)
+		return
+	}
+	return f(ctx, p)
+}
+
+// ExpectDoublySecureWithRequirements queues f to handle the next call to
+// DoublySecureWithRequirements that is not handled by previously queued
+// functions.
+func (m *Mock) ExpectDoublySecureWithRequirements(f DoublySecureWithRequirementsFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.doublySecureWithRequirementsFuncs = append(m.doublySecureWithRequirementsFuncs, f)
+	return m
+}
+
+// SetDoublySecureWithRequirements sets f to handle the calls to
+// DoublySecureWithRequirements once the functions queued with
+// ExpectDoublySecureWithRequirements have been used.
+func (m *Mock) SetDoublySecureWithRequirements(f DoublySecureWithRequirementsFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.doublySecureWithRequirementsDefault = f
+	return m
+}
+
+// DoublySecureWithRequirements implements the "DoublySecureWithRequirements"
+// method.
+func (m *Mock) DoublySecureWithRequirements(ctx context.Context, p *endpointswithrequirements.DoublySecureWithRequirementsPayload) (err error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: "DoublySecureWithRequirements", Payload: p})
+	f := m.doublySecureWithRequirementsDefault
+	if len(m.doublySecureWithRequirementsFuncs) > 0 {
+		f, m.doublySecureWithRequirementsFuncs = m.doublySecureWithRequirementsFuncs[0], m.doublySecureWithRequirementsFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("EndpointsWithRequirements: unexpected call to %q", "DoublySecureWithRequirements")
+		m.t.Error(err)
+		return
+	}
+	return f(ctx, p)
+}
+
+// SetBasicAuth sets the function that implements the authorization logic for
+// the Basic security scheme. The mock authorizes all requests by default.
+func (m *Mock) SetBasicAuth(f func(ctx context.Context, user, pass string, schema *security.BasicScheme) (context.Context, error)) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.basicAuth = f
+	return m
+}
+
+// BasicAuth implements the authorization logic for the Basic security scheme.
+func (m *Mock) BasicAuth(ctx context.Context, user, pass string, schema *security.BasicScheme) (context.Context, error) {
+	m.mu.Lock()
+	f := m.basicAuth
+	m.mu.Unlock()
+	if f == nil {
+		return ctx, nil
+	}
+	return f(ctx, user, pass, schema)
+}
+
+// SetJWTAuth sets the function that implements the authorization logic for the
+// JWT security scheme. The mock authorizes all requests by default.
+func (m *Mock) SetJWTAuth(f func(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error)) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jwtAuth = f
+	return m
+}
+
+// JWTAuth implements the authorization logic for the JWT security scheme.
+func (m *Mock) JWTAuth(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error) {
+	m.mu.Lock()
+	f := m.jwtAuth
+	m.mu.Unlock()
+	if f == nil {
+		return ctx, nil
+	}
+	return f(ctx, token, schema)
+}
+
+// Calls returns the calls made to the mock so far in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Called returns the number of calls made to the method with the given design
+// name.
+func (m *Mock) Called(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+`
+
+const MockPkgPathCode = `// Mock is a programmable implementation of the "PkgPathMethod" service. Tests
+// queue the functions that handle the next calls to a method with the Expect
+// methods and set the function that handles the calls once the queue is
+// exhausted with the Set methods. Unexpected calls and expectations left unmet
+// when the test completes cause the test to fail.
+type Mock struct {
+	t        testing.TB
+	mu       sync.Mutex
+	calls    []*Call
+	aFuncs   []AFunc
+	aDefault AFunc
+}
+
+// Call records a call made to the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload any
+}
+
+// AFunc is the type of the functions that handle the calls to the "A" method.
+type AFunc func(ctx context.Context, p *foo.Foo) (res *foo.Foo, err error)
+
+// NewMock returns a mock of the "PkgPathMethod" service that reports
+// unexpected calls and unmet expectations to t.
+func NewMock(t testing.TB) *Mock {
+	m := &Mock{t: t}
+	t.Cleanup(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if n := len(m.aFuncs); n > 0 {
+			t.Errorf("PkgPathMethod: %d expected call(s) to %q not made", n, "A")
+		}
+	})
+	return m
+}
+
+// ExpectA queues f to handle the next call to A that is not handled by
+// previously queued functions.
+func (m *Mock) ExpectA(f AFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aFuncs = append(m.aFuncs, f)
+	return m
+}
+
+// SetA sets f to handle the calls to A once the functions queued with ExpectA
+// have been used.
+func (m *Mock) SetA(f AFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aDefault = f
+	return m
+}
+
+// A implements the "A" method.
+func (m *Mock) A(ctx context.Context, p *foo.Foo) (res *foo.Foo, err error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: "A", Payload: p})
+	f := m.aDefault
+	if len(m.aFuncs) > 0 {
+		f, m.aFuncs = m.aFuncs[0], m.aFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("PkgPathMethod: unexpected call to %q", "A")
+		m.t.Error(err)
+		return
+	}
+	return f(ctx, p)
+}
+
+// Calls returns the calls made to the mock so far in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Called returns the number of calls made to the method with the given design
+// name.
+func (m *Mock) Called(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+`
+
+const MockComposedSecurityCode = `// Mock is a programmable implementation of the
+// "EndpointWithComposedRequirements" service. Tests queue the functions that
+// handle the next calls to a method with the Expect methods and set the
+// function that handles the calls once the queue is exhausted with the Set
+// methods. Unexpected calls and expectations left unmet when the test
+// completes cause the test to fail.
+type Mock struct {
+	t                                     testing.TB
+	mu                                    sync.Mutex
+	calls                                 []*Call
+	secureWithComposedRequirementsFuncs   []SecureWithComposedRequirementsFunc
+	secureWithComposedRequirementsDefault SecureWithComposedRequirementsFunc
+	jwtAuth                               func(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error)
+	apiKeyAuth                            func(ctx context.Context, key string, schema *security.APIKeyScheme) (context.Context, error)
+	mutualTLSAuth                         func(ctx context.Context, certs []*x509.Certificate, schema *security.MutualTLSScheme) (context.Context, error)
+}
+
+// Call records a call made to the mock.
+type Call struct {
+	// Method is the name of the method as defined in the design.
+	Method string
+	// Payload is the method payload, nil if the method has no payload.
+	Payload any
+}
+
+// SecureWithComposedRequirementsFunc is the type of the functions that handle
+// the calls to the "SecureWithComposedRequirements" method.
+type SecureWithComposedRequirementsFunc func(ctx context.Context, p *endpointwithcomposedrequirements.SecureWithComposedRequirementsPayload) (err error)
+
+// NewMock returns a mock of the "EndpointWithComposedRequirements" service
+// that reports unexpected calls and unmet expectations to t.
+func NewMock(t testing.TB) *Mock {
+	m := &Mock{t: t}
+	t.Cleanup(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if n := len(m.secureWithComposedRequirementsFuncs); n > 0 {
+			t.Errorf("EndpointWithComposedRequirements: %d expected call(s) to %q not made", n, "SecureWithComposedRequirements")
+		}
+	})
+	return m
+}
+
+// ExpectSecureWithComposedRequirements queues f to handle the next call to
+// SecureWithComposedRequirements that is not handled by previously queued
+// functions.
+func (m *Mock) ExpectSecureWithComposedRequirements(f SecureWithComposedRequirementsFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secureWithComposedRequirementsFuncs = append(m.secureWithComposedRequirementsFuncs, f)
+	return m
+}
+
+// SetSecureWithComposedRequirements sets f to handle the calls to
+// SecureWithComposedRequirements once the functions queued with
+// ExpectSecureWithComposedRequirements have been used.
+func (m *Mock) SetSecureWithComposedRequirements(f SecureWithComposedRequirementsFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secureWithComposedRequirementsDefault = f
+	return m
+}
+
+// SecureWithComposedRequirements implements the
+// "SecureWithComposedRequirements" method.
+func (m *Mock) SecureWithComposedRequirements(ctx context.Context, p *endpointwithcomposedrequirements.SecureWithComposedRequirementsPayload) (err error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &Call{Method: "SecureWithComposedRequirements", Payload: p})
+	f := m.secureWithComposedRequirementsDefault
+	if len(m.secureWithComposedRequirementsFuncs) > 0 {
+		f, m.secureWithComposedRequirementsFuncs = m.secureWithComposedRequirementsFuncs[0], m.secureWithComposedRequirementsFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		err = fmt.Errorf("EndpointWithComposedRequirements: unexpected call to %q", "SecureWithComposedRequirements")
+		m.t.Error(err)
+		return
+	}
+	return f(ctx, p)
+}
+
+// SetJWTAuth sets the function that implements the authorization logic for the
+// JWT security scheme. The mock authorizes all requests by default.
+func (m *Mock) SetJWTAuth(f func(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error)) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jwtAuth = f
+	return m
+}
+
+// JWTAuth implements the authorization logic for the JWT security scheme.
+func (m *Mock) JWTAuth(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error) {
+	m.mu.Lock()
+	f := m.jwtAuth
+	m.mu.Unlock()
+	if f == nil {
+		return ctx, nil
+	}
+	return f(ctx, token, schema)
+}
+
+// SetAPIKeyAuth sets the function that implements the authorization logic for
+// the APIKey security scheme. The mock authorizes all requests by default.
+func (m *Mock) SetAPIKeyAuth(f func(ctx context.Context, key string, schema *security.APIKeyScheme) (context.Context, error)) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apiKeyAuth = f
+	return m
+}
+
+// APIKeyAuth implements the authorization logic for the APIKey security scheme.
+func (m *Mock) APIKeyAuth(ctx context.Context, key string, schema *security.APIKeyScheme) (context.Context, error) {
+	m.mu.Lock()
+	f := m.apiKeyAuth
+	m.mu.Unlock()
+	if f == nil {
+		return ctx, nil
+	}
+	return f(ctx, key, schema)
+}
+
+// SetMutualTLSAuth sets the function that implements the authorization logic
+// for the MutualTLS security scheme. The mock authorizes all requests by
+// default.
+func (m *Mock) SetMutualTLSAuth(f func(ctx context.Context, certs []*x509.Certificate, schema *security.MutualTLSScheme) (context.Context, error)) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mutualTLSAuth = f
+	return m
+}
+
+// MutualTLSAuth implements the authorization logic for the MutualTLS security
+// scheme.
+func (m *Mock) MutualTLSAuth(ctx context.Context, certs []*x509.Certificate, schema *security.MutualTLSScheme) (context.Context, error) {
+	m.mu.Lock()
+	f := m.mutualTLSAuth
+	m.mu.Unlock()
+	if f == nil {
+		return ctx, nil
+	}
+	return f(ctx, certs, schema)
+}
+
+// Calls returns the calls made to the mock so far in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// Called returns the number of calls made to the method with the given design
+// name.
+func (m *Mock) Called(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+`
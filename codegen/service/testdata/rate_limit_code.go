@@ -0,0 +1,151 @@
+package testdata
+
+const RateLimitServiceCode = `// ApplyRateLimits wraps the "RateLimitService" service endpoints that define a
+// rate limit in the design with middlewares that enforce the quotas using the
+// given limiter.
+func ApplyRateLimits(e *Endpoints, l ratelimit.Limiter) {
+	e.A = NewARateLimitMiddleware(l)(e.A)
+	e.B = NewBRateLimitMiddleware(l)(e.B)
+}
+
+// NewARateLimitMiddleware returns an endpoint middleware that limits the
+// requests made to the "A" endpoint to 100 per minute.
+func NewARateLimitMiddleware(l ratelimit.Limiter) func(goa.Endpoint) goa.Endpoint {
+	limit := ratelimit.Limit{Requests: 100, Period: time.Minute}
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			key := "RateLimitService"
+			if err := ratelimit.Enforce(ctx, l, key, limit); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// NewBRateLimitMiddleware returns an endpoint middleware that limits the
+// requests made to the "B" endpoint to 100 per minute.
+func NewBRateLimitMiddleware(l ratelimit.Limiter) func(goa.Endpoint) goa.Endpoint {
+	limit := ratelimit.Limit{Requests: 100, Period: time.Minute}
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			key := "RateLimitService"
+			if err := ratelimit.Enforce(ctx, l, key, limit); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+`
+
+const RateLimitClientIPCode = `// ApplyRateLimits wraps the "RateLimitClientIP" service endpoints that define
+// a rate limit in the design with middlewares that enforce the quotas using
+// the given limiter.
+func ApplyRateLimits(e *Endpoints, l ratelimit.Limiter) {
+	e.A = NewARateLimitMiddleware(l)(e.A)
+}
+
+// NewARateLimitMiddleware returns an endpoint middleware that limits the
+// requests made to the "A" endpoint to 10 per 30 seconds.
+func NewARateLimitMiddleware(l ratelimit.Limiter) func(goa.Endpoint) goa.Endpoint {
+	limit := ratelimit.Limit{Requests: 10, Period: 30 * time.Second}
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			key := "RateLimitClientIP.A"
+			key += ":" + ratelimit.ClientIP(ctx)
+			if err := ratelimit.Enforce(ctx, l, key, limit); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+`
+
+const RateLimitPayloadAttributeCode = `// ApplyRateLimits wraps the "RateLimitPayloadAttribute" service endpoints that
+// define a rate limit in the design with middlewares that enforce the quotas
+// using the given limiter.
+func ApplyRateLimits(e *Endpoints, l ratelimit.Limiter) {
+	e.String = NewStringRateLimitMiddleware(l)(e.String)
+	e.Int = NewIntRateLimitMiddleware(l)(e.Int)
+}
+
+// NewStringRateLimitMiddleware returns an endpoint middleware that limits the
+// requests made to the "String" endpoint to 5 per hour.
+func NewStringRateLimitMiddleware(l ratelimit.Limiter) func(goa.Endpoint) goa.Endpoint {
+	limit := ratelimit.Limit{Requests: 5, Period: time.Hour}
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			key := "RateLimitPayloadAttribute.String"
+			p := req.(*StringPayload)
+			key += ":" + p.Tenant
+			if err := ratelimit.Enforce(ctx, l, key, limit); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// NewIntRateLimitMiddleware returns an endpoint middleware that limits the
+// requests made to the "Int" endpoint to 5 per 90 minutes.
+func NewIntRateLimitMiddleware(l ratelimit.Limiter) func(goa.Endpoint) goa.Endpoint {
+	limit := ratelimit.Limit{Requests: 5, Period: 90 * time.Minute}
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			key := "RateLimitPayloadAttribute.Int"
+			p := req.(*IntPayload)
+			if p.Account != nil {
+				key += ":" + fmt.Sprint(*p.Account)
+			}
+			if err := ratelimit.Enforce(ctx, l, key, limit); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+`
+
+const RateLimitPrincipalCode = `// ApplyRateLimits wraps the "RateLimitPrincipal" service endpoints that define
+// a rate limit in the design with middlewares that enforce the quotas using
+// the given limiter.
+func ApplyRateLimits(e *Endpoints, l ratelimit.Limiter) {
+	e.A = NewARateLimitMiddleware(l)(e.A)
+}
+
+// NewARateLimitMiddleware returns an endpoint middleware that limits the
+// requests made to the "A" endpoint to 1 per second.
+func NewARateLimitMiddleware(l ratelimit.Limiter) func(goa.Endpoint) goa.Endpoint {
+	limit := ratelimit.Limit{Requests: 1, Period: time.Second}
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			return next(ratelimit.WithPrincipalQuota(ctx, l, "RateLimitPrincipal.A", limit), req)
+		}
+	}
+}
+`
+
+const RateLimitAPICode = `// ApplyRateLimits wraps the "RateLimitAPI" service endpoints that define a
+// rate limit in the design with middlewares that enforce the quotas using the
+// given limiter.
+func ApplyRateLimits(e *Endpoints, l ratelimit.Limiter) {
+	e.A = NewARateLimitMiddleware(l)(e.A)
+}
+
+// NewARateLimitMiddleware returns an endpoint middleware that limits the
+// requests made to the "A" endpoint to 1000 per 24 hours.
+func NewARateLimitMiddleware(l ratelimit.Limiter) func(goa.Endpoint) goa.Endpoint {
+	limit := ratelimit.Limit{Requests: 1000, Period: 24 * time.Hour}
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			key := "RateLimitAPI"
+			if err := ratelimit.Enforce(ctx, l, key, limit); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+`
@@ -0,0 +1,95 @@
+package testdata
+
+import (
+	"time"
+
+	. "goa.design/goa/v3/dsl"
+)
+
+var RateLimitServiceDSL = func() {
+	Service("RateLimitService", func() {
+		RateLimit(100, time.Minute)
+		Method("A", func() {
+			Payload(String)
+		})
+		Method("B", func() {})
+	})
+}
+
+var RateLimitClientIPDSL = func() {
+	Service("RateLimitClientIP", func() {
+		Method("A", func() {
+			RateLimit(10, 30*time.Second, RateLimitByClientIP)
+		})
+		Method("B", func() {})
+	})
+}
+
+var RateLimitPayloadAttributeDSL = func() {
+	Service("RateLimitPayloadAttribute", func() {
+		Method("String", func() {
+			RateLimit(5, time.Hour, "tenant")
+			Payload(func() {
+				Attribute("tenant", String)
+				Required("tenant")
+			})
+		})
+		Method("Int", func() {
+			RateLimit(5, 90*time.Minute, "account")
+			Payload(func() {
+				Attribute("account", Int)
+			})
+		})
+	})
+}
+
+var RateLimitPrincipalDSL = func() {
+	var APIKeyAuth = APIKeySecurity("api_key")
+	Service("RateLimitPrincipal", func() {
+		Method("A", func() {
+			Security(APIKeyAuth)
+			RateLimit(1, time.Second, RateLimitByPrincipal)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+		})
+	})
+}
+
+var RateLimitPrincipalMutualTLSDSL = func() {
+	var MTLSAuth = MutualTLSSecurity("mtls")
+	Service("RateLimitPrincipalMutualTLS", func() {
+		Method("A", func() {
+			Security(MTLSAuth)
+			RateLimit(1, time.Second, RateLimitByPrincipal)
+		})
+	})
+}
+
+var RateLimitPrincipalRequirementsDSL = func() {
+	var APIKeyAuth = APIKeySecurity("api_key")
+	var JWTAuth = JWTSecurity("jwt")
+	var MTLSAuth = MutualTLSSecurity("mtls")
+	Service("RateLimitPrincipalRequirements", func() {
+		Method("A", func() {
+			Security(APIKeyAuth)
+			Security(JWTAuth)
+			Security(MTLSAuth)
+			RateLimit(1, time.Second, RateLimitByPrincipal)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+				Token("token", String)
+				Required("token")
+			})
+		})
+	})
+}
+
+var RateLimitAPIDSL = func() {
+	API("RateLimitAPI", func() {
+		RateLimit(1000, 24*time.Hour)
+	})
+	Service("RateLimitAPI", func() {
+		Method("A", func() {})
+	})
+}
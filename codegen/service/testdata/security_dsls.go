@@ -28,6 +28,28 @@ var OAuth2AuthorizationCode = OAuth2Security("authCode", func() {
 	Scope("api:read", "Read access")
 })
 
+var MutualTLSAuth = MutualTLSSecurity("mtls")
+
+var EndpointWithComposedRequirementsDSL = func() {
+	Service("EndpointWithComposedRequirements", func() {
+		Method("SecureWithComposedRequirements", func() {
+			AuthErrors()
+			Security(JWTAuth, APIKeyAuth, func() {
+				Scope("api:read")
+				SchemeScope("api:write", "jwt")
+			})
+			Security(MutualTLSAuth)
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var EndpointWithoutRequirementDSL = func() {
 	Service("EndpointWithoutRequirement", func() {
 		Method("Unsecure", func() {
@@ -121,6 +121,51 @@ func NewSecureWithAPIKeyOverrideEndpoint(s Service, authAPIKeyFn security.AuthAP
 }
 `
 
+var EndpointWithComposedRequirementsCode = `// NewSecureWithComposedRequirementsEndpoint returns an endpoint function that
+// calls the method "SecureWithComposedRequirements" of service
+// "EndpointWithComposedRequirements".
+func NewSecureWithComposedRequirementsEndpoint(s Service, authJWTFn security.AuthJWTFunc, authAPIKeyFn security.AuthAPIKeyFunc, authMutualTLSFn security.AuthMutualTLSFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SecureWithComposedRequirementsPayload)
+		var err error
+		var errs []error
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read", "api:write", "api:admin"},
+			RequiredScopes: []string{"api:read", "api:write"},
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		if err == nil {
+			sc := security.APIKeyScheme{
+				Name:           "api_key",
+				Scopes:         []string{"api:read", "api:write", "api:admin"},
+				RequiredScopes: []string{"api:read"},
+			}
+			var key string
+			if p.Key != nil {
+				key = *p.Key
+			}
+			ctx, err = authAPIKeyFn(ctx, key, &sc)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			sc := security.MutualTLSScheme{
+				Name: "mtls",
+			}
+			ctx, err = authMutualTLSFn(ctx, security.PeerCertificatesFromContext(ctx), &sc)
+		}
+		if err != nil {
+			return nil, security.AuthError(append(errs, err)...)
+		}
+		return nil, s.SecureWithComposedRequirements(ctx, p)
+	}
+}
+`
+
 var EndpointWithOAuth2Code = `// NewSecureWithOAuth2Endpoint returns an endpoint function that calls the
 // method "SecureWithOAuth2" of service "EndpointWithOAuth2".
 func NewSecureWithOAuth2Endpoint(s Service, authOAuth2Fn security.AuthOAuth2Func) goa.Endpoint {
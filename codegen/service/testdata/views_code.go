@@ -860,3 +860,267 @@ func ValidateUserTypeView(result *UserTypeView) (err error) {
 	return
 }
 `
+
+const FieldSelectionCode = `// Account is the viewed result type that is projected based on a view.
+type Account struct {
+	// Type to project
+	Projected *AccountView
+	// View to render
+	View string
+}
+
+// AccountCollection is the viewed result type that is projected based on a
+// view.
+type AccountCollection struct {
+	// Type to project
+	Projected AccountCollectionView
+	// View to render
+	View string
+}
+
+// AccountView is a type that runs validations on a projected type.
+type AccountView struct {
+	ID    *string
+	Name  *string
+	Owner *OwnerView
+	Tags  []*TagView
+}
+
+// OwnerView is a type that runs validations on a projected type.
+type OwnerView struct {
+	Name  *string
+	Email *string
+}
+
+// TagView is a type that runs validations on a projected type.
+type TagView struct {
+	Key   *string
+	Value *string
+}
+
+// AccountCollectionView is a type that runs validations on a projected type.
+type AccountCollectionView []*AccountView
+
+var (
+	// AccountMap is a map indexing the attribute names of Account by view name.
+	AccountMap = map[string][]string{
+		"default": {
+			"id",
+			"name",
+			"owner",
+			"tags",
+		},
+	}
+	// AccountCollectionMap is a map indexing the attribute names of
+	// AccountCollection by view name.
+	AccountCollectionMap = map[string][]string{
+		"default": {
+			"id",
+			"name",
+			"owner",
+			"tags",
+		},
+	}
+	// OwnerMap is a map indexing the attribute names of Owner by view name.
+	OwnerMap = map[string][]string{
+		"default": {
+			"name",
+			"email",
+		},
+	}
+)
+
+// ValidateAccount runs the validations defined on the viewed result type
+// Account.
+func ValidateAccount(result *Account) (err error) {
+	switch result.View {
+	case "default", "":
+		err = ValidateAccountView(result.Projected)
+	default:
+		err = goa.InvalidEnumValueError("view", result.View, []any{"default"})
+	}
+	return
+}
+
+// ValidateAccountCollection runs the validations defined on the viewed result
+// type AccountCollection.
+func ValidateAccountCollection(result AccountCollection) (err error) {
+	switch result.View {
+	case "default", "":
+		err = ValidateAccountCollectionView(result.Projected)
+	default:
+		err = goa.InvalidEnumValueError("view", result.View, []any{"default"})
+	}
+	return
+}
+
+// ValidateAccountView runs the validations defined on AccountView using the
+// "default" view.
+func ValidateAccountView(result *AccountView) (err error) {
+	if result.ID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("id", "result"))
+	}
+	if result.Owner != nil {
+		if err2 := ValidateOwnerView(result.Owner); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateOwnerView runs the validations defined on OwnerView using the
+// "default" view.
+func ValidateOwnerView(result *OwnerView) (err error) {
+
+	return
+}
+
+// ValidateTagView runs the validations defined on TagView.
+func ValidateTagView(result *TagView) (err error) {
+
+	return
+}
+
+// ValidateAccountCollectionView runs the validations defined on
+// AccountCollectionView using the "default" view.
+func ValidateAccountCollectionView(result AccountCollectionView) (err error) {
+	for _, item := range result {
+		if err2 := ValidateAccountView(item); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateAccountViewFields returns an error if one of the given field paths
+// is not defined in AccountView. name is the name of the payload attribute
+// that holds the field selection.
+func ValidateAccountViewFields(name string, paths []string) (err error) {
+	for _, path := range paths {
+		field, nested, ok := strings.Cut(path, ".")
+		switch field {
+		case "id", "name":
+			if ok {
+				err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+			}
+		case "owner":
+			if ok && ValidateOwnerViewFields(name, []string{nested}) != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+			}
+		case "tags":
+			if ok && ValidateTagViewFields(name, []string{nested}) != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+			}
+		default:
+			err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+		}
+	}
+	return
+}
+
+// ProjectAccountViewFields clears the fields of result that are not selected
+// by paths. Required fields are never cleared. result is left unchanged if
+// paths is empty.
+func ProjectAccountViewFields(result *AccountView, paths []string) {
+	if result == nil || len(paths) == 0 {
+		return
+	}
+	if ok, _ := goa.SelectField(paths, "name"); !ok {
+		result.Name = nil
+	}
+	if ok, nested := goa.SelectField(paths, "owner"); !ok {
+		result.Owner = nil
+	} else {
+		ProjectOwnerViewFields(result.Owner, nested)
+	}
+	if ok, nested := goa.SelectField(paths, "tags"); !ok {
+		result.Tags = nil
+	} else {
+		for _, v := range result.Tags {
+			ProjectTagViewFields(v, nested)
+		}
+	}
+}
+
+// ValidateOwnerViewFields returns an error if one of the given field paths is
+// not defined in OwnerView. name is the name of the payload attribute that
+// holds the field selection.
+func ValidateOwnerViewFields(name string, paths []string) (err error) {
+	for _, path := range paths {
+		field, _, ok := strings.Cut(path, ".")
+		switch field {
+		case "name", "email":
+			if ok {
+				err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+			}
+		default:
+			err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+		}
+	}
+	return
+}
+
+// ProjectOwnerViewFields clears the fields of result that are not selected by
+// paths. Required fields are never cleared. result is left unchanged if paths
+// is empty.
+func ProjectOwnerViewFields(result *OwnerView, paths []string) {
+	if result == nil || len(paths) == 0 {
+		return
+	}
+	if ok, _ := goa.SelectField(paths, "name"); !ok {
+		result.Name = nil
+	}
+	if ok, _ := goa.SelectField(paths, "email"); !ok {
+		result.Email = nil
+	}
+}
+
+// ValidateTagViewFields returns an error if one of the given field paths is
+// not defined in TagView. name is the name of the payload attribute that holds
+// the field selection.
+func ValidateTagViewFields(name string, paths []string) (err error) {
+	for _, path := range paths {
+		field, _, ok := strings.Cut(path, ".")
+		switch field {
+		case "key", "value":
+			if ok {
+				err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+			}
+		default:
+			err = goa.MergeErrors(err, goa.InvalidFieldSelectionError(name, path))
+		}
+	}
+	return
+}
+
+// ProjectTagViewFields clears the fields of result that are not selected by
+// paths. Required fields are never cleared. result is left unchanged if paths
+// is empty.
+func ProjectTagViewFields(result *TagView, paths []string) {
+	if result == nil || len(paths) == 0 {
+		return
+	}
+	if ok, _ := goa.SelectField(paths, "key"); !ok {
+		result.Key = nil
+	}
+	if ok, _ := goa.SelectField(paths, "value"); !ok {
+		result.Value = nil
+	}
+}
+
+// ValidateAccountCollectionViewFields returns an error if one of the given
+// field paths is not defined in AccountCollectionView. name is the name of the
+// payload attribute that holds the field selection.
+func ValidateAccountCollectionViewFields(name string, paths []string) (err error) {
+	return ValidateAccountViewFields(name, paths)
+}
+
+// ProjectAccountCollectionViewFields clears the fields of result that are not
+// selected by paths. Required fields are never cleared. result is left
+// unchanged if paths is empty.
+func ProjectAccountCollectionViewFields(result AccountCollectionView, paths []string) {
+	for _, item := range result {
+		ProjectAccountViewFields(item, paths)
+	}
+}
+`
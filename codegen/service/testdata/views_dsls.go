@@ -276,3 +276,70 @@ var ResultWithCustomFieldsDSL = func() {
 		})
 	})
 }
+
+var FieldSelectionDSL = func() {
+	var Tag = Type("Tag", func() {
+		Attribute("key", String)
+		Attribute("value", String)
+	})
+	var Owner = ResultType("application/vnd.owner", func() {
+		Attributes(func() {
+			Attribute("name", String)
+			Attribute("email", String)
+		})
+	})
+	var Account = ResultType("application/vnd.account", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+			Attribute("owner", Owner)
+			Attribute("tags", ArrayOf(Tag))
+			Required("id")
+		})
+	})
+	var FieldMask = Type("FieldMask", func() {
+		Attribute("paths", ArrayOf(String))
+	})
+	Service("FieldSelectionService", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("fields", String)
+			})
+			Result(Account)
+			FieldSelection()
+		})
+		Method("List", func() {
+			Payload(func() {
+				Attribute("mask", FieldMask)
+			})
+			Result(CollectionOf(Account))
+			FieldSelection("mask")
+		})
+	})
+}
+
+var FieldSelectionCacheDSL = func() {
+	var Item = ResultType("application/vnd.item", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+			Attribute("version", String)
+			Required("id")
+		})
+	})
+	Service("FieldSelectionCacheService", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("fields", String)
+			})
+			Result(Item)
+			FieldSelection()
+			HTTP(func() {
+				GET("/")
+				Param("fields")
+				ETag("version")
+			})
+		})
+	})
+}
@@ -29,6 +29,7 @@ func ViewsFile(_ string, service *expr.ServiceExpr) *codegen.File {
 		header := codegen.Header(service.Name+" views", "views",
 			[]*codegen.ImportSpec{
 				codegen.GoaImport(""),
+				{Path: "strings"},
 				{Path: "unicode/utf8"},
 			})
 		sections = []*codegen.SectionTemplate{header}
@@ -108,6 +109,15 @@ func ViewsFile(_ string, service *expr.ServiceExpr) *codegen.File {
 				})
 			}
 		}
+
+		// field selections
+		for _, f := range projectedFields(svc) {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "projected-type-fields",
+				Source: readTemplate("projected_type_fields"),
+				Data:   f,
+			})
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
@@ -30,6 +30,7 @@ func TestViews(t *testing.T) {
 		{"result-with-multiple-methods", testdata.ResultWithMultipleMethodsDSL, testdata.ResultWithMultipleMethodsCode},
 		{"result-with-enum-type", testdata.ResultWithEnumTypeDSL, testdata.ResultWithEnumType},
 		{"result-with-pkg-path", testdata.ResultWithPkgPathDSL, testdata.ResultWithPkgPathCode},
+		{"field-selection", testdata.FieldSelectionDSL, testdata.FieldSelectionCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -36,6 +36,16 @@ var TestTypesDSL = func() {
 			Required("required_string", "default_bool", "integer")
 		})
 
+		_ = Type("TimeTypes", func() {
+			Attribute("required_datetime", DateTime)
+			Attribute("datetime", DateTime)
+			Attribute("required_duration", Duration)
+			Attribute("duration", Duration)
+			Attribute("decimal", Decimal)
+			Attribute("datetime_array", ArrayOf(DateTime))
+			Required("required_datetime", "required_duration")
+		})
+
 		_ = Type("Super", func() {
 			Extend(Simple)
 			Attribute("ignored_attr", Float32)
@@ -36,11 +36,33 @@ func GoNativeTypeName(t expr.DataType) string {
 		return "[]byte"
 	case expr.AnyKind:
 		return "any"
+	case expr.DateTimeKind:
+		return "time.Time"
+	case expr.DurationKind:
+		return "time.Duration"
+	case expr.DecimalKind:
+		return "goa.Decimal"
 	default:
 		panic(fmt.Sprintf("cannot compute native Go type for %T", t)) // bug
 	}
 }
 
+// GoNativeTypeImport returns the import required by the Go type corresponding
+// to the given primitive type if any, nil otherwise.
+func GoNativeTypeImport(t expr.DataType) *ImportSpec {
+	if _, ok := t.(expr.Primitive); !ok {
+		return nil
+	}
+	switch t.Kind() {
+	case expr.DateTimeKind, expr.DurationKind:
+		return SimpleImport("time")
+	case expr.DecimalKind:
+		return GoaImport("")
+	default:
+		return nil
+	}
+}
+
 // AttributeTags computes the struct field tags from its metadata if any.
 func AttributeTags(_, att *expr.AttributeExpr) string {
 	var elems []string
@@ -51,6 +51,7 @@ func API(name string, fn func()) *expr.APIExpr {
 		return nil
 	}
 	expr.Root.API = expr.NewAPIExpr(name, fn)
+	eval.RecordLocation(expr.Root.API)
 	return expr.Root.API
 }
 
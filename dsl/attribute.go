@@ -155,6 +155,7 @@ func Attribute(name string, args ...any) {
 				Description: description,
 			}
 		}
+		eval.RecordLocation(attr)
 		if fn != nil {
 			eval.Execute(fn, attr)
 		}
@@ -243,7 +244,8 @@ func OneOf(name string, args ...any) {
 
 // Default sets the default value for an attribute.
 //
-// Default must appear in an Attribute DSL.
+// Default must appear in an Attribute DSL. Attributes of type DateTime,
+// Duration or Decimal cannot have a default value.
 //
 // Default takes one parameter: the default value.
 func Default(def any) {
@@ -252,6 +254,11 @@ func Default(def any) {
 		eval.IncompatibleDSL()
 		return
 	}
+	if a.Type != nil && !supportsValues(a.Type) {
+		eval.ReportError("default values are not supported for attributes of type %s",
+			expr.QualifiedTypeName(a.Type))
+		return
+	}
 	if a.Type != nil && !a.Type.IsCompatible(def) {
 		eval.ReportError("default value %#v is incompatible with attribute of type %s",
 			def, expr.QualifiedTypeName(a.Type))
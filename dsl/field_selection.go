@@ -0,0 +1,65 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// DefaultFieldSelectionAttribute is the name of the payload attribute that
+// holds the field selection when FieldSelection is called without argument.
+const DefaultFieldSelectionAttribute = "fields"
+
+// FieldSelection lets clients select the fields of the method result that
+// the server returns. The selection is read from a payload attribute named
+// "fields" by default. The attribute may be:
+//
+//   - a String listing comma separated field paths, e.g. "id,owner.name",
+//     usually mapped to a "fields" HTTP query parameter,
+//   - an array of strings, each element listing one or more field paths,
+//   - an object with a "paths" attribute that is an array of strings, for
+//     example a type bound to the google.protobuf.FieldMask message with
+//     ProtoMessage.
+//
+// Nested fields are selected using dots. The generated endpoint validates the
+// requested paths against the result type and returns an
+// "invalid_field_selection" error if a path does not exist. The fields that
+// are not selected are cleared from the projected result before it is
+// serialized, required fields are always returned. All the fields rendered by
+// the view are returned when the selection is empty.
+//
+// FieldSelection must appear in a Method expression. The method result must
+// be a result type.
+//
+// FieldSelection accepts an optional argument: the name of the payload
+// attribute that holds the field selection.
+//
+// Example:
+//
+//	Method("show", func() {
+//	    Payload(func() {
+//	        Attribute("id", String)
+//	        Attribute("fields", String)
+//	    })
+//	    Result(Account)
+//	    FieldSelection()
+//	    HTTP(func() {
+//	        GET("/{id}")
+//	        Param("fields")
+//	    })
+//	})
+func FieldSelection(name ...string) {
+	if len(name) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	att := DefaultFieldSelectionAttribute
+	if len(name) > 0 {
+		att = name[0]
+	}
+	m.FieldSelection = &expr.FieldSelectionExpr{Attribute: att, Method: m}
+}
@@ -341,3 +341,52 @@ func Trailers(fn func()) {
 		eval.IncompatibleDSL()
 	}
 }
+
+// ProtoMessage binds a type to an existing protocol buffer message. The gRPC
+// code generator references the message instead of generating a new one: the
+// generated proto file imports the file that defines the message and the
+// generated code converts the Goa type to and from the Go type produced by
+// protoc for the message. This makes it possible to reuse well-known types
+// such as google.protobuf.Empty, google.protobuf.FieldMask or
+// google.protobuf.Any as well as messages defined in shared proto packages.
+//
+// The attributes of the type must mirror the fields of the message: the
+// attribute names map to the message field names and the attributes that
+// correspond to non-message proto3 fields must be required. Messages that
+// are not in the standard include paths may need to be added to the protoc
+// include paths with the "protoc:include" meta.
+//
+// ProtoMessage must appear in a Type or ResultType expression.
+//
+// ProtoMessage accepts four arguments: the fully qualified name of the
+// message, the path of the proto file that defines the message, the name of
+// the Go type generated by protoc for the message and the import path of the
+// Go package that defines it.
+//
+// Example:
+//
+//	var FieldMask = Type("FieldMask", func() {
+//	    ProtoMessage("google.protobuf.FieldMask", "google/protobuf/field_mask.proto", "FieldMask", "google.golang.org/protobuf/types/known/fieldmaskpb")
+//	    Field(1, "paths", ArrayOf(String))
+//	    Required("paths")
+//	})
+func ProtoMessage(name, path, goType, goImport string) {
+	if name == "" || path == "" || goType == "" || goImport == "" {
+		eval.ReportError("ProtoMessage requires a message name, a proto file path, a Go type name and a Go import path")
+		return
+	}
+	var att *expr.AttributeExpr
+	switch e := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		att = e
+	case *expr.ResultTypeExpr:
+		att = e.AttributeExpr
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if att.Meta == nil {
+		att.Meta = make(expr.MetaExpr)
+	}
+	att.Meta["struct:proto:message"] = []string{name, path, goType, goImport}
+}
@@ -223,6 +223,37 @@ func Produces(args ...string) {
 	}
 }
 
+// ProblemDetails configures the generated servers to encode error responses
+// using the RFC 9457 problem details format ("application/problem+json")
+// instead of the default Goa error response format. This applies to errors
+// that use the default error type and to errors not described in the design
+// unless a formatter is given to the generated server constructor. The problem
+// details include the Goa error attributes as extension members so that
+// generated clients keep working.
+//
+// ProblemDetails must appear in a API HTTP expression or a Service HTTP
+// expression.
+//
+// ProblemDetails takes no argument.
+//
+// Example:
+//
+//	API("cellar", func() {
+//	    HTTP(func() {
+//	        ProblemDetails()
+//	    })
+//	})
+func ProblemDetails() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.ProblemDetails = true
+	case *expr.HTTPServiceExpr:
+		e.ProblemDetails = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
 // Path defines an API or service base path, i.e. a common HTTP path prefix to
 // all the API or service methods. The path may define wildcards (see GET for a
 // description of the wildcard syntax). The corresponding parameters must be
@@ -340,6 +371,7 @@ func route(method, path string) *expr.RouteExpr {
 		return r
 	}
 	r.Endpoint = a
+	eval.RecordLocation(r)
 	a.Routes = append(a.Routes, r)
 	return r
 }
@@ -0,0 +1,136 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Cache sets the directives of the Cache-Control header written with the
+// success responses of the endpoint.
+//
+// Cache must appear in a HTTP endpoint expression.
+//
+// Cache accepts one or more Cache-Control directives, see RFC 9111 section
+// 5.2.2. Use ETag and LastModified to define the cache validators of the
+// responses.
+//
+// Example:
+//
+//	var _ = Service("catalog", func() {
+//	    Method("show", func() {
+//	        Payload(func() {
+//	            Attribute("id", String)
+//	        })
+//	        Result(Item)
+//	        HTTP(func() {
+//	            GET("/items/{id}")
+//	            Cache("private", "max-age=60")
+//	            ETag("version")
+//	            LastModified("updated_at")
+//	        })
+//	    })
+//	})
+func Cache(directives ...string) {
+	if len(directives) == 0 {
+		eval.TooFewArgError()
+		return
+	}
+	c := httpCache()
+	if c == nil {
+		return
+	}
+	c.Directives = append(c.Directives, directives...)
+}
+
+// ETag sets the name of the result attribute that holds the entity tag of the
+// endpoint responses. The attribute must be a string. The generated server
+// code writes the value in the ETag response header, quoting it unless it is
+// already an entity tag, and replies to GET and HEAD requests whose
+// If-None-Match header matches the entity tag with 304 Not Modified without
+// encoding the response body.
+//
+// If the endpoint has a PUT or PATCH route the method payload must also define
+// a string attribute with the same name. The attribute is initialized from the
+// If-Match request header (with the quotes removed) unless the design maps it
+// explicitly. The method implementation should compare it with the current
+// entity tag of the resource and return the "precondition_failed" error if
+// they differ. The error is added to the method and mapped to the 412
+// Precondition Failed status code unless the design already defines it.
+//
+// If the method supports FieldSelection the ETag and LastModified attributes
+// are always included in the result so that the validators are computed from
+// the complete resource, and the entity tag of the responses that only contain
+// the selected fields is weak.
+//
+// ETag must appear in a HTTP endpoint expression.
+//
+// ETag accepts one argument: the name of the result attribute.
+//
+// Example:
+//
+//	var _ = Service("catalog", func() {
+//	    Method("update", func() {
+//	        Payload(func() {
+//	            Attribute("id", String)
+//	            Attribute("version", String, "Entity tag of the updated item")
+//	            Attribute("name", String)
+//	        })
+//	        Result(Item)
+//	        HTTP(func() {
+//	            PUT("/items/{id}")
+//	            ETag("version")
+//	        })
+//	    })
+//	})
+func ETag(attribute string) {
+	if c := httpCache(); c != nil {
+		c.ETag = attribute
+	}
+}
+
+// LastModified sets the name of the result attribute that holds the last
+// modification time of the endpoint responses. The attribute must be a
+// DateTime or a string with the date-time format. The generated server code
+// writes the value in the Last-Modified response header and replies to GET
+// and HEAD requests whose If-Modified-Since header is not older than the
+// modification time with 304 Not Modified without encoding the response body.
+// The If-Modified-Since header is ignored if the request has an If-None-Match
+// header.
+//
+// LastModified must appear in a HTTP endpoint expression.
+//
+// LastModified accepts one argument: the name of the result attribute.
+//
+// Example:
+//
+//	var _ = Service("catalog", func() {
+//	    Method("show", func() {
+//	        Payload(func() {
+//	            Attribute("id", String)
+//	        })
+//	        Result(Item)
+//	        HTTP(func() {
+//	            GET("/items/{id}")
+//	            LastModified("updated_at")
+//	        })
+//	    })
+//	})
+func LastModified(attribute string) {
+	if c := httpCache(); c != nil {
+		c.LastModified = attribute
+	}
+}
+
+// httpCache returns the cache expression of the current HTTP endpoint
+// expression, creating it if needed.
+func httpCache() *expr.HTTPCacheExpr {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if e.Cache == nil {
+		e.Cache = &expr.HTTPCacheExpr{Parent: e}
+	}
+	return e.Cache
+}
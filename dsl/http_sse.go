@@ -0,0 +1,97 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ServerSentEvents indicates that the method results are streamed to the
+// client using Server-Sent Events (text/event-stream) rather than a websocket
+// connection. This makes it possible for clients to consume the stream in
+// environments where websocket upgrades are not available, e.g. browsers
+// behind proxies that block them.
+//
+// ServerSentEvents must appear in a HTTP endpoint expression. The method
+// must define a StreamingResult and no StreamingPayload.
+//
+// ServerSentEvents accepts an optional function that maps result attributes
+// onto the event fields using SSEEventID, SSEEventType and SSEEventRetry. Each
+// result is encoded as JSON in the event "data" field. The generated server
+// and client streams implement the same interfaces as their websocket
+// counterparts so that the service implementation is not affected.
+//
+// Example:
+//
+//	var _ = Service("monitor", func() {
+//	    Method("watch", func() {
+//	        Payload(func() {
+//	            Attribute("last_id", String)
+//	        })
+//	        StreamingResult(Update)
+//	        HTTP(func() {
+//	            GET("/updates")
+//	            Header("last_id:Last-Event-ID")
+//	            ServerSentEvents(func() {
+//	                SSEEventID("id")
+//	                SSEEventType("kind")
+//	            })
+//	        })
+//	    })
+//	})
+func ServerSentEvents(fns ...func()) {
+	if len(fns) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	sse := &expr.HTTPSSEExpr{Parent: e}
+	if len(fns) > 0 {
+		if !eval.Execute(fns[0], sse) {
+			return
+		}
+	}
+	e.SSE = sse
+}
+
+// SSEEventID sets the name of the result attribute used to set the "id" field
+// of the server-sent events. The attribute must be of type String. Clients
+// send the ID of the last event received in the "Last-Event-ID" header when
+// reconnecting.
+//
+// SSEEventID must appear in a ServerSentEvents expression.
+func SSEEventID(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.IDField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSEEventType sets the name of the result attribute used to set the "event"
+// field of the server-sent events. The attribute must be of type String.
+//
+// SSEEventType must appear in a ServerSentEvents expression.
+func SSEEventType(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.EventField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSEEventRetry sets the name of the result attribute used to set the "retry"
+// field of the server-sent events, that is the client reconnection time in
+// milliseconds. The attribute must be of an integer type.
+//
+// SSEEventRetry must appear in a ServerSentEvents expression.
+func SSEEventRetry(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.RetryField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
@@ -118,6 +118,15 @@ const DefaultProtoc = expr.DefaultProtoc
 //	    Field(2, "age", Int32)
 //	})
 //
+// - "struct:proto:message" binds the type to an existing protobuf message
+// instead of generating one. The values are the fully qualified message name,
+// the proto file import path, the Go type name and the Go import path. See
+// ProtoMessage. Applicable to Type and ResultType only.
+//
+//	var Empty = Type("Empty", func() {
+//	    Meta("struct:proto:message", "google.protobuf.Empty", "google/protobuf/empty.proto", "Empty", "google.golang.org/protobuf/types/known/emptypb")
+//	})
+//
 // - "struct:tag:xxx" sets a generated Go struct field tag and overrides tags
 // that Goa would otherwise set. If the metadata value is a slice then the
 // strings are joined with the space character as separator. Applicable to
@@ -168,6 +177,43 @@ const DefaultProtoc = expr.DefaultProtoc
 //	    Meta("protoc:include", "/usr/local/include/google/protobuf")
 //	})
 //
+// - "grpc:connect" generates a MountConnect function in the gRPC server
+// package that serves the unary and server streaming methods over HTTP using
+// the Connect and gRPC-Web protocols so that browsers may call them directly.
+// Applicable to API and service definitions only. If used on an API
+// definition the function is generated for all services, unless disabled
+// for specific services by setting the value to "false". The Connect requests
+// do not go through the grpc.Server, the server interceptors must be given to
+// MountConnect to apply.
+//
+//	var _ = Service("service1", func() {
+//	    Meta("grpc:connect", "true")
+//	})
+//
+//	// Then in the server:
+//	grpcsvr := service1svr.New(endpoints, nil)
+//	service1svr.MountConnect(mux, grpcsvr,
+//	    connect.WithUnaryInterceptors(unaryInterceptors...),
+//	    connect.WithStreamInterceptors(streamInterceptors...))
+//
+// - "asyncapi:generate" specifies whether the AsyncAPI specification of the
+// HTTP streaming endpoints should be generated. Defaults to true. Applicable
+// to API, services and methods.
+//
+//	var _ = Method("chat", func() {
+//	    Meta("asyncapi:generate", "false")
+//	})
+//
+// - "typescript:generate" specifies whether the TypeScript client of the HTTP
+// services should be generated. Defaults to false. Applicable to API,
+// services and methods. Setting the meta to "true" on the API generates the
+// clients of all the services, setting it to "false" on a service or a
+// method excludes it.
+//
+//	var _ = API("calc", func() {
+//	    Meta("typescript:generate", "true")
+//	})
+//
 // - "swagger:generate" DEPRECATED, use "openapi:generate" instead.
 //
 // - "openapi:generate" specifies whether OpenAPI specification should be
@@ -33,6 +33,7 @@ func Method(name string, fn func()) {
 		return
 	}
 	ep := &expr.MethodExpr{Name: name, Service: s, DSLFunc: fn}
+	eval.RecordLocation(ep)
 	s.Methods = append(s.Methods, ep)
 }
 
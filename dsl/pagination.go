@@ -0,0 +1,154 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Paginated indicates that the method returns the items of a collection one
+// page at a time. The DSL function names the result attribute that lists the
+// page items and the payload and result attributes used to retrieve the next
+// page, either a cursor with PageCursor or an offset with PageOffset.
+//
+// Paginated causes the generated service client to include an iterator method
+// named after the method with the "All" suffix that transparently requests
+// the following pages until the collection is exhausted. The iterator works
+// with any transport client (HTTP or gRPC). The page size attribute defined
+// with PageSize is validated and the OpenAPI specification documents the
+// pagination parameters and links the response to the next page.
+//
+// Paginated must appear in a Method expression. The payload and result of the
+// method must be objects.
+//
+// Paginated accepts a single argument: the DSL function that defines the
+// pagination attributes.
+//
+// Example:
+//
+//	Method("list", func() {
+//	    Payload(func() {
+//	        Attribute("page_token", String)
+//	        Attribute("page_size", Int)
+//	    })
+//	    Result(func() {
+//	        Attribute("items", ArrayOf(Item))
+//	        Attribute("next_page_token", String)
+//	    })
+//	    Paginated(func() {
+//	        PageItems("items")
+//	        PageCursor("page_token", "next_page_token")
+//	        PageSize("page_size", 100)
+//	    })
+//	    HTTP(func() {
+//	        GET("/")
+//	        Param("page_token")
+//	        Param("page_size")
+//	    })
+//	})
+func Paginated(fn func()) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p := &expr.PaginationExpr{Method: m}
+	if !eval.Execute(fn, p) {
+		return
+	}
+	m.Pagination = p
+}
+
+// PageItems sets the name of the result attribute that lists the items of a
+// page. The attribute must be an array.
+//
+// PageItems must appear in a Paginated expression.
+//
+// Example:
+//
+//	Paginated(func() {
+//	    PageItems("items")
+//	})
+func PageItems(name string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Items = name
+}
+
+// PageCursor selects cursor based pagination. The first argument is the name
+// of the payload attribute that holds the token of the page to retrieve and
+// the second the name of the result attribute that holds the token of the
+// next page. Both attributes must be strings. The generated iterators stop
+// when the result does not define a next page token.
+//
+// PageCursor must appear in a Paginated expression.
+//
+// Example:
+//
+//	Paginated(func() {
+//	    PageCursor("page_token", "next_page_token")
+//	})
+func PageCursor(token, nextToken string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Kind = expr.CursorPaginationKind
+	p.Token = token
+	p.NextToken = nextToken
+}
+
+// PageOffset selects offset based pagination. The argument is the name of the
+// payload attribute that holds the index of the first item to retrieve. The
+// attribute must be an integer. The generated iterators increment the offset
+// by the number of items returned and stop when a page is empty or when it
+// contains fewer items than requested.
+//
+// PageOffset must appear in a Paginated expression.
+//
+// Example:
+//
+//	Paginated(func() {
+//	    PageOffset("offset")
+//	})
+func PageOffset(offset string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Kind = expr.OffsetPaginationKind
+	p.Offset = offset
+}
+
+// PageSize sets the name of the payload attribute that holds the maximum
+// number of items returned in a page. The attribute must be an integer.
+// PageSize accepts an optional maximum value. The generated code validates
+// that the page size is greater than 0 and lower than or equal to the maximum
+// if any.
+//
+// PageSize must appear in a Paginated expression.
+//
+// Example:
+//
+//	Paginated(func() {
+//	    PageSize("page_size", 100)
+//	})
+func PageSize(name string, maximum ...int) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(maximum) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	p.PageSize = name
+	if len(maximum) > 0 {
+		p.MaxPageSize = maximum[0]
+	}
+}
@@ -0,0 +1,89 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// RateLimitByClientIP is the RateLimit key that limits requests per
+	// client IP address.
+	RateLimitByClientIP = expr.RateLimitKeyClientIP
+	// RateLimitByPrincipal is the RateLimit key that limits requests per
+	// security principal. The quota is enforced once the request is
+	// authenticated and is keyed by the identity the auth function stores
+	// in the context with ratelimit.ContextWithPrincipal, or else by the
+	// "sub" claim of the JWT claims stored with
+	// security.ContextWithJWTClaims, or else by a SHA-256 hash of the
+	// credentials of the security requirement that succeeded (e.g. the
+	// attribute defined with Token, APIKey, Username or AccessToken), or
+	// else by a SHA-256 hash of the client certificate for mutual TLS
+	// requirements. Requests that fail authentication are limited per
+	// client IP address.
+	RateLimitByPrincipal = expr.RateLimitKeyPrincipal
+)
+
+// RateLimit defines a quota of requests for the methods of an API, a service
+// or for a single method. A rate limit defined on a method overrides the
+// service rate limit which in turn overrides the API rate limit.
+//
+// The code generator produces an endpoint middleware in the service package
+// that enforces the quota using a ratelimit.Limiter and an ApplyRateLimits
+// function that wraps the service endpoints with the middlewares, e.g.:
+//
+//	eps := calc.NewEndpoints(svc)
+//	calc.ApplyRateLimits(eps, ratelimit.NewTokenBucket())
+//
+// The generated example server calls ApplyRateLimits with an in-memory
+// ratelimit.TokenBucket. Requests that exceed the quota fail with a
+// "rate_limit_exceeded" error which is mapped to the HTTP status code 429 (Too
+// Many Requests) and to the gRPC code ResourceExhausted.
+//
+// RateLimit must appear in an API, a Service or a Method expression.
+//
+// RateLimit accepts two or three arguments: the number of requests allowed
+// during the given period, the period and optionally the key that identifies
+// requests sharing the same quota. The key is either the name of a payload
+// attribute, RateLimitByClientIP or RateLimitByPrincipal. All the requests
+// share the same quota if no key is provided. The quota is shared by all the
+// methods the rate limit applies to.
+//
+// Example:
+//
+//	var _ = Service("calc", func() {
+//	    RateLimit(1000, time.Hour, RateLimitByClientIP)
+//
+//	    Method("add", func() {
+//	        RateLimit(10, time.Minute, "tenant")
+//	        Payload(func() {
+//	            Attribute("tenant", String)
+//	            Attribute("a", Int)
+//	            Attribute("b", Int)
+//	        })
+//	    })
+//	})
+func RateLimit(requests int, per time.Duration, key ...string) {
+	if len(key) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	rl := &expr.RateLimitExpr{Requests: requests, Period: per}
+	if len(key) > 0 {
+		rl.Key = key[0]
+	}
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		rl.Parent = e
+		e.RateLimit = rl
+	case *expr.ServiceExpr:
+		rl.Parent = e
+		e.RateLimit = rl
+	case *expr.MethodExpr:
+		rl.Parent = e
+		e.RateLimit = rl
+	default:
+		eval.IncompatibleDSL()
+	}
+}
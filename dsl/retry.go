@@ -0,0 +1,119 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// DefaultRetryInitialBackoff is the delay before the first retry used
+	// when the Retry DSL does not call Backoff.
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	// DefaultRetryMaxBackoff is the maximum delay between two attempts
+	// used when the Retry DSL does not call Backoff.
+	DefaultRetryMaxBackoff = 10 * time.Second
+)
+
+// Retry defines the policy used by the generated clients to retry requests
+// that fail with temporary errors, that is errors defined with Temporary in
+// the design or transport errors flagged as such (e.g. HTTP 503 responses
+// or unavailable gRPC servers). A policy defined on a method overrides the
+// service policy which in turn overrides the API policy. Retry(1) on a method
+// disables retries for that method. Streaming methods are never retried.
+//
+// The delay between two attempts grows exponentially from the initial to the
+// maximum backoff and is randomized to avoid synchronized retries. Clients
+// stop retrying when the request context is canceled or when its deadline
+// would expire before the next attempt.
+//
+// Errors that are also timeouts are only retried if the method is
+// idempotent as the server may have processed the request.
+//
+// Retry must appear in an API, a Service or a Method expression.
+//
+// Retry accepts the maximum number of attempts including the initial request
+// and optionally a DSL function that may use Backoff and Idempotent.
+//
+// Example:
+//
+//	var _ = Service("calc", func() {
+//	    Retry(3)
+//
+//	    Method("get", func() {
+//	        Retry(5, func() {
+//	            Backoff(50*time.Millisecond, 2*time.Second)
+//	            Idempotent()
+//	        })
+//	    })
+//	})
+func Retry(attempts int, fn ...func()) {
+	if len(fn) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	r := &expr.RetryExpr{
+		MaxAttempts:    attempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+	}
+	if len(fn) > 0 {
+		if !eval.Execute(fn[0], r) {
+			return
+		}
+	}
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		r.Parent = e
+		e.Retry = r
+	case *expr.ServiceExpr:
+		r.Parent = e
+		e.Retry = r
+	case *expr.MethodExpr:
+		r.Parent = e
+		e.Retry = r
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Backoff sets the delay before the first retry and the maximum delay between
+// two attempts. The delay doubles after each attempt.
+//
+// Backoff must appear in a Retry expression.
+//
+// Example:
+//
+//	Retry(3, func() {
+//	    Backoff(50*time.Millisecond, 2*time.Second)
+//	})
+func Backoff(initial, maximum time.Duration) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.InitialBackoff = initial
+	r.MaxBackoff = maximum
+}
+
+// Idempotent indicates that the requests may be retried safely even if the
+// server may have processed them, in which case requests that fail with
+// timeout errors are retried as well.
+//
+// Idempotent must appear in a Retry expression.
+//
+// Example:
+//
+//	Retry(3, func() {
+//	    Idempotent()
+//	})
+func Idempotent() {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Idempotent = true
+}
@@ -175,16 +175,74 @@ func JWTSecurity(name string, fn ...func()) *expr.SchemeExpr {
 	return e
 }
 
+// MutualTLSSecurity defines a security scheme where clients authenticate
+// with the certificates they present during the TLS handshake. The generated
+// HTTP and gRPC server code stores the verified client certificate chain in
+// the request context and the generated endpoint code passes it to the scheme
+// auth function, see security.AuthMutualTLSFunc. The server TLS configuration
+// must request and verify the client certificates, e.g. by setting ClientAuth
+// to tls.VerifyClientCertIfGiven. The scheme is omitted from the OpenAPI v2
+// specification.
+//
+// The scheme is described in the OpenAPI v3 specification as a "mutualTLS"
+// security scheme. This scheme type was introduced in OpenAPI 3.1: requiring
+// MutualTLSSecurity anywhere in the design changes the version of the whole
+// OpenAPI v3 specification from 3.0.3 to 3.1.0. The specification then sets
+// jsonSchemaDialect and its schemas list their examples with the JSON Schema
+// "examples" keyword instead of the "example" keyword deprecated by OpenAPI
+// 3.1. Tools that only support OpenAPI 3.0 cannot read it.
+//
+// MutualTLSSecurity is a top level DSL.
+//
+// MutualTLSSecurity takes a name as first argument and an optional DSL as
+// second argument.
+//
+// Example:
+//
+//	var MTLS = MutualTLSSecurity("mtls", func() {
+//	    Description("Use a client certificate issued by the internal CA")
+//	})
+func MutualTLSSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		Kind:       expr.MutualTLSKind,
+		SchemeName: name,
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
 // Security defines authentication requirements to access an entire API, service
 // or individual service method.
 //
 // The requirement refers to one or more OAuth2Security, BasicAuthSecurity,
-// APIKeySecurity or JWTSecurity security scheme. If the schemes include a
-// OAuth2Security or JWTSecurity scheme then required scopes may be listed by
-// name in the Security DSL. All the listed schemes must be validated by the
-// client for the request to be authorized. Security may appear multiple times
-// in the same scope in which case the client may validate any one of the
-// requirements for the request to be authorized.
+// APIKeySecurity, JWTSecurity or MutualTLSSecurity security scheme. If the
+// schemes include a OAuth2Security or JWTSecurity scheme then required scopes
+// may be listed by name in the Security DSL, see Scope. All the listed schemes
+// must be validated by the client for the request to be authorized. Security
+// may appear multiple times in the same scope in which case the client may
+// validate any one of the requirements for the request to be authorized.
+//
+// The generated endpoint code returns the error returned by the auth function
+// of the last requirement if the request satisfies none of the requirements.
+// Use AuthErrors to return distinct errors for missing or invalid credentials
+// and for missing scopes instead.
 //
 // Security must appear in a API, Service or Method expression.
 //
@@ -216,6 +274,20 @@ func JWTSecurity(name string, fn ...func()) *expr.SchemeExpr {
 //	        Error(ErrBadRequest, ErrorResult)
 //	    })
 //
+//	    Method("delete", func() {
+//	        Description("Delete a result")
+//
+//	        // Require a JWT with the "api:admin" scope and an API key or
+//	        // a client certificate.
+//	        Security(JWT, APIKey, func() {
+//	            SchemeScope("api:admin", "jwt")
+//	        })
+//	        Security(MTLS)
+//
+//	        Payload(Operands)
+//	        Error(ErrBadRequest, ErrorResult)
+//	    })
+//
 //	    Method("health-check", func() {
 //	        Description("Check health")
 //
@@ -298,6 +370,41 @@ func NoSecurity() {
 	}
 }
 
+// AuthErrors makes the secured methods return an error named "unauthenticated"
+// if the request satisfies none of the security requirements and an error
+// named "insufficient_scope" if an auth function reported missing scopes, see
+// security.AuthError. AuthErrors adds these errors to the secured methods, the
+// "insufficient_scope" error only if the requirements list scopes, so that
+// they are described in the generated OpenAPI specifications and decoded by
+// the generated clients. The errors use the ErrorResult type and are mapped to
+// the 401 and 403 HTTP status codes and to the Unauthenticated and
+// PermissionDenied gRPC codes unless the design defines errors or mappings
+// with the same names.
+//
+// AuthErrors must appear in a API, Service or Method expression, it applies to
+// all the methods of the API or service when used at these levels.
+//
+// AuthErrors takes no argument.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    Security(JWT)
+//	    AuthErrors()
+//	})
+func AuthErrors() {
+	switch actual := eval.Current().(type) {
+	case *expr.MethodExpr:
+		actual.AuthErrors = true
+	case *expr.ServiceExpr:
+		actual.AuthErrors = true
+	case *expr.APIExpr:
+		actual.AuthErrors = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
 // Username defines the attribute used to provide the username to an endpoint
 // secured with basic authentication. The parameters and usage of Username are
 // the same as the goa DSL Attribute function.
@@ -505,7 +612,8 @@ func TokenField(tag any, name string, args ...any) {
 //
 // Scope accepts one or two arguments: the first argument is the scope name and
 // when used in JWTSecurity or OAuth2Security the second argument is a
-// description.
+// description. Use SchemeScope in Security to require a scope from a single
+// scheme of the requirement.
 //
 // Example:
 //
@@ -543,6 +651,51 @@ func Scope(name string, desc ...string) {
 	}
 }
 
+// SchemeScope lists a scope required by a single scheme of a security
+// requirement. Scopes listed with Scope are required by all the requirement
+// schemes.
+//
+// SchemeScope must appear in Security.
+//
+// SchemeScope accepts two arguments: the scope name and the name of one of the
+// schemes given to the enclosing Security.
+//
+// Example:
+//
+//	var JWT = JWTSecurity("jwt", func() {
+//	    Scope("api:read", "Read access")
+//	    Scope("api:admin", "Admin access")
+//	})
+//
+//	Method("admin", func() {
+//	    Security(JWT, APIKey, func() {
+//	        Scope("api:read")               // Required by both schemes
+//	        SchemeScope("api:admin", "jwt") // Required by the "jwt" scheme only
+//	    })
+//	})
+func SchemeScope(name, scheme string) {
+	current, ok := eval.Current().(*expr.SecurityExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	var found bool
+	for _, s := range current.Schemes {
+		if s.SchemeName == scheme {
+			found = true
+			break
+		}
+	}
+	if !found {
+		eval.ReportError("scope %q refers to scheme %q which is not part of the security requirement", name, scheme)
+		return
+	}
+	if current.SchemeScopes == nil {
+		current.SchemeScopes = make(map[string][]string)
+	}
+	current.SchemeScopes[scheme] = append(current.SchemeScopes[scheme], name)
+}
+
 // AuthorizationCodeFlow defines an authorizationCode OAuth2 flow as described
 // in section 1.3.1 of RFC 6749.
 //
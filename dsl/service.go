@@ -70,6 +70,7 @@ func Service(name string, fn func()) *expr.ServiceExpr {
 		return s
 	}
 	s := &expr.ServiceExpr{Name: name, DSLFunc: fn}
+	eval.RecordLocation(s)
 	expr.Root.Services = append(expr.Root.Services, s)
 	return s
 }
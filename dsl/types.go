@@ -38,6 +38,23 @@ const (
 
 	// Any is the type for an arbitrary JSON value (any in Go).
 	Any = expr.Any
+
+	// DateTime is the type for a point in time (time.Time in Go). Values are
+	// encoded as RFC3339 strings in HTTP requests and responses and as
+	// google.protobuf.Timestamp messages in gRPC messages. Example values are
+	// given as RFC3339 strings.
+	DateTime = expr.DateTime
+
+	// Duration is the type for an elapsed time (time.Duration in Go). Values
+	// are encoded as integer numbers of nanoseconds in HTTP requests and
+	// responses and as google.protobuf.Duration messages in gRPC messages.
+	// Example values are given as integer numbers of nanoseconds.
+	Duration = expr.Duration
+
+	// Decimal is the type for an arbitrary-precision decimal number
+	// (goa.Decimal in Go). Values are encoded as strings, e.g. "12.50", in
+	// both HTTP and gRPC messages. Example values are given as strings.
+	Decimal = expr.Decimal
 )
 
 // Empty represents empty values.
@@ -110,6 +110,7 @@ func Type(name string, args ...any) expr.UserType {
 			Meta:    expr.MetaExpr{"openapi:typename": []string{name}},
 		},
 	}
+	eval.RecordLocation(t)
 	expr.Root.Types = append(expr.Root.Types, t)
 	return t
 }
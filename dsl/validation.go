@@ -55,6 +55,7 @@ const (
 
 // Enum adds a "enum" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor76.
+// Enum cannot be used with attributes of type DateTime, Duration or Decimal.
 //
 // Example:
 //
@@ -69,6 +70,10 @@ const (
 //	})
 func Enum(vals ...any) {
 	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && !supportsValues(a.Type) {
+			incompatibleAttributeType("enum", a.Type.Name(), "a type other than datetime, duration or decimal")
+			return
+		}
 		for i, v := range vals {
 			// When can a.Type be nil? glad you asked
 			// There are two ways to write an Attribute declaration with the DSL that
@@ -458,6 +463,17 @@ func Required(names ...string) {
 	}
 }
 
+// supportsValues returns false if default and enum values cannot be used with
+// the given type. The generated code does not support such values for the
+// DateTime, Duration and Decimal types.
+func supportsValues(dt expr.DataType) bool {
+	switch dt.Kind() {
+	case expr.DateTimeKind, expr.DurationKind, expr.DecimalKind:
+		return false
+	}
+	return true
+}
+
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
 func incompatibleAttributeType(validation, actual, expected string) {
@@ -43,6 +43,31 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestEnumUnsupportedType(t *testing.T) {
+	cases := map[string]struct {
+		Type  expr.DataType
+		Value any
+	}{
+		"datetime": {expr.DateTime, "2006-01-02T15:04:05Z"},
+		"duration": {expr.Duration, 1},
+		"decimal":  {expr.Decimal, "1.5"},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(func() { Enum(tc.Value) }, att)
+		if eval.Context.Errors == nil {
+			t.Errorf("%s: Enum did not fail", k)
+		}
+		eval.Context = &eval.DSLContext{}
+		eval.Execute(func() { Default(tc.Value) }, att)
+		if eval.Context.Errors == nil {
+			t.Errorf("%s: Default did not fail", k)
+		}
+	}
+}
+
 func TestRequired(t *testing.T) {
 	att := &expr.AttributeExpr{
 		Type: &expr.UserTypeExpr{
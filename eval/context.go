@@ -22,6 +22,9 @@ type (
 		// may skip any callstack frame that belongs to them when computing error
 		// locations.
 		dslPackages []string
+		// locations records the locations of the DSL function calls
+		// that defined the expressions.
+		locations map[Expression]Location
 	}
 
 	// Stack represents the expression evaluation stack. The stack is appended to
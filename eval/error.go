@@ -70,18 +70,24 @@ func computeErrorLocation() (file string, line int) {
 		depth++
 		_, file, line, _ = runtime.Caller(depth)
 	}
+	file = relativePath(file)
+	return
+}
+
+// relativePath returns the path of file relative to the working directory or
+// file if it cannot be computed.
+func relativePath(file string) string {
 	wd, err := os.Getwd()
 	if err != nil {
-		return
+		return file
 	}
 	wd, err = filepath.Abs(wd)
 	if err != nil {
-		return
+		return file
 	}
 	f, err := filepath.Rel(wd, file)
 	if err != nil {
-		return
+		return file
 	}
-	file = f
-	return
+	return f
 }
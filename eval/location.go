@@ -0,0 +1,74 @@
+package eval
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Location is the position in the user code of the DSL function call that
+// defined an expression.
+type Location struct {
+	// File is the path to the file containing the DSL function call
+	// relative to the working directory when possible.
+	File string
+	// Line is the line number of the DSL function call.
+	Line int
+}
+
+// String returns the location formatted as "file:line".
+func (l Location) String() string {
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// RecordLocation records the location of the user code calling the DSL
+// function that defines e. DSL functions call RecordLocation when they create
+// an expression so that tools may report positions in the design.
+func RecordLocation(e Expression) {
+	file, line := dslCallerLocation()
+	if file == "" {
+		return
+	}
+	if Context.locations == nil {
+		Context.locations = make(map[Expression]Location)
+	}
+	Context.locations[e] = Location{File: file, Line: line}
+}
+
+// LocationOf returns the location recorded for e if any.
+func LocationOf(e Expression) (Location, bool) {
+	loc, ok := Context.locations[e]
+	return loc, ok
+}
+
+// dslCallerLocation returns the file and line of the first frame of the call
+// stack whose function does not belong to a DSL package or is defined in a
+// test file. It returns an empty string and 0 if there is none.
+func dslCallerLocation() (string, int) {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.File, "_test.go") || !isDSLFunction(frame.Function) {
+			if frame.File == "" {
+				return "", 0
+			}
+			return relativePath(frame.File), frame.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
+
+// isDSLFunction returns true if the function with the given fully qualified
+// name belongs to one of the DSL packages.
+func isDSLFunction(name string) bool {
+	for _, pkg := range Context.dslPackages {
+		if strings.HasPrefix(name, pkg+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package eval
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestRecordLocation(t *testing.T) {
+	Reset()
+	e := Expr(1)
+	_, _, line, _ := runtime.Caller(0)
+	RecordLocation(e)
+
+	loc, ok := LocationOf(e)
+	if !ok {
+		t.Fatal("no location recorded")
+	}
+	if filepath.Base(loc.File) != "location_test.go" {
+		t.Errorf("got file %q, expected location_test.go", loc.File)
+	}
+	if loc.Line != line+1 {
+		t.Errorf("got line %d, expected %d", loc.Line, line+1)
+	}
+	if _, ok := LocationOf(Expr(2)); ok {
+		t.Error("got location for expression that was not recorded")
+	}
+}
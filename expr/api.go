@@ -36,6 +36,17 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// AuthErrors is true if the secured methods of all the API
+		// services return the "unauthenticated" and
+		// "insufficient_scope" errors, see MethodExpr.HasAuthErrors.
+		AuthErrors bool
+		// RateLimit is the rate limit applied to all the API service
+		// methods unless overridden at the service or method level.
+		RateLimit *RateLimitExpr
+		// Retry is the retry policy used by the clients of all the API
+		// service methods unless overridden at the service or method
+		// level.
+		Retry *RetryExpr
 		// ClientInterceptors is the list of API client interceptors.
 		ClientInterceptors []*InterceptorExpr
 		// ServerInterceptors is the list of API server interceptors.
@@ -130,6 +141,18 @@ func (a *APIExpr) DefaultServer() *ServerExpr {
 // EvalName is the qualified name of the expression.
 func (a *APIExpr) EvalName() string { return "API " + a.Name }
 
+// Validate makes sure the API rate limit if any is valid.
+func (a *APIExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if a.RateLimit != nil {
+		verr.Merge(a.RateLimit.Validate())
+	}
+	if a.Retry != nil {
+		verr.Merge(a.Retry.Validate())
+	}
+	return verr
+}
+
 // Hash returns a unique hash value for a.
 func (a *APIExpr) Hash() string { return "_api_+" + a.Name }
 
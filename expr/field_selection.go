@@ -0,0 +1,94 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// FieldSelectionExpr describes the payload attribute used by clients to
+	// select the fields of the method result returned by the server.
+	FieldSelectionExpr struct {
+		// Attribute is the name of the payload attribute that holds the
+		// field selection.
+		Attribute string
+		// Method is the method that supports field selection.
+		Method *MethodExpr
+	}
+)
+
+// FieldMaskPathsAttribute is the name of the attribute that lists the field
+// paths when the field selection attribute is an object, for example a type
+// bound to the google.protobuf.FieldMask message.
+const FieldMaskPathsAttribute = "paths"
+
+// EvalName returns the generic definition name used in error messages.
+func (f *FieldSelectionExpr) EvalName() string {
+	suffix := "field selection"
+	var prefix string
+	if f.Method != nil {
+		prefix = f.Method.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the method result is a result type and that the field
+// selection attribute is defined in the method payload with a supported type.
+func (f *FieldSelectionExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	m := f.Method
+	if m.IsStreaming() {
+		verr.Add(f, "streaming methods do not support field selection")
+		return verr
+	}
+	if _, ok := m.Result.Type.(*ResultTypeExpr); !ok {
+		verr.Add(f, "result must be a result type, got %s", m.Result.Type.Name())
+	}
+	if !IsObject(m.Payload.Type) {
+		verr.Add(f, "payload must be an object to define attribute %q", f.Attribute)
+		return verr
+	}
+	att := m.Payload.Find(f.Attribute)
+	if att == nil {
+		verr.Add(f, "payload does not define attribute %q", f.Attribute)
+		return verr
+	}
+	if FieldSelectionKind(att) == "" {
+		verr.Add(f, "payload attribute %q must be a string, an array of strings or an object with a %q attribute that is an array of strings, got %s",
+			f.Attribute, FieldMaskPathsAttribute, att.Type.Name())
+	}
+	return verr
+}
+
+// Finalize documents the field selection attribute if it does not have a
+// description.
+func (f *FieldSelectionExpr) Finalize() {
+	att := f.Method.Payload.Find(f.Attribute)
+	if att == nil || att.Description != "" {
+		return
+	}
+	att.Description = fmt.Sprintf("Comma separated list of the result fields to return, nested fields are selected with dots (e.g. %q). All fields are returned if empty.", "owner.name")
+}
+
+// FieldSelectionKind returns "string", "array" or "mask" depending on whether
+// the given field selection attribute is a string, an array of strings or an
+// object that lists paths. It returns an empty string if the attribute type is
+// not supported.
+func FieldSelectionKind(att *AttributeExpr) string {
+	isStrings := func(dt DataType) bool {
+		arr := AsArray(dt)
+		return arr != nil && arr.ElemType.Type.Kind() == StringKind
+	}
+	switch {
+	case att.Type.Kind() == StringKind:
+		return "string"
+	case isStrings(att.Type):
+		return "array"
+	case IsObject(att.Type):
+		if paths := att.Find(FieldMaskPathsAttribute); paths != nil && isStrings(paths.Type) {
+			return "mask"
+		}
+	}
+	return ""
+}
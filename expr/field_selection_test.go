@@ -0,0 +1,58 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestFieldSelectionExprValidate(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidFieldSelectionDSL, ""},
+		{"invalid", testdata.InvalidFieldSelectionDSL,
+			`service "InvalidFieldSelectionService" method "NotResultType" field selection: result must be a result type, got string
+service "InvalidFieldSelectionService" method "Missing" field selection: payload does not define attribute "fields"
+service "InvalidFieldSelectionService" method "InvalidType" field selection: payload attribute "fields" must be a string, an array of strings or an object with a "paths" attribute that is an array of strings, got int
+service "InvalidFieldSelectionService" method "Streaming" field selection: streaming methods do not support field selection`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Error == "" {
+				expr.RunDSL(t, tc.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, tc.DSL)
+				assert.EqualError(t, err, tc.Error)
+			}
+		})
+	}
+}
+
+func TestFieldSelectionKind(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidFieldSelectionDSL)
+	svc := root.Service("ValidFieldSelectionService")
+	cases := []struct {
+		Method    string
+		Attribute string
+		Expected  string
+	}{
+		{"String", "fields", "string"},
+		{"Array", "select", "array"},
+		{"Mask", "mask", "mask"},
+	}
+	for _, c := range cases {
+		t.Run(c.Method, func(t *testing.T) {
+			att := svc.Method(c.Method).Payload.Find(c.Attribute)
+			assert.Equal(t, c.Expected, expr.FieldSelectionKind(att))
+		})
+	}
+	assert.NotEmpty(t, svc.Method("String").Payload.Find("fields").Description)
+	assert.Equal(t, "Selected fields", svc.Method("Array").Payload.Find("select").Description)
+}
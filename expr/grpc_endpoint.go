@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -136,12 +137,46 @@ func (e *GRPCEndpointExpr) Prepare() {
 		}
 	}
 
+	// Map the security errors to their default status codes.
+	e.prepareAuthErrors()
+
 	// Prepare responses
 	for _, er := range e.GRPCErrors {
 		er.Response.Prepare()
 	}
 }
 
+// prepareAuthErrors adds the Unauthenticated and PermissionDenied responses
+// of the "unauthenticated" and "insufficient_scope" errors of the method if
+// the design enables them with AuthErrors and does not map them already.
+func (e *GRPCEndpointExpr) prepareAuthErrors() {
+	if !e.MethodExpr.HasAuthErrors() {
+		return
+	}
+	errs := []struct {
+		name string
+		code int
+	}{
+		{goa.Unauthenticated, 16},  // codes.Unauthenticated
+		{goa.InsufficientScope, 7}, // codes.PermissionDenied
+	}
+loop:
+	for _, er := range errs {
+		if !hasError(e.MethodExpr.Errors, er.name) {
+			continue
+		}
+		for _, ge := range e.GRPCErrors {
+			if ge.Name == er.name {
+				continue loop
+			}
+		}
+		e.GRPCErrors = append(e.GRPCErrors, &GRPCErrorExpr{
+			Name:     er.name,
+			Response: &GRPCResponseExpr{StatusCode: er.code, Parent: e},
+		})
+	}
+}
+
 // Validate validates the endpoint expression by checking if the request
 // and responses contains the "rpc:tag" in the meta. It also makes sure
 // that there is only one response per status code.
@@ -254,7 +289,7 @@ func (e *GRPCEndpointExpr) Finalize() {
 				for _, sch := range dupReq.Schemes {
 					var field string
 					switch sch.Kind {
-					case NoKind:
+					case NoKind, MutualTLSKind:
 						continue
 					case BasicAuthKind:
 						field = TaggedAttribute(e.MethodExpr.Payload, "security:username")
@@ -342,6 +377,14 @@ func (e *GRPCEndpointExpr) Finalize() {
 					e.Request.Meta["struct:name:proto"] = []string{proto}
 				}
 			}
+			// propagate the existing protobuf message the user type is bound to
+			// so that the message is not generated.
+			if msg, ok := ut.Attribute().Meta["struct:proto:message"]; ok {
+				if e.Request.Meta == nil {
+					e.Request.Meta = make(MetaExpr)
+				}
+				e.Request.Meta["struct:proto:message"] = msg
+			}
 		}
 	} else {
 		// method payload is not an object type.
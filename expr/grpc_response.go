@@ -196,6 +196,14 @@ func (r *GRPCResponseExpr) Finalize(a *GRPCEndpointExpr, svcAtt *AttributeExpr)
 					r.Message.Meta["struct:name:proto"] = []string{proto}
 				}
 			}
+			// propagate the existing protobuf message the user type is bound to
+			// so that the message is not generated.
+			if msg, ok := ut.Attribute().Meta["struct:proto:message"]; ok {
+				if r.Message.Meta == nil {
+					r.Message.Meta = make(MetaExpr)
+				}
+				r.Message.Meta["struct:proto:message"] = msg
+			}
 		}
 	} else {
 		// method result is not an object type. Initialize response header or
@@ -38,7 +38,7 @@ func hash(dt DataType, ignoreFields, ignoreNames, ignoreTags bool, seen map[*Obj
 		seen = make(map[*Object]*string)
 	}
 	switch dt.Kind() {
-	case BooleanKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind, Float32Kind, Float64Kind, StringKind, BytesKind, AnyKind:
+	case BooleanKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind, Float32Kind, Float64Kind, StringKind, BytesKind, AnyKind, DateTimeKind, DurationKind, DecimalKind:
 		n := dt.Name()
 		return &n
 	case ArrayKind:
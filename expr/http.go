@@ -25,6 +25,9 @@ type (
 		// Produces lists the mime types generated by the API
 		// controllers.
 		Produces []string
+		// ProblemDetails indicates whether error responses use the RFC
+		// 9457 problem details format by default.
+		ProblemDetails bool
 		// Services contains the services created by the DSL.
 		Services []*HTTPServiceExpr
 		// Errors lists the error HTTP responses.
@@ -85,7 +85,7 @@ func defaultRequestHeaderAttributes(e *HTTPEndpointExpr) map[string]bool {
 		for _, sch := range req.Schemes {
 			var field string
 			switch sch.Kind {
-			case NoKind:
+			case NoKind, MutualTLSKind:
 				continue
 			case BasicAuthKind:
 				user := TaggedAttribute(e.MethodExpr.Payload, "security:username")
@@ -0,0 +1,156 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// HTTPCacheExpr describes the caching of the responses of an HTTP
+	// endpoint: the Cache-Control response header directives and the
+	// result attributes used as cache validators.
+	HTTPCacheExpr struct {
+		// Directives lists the Cache-Control response header directives.
+		Directives []string
+		// ETag is the name of the result attribute that holds the entity
+		// tag of the response if any.
+		ETag string
+		// LastModified is the name of the result attribute that holds the
+		// last modification time of the response if any.
+		LastModified string
+		// Parent is the HTTP endpoint expression.
+		Parent *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *HTTPCacheExpr) EvalName() string {
+	suffix := "cache"
+	var prefix string
+	if c.Parent != nil {
+		prefix = c.Parent.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// HasValidators returns true if the responses define an entity tag or a last
+// modification time in which case the endpoint handles conditional GET and
+// HEAD requests.
+func (c *HTTPCacheExpr) HasValidators() bool {
+	return c.ETag != "" || c.LastModified != ""
+}
+
+// IfMatch returns the name of the payload attribute initialized from the
+// If-Match request header, that is the ETag attribute if the endpoint has a
+// PUT or PATCH route. It returns an empty string otherwise.
+func (c *HTTPCacheExpr) IfMatch() string {
+	if c.ETag == "" {
+		return ""
+	}
+	for _, r := range c.Parent.Routes {
+		if r.Method == "PUT" || r.Method == "PATCH" {
+			return c.ETag
+		}
+	}
+	return ""
+}
+
+// Validate makes sure the validator attributes exist in the method result
+// and payload and have compatible types.
+func (c *HTTPCacheExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	e := c.Parent
+	m := e.MethodExpr
+	if !c.HasValidators() {
+		return verr
+	}
+	if m.IsStreaming() || e.SkipResponseBodyEncodeDecode {
+		verr.Add(c, "ETag and LastModified cannot be used by streaming endpoints or endpoints that use SkipResponseBodyEncodeDecode.")
+		return verr
+	}
+	validateField := func(att *AttributeExpr, in, name, field, kind string, valid func(*AttributeExpr) bool) {
+		if field == "" {
+			return
+		}
+		obj := AsObject(att.Type)
+		if obj == nil {
+			verr.Add(c, "%s %q is defined but the method %s type is not an object.", name, field, in)
+			return
+		}
+		fatt := obj.Attribute(field)
+		if fatt == nil {
+			verr.Add(c, "%s %q is not an attribute of the method %s type.", name, field, in)
+			return
+		}
+		if !valid(fatt) {
+			verr.Add(c, "%s %q must be %s.", name, field, kind)
+		}
+	}
+	isString := func(att *AttributeExpr) bool { return att.Type == String }
+	isTime := func(att *AttributeExpr) bool {
+		return att.Type == DateTime || att.Type == String && att.Validation != nil && att.Validation.Format == FormatDateTime
+	}
+	validateField(m.Result, "result", "ETag attribute", c.ETag, "a string", isString)
+	validateField(m.Result, "result", "LastModified attribute", c.LastModified, "a DateTime or a string with the date-time format", isTime)
+	validateField(m.Payload, "payload", "ETag attribute", c.IfMatch(), "a string", isString)
+	return verr
+}
+
+// prepare adds the precondition failed error and its 412 response to the
+// method if the endpoint handles the If-Match request header and the design
+// does not define them already.
+func (c *HTTPCacheExpr) prepare() {
+	if c.IfMatch() == "" {
+		return
+	}
+	e := c.Parent
+	if e.MethodExpr.Error(goa.PreconditionFailed) == nil {
+		e.MethodExpr.Errors = append(e.MethodExpr.Errors, &ErrorExpr{
+			AttributeExpr: &AttributeExpr{
+				Type:        ErrorResult,
+				Description: "The entity tag of the If-Match header does not match the current entity tag of the resource.",
+			},
+			Name: goa.PreconditionFailed,
+		})
+	}
+	for _, he := range e.HTTPErrors {
+		if he.Name == goa.PreconditionFailed {
+			return
+		}
+	}
+	for _, he := range e.Service.HTTPErrors {
+		if he.Name == goa.PreconditionFailed {
+			return
+		}
+	}
+	for _, he := range Root.API.HTTP.Errors {
+		if he.Name == goa.PreconditionFailed {
+			return
+		}
+	}
+	e.HTTPErrors = append(e.HTTPErrors, &HTTPErrorExpr{
+		Name:     goa.PreconditionFailed,
+		Response: &HTTPResponseExpr{StatusCode: StatusPreconditionFailed, Parent: e},
+	})
+}
+
+// finalize maps the ETag payload attribute to the If-Match request header
+// unless the design maps it explicitly.
+func (c *HTTPCacheExpr) finalize() {
+	field := c.IfMatch()
+	if field == "" {
+		return
+	}
+	e := c.Parent
+	if name, _ := findKey(e, field); name != "" {
+		return
+	}
+	e.Headers.Type.(*Object).Set(field, e.MethodExpr.Payload.Find(field))
+	e.Headers.Map("If-Match", field)
+	if e.MethodExpr.Payload.IsRequired(field) {
+		if e.Headers.Validation == nil {
+			e.Headers.Validation = &ValidationExpr{}
+		}
+		e.Headers.Validation.AddRequired(field)
+	}
+}
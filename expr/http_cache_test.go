@@ -0,0 +1,67 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestHTTPCacheExprValidate(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidHTTPCacheDSL, ""},
+		{"invalid", testdata.InvalidHTTPCacheDSL,
+			`service "InvalidHTTPCacheService" HTTP endpoint "Types" cache: ETag attribute "version" must be a string.
+service "InvalidHTTPCacheService" HTTP endpoint "Types" cache: LastModified attribute "updated_at" must be a DateTime or a string with the date-time format.
+service "InvalidHTTPCacheService" HTTP endpoint "Missing" cache: ETag attribute "version" is defined but the method result type is not an object.
+service "InvalidHTTPCacheService" HTTP endpoint "Missing" cache: ETag attribute "version" is not an attribute of the method payload type.
+service "InvalidHTTPCacheService" HTTP endpoint "Streaming" cache: ETag and LastModified cannot be used by streaming endpoints or endpoints that use SkipResponseBodyEncodeDecode.`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Error == "" {
+				expr.RunDSL(t, tc.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, tc.DSL)
+				assert.EqualError(t, err, tc.Error)
+			}
+		})
+	}
+}
+
+func TestHTTPCacheExprFinalize(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidHTTPCacheDSL)
+	svc := root.API.HTTP.Service("ValidHTTPCacheService")
+
+	show := svc.Endpoint("Show")
+	require.NotNil(t, show.Cache)
+	assert.Equal(t, []string{"private", "max-age=60"}, show.Cache.Directives)
+	assert.Empty(t, show.Cache.IfMatch())
+	assert.Nil(t, show.MethodExpr.Error(goa.PreconditionFailed))
+
+	update := svc.Endpoint("Update")
+	assert.Equal(t, "version", update.Cache.IfMatch())
+	name, ok := update.Headers.FindKey("version")
+	assert.True(t, ok)
+	assert.Equal(t, "If-Match", name)
+	assert.True(t, update.Headers.IsRequired("version"))
+	require.NotNil(t, update.MethodExpr.Error(goa.PreconditionFailed))
+	require.Len(t, update.HTTPErrors, 1)
+	assert.Equal(t, expr.StatusPreconditionFailed, update.HTTPErrors[0].Response.StatusCode)
+
+	mapped := svc.Endpoint("Mapped")
+	name, ok = mapped.Headers.FindKey("version")
+	assert.True(t, ok)
+	assert.Equal(t, "X-Version", name)
+	require.Len(t, mapped.HTTPErrors, 1)
+	assert.Equal(t, expr.StatusConflict, mapped.HTTPErrors[0].Response.StatusCode)
+}
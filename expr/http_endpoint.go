@@ -7,6 +7,7 @@ import (
 
 	"github.com/dimfeld/httppath"
 	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -66,6 +67,12 @@ type (
 		MultipartRequest bool
 		// Redirect defines a redirect for the endpoint.
 		Redirect *HTTPRedirectExpr
+		// SSE defines the Server-Sent Events stream used to send the
+		// method results if any.
+		SSE *HTTPSSEExpr
+		// Cache defines the Cache-Control directives and the cache
+		// validators of the endpoint responses if any.
+		Cache *HTTPCacheExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -274,6 +281,11 @@ func (e *HTTPEndpointExpr) Prepare() {
 		e.Responses = []*HTTPResponseExpr{{StatusCode: status}}
 	}
 
+	// Add the precondition failed error used by optimistic concurrency.
+	if e.Cache != nil {
+		e.Cache.prepare()
+	}
+
 	// Error -> ResponseError
 	methodErrors := map[string]struct{}{}
 	for _, v := range e.HTTPErrors {
@@ -325,6 +337,9 @@ func (e *HTTPEndpointExpr) Prepare() {
 		}
 	}
 
+	// Map the security errors to their default status codes.
+	e.prepareAuthErrors()
+
 	// Prepare responses
 	for _, r := range e.Responses {
 		r.Prepare()
@@ -334,6 +349,37 @@ func (e *HTTPEndpointExpr) Prepare() {
 	}
 }
 
+// prepareAuthErrors adds the 401 and 403 responses of the "unauthenticated"
+// and "insufficient_scope" errors of the method if the design enables them
+// with AuthErrors and does not map them already.
+func (e *HTTPEndpointExpr) prepareAuthErrors() {
+	if !e.MethodExpr.HasAuthErrors() {
+		return
+	}
+	errs := []struct {
+		name   string
+		status int
+	}{
+		{goa.Unauthenticated, StatusUnauthorized},
+		{goa.InsufficientScope, StatusForbidden},
+	}
+loop:
+	for _, er := range errs {
+		if !hasError(e.MethodExpr.Errors, er.name) {
+			continue
+		}
+		for _, he := range e.HTTPErrors {
+			if he.Name == er.name {
+				continue loop
+			}
+		}
+		e.HTTPErrors = append(e.HTTPErrors, &HTTPErrorExpr{
+			Name:     er.name,
+			Response: &HTTPResponseExpr{StatusCode: er.status, Parent: e},
+		})
+	}
+}
+
 // Validate validates the endpoint expression.
 func (e *HTTPEndpointExpr) Validate() error {
 	verr := new(eval.ValidationErrors)
@@ -377,6 +423,15 @@ func (e *HTTPEndpointExpr) Validate() error {
 		}
 	}
 
+	// Server-sent events require a server streaming method.
+	if e.SSE != nil {
+		verr.Merge(e.SSE.Validate())
+	}
+
+	if e.Cache != nil {
+		verr.Merge(e.Cache.Validate())
+	}
+
 	// Redirect is not compatible with Response.
 	if e.Redirect != nil {
 		found := false
@@ -666,7 +721,7 @@ func (e *HTTPEndpointExpr) Finalize() {
 			for _, sch := range dupReq.Schemes {
 				var field string
 				switch sch.Kind {
-				case NoKind:
+				case NoKind, MutualTLSKind:
 					continue
 				case BasicAuthKind:
 					sch.In = "header"
@@ -699,6 +754,11 @@ func (e *HTTPEndpointExpr) Finalize() {
 		}
 	}
 
+	// Map the entity tag payload attribute to the If-Match header.
+	if e.Cache != nil {
+		e.Cache.finalize()
+	}
+
 	// Initialize the HTTP specific attributes with the corresponding
 	// payload attributes.
 	initAttr(e.Params, e.MethodExpr.Payload)
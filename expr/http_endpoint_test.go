@@ -174,6 +174,20 @@ service "Service" HTTP endpoint "Method": HTTP endpoint response body must be em
 			DSL:   testdata.EndpointPayloadMissingRequired,
 			Error: `service "Service" HTTP endpoint "Method": The following HTTP request body attribute is required but the corresponding method payload attribute is not: nonreq. Use 'Required' to make the attribute required in the method payload as well.`,
 		},
+		"endpoint-server-sent-events": {
+			DSL: testdata.EndpointServerSentEvents,
+		},
+		"endpoint-server-sent-events-payload-streaming": {
+			DSL:   testdata.EndpointServerSentEventsPayloadStreaming,
+			Error: `service "Service" HTTP endpoint "Method" server-sent events: Server-sent events can only be used by methods that define a StreamingResult and no StreamingPayload.`,
+		},
+		"endpoint-server-sent-events-invalid-fields": {
+			DSL: testdata.EndpointServerSentEventsInvalidFields,
+			Error: `service "Service" HTTP endpoint "Method" server-sent events: event ID attribute "id" must be a string.
+service "Service" HTTP endpoint "Method" server-sent events: event type attribute "kind" is not an attribute of the method result type.
+service "Service" HTTP endpoint "Method" server-sent events: event retry attribute "retry" must be an integer.
+HTTP response of service "Service" HTTP endpoint "Method": Server-sent events responses cannot define headers or cookies.`,
+		},
 		"streaming-endpoint-has-request-body": {
 			DSL: testdata.StreamingEndpointRequestBody,
 			Error: `service "Service" HTTP endpoint "MethodA": HTTP endpoint request body must be empty when the endpoint uses streaming. Payload attributes must be mapped to headers and/or params.
@@ -40,6 +40,9 @@ type (
 		HTTPErrors []*HTTPErrorExpr
 		// FileServers is the list of static asset serving endpoints
 		FileServers []*HTTPFileServerExpr
+		// ProblemDetails indicates whether the service error responses
+		// use the RFC 9457 problem details format.
+		ProblemDetails bool
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -66,6 +69,16 @@ func (svc *HTTPServiceExpr) Error(name string) *ErrorExpr {
 	return Root.Error(name)
 }
 
+// UsesProblemDetails returns true if the service error responses use the RFC
+// 9457 problem details format, either because the service or the API design
+// says so.
+func (svc *HTTPServiceExpr) UsesProblemDetails() bool {
+	if svc.ProblemDetails {
+		return true
+	}
+	return Root.API != nil && Root.API.HTTP != nil && Root.API.HTTP.ProblemDetails
+}
+
 // Endpoint returns the service endpoint with the given name or nil if there
 // isn't one.
 func (svc *HTTPServiceExpr) Endpoint(name string) *HTTPEndpointExpr {
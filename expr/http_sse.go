@@ -0,0 +1,79 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPSSEExpr describes an endpoint that streams its results to the
+	// client using Server-Sent Events (text/event-stream) instead of a
+	// websocket connection.
+	HTTPSSEExpr struct {
+		// IDField is the name of the result attribute used to set the
+		// event "id" field if any.
+		IDField string
+		// EventField is the name of the result attribute used to set the
+		// event "event" field if any.
+		EventField string
+		// RetryField is the name of the result attribute used to set the
+		// event "retry" field if any.
+		RetryField string
+		// Parent is the HTTP endpoint expression.
+		Parent *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (s *HTTPSSEExpr) EvalName() string {
+	suffix := "server-sent events"
+	var prefix string
+	if s.Parent != nil {
+		prefix = s.Parent.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the fields mapped to the event id, type and retry exist
+// in the method result and have compatible types.
+func (s *HTTPSSEExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	e := s.Parent
+	m := e.MethodExpr
+	if m.Stream != ServerStreamKind {
+		verr.Add(s, "Server-sent events can only be used by methods that define a StreamingResult and no StreamingPayload.")
+		return verr
+	}
+	validateField := func(name, field, kind string, types ...DataType) {
+		if field == "" {
+			return
+		}
+		obj := AsObject(m.Result.Type)
+		if obj == nil {
+			verr.Add(s, "%s %q is defined but the method result type is not an object.", name, field)
+			return
+		}
+		att := obj.Attribute(field)
+		if att == nil {
+			verr.Add(s, "%s %q is not an attribute of the method result type.", name, field)
+			return
+		}
+		for _, t := range types {
+			if att.Type == t {
+				return
+			}
+		}
+		verr.Add(s, "%s %q must be %s.", name, field, kind)
+	}
+	validateField("event ID attribute", s.IDField, "a string", String)
+	validateField("event type attribute", s.EventField, "a string", String)
+	validateField("event retry attribute", s.RetryField, "an integer", Int, Int32, Int64, UInt, UInt32, UInt64)
+	for _, r := range e.Responses {
+		if r.StatusCode >= 400 {
+			continue
+		}
+		if !r.Headers.IsEmpty() || !r.Cookies.IsEmpty() {
+			verr.Add(r, "Server-sent events responses cannot define headers or cookies.")
+		}
+	}
+	return verr
+}
@@ -3,8 +3,10 @@ package expr
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -32,6 +34,21 @@ type (
 		// schemes. Incoming requests must validate at least one
 		// requirement to be authorized.
 		Requirements []*SecurityExpr
+		// AuthErrors is true if the method returns the
+		// "unauthenticated" and "insufficient_scope" errors when the
+		// request does not satisfy its security requirements, see
+		// HasAuthErrors.
+		AuthErrors bool
+		// RateLimit is the rate limit applied to the method if any.
+		RateLimit *RateLimitExpr
+		// Retry is the retry policy used by the method clients if any.
+		Retry *RetryExpr
+		// Pagination describes how the method returns pages of items if
+		// the method is paginated.
+		Pagination *PaginationExpr
+		// FieldSelection describes the payload attribute used to select
+		// the result fields if the method supports field selection.
+		FieldSelection *FieldSelectionExpr
 		// ClientInterceptors is the list of client interceptors.
 		ClientInterceptors []*InterceptorExpr
 		// ServerInterceptors is the list of server interceptors.
@@ -89,7 +106,9 @@ func (m *MethodExpr) EvalName() string {
 
 // Prepare makes sure the payload and result types are initialized (to the Empty
 // type if nil) and merges the method interceptors with the API and service level
-// interceptors.
+// interceptors. It also adds the errors returned by the generated code when
+// the request does not satisfy the method security requirements if the design
+// enables them with AuthErrors.
 func (m *MethodExpr) Prepare() {
 	if m.Payload == nil {
 		m.Payload = &AttributeExpr{Type: Empty}
@@ -100,6 +119,67 @@ func (m *MethodExpr) Prepare() {
 	if m.Result == nil {
 		m.Result = &AttributeExpr{Type: Empty}
 	}
+	m.prepareAuthErrors()
+}
+
+// HasAuthErrors returns true if the method, its service or the API enables
+// the "unauthenticated" and "insufficient_scope" errors with the AuthErrors
+// DSL.
+func (m *MethodExpr) HasAuthErrors() bool {
+	if m.AuthErrors || m.Service != nil && m.Service.AuthErrors {
+		return true
+	}
+	return Root.API != nil && Root.API.AuthErrors
+}
+
+// prepareAuthErrors adds the "unauthenticated" error to the secured methods
+// and the "insufficient_scope" error to the methods whose security
+// requirements list scopes if the design enables them, see HasAuthErrors.
+// The errors are not added if the method or its service already define errors
+// with the same names. Errors with the same names defined at the API level are
+// used if any.
+func (m *MethodExpr) prepareAuthErrors() {
+	if !m.HasAuthErrors() {
+		return
+	}
+	reqs := m.Requirements
+	if len(reqs) == 0 && m.Service != nil {
+		reqs = m.Service.Requirements
+	}
+	if len(reqs) == 0 && Root.API != nil {
+		reqs = Root.API.Requirements
+	}
+	var scopes bool
+	for _, r := range reqs {
+		for _, s := range r.Schemes {
+			if s.Kind == NoKind {
+				return
+			}
+		}
+		if len(r.Scopes) > 0 || len(r.SchemeScopes) > 0 {
+			scopes = true
+		}
+	}
+	if len(reqs) == 0 {
+		return
+	}
+	add := func(name, desc string) {
+		if hasError(m.Errors, name) || m.Service != nil && hasError(m.Service.Errors, name) {
+			return
+		}
+		if e := Root.Error(name); e != nil {
+			m.Errors = append(m.Errors, e)
+			return
+		}
+		m.Errors = append(m.Errors, &ErrorExpr{
+			AttributeExpr: &AttributeExpr{Type: ErrorResult, Description: desc},
+			Name:          name,
+		})
+	}
+	add(goa.Unauthenticated, "The request credentials are missing or invalid.")
+	if scopes {
+		add(goa.InsufficientScope, "The request credentials lack the required scopes.")
+	}
 }
 
 // Validate validates the method payloads, results, errors, security
@@ -112,6 +192,24 @@ func (m *MethodExpr) Validate() error {
 	verr.Merge(m.validateRequirements())
 	verr.Merge(m.validateErrors())
 	verr.Merge(m.validateInterceptors())
+	if m.RateLimit != nil {
+		verr.Merge(m.RateLimit.Validate())
+	}
+	if rl := m.EffectiveRateLimit(); rl != nil {
+		verr.Merge(rl.validateMethod(m))
+	}
+	if m.Retry != nil {
+		verr.Merge(m.Retry.Validate())
+		if m.IsStreaming() {
+			verr.Add(m, "streaming method %q of service %q cannot define a retry policy", m.Name, m.Service.Name)
+		}
+	}
+	if m.Pagination != nil {
+		verr.Merge(m.Pagination.Validate())
+	}
+	if m.FieldSelection != nil {
+		verr.Merge(m.FieldSelection.Validate())
+	}
 	return verr
 }
 
@@ -177,6 +275,36 @@ func (m *MethodExpr) validateRequirements() *eval.ValidationErrors {
 				verr.Add(m, "security scope %q not found in any of the security schemes.", scope)
 			}
 		}
+		names := make([]string, 0, len(r.SchemeScopes))
+		for name := range r.SchemeScopes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var scheme *SchemeExpr
+			for _, s := range r.Schemes {
+				if s.SchemeName == name {
+					scheme = s
+					break
+				}
+			}
+			if scheme == nil {
+				verr.Add(m, "security scopes %v refer to scheme %q which is not part of the security requirement.", r.SchemeScopes[name], name)
+				continue
+			}
+			for _, scope := range r.SchemeScopes[name] {
+				found := false
+				for _, se := range scheme.Scopes {
+					if se.Name == scope {
+						found = true
+						break
+					}
+				}
+				if !found {
+					verr.Add(m, "security scope %q not found in security scheme %q.", scope, name)
+				}
+			}
+		}
 	}
 	if !hasBasicAuth {
 		if hasTag(m.Payload, "security:username") {
@@ -355,6 +483,12 @@ func (m *MethodExpr) Finalize() {
 	for _, e := range m.Errors {
 		e.Finalize()
 	}
+	if m.Pagination != nil {
+		m.Pagination.Finalize()
+	}
+	if m.FieldSelection != nil {
+		m.FieldSelection.Finalize()
+	}
 
 	// Inherit security requirements
 	noreq := false
@@ -396,12 +530,22 @@ func (m *MethodExpr) IsResultStreaming() bool {
 	return m.Stream == ServerStreamKind || m.Stream == BidirectionalStreamKind
 }
 
+// hasError returns true if errs contains an error with the given name.
+func hasError(errs []*ErrorExpr, name string) bool {
+	for _, e := range errs {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // helper function that duplicates just enough of a security expression so that
 // its scheme names can be overridden without affecting the original.
 func copyReqs(reqs []*SecurityExpr) []*SecurityExpr {
 	reqs2 := make([]*SecurityExpr, len(reqs))
 	for i, req := range reqs {
-		req2 := &SecurityExpr{Scopes: req.Scopes}
+		req2 := &SecurityExpr{Scopes: req.Scopes, SchemeScopes: req.SchemeScopes}
 		schs := make([]*SchemeExpr, len(req.Schemes))
 		for j, sch := range req.Schemes {
 			schs[j] = &SchemeExpr{
@@ -5,9 +5,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"goa.design/goa/v3/expr"
 	"goa.design/goa/v3/expr/testdata"
+	goa "goa.design/goa/v3/pkg"
 )
 
 func TestMethodExprValidate(t *testing.T) {
@@ -17,6 +19,13 @@ func TestMethodExprValidate(t *testing.T) {
 		Error string
 	}{
 		{"valid-security-schemes-extend", testdata.ValidSecuritySchemesExtendDSL, ""},
+		{"valid-scheme-scopes", testdata.ValidSchemeScopesDSL, ""},
+		{"invalid-scheme-scopes", testdata.InvalidSchemeScopesDSL,
+			`service "InvalidSchemeScopesService" method "SecureMethod": security scope "not:found" not found in security scheme "jwt".`,
+		},
+		{"unknown-scheme-scope", testdata.UnknownSchemeScopeDSL,
+			`[testdata/method_validate_dsls.go:110] scope "api:read" refers to scheme "api_key" which is not part of the security requirement in Securityscheme jwt`,
+		},
 		{"invalid-security-schemes", testdata.InvalidSecuritySchemesDSL,
 			`service "InvalidSecuritySchemesService" method "SecureMethod": payload of method "SecureMethod" of service "InvalidSecuritySchemesService" does not define a username attribute, use Username to define one
 service "InvalidSecuritySchemesService" method "SecureMethod": payload of method "SecureMethod" of service "InvalidSecuritySchemesService" does not define a password attribute, use Password to define one
@@ -104,6 +113,68 @@ func TestMethodExprError(t *testing.T) {
 	}
 }
 
+func TestMethodExprAuthErrors(t *testing.T) {
+	root := expr.RunDSL(t, testdata.AuthErrorsDSL)
+	svc := root.API.HTTP.Service("AuthErrorsService")
+	statuses := func(e *expr.HTTPEndpointExpr) map[string]int {
+		res := make(map[string]int)
+		for _, he := range e.HTTPErrors {
+			res[he.Name] = he.Response.StatusCode
+		}
+		return res
+	}
+
+	scoped := svc.Endpoint("Scoped")
+	require.NotNil(t, scoped.MethodExpr.Error(goa.Unauthenticated))
+	require.NotNil(t, scoped.MethodExpr.Error(goa.InsufficientScope))
+	assert.Equal(t, map[string]int{goa.Unauthenticated: expr.StatusUnauthorized, goa.InsufficientScope: expr.StatusForbidden}, statuses(scoped))
+	grpcErrs := make(map[string]int)
+	for _, ge := range root.API.GRPC.Service("AuthErrorsService").Endpoint("Scoped").GRPCErrors {
+		grpcErrs[ge.Name] = ge.Response.StatusCode
+	}
+	assert.Equal(t, map[string]int{goa.Unauthenticated: 16, goa.InsufficientScope: 7}, grpcErrs)
+
+	inherited := svc.Endpoint("Inherited")
+	require.NotNil(t, inherited.MethodExpr.Error(goa.Unauthenticated))
+	assert.Nil(t, inherited.MethodExpr.Error(goa.InsufficientScope))
+	assert.Equal(t, map[string]int{goa.Unauthenticated: expr.StatusUnauthorized}, statuses(inherited))
+
+	mapped := svc.Endpoint("Mapped")
+	require.NotNil(t, mapped.MethodExpr.Error(goa.Unauthenticated))
+	assert.Equal(t, map[string]int{goa.Unauthenticated: expr.StatusNotFound}, statuses(mapped))
+
+	unsecured := svc.Endpoint("Unsecured")
+	assert.Nil(t, unsecured.MethodExpr.Error(goa.Unauthenticated))
+	assert.Empty(t, unsecured.HTTPErrors)
+
+	noAuthErrs := root.API.HTTP.Service("NoAuthErrorsService")
+	disabled := noAuthErrs.Endpoint("Scoped")
+	assert.Nil(t, disabled.MethodExpr.Error(goa.Unauthenticated))
+	assert.Nil(t, disabled.MethodExpr.Error(goa.InsufficientScope))
+	assert.Empty(t, disabled.HTTPErrors)
+	assert.Empty(t, root.API.GRPC.Service("NoAuthErrorsService").Endpoint("Scoped").GRPCErrors)
+
+	enabled := noAuthErrs.Endpoint("Enabled")
+	require.NotNil(t, enabled.MethodExpr.Error(goa.Unauthenticated))
+	assert.Equal(t, map[string]int{goa.Unauthenticated: expr.StatusUnauthorized}, statuses(enabled))
+}
+
+func TestMethodExprPrepareAuthErrorsNoAPI(t *testing.T) {
+	api := expr.Root.API
+	defer func() { expr.Root.API = api }()
+	expr.Root.API = nil
+	scheme := &expr.SchemeExpr{Kind: expr.JWTKind, SchemeName: "jwt"}
+	secured := &expr.MethodExpr{Name: "secured", AuthErrors: true, Requirements: []*expr.SecurityExpr{{Schemes: []*expr.SchemeExpr{scheme}}}}
+	unsecured := &expr.MethodExpr{Name: "unsecured", AuthErrors: true}
+
+	require.NotPanics(t, secured.Prepare)
+	require.NotPanics(t, unsecured.Prepare)
+
+	require.Len(t, secured.Errors, 1)
+	assert.Equal(t, goa.Unauthenticated, secured.Errors[0].Name)
+	assert.Empty(t, unsecured.Errors)
+}
+
 func TestMethodExprEvalName(t *testing.T) {
 	cases := map[string]struct {
 		name     string
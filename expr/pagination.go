@@ -0,0 +1,169 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// PaginationKind is the type used to enumerate the pagination styles.
+	PaginationKind int
+
+	// PaginationExpr describes how a method returns the items of a
+	// collection one page at a time.
+	PaginationExpr struct {
+		// Kind is the pagination style.
+		Kind PaginationKind
+		// Items is the name of the result attribute that lists the
+		// items of a page.
+		Items string
+		// Token is the name of the payload attribute that holds the
+		// token of the page to retrieve (cursor pagination).
+		Token string
+		// NextToken is the name of the result attribute that holds the
+		// token of the next page (cursor pagination).
+		NextToken string
+		// Offset is the name of the payload attribute that holds the
+		// index of the first item to retrieve (offset pagination).
+		Offset string
+		// PageSize is the name of the payload attribute that holds the
+		// maximum number of items in a page if any.
+		PageSize string
+		// MaxPageSize is the maximum value of the page size attribute,
+		// 0 if there is no maximum.
+		MaxPageSize int
+		// Method is the paginated method.
+		Method *MethodExpr
+	}
+)
+
+const (
+	// CursorPaginationKind identifies pagination using opaque tokens
+	// returned in each page.
+	CursorPaginationKind PaginationKind = iota + 1
+	// OffsetPaginationKind identifies pagination using the index of the
+	// first item of the page.
+	OffsetPaginationKind
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (p *PaginationExpr) EvalName() string {
+	suffix := "pagination"
+	var prefix string
+	if p.Method != nil {
+		prefix = p.Method.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the pagination attributes are defined in the method
+// payload and result with the proper types.
+func (p *PaginationExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	m := p.Method
+	if m.IsStreaming() {
+		verr.Add(p, "streaming methods cannot be paginated")
+		return verr
+	}
+	if p.Kind == 0 {
+		verr.Add(p, "pagination must define a cursor with PageCursor or an offset with PageOffset")
+	}
+	if p.Items == "" {
+		verr.Add(p, "pagination must define the items attribute with PageItems")
+	} else if err := p.checkAttribute(m.Result, "result", p.Items, "an array", IsArray); err != "" {
+		verr.Add(p, "%s", err)
+	}
+	switch p.Kind {
+	case CursorPaginationKind:
+		if err := p.checkAttribute(m.Payload, "payload", p.Token, "a string", isString); err != "" {
+			verr.Add(p, "%s", err)
+		}
+		if err := p.checkAttribute(m.Result, "result", p.NextToken, "a string", isString); err != "" {
+			verr.Add(p, "%s", err)
+		}
+	case OffsetPaginationKind:
+		if err := p.checkAttribute(m.Payload, "payload", p.Offset, "an integer", isInteger); err != "" {
+			verr.Add(p, "%s", err)
+		}
+	}
+	if p.PageSize != "" {
+		if err := p.checkAttribute(m.Payload, "payload", p.PageSize, "an integer", isInteger); err != "" {
+			verr.Add(p, "%s", err)
+		}
+	}
+	if p.MaxPageSize < 0 {
+		verr.Add(p, "maximum page size cannot be negative, got %d", p.MaxPageSize)
+	}
+	return verr
+}
+
+// Finalize adds the page size validations and documents the pagination
+// attributes that do not have a description.
+func (p *PaginationExpr) Finalize() {
+	m := p.Method
+	describe := func(att *AttributeExpr, desc string) {
+		if att != nil && att.Description == "" {
+			att.Description = desc
+		}
+	}
+	switch p.Kind {
+	case CursorPaginationKind:
+		describe(m.Payload.Find(p.Token), fmt.Sprintf("Token of the page to retrieve as returned in %q, first page if not set.", p.NextToken))
+		describe(m.Result.Find(p.NextToken), "Token of the next page, not set if this is the last page.")
+	case OffsetPaginationKind:
+		describe(m.Payload.Find(p.Offset), "Index of the first item to retrieve.")
+	}
+	if p.PageSize == "" {
+		return
+	}
+	att := m.Payload.Find(p.PageSize)
+	if att == nil {
+		return
+	}
+	describe(att, "Maximum number of items to retrieve.")
+	if att.Validation == nil {
+		att.Validation = &ValidationExpr{}
+	}
+	if att.Validation.Minimum == nil && att.Validation.ExclusiveMinimum == nil {
+		minimum := float64(1)
+		att.Validation.Minimum = &minimum
+	}
+	if p.MaxPageSize > 0 && att.Validation.Maximum == nil && att.Validation.ExclusiveMaximum == nil {
+		maximum := float64(p.MaxPageSize)
+		att.Validation.Maximum = &maximum
+	}
+}
+
+// checkAttribute returns an error message if the attribute with the given
+// name is not defined in parent or if its type does not satisfy check.
+func (p *PaginationExpr) checkAttribute(parent *AttributeExpr, kind, name, typ string, check func(DataType) bool) string {
+	if name == "" {
+		return fmt.Sprintf("%s attribute name cannot be empty", kind)
+	}
+	if !IsObject(parent.Type) {
+		return fmt.Sprintf("%s must be an object to define attribute %q", kind, name)
+	}
+	att := parent.Find(name)
+	if att == nil {
+		return fmt.Sprintf("%s does not define attribute %q", kind, name)
+	}
+	if !check(att.Type) {
+		return fmt.Sprintf("%s attribute %q must be %s, got %s", kind, name, typ, att.Type.Name())
+	}
+	return ""
+}
+
+// isString returns true if dt is a string.
+func isString(dt DataType) bool {
+	return dt.Kind() == StringKind
+}
+
+// isInteger returns true if dt is a signed or unsigned integer.
+func isInteger(dt DataType) bool {
+	switch dt.Kind() {
+	case IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind:
+		return true
+	}
+	return false
+}
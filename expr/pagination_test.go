@@ -0,0 +1,59 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestPaginationExprValidate(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidPaginationDSL, ""},
+		{"invalid", testdata.InvalidPaginationDSL,
+			`service "InvalidPaginationService" method "NoCursor" pagination: pagination must define a cursor with PageCursor or an offset with PageOffset
+service "InvalidPaginationService" method "NoCursor" pagination: result attribute "items" must be an array, got string
+service "InvalidPaginationService" method "NoCursor" pagination: payload attribute "page_size" must be an integer, got string
+service "InvalidPaginationService" method "NoCursor" pagination: maximum page size cannot be negative, got -1
+service "InvalidPaginationService" method "Missing" pagination: payload attribute "page_token" must be a string, got int
+service "InvalidPaginationService" method "Missing" pagination: result does not define attribute "next_page_token"
+service "InvalidPaginationService" method "Streaming" pagination: streaming methods cannot be paginated`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Error == "" {
+				expr.RunDSL(t, tc.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, tc.DSL)
+				assert.EqualError(t, err, tc.Error)
+			}
+		})
+	}
+}
+
+func TestPaginationExprFinalize(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidPaginationDSL)
+	svc := root.Service("ValidPaginationService")
+
+	size := svc.Method("Cursor").Payload.Find("page_size")
+	require.NotNil(t, size.Validation)
+	require.NotNil(t, size.Validation.Minimum)
+	require.NotNil(t, size.Validation.Maximum)
+	assert.Equal(t, float64(1), *size.Validation.Minimum)
+	assert.Equal(t, float64(100), *size.Validation.Maximum)
+	assert.NotEmpty(t, svc.Method("Cursor").Payload.Find("page_token").Description)
+	assert.NotEmpty(t, svc.Method("Cursor").Result.Find("next_page_token").Description)
+
+	limit := svc.Method("Offset").Payload.Find("limit")
+	require.NotNil(t, limit.Validation)
+	assert.Equal(t, float64(0), *limit.Validation.Minimum)
+	assert.Nil(t, limit.Validation.Maximum)
+}
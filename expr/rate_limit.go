@@ -0,0 +1,127 @@
+package expr
+
+import (
+	"fmt"
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+const (
+	// RateLimitKeyClientIP is the rate limit key used to limit requests
+	// per client IP address.
+	RateLimitKeyClientIP = "$client_ip"
+	// RateLimitKeyPrincipal is the rate limit key used to limit requests
+	// per security principal once the request is authenticated, see
+	// ratelimit.EnforcePrincipal for how the principal is identified.
+	// Requests that fail authentication are limited per client IP
+	// address.
+	RateLimitKeyPrincipal = "$principal"
+)
+
+type (
+	// RateLimitExpr describes a rate limit applied to the methods of an
+	// API, a service or to a single method.
+	RateLimitExpr struct {
+		// Requests is the maximum number of requests allowed during
+		// Period.
+		Requests int
+		// Period is the duration of the rate limit window.
+		Period time.Duration
+		// Key identifies the requests that share the same quota. It is
+		// either the name of a payload attribute, RateLimitKeyClientIP,
+		// RateLimitKeyPrincipal or empty in which case the quota is
+		// shared by all requests.
+		Key string
+		// Parent is the API, service or method expression that defines
+		// the rate limit.
+		Parent eval.Expression
+	}
+)
+
+// EffectiveRateLimit returns the rate limit that applies to the method: the
+// method rate limit if any, the service rate limit otherwise and the API rate
+// limit as a last resort. It returns nil if no rate limit applies.
+func (m *MethodExpr) EffectiveRateLimit() *RateLimitExpr {
+	if m.RateLimit != nil {
+		return m.RateLimit
+	}
+	if m.Service != nil && m.Service.RateLimit != nil {
+		return m.Service.RateLimit
+	}
+	if Root.API != nil {
+		return Root.API.RateLimit
+	}
+	return nil
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (r *RateLimitExpr) EvalName() string {
+	suffix := "rate limit"
+	var prefix string
+	if r.Parent != nil {
+		prefix = r.Parent.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Scope returns the name of the expression that defines the rate limit. All
+// the methods that share the same scope and key share the same quota.
+func (r *RateLimitExpr) Scope() string {
+	switch p := r.Parent.(type) {
+	case *APIExpr:
+		return p.Name
+	case *ServiceExpr:
+		return p.Name
+	case *MethodExpr:
+		return fmt.Sprintf("%s.%s", p.Service.Name, p.Name)
+	}
+	return ""
+}
+
+// Validate makes sure the rate limit quota is valid.
+func (r *RateLimitExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if r.Requests <= 0 {
+		verr.Add(r, "number of requests must be greater than 0, got %d", r.Requests)
+	}
+	if r.Period <= 0 {
+		verr.Add(r, "period must be greater than 0, got %s", r.Period)
+	}
+	return verr
+}
+
+// validateMethod makes sure the rate limit key can be computed for the given
+// method.
+func (r *RateLimitExpr) validateMethod(m *MethodExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	switch r.Key {
+	case "", RateLimitKeyClientIP:
+	case RateLimitKeyPrincipal:
+		reqs := m.Requirements
+		if len(reqs) == 0 && m.Service != nil {
+			reqs = m.Service.Requirements
+		}
+		if len(reqs) == 0 && Root.API != nil {
+			reqs = Root.API.Requirements
+		}
+		if len(reqs) == 0 || len(reqs[0].Schemes) == 0 || reqs[0].Schemes[0].Kind == NoKind {
+			verr.Add(m, "rate limit key is the security principal but method %q of service %q is not secured", m.Name, m.Service.Name)
+		}
+	default:
+		obj := AsObject(m.Payload.Type)
+		if obj == nil {
+			verr.Add(m, "rate limit key %q is not an attribute of the payload of method %q of service %q", r.Key, m.Name, m.Service.Name)
+			break
+		}
+		att := obj.Attribute(r.Key)
+		if att == nil {
+			verr.Add(m, "rate limit key %q is not an attribute of the payload of method %q of service %q", r.Key, m.Name, m.Service.Name)
+			break
+		}
+		if _, ok := att.Type.(Primitive); !ok || att.Type == Bytes || att.Type == Any {
+			verr.Add(m, "rate limit key %q of method %q of service %q must be a primitive attribute other than bytes or any", r.Key, m.Name, m.Service.Name)
+		}
+	}
+	return verr
+}
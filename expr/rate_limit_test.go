@@ -0,0 +1,37 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestRateLimitExprValidate(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidRateLimitDSL, ""},
+		{"invalid", testdata.InvalidRateLimitDSL,
+			`service "InvalidRateLimitService" rate limit: number of requests must be greater than 0, got 0
+service "InvalidRateLimitService" rate limit: period must be greater than 0, got 0s
+service "InvalidRateLimitService" method "MissingAttribute": rate limit key "tenant" is not an attribute of the payload of method "MissingAttribute" of service "InvalidRateLimitService"
+service "InvalidRateLimitService" method "InvalidAttribute": rate limit key "tenant" of method "InvalidAttribute" of service "InvalidRateLimitService" must be a primitive attribute other than bytes or any
+service "InvalidRateLimitService" method "NotSecured": rate limit key is the security principal but method "NotSecured" of service "InvalidRateLimitService" is not secured`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Error == "" {
+				expr.RunDSL(t, tc.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, tc.DSL)
+				assert.EqualError(t, err, tc.Error)
+			}
+		})
+	}
+}
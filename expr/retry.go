@@ -0,0 +1,72 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// RetryExpr describes the policy used by the generated clients to retry
+	// requests that fail with temporary errors.
+	RetryExpr struct {
+		// MaxAttempts is the maximum number of attempts including the
+		// initial request.
+		MaxAttempts int
+		// InitialBackoff is the delay before the first retry.
+		InitialBackoff time.Duration
+		// MaxBackoff caps the delay between two attempts.
+		MaxBackoff time.Duration
+		// Idempotent is true if the requests may be retried after a
+		// timeout, that is when the server may have processed them.
+		Idempotent bool
+		// Parent is the API, service or method expression that defines
+		// the retry policy.
+		Parent eval.Expression
+	}
+)
+
+// EffectiveRetry returns the retry policy that applies to the method: the
+// method policy if any, the service policy otherwise and the API policy as a
+// last resort. It returns nil if no policy applies or if the method is
+// streaming as streams cannot be replayed.
+func (m *MethodExpr) EffectiveRetry() *RetryExpr {
+	if m.IsStreaming() {
+		return nil
+	}
+	if m.Retry != nil {
+		return m.Retry
+	}
+	if m.Service != nil && m.Service.Retry != nil {
+		return m.Service.Retry
+	}
+	if Root.API != nil {
+		return Root.API.Retry
+	}
+	return nil
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (r *RetryExpr) EvalName() string {
+	suffix := "retry policy"
+	var prefix string
+	if r.Parent != nil {
+		prefix = r.Parent.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the retry policy is valid.
+func (r *RetryExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if r.MaxAttempts < 1 {
+		verr.Add(r, "maximum number of attempts must be greater than 0, got %d", r.MaxAttempts)
+	}
+	if r.InitialBackoff <= 0 {
+		verr.Add(r, "initial backoff must be greater than 0, got %s", r.InitialBackoff)
+	}
+	if r.MaxBackoff < r.InitialBackoff {
+		verr.Add(r, "maximum backoff %s must be greater than or equal to initial backoff %s", r.MaxBackoff, r.InitialBackoff)
+	}
+	return verr
+}
@@ -0,0 +1,43 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestRetryExprValidate(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidRetryDSL, ""},
+		{"invalid", testdata.InvalidRetryDSL,
+			`service "InvalidRetryService" retry policy: maximum number of attempts must be greater than 0, got 0
+service "InvalidRetryService" retry policy: maximum backoff 1ms must be greater than or equal to initial backoff 1s
+service "InvalidRetryService" method "Streaming": streaming method "Streaming" of service "InvalidRetryService" cannot define a retry policy`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Error == "" {
+				expr.RunDSL(t, tc.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, tc.DSL)
+				assert.EqualError(t, err, tc.Error)
+			}
+		})
+	}
+}
+
+func TestMethodExprEffectiveRetry(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidRetryDSL)
+	svc := root.Service("ValidRetryService")
+	assert.Equal(t, 5, svc.Method("Inherited").EffectiveRetry().MaxAttempts)
+	assert.Equal(t, 1, svc.Method("Disabled").EffectiveRetry().MaxAttempts)
+	assert.True(t, svc.Method("Idempotent").EffectiveRetry().Idempotent)
+}
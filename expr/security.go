@@ -22,6 +22,9 @@ const (
 	JWTKind
 	// NoKind means to have no security for this endpoint.
 	NoKind
+	// MutualTLSKind means a "mutualTLS" security scheme where clients
+	// authenticate with TLS certificates.
+	MutualTLSKind
 )
 
 // FlowKind is a type of OAuth2 flow.
@@ -47,6 +50,9 @@ type (
 		Schemes []*SchemeExpr
 		// Scopes list the required scopes if any.
 		Scopes []string
+		// SchemeScopes lists the scopes required by a single scheme of
+		// the requirement in addition to Scopes indexed by scheme name.
+		SchemeScopes map[string][]string
 	}
 
 	// SchemeExpr defines a security scheme used to authenticate against the
@@ -105,11 +111,24 @@ func (s *SecurityExpr) EvalName() string {
 	return "Security" + suffix
 }
 
+// RequiredScopes returns the scopes required by the requirement scheme with
+// the given name: the requirement scopes followed by the scopes specific to
+// the scheme.
+func (s *SecurityExpr) RequiredScopes(scheme string) []string {
+	if len(s.SchemeScopes[scheme]) == 0 {
+		return s.Scopes
+	}
+	scopes := make([]string, 0, len(s.Scopes)+len(s.SchemeScopes[scheme]))
+	scopes = append(scopes, s.Scopes...)
+	return append(scopes, s.SchemeScopes[scheme]...)
+}
+
 // DupRequirement creates a copy of the given security requirement.
 func DupRequirement(req *SecurityExpr) *SecurityExpr {
 	dup := &SecurityExpr{
-		Scopes:  req.Scopes,
-		Schemes: make([]*SchemeExpr, 0, len(req.Schemes)),
+		Scopes:       req.Scopes,
+		SchemeScopes: req.SchemeScopes,
+		Schemes:      make([]*SchemeExpr, 0, len(req.Schemes)),
 	}
 	for _, s := range req.Schemes {
 		dup.Schemes = append(dup.Schemes, DupScheme(s))
@@ -142,6 +161,8 @@ func (s *SchemeExpr) Type() string {
 		return "APIKey"
 	case JWTKind:
 		return "JWT"
+	case MutualTLSKind:
+		return "MutualTLS"
 	default:
 		panic(fmt.Sprintf("unknown scheme kind: %#v", s.Kind)) // bug
 	}
@@ -215,6 +236,8 @@ func (k SchemeKind) String() string {
 		return "JWT"
 	case OAuth2Kind:
 		return "OAuth2"
+	case MutualTLSKind:
+		return "MutualTLS"
 	case NoKind:
 		return "None"
 	default:
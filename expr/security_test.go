@@ -221,6 +221,10 @@ func TestSchemeExprType(t *testing.T) {
 			kind:     JWTKind,
 			expected: "JWT",
 		},
+		"mutual tls": {
+			kind:     MutualTLSKind,
+			expected: "MutualTLS",
+		},
 		"NoKind": {
 			kind:     NoKind,
 			expected: "", // should have panicked!
@@ -270,6 +274,27 @@ func TestSecurityExprEvalName(t *testing.T) {
 	}
 }
 
+func TestSecurityExprRequiredScopes(t *testing.T) {
+	se := &SecurityExpr{
+		Scopes:       []string{"api:read"},
+		SchemeScopes: map[string][]string{"jwt": {"api:write", "api:admin"}},
+	}
+	cases := map[string]struct {
+		scheme   string
+		expected []string
+	}{
+		"scheme scopes":    {scheme: "jwt", expected: []string{"api:read", "api:write", "api:admin"}},
+		"no scheme scopes": {scheme: "api_key", expected: []string{"api:read"}},
+	}
+
+	for k, tc := range cases {
+		actual := se.RequiredScopes(tc.scheme)
+		if fmt.Sprint(actual) != fmt.Sprint(tc.expected) {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
 func TestSchemeExprValidate(t *testing.T) {
 	var (
 		tokenURL         = "http://example.com/token"
@@ -370,6 +395,10 @@ func TestSchemeKindString(t *testing.T) {
 			kind:     OAuth2Kind,
 			expected: "OAuth2",
 		},
+		"mutual tls": {
+			kind:     MutualTLSKind,
+			expected: "MutualTLS",
+		},
 		"no kind": {
 			kind:     NoKind,
 			expected: "None",
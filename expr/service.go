@@ -27,6 +27,16 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// AuthErrors is true if the secured service methods return the
+		// "unauthenticated" and "insufficient_scope" errors, see
+		// MethodExpr.HasAuthErrors.
+		AuthErrors bool
+		// RateLimit is the rate limit applied to all the service methods
+		// unless overridden at the method level.
+		RateLimit *RateLimitExpr
+		// Retry is the retry policy used by the clients of all the
+		// service methods unless overridden at the method level.
+		Retry *RetryExpr
 		// ClientInterceptors is the list of client interceptors.
 		ClientInterceptors []*InterceptorExpr
 		// ServerInterceptors is the list of server interceptors.
@@ -91,6 +101,12 @@ func (s *ServiceExpr) Validate() error {
 			}
 		}
 	}
+	if s.RateLimit != nil {
+		verr.Merge(s.RateLimit.Validate())
+	}
+	if s.Retry != nil {
+		verr.Merge(s.Retry.Validate())
+	}
 	return verr
 }
 
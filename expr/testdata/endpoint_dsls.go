@@ -713,3 +713,59 @@ var GRPCEndpointWithExtendedTypes = func() {
 		})
 	})
 }
+
+var EndpointServerSentEvents = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(func() {
+				Attribute("id", String)
+				Attribute("kind", String)
+				Attribute("retry", Int32)
+				Attribute("value", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents(func() {
+					SSEEventID("id")
+					SSEEventType("kind")
+					SSEEventRetry("retry")
+				})
+			})
+		})
+	})
+}
+
+var EndpointServerSentEventsPayloadStreaming = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var EndpointServerSentEventsInvalidFields = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(func() {
+				Attribute("id", Int)
+				Attribute("retry", String)
+			})
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents(func() {
+					SSEEventID("id")
+					SSEEventType("kind")
+					SSEEventRetry("retry")
+				})
+				Response(StatusOK, func() {
+					Header("id:X-Id")
+				})
+			})
+		})
+	})
+}
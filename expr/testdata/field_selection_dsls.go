@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidFieldSelectionDSL = func() {
+	var Account = ResultType("application/vnd.account", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+		})
+	})
+	var FieldMask = Type("FieldMask", func() {
+		Attribute("paths", ArrayOf(String))
+	})
+	Service("ValidFieldSelectionService", func() {
+		Method("String", func() {
+			Payload(func() {
+				Attribute("fields", String)
+			})
+			Result(Account)
+			FieldSelection()
+		})
+		Method("Array", func() {
+			Payload(func() {
+				Attribute("select", ArrayOf(String), "Selected fields")
+			})
+			Result(CollectionOf(Account))
+			FieldSelection("select")
+		})
+		Method("Mask", func() {
+			Payload(func() {
+				Attribute("mask", FieldMask)
+			})
+			Result(Account)
+			FieldSelection("mask")
+		})
+	})
+}
+
+var InvalidFieldSelectionDSL = func() {
+	var Account = ResultType("application/vnd.account", func() {
+		Attributes(func() {
+			Attribute("id", String)
+		})
+	})
+	Service("InvalidFieldSelectionService", func() {
+		Method("NotResultType", func() {
+			Payload(func() {
+				Attribute("fields", String)
+			})
+			Result(String)
+			FieldSelection()
+		})
+		Method("Missing", func() {
+			Result(Account)
+			FieldSelection()
+		})
+		Method("InvalidType", func() {
+			Payload(func() {
+				Attribute("fields", Int)
+			})
+			Result(Account)
+			FieldSelection()
+		})
+		Method("Streaming", func() {
+			StreamingResult(Account)
+			FieldSelection()
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidHTTPCacheDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("id", String)
+		Attribute("version", String)
+		Attribute("updated_at", String, func() {
+			Format(FormatDateTime)
+		})
+		Attribute("modified", DateTime)
+	})
+	Service("ValidHTTPCacheService", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(Item)
+			HTTP(func() {
+				GET("/items/{id}")
+				Cache("private", "max-age=60")
+				ETag("version")
+				LastModified("updated_at")
+			})
+		})
+		Method("Modified", func() {
+			Result(Item)
+			HTTP(func() {
+				GET("/modified")
+				LastModified("modified")
+			})
+		})
+		Method("Update", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("version", String)
+				Required("version")
+			})
+			Result(Item)
+			HTTP(func() {
+				PUT("/items/{id}")
+				ETag("version")
+			})
+		})
+		Method("Mapped", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("version", String)
+			})
+			Result(Item)
+			Error("precondition_failed")
+			HTTP(func() {
+				PATCH("/items/{id}")
+				Header("version:X-Version")
+				ETag("version")
+				Response("precondition_failed", StatusConflict)
+			})
+		})
+	})
+}
+
+var InvalidHTTPCacheDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("version", Int)
+		Attribute("updated_at", String)
+	})
+	Service("InvalidHTTPCacheService", func() {
+		Method("Types", func() {
+			Result(Item)
+			HTTP(func() {
+				GET("/types")
+				ETag("version")
+				LastModified("updated_at")
+			})
+		})
+		Method("Missing", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(String)
+			HTTP(func() {
+				PUT("/missing/{id}")
+				ETag("version")
+			})
+		})
+		Method("Streaming", func() {
+			StreamingResult(Item)
+			HTTP(func() {
+				GET("/streaming")
+				ETag("version")
+			})
+		})
+	})
+}
@@ -71,3 +71,114 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var MutualTLS = MutualTLSSecurity("mtls")
+
+var ValidSchemeScopesDSL = func() {
+	Service("ValidSchemeScopesService", func() {
+		Method("SecureMethod", func() {
+			Security(JWTAuth, APIKeyAuth, func() {
+				Scope("api:read")
+				SchemeScope("api:admin", "jwt")
+			})
+			Security(MutualTLS)
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+			})
+		})
+	})
+}
+
+var InvalidSchemeScopesDSL = func() {
+	Service("InvalidSchemeScopesService", func() {
+		Method("SecureMethod", func() {
+			Security(JWTAuth, func() {
+				SchemeScope("not:found", "jwt") // invalid security scope
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+	})
+}
+
+var UnknownSchemeScopeDSL = func() {
+	Service("UnknownSchemeScopeService", func() {
+		Method("SecureMethod", func() {
+			Security(JWTAuth, func() {
+				SchemeScope("api:read", "api_key") // invalid: scheme not in requirement
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+	})
+}
+
+var AuthErrorsDSL = func() {
+	Service("AuthErrorsService", func() {
+		Security(JWTAuth)
+		AuthErrors()
+		Method("Scoped", func() {
+			Security(JWTAuth, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/scoped")
+			})
+			GRPC(func() {})
+		})
+		Method("Inherited", func() {
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/inherited")
+			})
+		})
+		Method("Mapped", func() {
+			Payload(func() {
+				Token("token", String)
+			})
+			Error("unauthenticated", String)
+			HTTP(func() {
+				GET("/mapped")
+				Response("unauthenticated", StatusNotFound)
+			})
+		})
+		Method("Unsecured", func() {
+			NoSecurity()
+			HTTP(func() {
+				GET("/unsecured")
+			})
+		})
+	})
+	Service("NoAuthErrorsService", func() {
+		Security(JWTAuth)
+		Method("Scoped", func() {
+			Security(JWTAuth, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/scoped")
+			})
+			GRPC(func() {})
+		})
+		Method("Enabled", func() {
+			AuthErrors()
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/enabled")
+			})
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidPaginationDSL = func() {
+	Service("ValidPaginationService", func() {
+		Method("Cursor", func() {
+			Payload(func() {
+				Attribute("page_token", String)
+				Attribute("page_size", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next_page_token", String)
+			})
+			Paginated(func() {
+				PageItems("items")
+				PageCursor("page_token", "next_page_token")
+				PageSize("page_size", 100)
+			})
+		})
+		Method("Offset", func() {
+			Payload(func() {
+				Attribute("offset", Int64)
+				Attribute("limit", Int, func() {
+					Minimum(0)
+				})
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated(func() {
+				PageItems("items")
+				PageOffset("offset")
+				PageSize("limit")
+			})
+		})
+	})
+}
+
+var InvalidPaginationDSL = func() {
+	Service("InvalidPaginationService", func() {
+		Method("NoCursor", func() {
+			Payload(func() {
+				Attribute("page_size", String)
+			})
+			Result(func() {
+				Attribute("items", String)
+			})
+			Paginated(func() {
+				PageItems("items")
+				PageSize("page_size", -1)
+			})
+		})
+		Method("Missing", func() {
+			Payload(func() {
+				Attribute("page_token", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated(func() {
+				PageItems("items")
+				PageCursor("page_token", "next_page_token")
+			})
+		})
+		Method("Streaming", func() {
+			StreamingResult(String)
+			Paginated(func() {
+				PageItems("items")
+				PageOffset("offset")
+			})
+		})
+	})
+}
@@ -0,0 +1,48 @@
+package testdata
+
+import (
+	"time"
+
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidRateLimitDSL = func() {
+	API("ValidRateLimit", func() {
+		RateLimit(1000, time.Hour)
+	})
+	Service("ValidRateLimitService", func() {
+		RateLimit(100, time.Minute, RateLimitByClientIP)
+		Method("Inherited", func() {})
+		Method("Attribute", func() {
+			RateLimit(10, time.Second, "tenant")
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+		})
+		Method("Principal", func() {
+			Security(APIKeyAuth)
+			RateLimit(10, time.Second, RateLimitByPrincipal)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+		})
+	})
+}
+
+var InvalidRateLimitDSL = func() {
+	Service("InvalidRateLimitService", func() {
+		RateLimit(0, 0)
+		Method("MissingAttribute", func() {
+			RateLimit(10, time.Second, "tenant")
+		})
+		Method("InvalidAttribute", func() {
+			RateLimit(10, time.Second, "tenant")
+			Payload(func() {
+				Attribute("tenant", ArrayOf(String))
+			})
+		})
+		Method("NotSecured", func() {
+			RateLimit(10, time.Second, RateLimitByPrincipal)
+		})
+	})
+}
@@ -0,0 +1,39 @@
+package testdata
+
+import (
+	"time"
+
+	. "goa.design/goa/v3/dsl"
+)
+
+var ValidRetryDSL = func() {
+	API("ValidRetry", func() {
+		Retry(3)
+	})
+	Service("ValidRetryService", func() {
+		Retry(5, func() {
+			Backoff(time.Millisecond, time.Second)
+		})
+		Method("Inherited", func() {})
+		Method("Disabled", func() {
+			Retry(1)
+		})
+		Method("Idempotent", func() {
+			Retry(2, func() {
+				Idempotent()
+			})
+		})
+	})
+}
+
+var InvalidRetryDSL = func() {
+	Service("InvalidRetryService", func() {
+		Retry(0, func() {
+			Backoff(time.Second, time.Millisecond)
+		})
+		Method("Streaming", func() {
+			Retry(3)
+			StreamingResult(String)
+		})
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
 	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -27,7 +29,8 @@ type (
 		Hash() string
 	}
 
-	// Primitive is the type for null, boolean, integer, number, string, and time.
+	// Primitive is the type for null, boolean, integer, number, string, time
+	// and decimal values.
 	Primitive Kind
 
 	// Array is the type used to describe field arrays or repeated fields.
@@ -127,6 +130,12 @@ const (
 	ResultTypeKind
 	// AnyKind represents an unknown type.
 	AnyKind
+	// DateTimeKind represents a point in time.
+	DateTimeKind
+	// DurationKind represents an elapsed time.
+	DurationKind
+	// DecimalKind represents an arbitrary-precision decimal number.
+	DecimalKind
 )
 
 const (
@@ -165,6 +174,18 @@ const (
 
 	// Any is the type for an arbitrary JSON value (any in Go).
 	Any = Primitive(AnyKind)
+
+	// DateTime is the type for a point in time (time.Time in Go). DateTime
+	// values are RFC3339 date time strings, e.g. "2006-01-02T15:04:05Z".
+	DateTime = Primitive(DateTimeKind)
+
+	// Duration is the type for an elapsed time (time.Duration in Go).
+	// Duration values are integer numbers of nanoseconds.
+	Duration = Primitive(DurationKind)
+
+	// Decimal is the type for an arbitrary-precision decimal number
+	// (goa.Decimal in Go). Decimal values are decimal strings, e.g. "12.50".
+	Decimal = Primitive(DecimalKind)
 )
 
 // Built-in composite types
@@ -316,6 +337,12 @@ func (p Primitive) Name() string {
 		return "bytes"
 	case Any:
 		return "any"
+	case DateTime:
+		return "datetime"
+	case Duration:
+		return "duration"
+	case Decimal:
+		return "decimal"
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -323,8 +350,31 @@ func (p Primitive) Name() string {
 
 // IsCompatible returns true if val is compatible with p.
 func (p Primitive) IsCompatible(val any) bool {
-	if p == Any {
+	switch p {
+	case Any:
 		return true
+	case DateTime:
+		switch v := val.(type) {
+		case time.Time:
+			return true
+		case string:
+			_, err := time.Parse(time.RFC3339, v)
+			return err == nil
+		}
+		return false
+	case Duration:
+		switch val.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+			return true
+		}
+		return false
+	case Decimal:
+		v, ok := val.(string)
+		if !ok {
+			return false
+		}
+		_, err := goa.ParseDecimal(v)
+		return err == nil
 	}
 	switch val.(type) {
 	case bool:
@@ -371,6 +421,12 @@ func (p Primitive) Example(r *ExampleGenerator) any {
 		return r.String()
 	case Bytes:
 		return []byte(r.String())
+	case DateTime:
+		return time.Unix(int64(r.Int())%1454957045, 0).UTC().Format(time.RFC3339) // to obtain a "fixed" rand
+	case Duration:
+		return int64(r.Int()%3600) * int64(time.Second)
+	case Decimal:
+		return fmt.Sprintf("%d.%02d", r.Int()%1000, r.Int()%100)
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -1,6 +1,9 @@
 package expr
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestAsObject(t *testing.T) {
 	var (
@@ -687,6 +690,36 @@ func TestPrimitiveIsCompatible(t *testing.T) {
 			values:   []any{b, i, i8, i16, i32, ui, ui8, ui16, ui32, i64, ui64, f32, f64},
 			expected: false,
 		},
+		"datetime compatible": {
+			p:        DateTime,
+			values:   []any{"2006-01-02T15:04:05Z", "2006-01-02T15:04:05.999+07:00", time.Now()},
+			expected: true,
+		},
+		"datetime not compatible": {
+			p:        DateTime,
+			values:   []any{b, i, i64, f64, s, bs, "2006-01-02"},
+			expected: false,
+		},
+		"duration compatible": {
+			p:        Duration,
+			values:   []any{i, i8, i16, i32, ui, ui8, ui16, ui32, i64},
+			expected: true,
+		},
+		"duration not compatible": {
+			p:        Duration,
+			values:   []any{b, ui64, f32, f64, s, bs, "1h"},
+			expected: false,
+		},
+		"decimal compatible": {
+			p:        Decimal,
+			values:   []any{"12.50", "-1", "2.5e3"},
+			expected: true,
+		},
+		"decimal not compatible": {
+			p:        Decimal,
+			values:   []any{b, i, i64, f32, f64, s, bs},
+			expected: false,
+		},
 		"not supported types": {
 			p:        Boolean,
 			values:   []any{ss, is},
@@ -47,6 +47,7 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
 		}
 		imports = append(imports, data.Service.UserTypeImports...)
+		imports = append(imports, data.Service.ProtoImports...)
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC client", "client", imports),
 		}
@@ -71,9 +72,10 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		})
 		for _, e := range data.Endpoints {
 			sections = append(sections, &codegen.SectionTemplate{
-				Name:   "client-endpoint-init",
-				Source: readTemplate("client_endpoint_init"),
-				Data:   e,
+				Name:    "client-endpoint-init",
+				Source:  readTemplate("client_endpoint_init"),
+				Data:    e,
+				FuncMap: map[string]any{"hasCustomErrors": hasCustomErrors},
 			})
 		}
 		for _, e := range data.Endpoints {
@@ -136,6 +138,7 @@ func clientEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 			{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
 		}
 		imports = append(imports, data.Service.UserTypeImports...)
+		imports = append(imports, data.Service.ProtoImports...)
 		sections = []*codegen.SectionTemplate{codegen.Header(svc.Name()+" gRPC client encoders and decoders", "client", imports)}
 		fm := transTmplFuncs(svc)
 		fm["metadataEncodeDecodeData"] = metadataEncodeDecodeData
@@ -180,3 +183,14 @@ func isBearer(schemes []*service.SchemeData) bool {
 	}
 	return false
 }
+
+// hasCustomErrors returns true if any of the given errors uses a custom type
+// i.e. requires a conversion from its gRPC error response message.
+func hasCustomErrors(errors []*ErrorData) bool {
+	for _, e := range errors {
+		if e.Response.ClientConvert != nil {
+			return true
+		}
+	}
+	return false
+}
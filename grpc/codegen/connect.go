@@ -0,0 +1,98 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// ConnectData contains the data needed to render the code that serves
+	// the gRPC service methods using the Connect and gRPC-Web protocols.
+	ConnectData struct {
+		// Service is the gRPC service data.
+		Service *ServiceData
+		// Endpoints lists the unary and server streaming endpoints, the
+		// client and bidirectional streaming endpoints are not supported.
+		Endpoints []*ConnectEndpointData
+	}
+
+	// ConnectEndpointData contains the data needed to mount a single gRPC
+	// method on the HTTP muxer.
+	ConnectEndpointData struct {
+		*EndpointData
+		// FullMethod is the full gRPC method name, e.g. "/calc.Calc/Add".
+		FullMethod string
+		// RequestType is the name of the generated request message type.
+		RequestType string
+		// StreamStruct is the name of the struct that implements the
+		// generated server stream interface, empty for unary endpoints.
+		StreamStruct string
+	}
+)
+
+// connectEnabled returns true if the "grpc:connect" meta is set on the service
+// or if it is set on the API and not disabled on the service.
+func connectEnabled(svc *expr.GRPCServiceExpr) bool {
+	if v, ok := svc.ServiceExpr.Meta.Last("grpc:connect"); ok {
+		return v != "false"
+	}
+	if v, ok := expr.Root.API.Meta.Last("grpc:connect"); ok {
+		return v != "false"
+	}
+	return false
+}
+
+// connectFile returns the file that mounts the gRPC service methods on a goa
+// HTTP muxer using the Connect and gRPC-Web protocols.
+func connectFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
+	data := GRPCServices.Get(svc.Name())
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "grpc", svcName, "server", "connect.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "google.golang.org/grpc"},
+		{Path: "google.golang.org/protobuf/proto"},
+		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaImport("grpc/connect"),
+		{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
+	}
+	cdata := &ConnectData{Service: data}
+	protoSvc := pkgName(svc, svcName) + "." + data.Name
+	for _, e := range data.Endpoints {
+		ed := &ConnectEndpointData{
+			EndpointData: e,
+			FullMethod:   "/" + protoSvc + "/" + e.Method.VarName,
+			RequestType:  strings.TrimPrefix(e.Request.Message.Ref, "*"),
+		}
+		if e.ServerStream != nil {
+			if e.Method.StreamKind != expr.ServerStreamKind {
+				continue
+			}
+			ed.StreamStruct = e.Method.VarName + "ConnectStream"
+		}
+		cdata.Endpoints = append(cdata.Endpoints, ed)
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name()+" Connect and gRPC-Web server", "server", imports),
+		{
+			Name:   "connect-mount",
+			Source: readTemplate("connect_mount"),
+			Data:   cdata,
+		},
+	}
+	for _, e := range cdata.Endpoints {
+		if e.StreamStruct == "" {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "connect-stream",
+			Source: readTemplate("connect_stream"),
+			Data:   e,
+		})
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
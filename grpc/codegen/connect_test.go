@@ -0,0 +1,41 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestConnectFile(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		NumFiles int
+	}{
+		{"service", testdata.ConnectDSL, 3},
+		{"api", testdata.ConnectAPIDSL, 5},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			fs := ServerFiles("", expr.Root)
+			require.Len(t, fs, c.NumFiles)
+			f := fs[len(fs)-1]
+			assert.Equal(t, "connect.go", filepath.Base(f.Path))
+			var buf bytes.Buffer
+			for _, s := range f.SectionTemplates[1:] {
+				require.NoError(t, s.Write(&buf))
+			}
+			code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+			golden := filepath.Join("testdata", "connect-"+c.Name+".golden")
+			compareOrUpdateGolden(t, code, golden)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// harnessData contains the data needed to render the gRPC test harness
+	// and contract tests of a service.
+	harnessData struct {
+		// Service is the gRPC service data.
+		Service *ServiceData
+		// ServerPkg is the name of the generated gRPC server package.
+		ServerPkg string
+		// ClientPkg is the name of the generated gRPC client package.
+		ClientPkg string
+		// Register is the name of the protoc generated function that
+		// registers the server.
+		Register string
+		// Endpoints lists the Go expressions of the endpoints given to
+		// the service client constructor in method order.
+		Endpoints []string
+		// HasServerInterceptors is true if the service endpoints
+		// constructor requires server interceptors.
+		HasServerInterceptors bool
+		// HasClientInterceptors is true if the service client
+		// constructor requires client interceptors.
+		HasClientInterceptors bool
+		// Cases lists the contract test cases.
+		Cases []*grpcContractCaseData
+	}
+
+	// grpcContractCaseData contains the data needed to render a gRPC
+	// contract test case.
+	grpcContractCaseData struct {
+		*service.ContractCaseData
+		// Code is the expected status code constant.
+		Code string
+	}
+)
+
+// HarnessFiles returns the files that contain the in-process gRPC test
+// harness and the contract tests of each service. The harness serves the
+// service over an in-memory connection. The contract tests call each unary
+// method through the generated gRPC client and server using the service mock
+// and check that the status codes defined in the design are produced.
+// Contract tests are not generated for services that use interceptors since
+// the tests cannot provide the interceptor implementations.
+func HarnessFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.GRPC.Services {
+		data := GRPCServices.Get(svc.Name())
+		if len(data.Endpoints) == 0 {
+			continue
+		}
+		files = append(files, harnessFiles(genpkg, svc, data)...)
+	}
+	return files
+}
+
+// harnessFiles returns the gRPC test harness and contract test files of the
+// given service.
+func harnessFiles(genpkg string, svc *expr.GRPCServiceExpr, data *ServiceData) []*codegen.File {
+	hd := buildHarnessData(svc, data)
+	sd := data.Service
+	dir := filepath.Join(codegen.Gendir, sd.PathName, service.TestPkgName(sd))
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "errors"},
+		{Path: "net"},
+		{Path: "sync"},
+		{Path: "testing"},
+		{Path: "google.golang.org/grpc"},
+		{Path: "google.golang.org/grpc/codes"},
+		{Path: "google.golang.org/grpc/credentials/insecure"},
+		{Path: "google.golang.org/grpc/status"},
+		{Path: "google.golang.org/grpc/test/bufconn"},
+		codegen.GoaImport(""),
+		{Path: path.Join(genpkg, sd.PathName), Name: sd.PkgName},
+		{Path: path.Join(genpkg, "grpc", sd.PathName, "server"), Name: hd.ServerPkg},
+		{Path: path.Join(genpkg, "grpc", sd.PathName, "client"), Name: hd.ClientPkg},
+		{Path: path.Join(genpkg, "grpc", sd.PathName, pbPkgName), Name: data.PkgName},
+	}
+	imports = append(imports, sd.UserTypeImports...)
+	harness := &codegen.File{
+		Path: filepath.Join(dir, "grpc.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(svc.Name()+" gRPC test harness", service.TestPkgName(sd), imports),
+			{Name: "grpc-harness", Source: readTemplate("harness"), Data: hd},
+		},
+	}
+	if len(hd.Cases) == 0 || hd.HasServerInterceptors || hd.HasClientInterceptors {
+		return []*codegen.File{harness}
+	}
+	contract := &codegen.File{
+		Path: filepath.Join(dir, "grpc_contract_test.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(svc.Name()+" gRPC contract tests", service.TestPkgName(sd), imports),
+			{Name: "grpc-contract-test", Source: readTemplate("contract_test"), Data: hd},
+		},
+	}
+	return []*codegen.File{harness, contract}
+}
+
+// buildHarnessData builds the data needed to render the gRPC test harness and
+// contract tests of the given service.
+func buildHarnessData(svc *expr.GRPCServiceExpr, data *ServiceData) *harnessData {
+	sd := data.Service
+	hd := &harnessData{
+		Service:               data,
+		ServerPkg:             sd.PkgName + "svr",
+		ClientPkg:             sd.PkgName + "c",
+		Register:              "Register" + codegen.Goify(sd.VarName, true) + "Server",
+		HasServerInterceptors: len(sd.ServerInterceptors) > 0,
+		HasClientInterceptors: len(sd.ClientInterceptors) > 0,
+	}
+	for _, m := range sd.Methods {
+		if data.Endpoint(m.Name) == nil {
+			hd.Endpoints = append(hd.Endpoints, "nil")
+			continue
+		}
+		hd.Endpoints = append(hd.Endpoints, "c."+m.VarName+"()")
+	}
+	for _, e := range svc.GRPCEndpoints {
+		if e.MethodExpr.IsStreaming() {
+			continue
+		}
+		if c := service.ContractCase(sd, e.MethodExpr, nil); c != nil {
+			hd.Cases = append(hd.Cases, &grpcContractCaseData{ContractCaseData: c, Code: statusCodeToGRPCConst(e.Response.StatusCode)})
+		}
+		for _, ge := range e.GRPCErrors {
+			if c := service.ContractCase(sd, e.MethodExpr, ge.ErrorExpr); c != nil {
+				hd.Cases = append(hd.Cases, &grpcContractCaseData{ContractCaseData: c, Code: statusCodeToGRPCConst(ge.Response.StatusCode)})
+			}
+		}
+	}
+	return hd
+}
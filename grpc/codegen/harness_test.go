@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestHarnessFiles(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Harness  string
+		Contract string
+	}{
+		{"unary-rpc-with-errors", testdata.UnaryRPCWithErrorsDSL, testdata.HarnessUnaryRPCWithErrorsCode, testdata.ContractUnaryRPCWithErrorsCode},
+		{"unary-rpc-with-retry", testdata.UnaryRPCWithRetryDSL, testdata.HarnessUnaryRPCWithRetryCode, testdata.ContractUnaryRPCWithRetryCode},
+		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL, testdata.HarnessBidirectionalStreamingRPCCode, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			fs := HarnessFiles("gen", expr.Root)
+			if c.Contract == "" {
+				require.Len(t, fs, 1)
+			} else {
+				require.Len(t, fs, 2)
+			}
+			require.Len(t, fs[0].SectionTemplates, 2)
+			assert.Equal(t, c.Harness, codegen.SectionCode(t, fs[0].SectionTemplates[1]))
+			if c.Contract != "" {
+				require.Len(t, fs[1].SectionTemplates, 2)
+				assert.Equal(t, c.Contract, codegen.SectionCode(t, fs[1].SectionTemplates[1]))
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ func TestProtoFiles(t *testing.T) {
 		{"protofiles-struct-meta-type", testdata.StructMetaTypeDSL, testdata.StructMetaTypePackageCode},
 		{"protofiles-default-fields", testdata.DefaultFieldsDSL, testdata.DefaultFieldsPackageCode},
 		{"protofiles-custom-message-name", testdata.CustomMessageNameDSL, testdata.CustomMessageNamePackageCode},
+		{"protofiles-bound-message", testdata.BoundMessageDSL, testdata.BoundMessagePackageCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -16,8 +16,41 @@ type (
 	protoBufScope struct {
 		scope *codegen.NameScope
 	}
+
+	// externalMessage describes an existing protocol buffer message that is
+	// referenced rather than generated: either a well-known type used to
+	// represent a primitive type or a message bound to a user type with the
+	// "struct:proto:message" meta.
+	externalMessage struct {
+		// Name is the fully qualified name of the message.
+		Name string
+		// Path is the path of the proto file that defines the message.
+		Path string
+		// GoType is the name of the Go type generated for the message.
+		GoType string
+		// GoImport is the import path of the Go package that defines
+		// GoType.
+		GoImport string
+	}
 )
 
+// wellKnownTypes lists the well-known types used to represent primitive types
+// indexed by primitive kind.
+var wellKnownTypes = map[expr.Kind]*externalMessage{
+	expr.DateTimeKind: {
+		Name:     "google.protobuf.Timestamp",
+		Path:     "google/protobuf/timestamp.proto",
+		GoType:   "timestamppb.Timestamp",
+		GoImport: "google.golang.org/protobuf/types/known/timestamppb",
+	},
+	expr.DurationKind: {
+		Name:     "google.protobuf.Duration",
+		Path:     "google/protobuf/duration.proto",
+		GoType:   "durationpb.Duration",
+		GoImport: "google.golang.org/protobuf/types/known/durationpb",
+	},
+}
+
 // Name returns the protocol buffer type name.
 func (p *protoBufScope) Name(att *expr.AttributeExpr, pkg string, _, _ bool) string {
 	return protoBufGoFullTypeName(att, pkg, p.scope)
@@ -216,6 +249,11 @@ func protoBufFullMessageName(att *expr.AttributeExpr, pkg string, s *codegen.Nam
 // protoBufGoTypeName returns the protocol buffer type name for the given
 // attribute generated after compiling the proto file (in *.pb.go).
 func protoBufGoTypeName(att *expr.AttributeExpr, s *codegen.NameScope) string {
+	if msg := boundMessage(att); msg != nil {
+		// The Go type lives in a different package, make sure the name can
+		// be used to build identifiers.
+		return codegen.Goify(msg.GoType, true)
+	}
 	return protoBufGoFullTypeName(att, "", s)
 }
 
@@ -231,6 +269,9 @@ func protoBufGoFullTypeName(att *expr.AttributeExpr, pkg string, s *codegen.Name
 		}
 		return typ
 	}
+	if msg := boundMessage(att); msg != nil {
+		return msg.GoType
+	}
 	switch actual := att.Type.(type) {
 	case expr.UserType, expr.CompositeExpr, *expr.Union:
 		return protoBufFullMessageName(att, pkg, s)
@@ -291,6 +332,9 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 		if actual == expr.Empty {
 			return " {}"
 		}
+		if msg := boundMessage(att); msg != nil {
+			return msg.Name
+		}
 		if prim := getPrimitive(att); prim != nil {
 			return protoBufMessageDef(prim, sd)
 		}
@@ -318,7 +362,7 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 				} else {
 					typ = protoType(nat.Attribute, sd)
 				}
-				if !att.IsRequired(nat.Name) && expr.IsPrimitive(nat.Attribute.Type) {
+				if !att.IsRequired(nat.Name) && expr.IsPrimitive(nat.Attribute.Type) && !isWellKnown(nat.Attribute.Type) {
 					opt = "optional "
 				}
 				if nat.Attribute.Description != "" {
@@ -429,6 +473,10 @@ func protoNativeType(t expr.DataType) string {
 		return "string"
 	case expr.BytesKind:
 		return "bytes"
+	case expr.DecimalKind:
+		return "string"
+	case expr.DateTimeKind, expr.DurationKind:
+		return wellKnownTypes[t.Kind()].Name
 	default:
 		panic(fmt.Sprintf("cannot compute native protocol buffer type for %T", t)) // bug
 	}
@@ -461,11 +509,44 @@ func protoBufNativeGoTypeName(t expr.DataType) string {
 		return "string"
 	case expr.BytesKind:
 		return "[]byte"
+	case expr.DecimalKind:
+		return "string"
+	case expr.DateTimeKind, expr.DurationKind:
+		return "*" + wellKnownTypes[t.Kind()].GoType
 	default:
 		panic(fmt.Sprintf("cannot compute native protocol buffer type for %T %v", t, t)) // bug
 	}
 }
 
+// isWellKnown returns true if the given type is a primitive type represented
+// with a protocol buffer well-known type. The corresponding message fields are
+// pointers in the generated Go code whether they are required or not.
+func isWellKnown(t expr.DataType) bool {
+	_, ok := wellKnownTypes[t.Kind()]
+	return ok && expr.IsPrimitive(t)
+}
+
+// boundMessage returns the existing protocol buffer message the given
+// attribute type is bound to via the "struct:proto:message" meta, nil if the
+// type is not a user type or if the message must be generated.
+func boundMessage(att *expr.AttributeExpr) *externalMessage {
+	ut, ok := att.Type.(expr.UserType)
+	if !ok {
+		return nil
+	}
+	meta := ut.Attribute().Meta["struct:proto:message"]
+	if len(meta) < 4 {
+		return nil
+	}
+	elems := strings.Split(meta[3], "/")
+	return &externalMessage{
+		Name:     meta[0],
+		Path:     meta[1],
+		GoType:   elems[len(elems)-1] + "." + meta[2],
+		GoImport: meta[3],
+	}
+}
+
 // rpcTag returns the unique numbered RPC tag from the given attribute.
 func rpcTag(a *expr.AttributeExpr) uint64 {
 	var tag uint64
@@ -207,7 +207,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				srcField     = sourceVar + "." + ta.SourceCtx.Scope.Field(srcc, srcMatt.ElemName(n), true)
 				tgtField     = ta.TargetCtx.Scope.Field(tgtc, tgtMatt.ElemName(n), true)
 				srcPtr       = ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr)
-				tgtPtr       = ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr)
+				tgtPtr       = ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr) && !(ta.proto && isWellKnown(tgtc.Type))
 				srcFieldConv = convertType(srcc, tgtc, srcPtr, tgtPtr, srcField, ta)
 				_, isSrcUT   = srcc.Type.(expr.UserType)
 				_, isTgtUT   = tgtc.Type.(expr.UserType)
@@ -623,7 +623,7 @@ func convertType(src, tgt *expr.AttributeExpr, srcPtr bool, tgtPtr bool, srcVar
 
 	srcType, _ := codegen.GetMetaType(src)
 	tgtType, _ := codegen.GetMetaType(tgt)
-	if srcType == "" && tgtType == "" && (src.Type != expr.Int) && (src.Type != expr.UInt) {
+	if srcType == "" && tgtType == "" && (src.Type != expr.Int) && (src.Type != expr.UInt) && !isWellKnown(src.Type) && src.Type != expr.Decimal {
 		// Nothing to do
 		return srcVar
 	}
@@ -638,11 +638,20 @@ func convertType(src, tgt *expr.AttributeExpr, srcPtr bool, tgtPtr bool, srcVar
 // representation to another.
 // NOTE: For Int and UInt kinds, protocol buffer Go compiler generates
 // int32 and uint32 respectively whereas Goa generates int and uint.
+// For DateTime and Duration kinds, protocol buffer Go compiler generates
+// pointers to the corresponding well-known types whereas Goa generates
+// time.Time and time.Duration respectively.
 func convertPrimitiveToProto(_, tgt *expr.AttributeExpr, srcPtr, _ bool, srcVar string, _ *transformAttrs) string {
-	tgtType := protoBufNativeGoTypeName(tgt.Type)
 	if srcPtr {
 		srcVar = "*" + srcVar
 	}
+	switch tgt.Type.Kind() {
+	case expr.DateTimeKind:
+		return fmt.Sprintf("timestamppb.New(%s)", srcVar)
+	case expr.DurationKind:
+		return fmt.Sprintf("durationpb.New(%s)", srcVar)
+	}
+	tgtType := protoBufNativeGoTypeName(tgt.Type)
 	return fmt.Sprintf("%s(%s)", tgtType, srcVar)
 }
 
@@ -651,6 +660,16 @@ func convertPrimitiveFromProto(_, tgt *expr.AttributeExpr, srcPtr, _ bool, srcVa
 	if tgtType == "" {
 		tgtType = ta.TargetCtx.Scope.Ref(tgt, ta.TargetCtx.Pkg(tgt))
 	}
+	if isWellKnown(tgt.Type) {
+		conv := srcVar + ".AsTime()"
+		if tgt.Type.Kind() == expr.DurationKind {
+			conv = srcVar + ".AsDuration()"
+		}
+		if _, ok := tgt.Type.(expr.UserType); !ok {
+			return conv
+		}
+		return fmt.Sprintf("%s(%s)", tgtType, conv)
+	}
 	if srcPtr {
 		srcVar = "*" + srcVar
 	}
@@ -23,6 +23,7 @@ func TestProtoBufTransform(t *testing.T) {
 		required   = root.UserType("Required")
 		defaultT   = root.UserType("Default")
 		customtype = root.UserType("CustomTypes")
+		timeTypes  = root.UserType("TimeTypes")
 
 		simpleMap  = root.UserType("SimpleMap")
 		nestedMap  = root.UserType("NestedMap")
@@ -86,6 +87,7 @@ func TestProtoBufTransform(t *testing.T) {
 			{"required-ptr-to-simple", required, simple, true, ptrCtx, requiredPtrSvcToSimpleProtoCode},
 			{"simple-to-customtype", customtype, simple, true, svcCtx, customSvcToSimpleProtoCode},
 			{"customtype-to-customtype", customtype, customtype, true, svcCtx, customSvcToCustomProtoCode},
+			{"time-types-to-time-types", timeTypes, timeTypes, true, svcCtx, timeTypesSvcToTimeTypesProtoCode},
 
 			// maps
 			{"map-to-map", simpleMap, simpleMap, true, svcCtx, simpleMapSvcToSimpleMapProtoCode},
@@ -128,6 +130,7 @@ func TestProtoBufTransform(t *testing.T) {
 			{"simple-to-required-ptr", simple, required, false, ptrCtx, simpleProtoToRequiredPtrSvcCode},
 			{"simple-to-customtype", simple, customtype, false, svcCtx, simpleProtoToCustomSvcCode},
 			{"customtype-to-customtype", customtype, customtype, false, svcCtx, customProtoToCustomSvcCode},
+			{"time-types-to-time-types", timeTypes, timeTypes, false, svcCtx, timeTypesProtoToTimeTypesSvcCode},
 
 			// maps
 			{"map-to-map", simpleMap, simpleMap, false, svcCtx, simpleMapProtoToSimpleMapSvcCode},
@@ -355,6 +358,56 @@ const (
 		}
 	}
 }
+`
+
+	timeTypesSvcToTimeTypesProtoCode = `func transform() {
+	target := &proto.TimeTypes{
+		RequiredDatetime: timestamppb.New(source.RequiredDatetime),
+		RequiredDuration: durationpb.New(source.RequiredDuration),
+	}
+	if source.Datetime != nil {
+		target.Datetime = timestamppb.New(*source.Datetime)
+	}
+	if source.Duration != nil {
+		target.Duration = durationpb.New(*source.Duration)
+	}
+	if source.Decimal != nil {
+		decimal := string(*source.Decimal)
+		target.Decimal = &decimal
+	}
+	if source.DatetimeArray != nil {
+		target.DatetimeArray = make([]*timestamppb.Timestamp, len(source.DatetimeArray))
+		for i, val := range source.DatetimeArray {
+			target.DatetimeArray[i] = timestamppb.New(val)
+		}
+	}
+}
+`
+
+	timeTypesProtoToTimeTypesSvcCode = `func transform() {
+	target := &proto.TimeTypes{
+		RequiredDatetime: source.RequiredDatetime.AsTime(),
+		RequiredDuration: source.RequiredDuration.AsDuration(),
+	}
+	if source.Datetime != nil {
+		datetime := source.Datetime.AsTime()
+		target.Datetime = &datetime
+	}
+	if source.Duration != nil {
+		duration := source.Duration.AsDuration()
+		target.Duration = &duration
+	}
+	if source.Decimal != nil {
+		decimal := goa.Decimal(*source.Decimal)
+		target.Decimal = &decimal
+	}
+	if source.DatetimeArray != nil {
+		target.DatetimeArray = make([]time.Time, len(source.DatetimeArray))
+		for i, val := range source.DatetimeArray {
+			target.DatetimeArray[i] = val.AsTime()
+		}
+	}
+}
 `
 
 	simpleMapSvcToSimpleMapProtoCode = `func transform() {
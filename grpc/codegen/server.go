@@ -13,7 +13,9 @@ import (
 // ServerFiles returns all the server files for every gRPC service. The files
 // contain the server which implements the generated gRPC server interface and
 // encoders and decoders to transform protocol buffer types and gRPC metadata
-// into goa types and vice versa.
+// into goa types and vice versa. The services with the "grpc:connect" meta
+// also get a file that mounts the gRPC methods on a HTTP muxer using the
+// Connect and gRPC-Web protocols.
 func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 	svcLen := len(root.API.GRPC.Services)
 	fw := make([]*codegen.File, 2*svcLen)
@@ -23,6 +25,11 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 	for i, svc := range root.API.GRPC.Services {
 		fw[i+svcLen] = serverEncodeDecode(genpkg, svc)
 	}
+	for _, svc := range root.API.GRPC.Services {
+		if connectEnabled(svc) {
+			fw = append(fw, connectFile(genpkg, svc))
+		}
+	}
 	return fw
 }
 
@@ -47,7 +54,11 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
 			{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
 		}
+		if data.Service.Schemes.HasType("MutualTLS") {
+			imports = append(imports, codegen.GoaImport("security"))
+		}
 		imports = append(imports, data.Service.UserTypeImports...)
+		imports = append(imports, data.Service.ProtoImports...)
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC server", "server", imports),
 			{
@@ -145,6 +156,7 @@ func serverEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 			{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
 		}
 		imports = append(imports, data.Service.UserTypeImports...)
+		imports = append(imports, data.Service.ProtoImports...)
 		sections = []*codegen.SectionTemplate{codegen.Header(title, "server", imports)}
 
 		for _, e := range data.Endpoints {
@@ -104,6 +104,11 @@ type (
 		ServerInterface string
 		// ServerStream is the server stream data.
 		ServerStream *StreamData
+		// ClientIP is true if the method rate limit is keyed by client IP
+		// address or by principal (unauthenticated requests are limited
+		// per address) in which case the server stores the peer address
+		// in the request context.
+		ClientIP bool
 
 		// client side
 
@@ -485,6 +490,14 @@ func (ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 				sd.Messages = append(sd.Messages, msgs...)
 				return msgs[0]
 			}
+			if msg := boundMessage(att); msg != nil {
+				return &service.UserTypeData{
+					Name:    att.Type.Name(),
+					VarName: msg.Name,
+					Ref:     protoBufGoFullTypeRef(att, sd.PkgName, sd.Scope),
+					Type:    att.Type.(expr.UserType),
+				}
+			}
 			// lookup message in sd.Messages
 			if ut, ok := att.Type.(expr.UserType); ok {
 				name := ut.Name()
@@ -610,6 +623,11 @@ func (ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 		{
 			for _, req := range e.Requirements {
 				for _, sch := range req.Schemes {
+					if sch.Kind == expr.MutualTLSKind {
+						// The client certificates are provided by
+						// the gRPC transport credentials.
+						continue
+					}
 					s := md.Requirements.Scheme(sch.SchemeName).Dup()
 					s.In = sch.In
 					switch s.In {
@@ -641,6 +659,9 @@ func (ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			ClientStruct:     sd.ClientStruct,
 			ClientInterface:  sd.ClientInterface,
 		}
+		if rl := e.MethodExpr.EffectiveRateLimit(); rl != nil && (rl.Key == expr.RateLimitKeyClientIP || rl.Key == expr.RateLimitKeyPrincipal) {
+			ed.ClientIP = true
+		}
 		sd.Endpoints = append(sd.Endpoints, ed)
 		if e.MethodExpr.IsStreaming() {
 			ed.ServerStream = buildStreamData(e, sd, true)
@@ -674,7 +695,37 @@ func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]st
 			}
 		}
 	}
+	if msg := boundMessage(at); msg != nil {
+		// The message is defined in an existing proto file, import it instead
+		// of generating it.
+		imports = append(imports, msg.Path)
+		found := false
+		for _, i := range sd.Service.ProtoImports {
+			if i.Path == msg.GoImport {
+				found = true
+				break
+			}
+		}
+		if !found {
+			elems := strings.Split(msg.GoImport, "/")
+			sd.Service.ProtoImports = append(sd.Service.ProtoImports, &codegen.ImportSpec{Path: msg.GoImport, Name: elems[len(elems)-1]})
+		}
+		return
+	}
 	if expr.IsPrimitive(at.Type) {
+		if wkt, ok := wellKnownTypes[at.Type.Kind()]; ok && at.Meta["struct:field:proto"] == nil {
+			imports = append(imports, wkt.Path)
+			found := false
+			for _, i := range sd.Service.ProtoImports {
+				if i.Path == wkt.GoImport {
+					found = true
+					break
+				}
+			}
+			if !found {
+				sd.Service.ProtoImports = append(sd.Service.ProtoImports, codegen.SimpleImport(wkt.GoImport))
+			}
+		}
 		return
 	}
 	collect := func(at *expr.AttributeExpr) ([]*service.UserTypeData, []string) {
@@ -785,6 +836,9 @@ func collectValidations(att *expr.AttributeExpr, attName string, ctx *codegen.At
 		vtx := protoBufTypeContext(sd.PkgName, sd.Scope, false)
 		def := codegen.AttributeValidationCode(att, dt, vtx, true, false, gattName, attName)
 		name := protoBufMessageName(att, sd.Scope)
+		if boundMessage(att) != nil {
+			name = protoBufGoTypeName(att, sd.Scope)
+		}
 		kind := validateClient
 		if req {
 			kind = validateServer
@@ -22,12 +22,31 @@ func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 				{{- end }}
 			{{- end }}
 			case *goapb.ErrorResponse:
+			{{- if hasCustomErrors .Errors }}
+				switch message.Name {
+				{{- range .Errors }}
+					{{- if .Response.ClientConvert }}
+					case {{ printf "%q" .Name }}:
+						message := &{{ .Response.ClientConvert.SrcName }}{}
+						if goagrpc.DecodeErrorMetadata(err, message) {
+						{{- if .Response.ClientConvert.Validation }}
+							if err := {{ .Response.ClientConvert.Validation.Name }}(message); err == nil {
+								return nil, {{ .Response.ClientConvert.Init.Name }}({{ range .Response.ClientConvert.Init.Args }}{{ .Name }}, {{ end }})
+							}
+						{{- else }}
+							return nil, {{ .Response.ClientConvert.Init.Name }}({{ range .Response.ClientConvert.Init.Args }}{{ .Name }}, {{ end }})
+						{{- end }}
+						}
+					{{- end }}
+				{{- end }}
+				}
+			{{- end }}
 				return nil, goagrpc.NewServiceError(message)
 			default:
-				return nil, goa.Fault(err.Error())
+				return nil, goagrpc.NewClientFault(err)
 			}
 		{{- else }}
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		{{- end }}
 		}
 		return res, nil
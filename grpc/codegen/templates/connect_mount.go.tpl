@@ -0,0 +1,17 @@
+{{ printf "MountConnect configures the mux to serve the %q service gRPC methods using the Connect and gRPC-Web protocols. Client and bidirectional streaming methods are not mounted. The requests do not go through the grpc.Server: pass the interceptors given to grpc.NewServer with connect.WithUnaryInterceptors and connect.WithStreamInterceptors for them to apply." .Service.Service.Name | comment }}
+func MountConnect(mux goahttp.Muxer, srv *{{ .Service.ServerStruct }}, opts ...connect.HandlerOption) {
+{{- range .Endpoints }}
+	mux.Handle("POST", {{ printf "%q" .FullMethod }}, connect.
+	{{- if .StreamStruct }}NewServerStreamHandler{{ else }}NewUnaryHandler{{ end }}({{ printf "%q" .FullMethod }},
+		func() proto.Message { return &{{ .RequestType }}{} },
+	{{- if .StreamStruct }}
+		func(message proto.Message, stream grpc.ServerStream) error {
+			return srv.{{ .Method.VarName }}(message.({{ .Request.Message.Ref }}), &{{ .StreamStruct }}{stream})
+		}, opts...))
+	{{- else }}
+		func(ctx context.Context, message proto.Message) (proto.Message, error) {
+			return srv.{{ .Method.VarName }}(ctx, message.({{ .Request.Message.Ref }}))
+		}, opts...))
+	{{- end }}
+{{- end }}
+}
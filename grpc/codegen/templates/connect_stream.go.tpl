@@ -0,0 +1,9 @@
+{{ printf "%s implements the %s interface on top of the Connect and gRPC-Web server streams." .StreamStruct .ServerStream.Interface | comment }}
+type {{ .StreamStruct }} struct {
+	grpc.ServerStream
+}
+
+// Send writes a response message to the stream.
+func (s *{{ .StreamStruct }}) Send(m {{ .Response.Message.Ref }}) error {
+	return s.SendMsg(m)
+}
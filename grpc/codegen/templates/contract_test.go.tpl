@@ -0,0 +1,56 @@
+{{ printf "TestGRPCContract calls the %q service methods through the generated gRPC transport and checks that each designed response and error produces the expected status code." .Service.Service.Name | comment }}
+func TestGRPCContract(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Mock   func(*Mock)
+		Call   func(context.Context, *{{ .Service.Service.PkgName }}.Client) error
+		Code   codes.Code
+		Error  string
+	}{
+{{- range .Cases }}
+		{
+			Name: {{ printf "%q" .Name }},
+			Mock: func(m *Mock) {
+				m.Expect{{ .Method.VarName }}(func({{ .Method.Params }}) ({{ .Method.Results }}) {
+			{{- if .Err }}
+					err = {{ .Err }}
+					return
+			{{- else }}
+					return {{ .Returns }}
+			{{- end }}
+				})
+			},
+			Call: func(ctx context.Context, c *{{ $.Service.Service.PkgName }}.Client) error {
+				{{ if .Method.Result }}_, {{ end }}err := c.{{ .Method.VarName }}(ctx{{ if .Payload }}, {{ .Payload }}{{ end }})
+				return err
+			},
+			Code:   {{ .Code }},
+			Error:  {{ printf "%q" .Error }},
+		},
+{{- end }}
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMock(t)
+			c.Mock(m)
+			h := NewGRPCHarness(t, m)
+			err := c.Call(context.Background(), h.Client)
+			if got := h.Code(); got != c.Code {
+				t.Errorf("got status code %s, expected %s", got, c.Code)
+			}
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var en goa.GoaErrorNamer
+			if !errors.As(err, &en) {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			if en.GoaErrorName() != c.Error {
+				t.Errorf("got error %q, expected %q", en.GoaErrorName(), c.Error)
+			}
+		})
+	}
+}
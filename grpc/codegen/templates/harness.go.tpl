@@ -0,0 +1,56 @@
+{{ printf "GRPCHarness serves the %q service in-process over an in-memory connection using the generated gRPC server and client." .Service.Service.Name | comment }}
+type GRPCHarness struct {
+	// Server is the gRPC server.
+	Server *grpc.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *{{ .Service.Service.PkgName }}.Client
+
+	mu   sync.Mutex
+	code codes.Code
+}
+
+{{ printf "NewGRPCHarness starts a gRPC server that serves the %q service implemented by s and returns a harness whose client sends requests to the server. The server is stopped when the test completes." .Service.Service.Name | comment }}
+func NewGRPCHarness(t testing.TB, s {{ .Service.Service.PkgName }}.Service{{ if .HasServerInterceptors }}, si {{ .Service.Service.PkgName }}.ServerInterceptors{{ end }}{{ if .HasClientInterceptors }}, ci {{ .Service.Service.PkgName }}.ClientInterceptors{{ end }}) *GRPCHarness {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	h := &GRPCHarness{Server: grpc.NewServer()}
+	endpoints := {{ .Service.Service.PkgName }}.NewEndpoints(s{{ if .HasServerInterceptors }}, si{{ end }})
+	{{ .Service.PkgName }}.{{ .Register }}(h.Server, {{ .ServerPkg }}.{{ .Service.ServerInit }}(endpoints{{ if .Service.HasUnaryEndpoint }}, nil{{ end }}{{ if .Service.HasStreamingEndpoint }}, nil{{ end }}))
+	go h.Server.Serve(lis)
+	t.Cleanup(h.Server.Stop)
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(h.record))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	c := {{ .ClientPkg }}.New{{ .Service.ClientStruct }}(cc)
+	h.Client = &{{ .Service.Service.PkgName }}.Client{
+	{{- range $i, $e := .Endpoints }}
+		{{- with index $.Service.Service.Methods $i }}
+		{{ .VarName }}Endpoint: {{ if .ClientInterceptors }}{{ $.Service.Service.PkgName }}.Wrap{{ .VarName }}ClientEndpoint({{ $e }}, ci){{ else }}{{ $e }}{{ end }},
+		{{- end }}
+	{{- end }}
+	}
+	return h
+}
+
+// record records the status code of the unary calls made by Client.
+func (h *GRPCHarness) record(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	h.mu.Lock()
+	h.code = status.Code(err)
+	h.mu.Unlock()
+	return err
+}
+
+// Code returns the status code of the last unary call made by Client.
+func (h *GRPCHarness) Code() codes.Code {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.code
+}
@@ -66,6 +66,24 @@
 		{{ .VarName }}[i] = v
 	{{- else if eq .Type.ElemType.Type.Name "any" }}
 		{{ .VarName }}[i] = rv
+	{{- else if eq .Type.ElemType.Type.Name "datetime" }}
+		v, err2 := time.Parse(time.RFC3339, rv)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of datetimes"))
+		}
+		{{ .VarName }}[i] = v
+	{{- else if eq .Type.ElemType.Type.Name "duration" }}
+		v, err2 := strconv.ParseInt(rv, 10, 64)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of durations"))
+		}
+		{{ .VarName }}[i] = time.Duration(v)
+	{{- else if eq .Type.ElemType.Type.Name "decimal" }}
+		v, err2 := goa.ParseDecimal(rv)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of decimals"))
+		}
+		{{ .VarName }}[i] = v
 	{{- else }}
 		// unsupported slice type {{ .Type.ElemType.Type.Name }} for var {{ .VarName }}
 	{{- end }}
@@ -153,6 +171,29 @@
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "datetime" }}
+		v, err2 := time.Parse(time.RFC3339, {{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "datetime"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "duration" }}
+		v, err2 := strconv.ParseInt({{ .VarName }}Raw, 10, 64)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "duration"))
+		}
+		{{- if .Pointer }}
+		pv := time.Duration(v)
+		{{ .VarName }} = &pv
+		{{- else }}
+		{{ .VarName }} = time.Duration(v)
+		{{- end }}
+	{{- else if eq .Type.Name "decimal" }}
+		v, err2 := goa.ParseDecimal({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "decimal"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
@@ -23,6 +23,12 @@
 		{{ .VarName }} := string({{ .Target }})
 	{{- else if eq .Type.Name "any" -}}
 		{{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
+	{{- else if eq .Type.Name "datetime" -}}
+		{{ .VarName }} := {{ .Target }}.Format(time.RFC3339Nano)
+	{{- else if eq .Type.Name "duration" -}}
+		{{ .VarName }} := strconv.FormatInt(int64({{ .Target }}), 10)
+	{{- else if eq .Type.Name "decimal" -}}
+		{{ .VarName }} := string({{ .Target }})
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for field {{ .FieldName }}
 	{{- end }}
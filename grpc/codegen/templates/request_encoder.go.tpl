@@ -24,11 +24,15 @@ func Encode{{ .Method.VarName }}Request(ctx context.Context, v any, md *metadata
 				} else {
 			{{- end }}
 				(*md).Append({{ printf "%q" .Name }},
-					{{- if eq .Type.Name "bytes" }} string(
+					{{- if or (eq .Type.Name "bytes") (eq .Type.Name "decimal") }} string(
+					{{- else if eq .Type.Name "duration" }} strconv.FormatInt(int64(
+					{{- else if eq .Type.Name "datetime" }} (
 					{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v",
 					{{- end }}
 					{{- if .Pointer }}*{{ end }}payload{{ if .FieldName }}.{{ .FieldName }}{{ end }}
-					{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
+					{{- if eq .Type.Name "duration" }}), 10)
+					{{- else if eq .Type.Name "datetime" }}).Format(time.RFC3339Nano)
+					{{- else if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
 					{{- end }})
 			{{- if (and (eq .Name "Authorization") (isBearer $.MetadataSchemes)) }}
 				}
@@ -36,11 +36,15 @@ func Encode{{ .Method.VarName }}Response(ctx context.Context, v any, hdr, trlr *
 			if res.{{ .Metadata.FieldName }} != nil {
 		{{- end }}
 		{{ .VarName }}.Append({{ printf "%q" .Metadata.Name }},
-			{{- if eq .Metadata.Type.Name "bytes" }} string(
+			{{- if or (eq .Metadata.Type.Name "bytes") (eq .Metadata.Type.Name "decimal") }} string(
+			{{- else if eq .Metadata.Type.Name "duration" }} strconv.FormatInt(int64(
+			{{- else if eq .Metadata.Type.Name "datetime" }} (
 			{{- else if not (eq .Metadata.TypeName "string") }} fmt.Sprintf("%v",
 			{{- end }}
 			{{- if .Metadata.Pointer }}*{{ end }}p.{{ .Metadata.FieldName }}
-			{{- if or (eq .Metadata.Type.Name "bytes") (not (eq .Metadata.TypeName "string")) }})
+			{{- if eq .Metadata.Type.Name "duration" }}), 10)
+			{{- else if eq .Metadata.Type.Name "datetime" }}).Format(time.RFC3339Nano)
+			{{- else if or (eq .Metadata.Type.Name "bytes") (not (eq .Metadata.TypeName "string")) }})
 			{{- end }})
 		{{- if .Metadata.Pointer }}
 			}
@@ -8,6 +8,12 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 {{- end }}
 	ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 	ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+{{- if .ClientIP }}
+	ctx = context.WithValue(ctx, goa.ClientIPKey, goagrpc.ClientIP(ctx))
+{{- end }}
+{{- if .Method.Schemes.HasType "MutualTLS" }}
+	ctx = security.ContextWithPeerCertificates(ctx, goagrpc.PeerCertificates(ctx))
+{{- end }}
 
 {{- if .ServerStream }}
 	{{if .PayloadRef }}p{{ else }}_{{ end }}, err := s.{{ .Method.VarName }}H.Decode(ctx, {{ if .Method.StreamingPayload }}nil{{ else }}message{{ end }})
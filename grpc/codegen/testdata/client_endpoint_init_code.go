@@ -10,7 +10,7 @@ func (c *Client) MethodUnaryRPCA() goa.Endpoint {
 			DecodeMethodUnaryRPCAResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -26,7 +26,7 @@ func (c *Client) MethodUnaryRPCB() goa.Endpoint {
 			DecodeMethodUnaryRPCBResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -43,7 +43,7 @@ func (c *Client) MethodUnaryRPCNoPayload() goa.Endpoint {
 			DecodeMethodUnaryRPCNoPayloadResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -60,7 +60,7 @@ func (c *Client) MethodUnaryRPCNoResult() goa.Endpoint {
 			nil)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -92,9 +92,30 @@ func (c *Client) MethodUnaryRPCWithErrors() goa.Endpoint {
 			case *service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsCustomErrorError:
 				return nil, NewMethodUnaryRPCWithErrorsCustomErrorError(message)
 			case *goapb.ErrorResponse:
+				switch message.Name {
+				case "internal":
+					message := &service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsInternalError{}
+					if goagrpc.DecodeErrorMetadata(err, message) {
+						if err := ValidateMethodUnaryRPCWithErrorsInternalError(message); err == nil {
+							return nil, NewMethodUnaryRPCWithErrorsInternalError(message)
+						}
+					}
+				case "bad_request":
+					message := &service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsBadRequestError{}
+					if goagrpc.DecodeErrorMetadata(err, message) {
+						if err := ValidateMethodUnaryRPCWithErrorsBadRequestError(message); err == nil {
+							return nil, NewMethodUnaryRPCWithErrorsBadRequestError(message)
+						}
+					}
+				case "custom_error":
+					message := &service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsCustomErrorError{}
+					if goagrpc.DecodeErrorMetadata(err, message) {
+						return nil, NewMethodUnaryRPCWithErrorsCustomErrorError(message)
+					}
+				}
 				return nil, goagrpc.NewServiceError(message)
 			default:
-				return nil, goa.Fault(err.Error())
+				return nil, goagrpc.NewClientFault(err)
 			}
 		}
 		return res, nil
@@ -112,7 +133,7 @@ func (c *Client) MethodUnaryRPCAcronymJWT() goa.Endpoint {
 			nil)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -129,7 +150,7 @@ func (c *Client) MethodServerStreamingRPC() goa.Endpoint {
 			DecodeMethodServerStreamingRPCResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -146,7 +167,7 @@ func (c *Client) MethodClientStreamingRPC() goa.Endpoint {
 			DecodeMethodClientStreamingRPCResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -165,7 +186,7 @@ func (c *Client) MethodClientStreamingNoResult() goa.Endpoint {
 			DecodeMethodClientStreamingNoResultResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -184,7 +205,7 @@ func (c *Client) MethodClientStreamingRPCWithPayload() goa.Endpoint {
 			DecodeMethodClientStreamingRPCWithPayloadResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -203,7 +224,7 @@ func (c *Client) MethodBidirectionalStreamingRPC() goa.Endpoint {
 			DecodeMethodBidirectionalStreamingRPCResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -222,7 +243,7 @@ func (c *Client) MethodBidirectionalStreamingRPCWithPayload() goa.Endpoint {
 			DecodeMethodBidirectionalStreamingRPCWithPayloadResponse)
 		res, err := inv.Invoke(ctx, v)
 		if err != nil {
-			return nil, goa.Fault(err.Error())
+			return nil, goagrpc.NewClientFault(err)
 		}
 		return res, nil
 	}
@@ -246,7 +267,7 @@ func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 			case *goapb.ErrorResponse:
 				return nil, goagrpc.NewServiceError(message)
 			default:
-				return nil, goa.Fault(err.Error())
+				return nil, goagrpc.NewClientFault(err)
 			}
 		}
 		return res, nil
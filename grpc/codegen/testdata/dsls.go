@@ -52,6 +52,18 @@ var UnaryRPCNoResultDSL = func() {
 	})
 }
 
+var UnaryRPCWithMutualTLSDSL = func() {
+	var MTLS = MutualTLSSecurity("mtls")
+	Service("ServiceUnaryRPCWithMutualTLS", func() {
+		Method("MethodUnaryRPCWithMutualTLS", func() {
+			Security(MTLS)
+			AuthErrors()
+			Payload(String)
+			GRPC(func() {})
+		})
+	})
+}
+
 var UnaryRPCWithErrorsDSL = func() {
 	var ErrorType = Type("ErrorType", func() {
 		Attribute("a", String)
@@ -1010,6 +1022,29 @@ var CustomMessageNameDSL = func() {
 	})
 }
 
+var BoundMessageDSL = func() {
+	var Empty = Type("Empty", func() {
+		ProtoMessage("google.protobuf.Empty", "google/protobuf/empty.proto", "Empty", "google.golang.org/protobuf/types/known/emptypb")
+	})
+	var FieldMask = Type("FieldMask", func() {
+		ProtoMessage("google.protobuf.FieldMask", "google/protobuf/field_mask.proto", "FieldMask", "google.golang.org/protobuf/types/known/fieldmaskpb")
+		Field(1, "paths", ArrayOf(String))
+		Required("paths")
+	})
+	Service("BoundMessage", func() {
+		Method("Update", func() {
+			Payload(func() {
+				Field(1, "name", String)
+				Field(2, "mask", FieldMask)
+				Field(3, "options", Empty)
+				Required("name", "mask")
+			})
+			Result(FieldMask)
+			GRPC(func() {})
+		})
+	})
+}
+
 var InterceptorsDSL = func() {
 	var LogInterceptor = Interceptor("Log", func() {
 		Description("Logs request and response details")
@@ -1040,3 +1075,59 @@ var InterceptorsDSL = func() {
 		})
 	})
 }
+
+var ConnectDSL = func() {
+	Service("ServiceConnect", func() {
+		Meta("grpc:connect", "true")
+		Method("MethodUnaryRPC", func() {
+			Payload(String)
+			Result(String)
+			GRPC(func() {})
+		})
+		Method("MethodServerStreamingRPC", func() {
+			Payload(Int)
+			StreamingResult(String)
+			GRPC(func() {})
+		})
+		Method("MethodBidirectionalStreamingRPC", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			GRPC(func() {})
+		})
+	})
+}
+
+var ConnectAPIDSL = func() {
+	API("TestAPI", func() {
+		Meta("grpc:connect", "true")
+	})
+	Service("ServiceConnectAPI", func() {
+		Method("MethodUnaryRPC", func() {
+			Payload(String)
+			GRPC(func() {})
+		})
+	})
+	Service("ServiceConnectDisabled", func() {
+		Meta("grpc:connect", "false")
+		Method("MethodUnaryRPC", func() {
+			Payload(String)
+			GRPC(func() {})
+		})
+	})
+}
+
+var UnaryRPCWithRetryDSL = func() {
+	Service("ServiceUnaryRPCWithRetry", func() {
+		Retry(3)
+		Method("MethodUnaryRPCWithRetry", func() {
+			Payload(String)
+			Result(String)
+			Error("unavailable", func() {
+				Temporary()
+			})
+			GRPC(func() {
+				Response("unavailable", CodeUnavailable)
+			})
+		})
+	})
+}
@@ -0,0 +1,326 @@
+package testdata
+
+const HarnessUnaryRPCWithErrorsCode = `// GRPCHarness serves the "ServiceUnaryRPCWithErrors" service in-process over
+// an in-memory connection using the generated gRPC server and client.
+type GRPCHarness struct {
+	// Server is the gRPC server.
+	Server *grpc.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *serviceunaryrpcwitherrors.Client
+
+	mu   sync.Mutex
+	code codes.Code
+}
+
+// NewGRPCHarness starts a gRPC server that serves the
+// "ServiceUnaryRPCWithErrors" service implemented by s and returns a harness
+// whose client sends requests to the server. The server is stopped when the
+// test completes.
+func NewGRPCHarness(t testing.TB, s serviceunaryrpcwitherrors.Service) *GRPCHarness {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	h := &GRPCHarness{Server: grpc.NewServer()}
+	endpoints := serviceunaryrpcwitherrors.NewEndpoints(s)
+	service_unary_rpc_with_errorspb.RegisterServiceUnaryRPCWithErrorsServer(h.Server, serviceunaryrpcwitherrorssvr.New(endpoints, nil))
+	go h.Server.Serve(lis)
+	t.Cleanup(h.Server.Stop)
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(h.record))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	c := serviceunaryrpcwitherrorsc.NewClient(cc)
+	h.Client = &serviceunaryrpcwitherrors.Client{
+		MethodUnaryRPCWithErrorsEndpoint: c.MethodUnaryRPCWithErrors(),
+	}
+	return h
+}
+
+// record records the status code of the unary calls made by Client.
+func (h *GRPCHarness) record(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	h.mu.Lock()
+	h.code = status.Code(err)
+	h.mu.Unlock()
+	return err
+}
+
+// Code returns the status code of the last unary call made by Client.
+func (h *GRPCHarness) Code() codes.Code {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.code
+}
+`
+
+const ContractUnaryRPCWithErrorsCode = `// TestGRPCContract calls the "ServiceUnaryRPCWithErrors" service methods
+// through the generated gRPC transport and checks that each designed response
+// and error produces the expected status code.
+func TestGRPCContract(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Mock  func(*Mock)
+		Call  func(context.Context, *serviceunaryrpcwitherrors.Client) error
+		Code  codes.Code
+		Error string
+	}{
+		{
+			Name: "MethodUnaryRPCWithErrors",
+			Mock: func(m *Mock) {
+				m.ExpectMethodUnaryRPCWithErrors(func(ctx context.Context, p string) (res string, err error) {
+					return "Qui eius.", nil
+				})
+			},
+			Call: func(ctx context.Context, c *serviceunaryrpcwitherrors.Client) error {
+				_, err := c.MethodUnaryRPCWithErrors(ctx, "Voluptatibus in nam aliquid eligendi qui aut.")
+				return err
+			},
+			Code:  codes.OK,
+			Error: "",
+		},
+		{
+			Name: "MethodUnaryRPCWithErrors timeout",
+			Mock: func(m *Mock) {
+				m.ExpectMethodUnaryRPCWithErrors(func(ctx context.Context, p string) (res string, err error) {
+					err = serviceunaryrpcwitherrors.MakeTimeout(errors.New("timeout"))
+					return
+				})
+			},
+			Call: func(ctx context.Context, c *serviceunaryrpcwitherrors.Client) error {
+				_, err := c.MethodUnaryRPCWithErrors(ctx, "Voluptatibus in nam aliquid eligendi qui aut.")
+				return err
+			},
+			Code:  codes.Canceled,
+			Error: "timeout",
+		},
+		{
+			Name: "MethodUnaryRPCWithErrors custom_error",
+			Mock: func(m *Mock) {
+				m.ExpectMethodUnaryRPCWithErrors(func(ctx context.Context, p string) (res string, err error) {
+					err = &serviceunaryrpcwitherrors.ErrorType{}
+					return
+				})
+			},
+			Call: func(ctx context.Context, c *serviceunaryrpcwitherrors.Client) error {
+				_, err := c.MethodUnaryRPCWithErrors(ctx, "Voluptatibus in nam aliquid eligendi qui aut.")
+				return err
+			},
+			Code:  codes.Unknown,
+			Error: "custom_error",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMock(t)
+			c.Mock(m)
+			h := NewGRPCHarness(t, m)
+			err := c.Call(context.Background(), h.Client)
+			if got := h.Code(); got != c.Code {
+				t.Errorf("got status code %s, expected %s", got, c.Code)
+			}
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var en goa.GoaErrorNamer
+			if !errors.As(err, &en) {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			if en.GoaErrorName() != c.Error {
+				t.Errorf("got error %q, expected %q", en.GoaErrorName(), c.Error)
+			}
+		})
+	}
+}
+`
+
+const HarnessBidirectionalStreamingRPCCode = `// GRPCHarness serves the "ServiceBidirectionalStreamingRPC" service in-process
+// over an in-memory connection using the generated gRPC server and client.
+type GRPCHarness struct {
+	// Server is the gRPC server.
+	Server *grpc.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *servicebidirectionalstreamingrpc.Client
+
+	mu   sync.Mutex
+	code codes.Code
+}
+
+// NewGRPCHarness starts a gRPC server that serves the
+// "ServiceBidirectionalStreamingRPC" service implemented by s and returns a
+// harness whose client sends requests to the server. The server is stopped
+// when the test completes.
+func NewGRPCHarness(t testing.TB, s servicebidirectionalstreamingrpc.Service) *GRPCHarness {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	h := &GRPCHarness{Server: grpc.NewServer()}
+	endpoints := servicebidirectionalstreamingrpc.NewEndpoints(s)
+	service_bidirectional_streaming_rpcpb.RegisterServiceBidirectionalStreamingRPCServer(h.Server, servicebidirectionalstreamingrpcsvr.New(endpoints, nil))
+	go h.Server.Serve(lis)
+	t.Cleanup(h.Server.Stop)
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(h.record))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	c := servicebidirectionalstreamingrpcc.NewClient(cc)
+	h.Client = &servicebidirectionalstreamingrpc.Client{
+		MethodBidirectionalStreamingRPCEndpoint: c.MethodBidirectionalStreamingRPC(),
+	}
+	return h
+}
+
+// record records the status code of the unary calls made by Client.
+func (h *GRPCHarness) record(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	h.mu.Lock()
+	h.code = status.Code(err)
+	h.mu.Unlock()
+	return err
+}
+
+// Code returns the status code of the last unary call made by Client.
+func (h *GRPCHarness) Code() codes.Code {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.code
+}
+`
+
+const HarnessUnaryRPCWithRetryCode = `// GRPCHarness serves the "ServiceUnaryRPCWithRetry" service in-process over an
+// in-memory connection using the generated gRPC server and client.
+type GRPCHarness struct {
+	// Server is the gRPC server.
+	Server *grpc.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *serviceunaryrpcwithretry.Client
+
+	mu   sync.Mutex
+	code codes.Code
+}
+
+// NewGRPCHarness starts a gRPC server that serves the
+// "ServiceUnaryRPCWithRetry" service implemented by s and returns a harness
+// whose client sends requests to the server. The server is stopped when the
+// test completes.
+func NewGRPCHarness(t testing.TB, s serviceunaryrpcwithretry.Service) *GRPCHarness {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	h := &GRPCHarness{Server: grpc.NewServer()}
+	endpoints := serviceunaryrpcwithretry.NewEndpoints(s)
+	service_unary_rpc_with_retrypb.RegisterServiceUnaryRPCWithRetryServer(h.Server, serviceunaryrpcwithretrysvr.New(endpoints, nil))
+	go h.Server.Serve(lis)
+	t.Cleanup(h.Server.Stop)
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(h.record))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	c := serviceunaryrpcwithretryc.NewClient(cc)
+	h.Client = &serviceunaryrpcwithretry.Client{
+		MethodUnaryRPCWithRetryEndpoint: c.MethodUnaryRPCWithRetry(),
+	}
+	return h
+}
+
+// record records the status code of the unary calls made by Client.
+func (h *GRPCHarness) record(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	h.mu.Lock()
+	h.code = status.Code(err)
+	h.mu.Unlock()
+	return err
+}
+
+// Code returns the status code of the last unary call made by Client.
+func (h *GRPCHarness) Code() codes.Code {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.code
+}
+`
+
+const ContractUnaryRPCWithRetryCode = `// TestGRPCContract calls the "ServiceUnaryRPCWithRetry" service methods
+// through the generated gRPC transport and checks that each designed response
+// and error produces the expected status code.
+func TestGRPCContract(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Mock  func(*Mock)
+		Call  func(context.Context, *serviceunaryrpcwithretry.Client) error
+		Code  codes.Code
+		Error string
+	}{
+		{
+			Name: "MethodUnaryRPCWithRetry",
+			Mock: func(m *Mock) {
+				m.ExpectMethodUnaryRPCWithRetry(func(ctx context.Context, p string) (res string, err error) {
+					return "Nisi quam laborum commodi.", nil
+				})
+			},
+			Call: func(ctx context.Context, c *serviceunaryrpcwithretry.Client) error {
+				_, err := c.MethodUnaryRPCWithRetry(ctx, "Aliquam commodi harum recusandae sint.")
+				return err
+			},
+			Code:  codes.OK,
+			Error: "",
+		},
+		{
+			Name: "MethodUnaryRPCWithRetry unavailable",
+			Mock: func(m *Mock) {
+				m.ExpectMethodUnaryRPCWithRetry(func(ctx context.Context, p string) (res string, err error) {
+					err = serviceunaryrpcwithretry.MakeUnavailable(errors.New("unavailable"))
+					return
+				})
+			},
+			Call: func(ctx context.Context, c *serviceunaryrpcwithretry.Client) error {
+				_, err := c.MethodUnaryRPCWithRetry(ctx, "Aliquam commodi harum recusandae sint.")
+				return err
+			},
+			Code:  codes.Unavailable,
+			Error: "unavailable",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMock(t)
+			c.Mock(m)
+			h := NewGRPCHarness(t, m)
+			err := c.Call(context.Background(), h.Client)
+			if got := h.Code(); got != c.Code {
+				t.Errorf("got status code %s, expected %s", got, c.Code)
+			}
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var en goa.GoaErrorNamer
+			if !errors.As(err, &en) {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			if en.GoaErrorName() != c.Error {
+				t.Errorf("got error %q, expected %q", en.GoaErrorName(), c.Error)
+			}
+		})
+	}
+}
+`
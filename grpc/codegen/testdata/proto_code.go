@@ -525,3 +525,25 @@ message CustomType {
 	optional string b = 2;
 }
 `
+
+const BoundMessagePackageCode = `
+syntax = "proto3";
+
+package bound_message;
+
+option go_package = "/bound_messagepb";
+import "google/protobuf/field_mask.proto";
+import "google/protobuf/empty.proto";
+
+// Service is the BoundMessage service interface.
+service BoundMessage {
+	// Update implements Update.
+	rpc Update (UpdateRequest) returns (google.protobuf.FieldMask);
+}
+
+message UpdateRequest {
+	string name = 1;
+	google.protobuf.FieldMask mask = 2;
+	google.protobuf.Empty options = 3;
+}
+`
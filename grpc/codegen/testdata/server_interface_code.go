@@ -51,6 +51,29 @@ func (s *Server) MethodUnaryRPCNoResult(ctx context.Context, message *service_un
 }
 `
 
+const UnaryRPCWithMutualTLSServerInterfaceCode = `// MethodUnaryRPCWithMutualTLS implements the "MethodUnaryRPCWithMutualTLS"
+// method in
+// service_unary_rpc_with_mutual_tlspb.ServiceUnaryRPCWithMutualTLSServer
+// interface.
+func (s *Server) MethodUnaryRPCWithMutualTLS(ctx context.Context, message *service_unary_rpc_with_mutual_tlspb.MethodUnaryRPCWithMutualTLSRequest) (*service_unary_rpc_with_mutual_tlspb.MethodUnaryRPCWithMutualTLSResponse, error) {
+	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithMutualTLS")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithMutualTLS")
+	ctx = security.ContextWithPeerCertificates(ctx, goagrpc.PeerCertificates(ctx))
+	resp, err := s.MethodUnaryRPCWithMutualTLSH.Handle(ctx, message)
+	if err != nil {
+		var en goa.GoaErrorNamer
+		if errors.As(err, &en) {
+			switch en.GoaErrorName() {
+			case "unauthenticated":
+				return nil, goagrpc.NewStatusError(codes.Unauthenticated, err, goagrpc.NewErrorResponse(err))
+			}
+		}
+		return nil, goagrpc.EncodeError(err)
+	}
+	return resp.(*service_unary_rpc_with_mutual_tlspb.MethodUnaryRPCWithMutualTLSResponse), nil
+}
+`
+
 const UnaryRPCWithErrorsServerInterfaceCode = `// MethodUnaryRPCWithErrors implements the "MethodUnaryRPCWithErrors" method in
 // service_unary_rpc_with_errorspb.ServiceUnaryRPCWithErrorsServer interface.
 func (s *Server) MethodUnaryRPCWithErrors(ctx context.Context, message *service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsRequest) (*service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsResponse, error) {
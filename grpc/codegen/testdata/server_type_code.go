@@ -622,3 +622,104 @@ func NewProtoMethodResponse() *default_fieldspb.MethodResponse {
 	return message
 }
 `
+
+const BoundMessageServerTypeCode = `// NewUpdatePayload builds the payload of the "Update" endpoint of the
+// "BoundMessage" service from the gRPC request type.
+func NewUpdatePayload(message *bound_messagepb.UpdateRequest) *boundmessage.UpdatePayload {
+	v := &boundmessage.UpdatePayload{
+		Name: message.Name,
+	}
+	if message.Mask != nil {
+		v.Mask = protobufFieldmaskpbFieldMaskToBoundmessageFieldMask(message.Mask)
+	}
+	if message.Options != nil {
+		v.Options = protobufEmptypbEmptyToBoundmessageEmpty(message.Options)
+	}
+	return v
+}
+
+// NewProtoFieldmaskpbFieldMask builds the gRPC response type from the result
+// of the "Update" endpoint of the "BoundMessage" service.
+func NewProtoFieldmaskpbFieldMask(result *boundmessage.FieldMask) *fieldmaskpb.FieldMask {
+	message := &fieldmaskpb.FieldMask{}
+	if result.Paths != nil {
+		message.Paths = make([]string, len(result.Paths))
+		for i, val := range result.Paths {
+			message.Paths[i] = val
+		}
+	}
+	return message
+}
+
+// ValidateUpdateRequest runs the validations defined on UpdateRequest.
+func ValidateUpdateRequest(message *bound_messagepb.UpdateRequest) (err error) {
+	if message.Mask == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("mask", "message"))
+	}
+	if message.Mask != nil {
+		if err2 := ValidateFieldmaskpbFieldMask(message.Mask); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateFieldmaskpbFieldMask runs the validations defined on
+// FieldmaskpbFieldMask.
+func ValidateFieldmaskpbFieldMask(mask *fieldmaskpb.FieldMask) (err error) {
+	if mask.Paths == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("paths", "mask"))
+	}
+	return
+}
+
+// protobufFieldmaskpbFieldMaskToBoundmessageFieldMask builds a value of type
+// *boundmessage.FieldMask from a value of type *fieldmaskpb.FieldMask.
+func protobufFieldmaskpbFieldMaskToBoundmessageFieldMask(v *fieldmaskpb.FieldMask) *boundmessage.FieldMask {
+	res := &boundmessage.FieldMask{}
+	if v.Paths != nil {
+		res.Paths = make([]string, len(v.Paths))
+		for i, val := range v.Paths {
+			res.Paths[i] = val
+		}
+	}
+
+	return res
+}
+
+// protobufEmptypbEmptyToBoundmessageEmpty builds a value of type
+// *boundmessage.Empty from a value of type *emptypb.Empty.
+func protobufEmptypbEmptyToBoundmessageEmpty(v *emptypb.Empty) *boundmessage.Empty {
+	if v == nil {
+		return nil
+	}
+	res := &boundmessage.Empty{}
+
+	return res
+}
+
+// svcBoundmessageFieldMaskToFieldmaskpbFieldMask builds a value of type
+// *fieldmaskpb.FieldMask from a value of type *boundmessage.FieldMask.
+func svcBoundmessageFieldMaskToFieldmaskpbFieldMask(v *boundmessage.FieldMask) *fieldmaskpb.FieldMask {
+	res := &fieldmaskpb.FieldMask{}
+	if v.Paths != nil {
+		res.Paths = make([]string, len(v.Paths))
+		for i, val := range v.Paths {
+			res.Paths[i] = val
+		}
+	}
+
+	return res
+}
+
+// svcBoundmessageEmptyToEmptypbEmpty builds a value of type *emptypb.Empty
+// from a value of type *boundmessage.Empty.
+func svcBoundmessageEmptyToEmptypbEmpty(v *boundmessage.Empty) *emptypb.Empty {
+	if v == nil {
+		return nil
+	}
+	res := &emptypb.Empty{}
+
+	return res
+}
+`
@@ -0,0 +1,416 @@
+/*
+Package connect serves gRPC service methods over HTTP/1.1 and HTTP/2 using the
+Connect and gRPC-Web protocols so that browsers may call them without a
+translating proxy. See https://connectrpc.com/docs/protocol and
+https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-WEB.md.
+
+The handlers wrap the methods of the generated gRPC servers: the request
+headers are exposed as incoming gRPC metadata and the headers and trailers set
+with grpc.SetHeader, grpc.SendHeader and grpc.SetTrailer are written back
+using the encoding defined by each protocol. This makes it possible to reuse
+the generated request decoders and response encoders as is. Unary and server
+streaming methods are supported, client and bidirectional streaming methods
+require full-duplex HTTP/2 streams and are not.
+
+The generated gRPC server packages define a MountConnect function that mounts
+the handlers on a goa HTTP muxer when the "grpc:connect" meta is set on the
+service or API design.
+*/
+package connect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxMessageSize is the maximum size in bytes of a request message, it
+// matches the gRPC server default.
+const MaxMessageSize = 4 << 20
+
+type (
+	// UnaryFunc is the function called by the unary handlers with the
+	// decoded request message, typically a generated gRPC server method.
+	UnaryFunc func(ctx context.Context, message proto.Message) (proto.Message, error)
+
+	// ServerStreamFunc is the function called by the server streaming
+	// handlers with the decoded request message and the stream used to
+	// send the responses, typically a generated gRPC server method.
+	ServerStreamFunc func(message proto.Message, stream grpc.ServerStream) error
+
+	// HandlerOption configures the handlers created by NewUnaryHandler and
+	// NewServerStreamHandler.
+	HandlerOption func(*handlerOptions)
+
+	// handlerOptions holds the handler options.
+	handlerOptions struct {
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	}
+
+	// codec marshals and unmarshals protocol buffer messages.
+	codec interface {
+		// Name is the codec name used in the content types.
+		Name() string
+		Marshal(proto.Message) ([]byte, error)
+		Unmarshal([]byte, proto.Message) error
+	}
+
+	// protocol describes the wire protocol negotiated for a request.
+	protocol struct {
+		// web is true for gRPC-Web requests, false for Connect requests.
+		web bool
+		// text is true for base64 encoded gRPC-Web requests.
+		text bool
+		// enveloped is true if the messages are length-prefixed, this is
+		// the case for all requests except Connect unary requests.
+		enveloped bool
+		// codec is the message codec.
+		codec codec
+		// contentType is the response content type.
+		contentType string
+	}
+
+	protoCodec struct{}
+	jsonCodec  struct{}
+)
+
+// WithUnaryInterceptors returns a handler option that runs the given
+// interceptors around the unary methods. The first interceptor is the
+// outermost one as with grpc.ChainUnaryInterceptor. The handlers do not go
+// through the grpc.Server so the interceptors given to grpc.NewServer must be
+// provided again with this option to apply to the Connect and gRPC-Web
+// requests.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) HandlerOption {
+	return func(o *handlerOptions) {
+		o.unary = append(o.unary, interceptors...)
+	}
+}
+
+// WithStreamInterceptors returns a handler option that runs the given
+// interceptors around the server streaming methods. The first interceptor is
+// the outermost one as with grpc.ChainStreamInterceptor. The handlers do not
+// go through the grpc.Server so the interceptors given to grpc.NewServer must
+// be provided again with this option to apply to the Connect and gRPC-Web
+// requests.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) HandlerOption {
+	return func(o *handlerOptions) {
+		o.stream = append(o.stream, interceptors...)
+	}
+}
+
+// NewUnaryHandler returns a HTTP handler that serves the unary gRPC method
+// with the given full name (e.g. "/calc.Calc/Add") using the Connect and
+// gRPC-Web protocols. newReq returns a new request message, fn handles the
+// request. The unary interceptors given with WithUnaryInterceptors run
+// around fn.
+func NewUnaryHandler(fullMethod string, newReq func() proto.Message, fn UnaryFunc, opts ...HandlerOption) http.HandlerFunc {
+	fn = chainUnary(fullMethod, fn, newHandlerOptions(opts).unary)
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, ok := negotiate(r, false)
+		if !ok {
+			unsupported(w)
+			return
+		}
+		s, cancel := newServerStream(w, r, fullMethod, p)
+		defer cancel()
+		msg := newReq()
+		err := s.readRequest(msg)
+		if err == nil {
+			var res proto.Message
+			res, err = fn(s.ctx, msg)
+			if err == nil {
+				err = s.SendMsg(res)
+			}
+		}
+		s.end(err)
+	}
+}
+
+// NewServerStreamHandler returns a HTTP handler that serves the server
+// streaming gRPC method with the given full name (e.g. "/calc.Calc/Watch")
+// using the Connect and gRPC-Web protocols. newReq returns a new request
+// message, fn handles the request. The stream interceptors given with
+// WithStreamInterceptors run around fn.
+func NewServerStreamHandler(fullMethod string, newReq func() proto.Message, fn ServerStreamFunc, opts ...HandlerOption) http.HandlerFunc {
+	fn = chainStream(fullMethod, fn, newHandlerOptions(opts).stream)
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, ok := negotiate(r, true)
+		if !ok {
+			unsupported(w)
+			return
+		}
+		s, cancel := newServerStream(w, r, fullMethod, p)
+		defer cancel()
+		s.streaming = true
+		msg := newReq()
+		err := s.readRequest(msg)
+		if err == nil {
+			err = fn(msg, grpcStream{s})
+		}
+		s.end(err)
+	}
+}
+
+// newHandlerOptions applies the given options.
+func newHandlerOptions(opts []HandlerOption) *handlerOptions {
+	o := &handlerOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// chainUnary returns a function that calls fn through the given interceptors.
+func chainUnary(fullMethod string, fn UnaryFunc, interceptors []grpc.UnaryServerInterceptor) UnaryFunc {
+	if len(interceptors) == 0 {
+		return fn
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return fn(ctx, req.(proto.Message))
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return func(ctx context.Context, message proto.Message) (proto.Message, error) {
+		res, err := handler(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := res.(proto.Message)
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "invalid response message type %T", res)
+		}
+		return m, nil
+	}
+}
+
+// chainStream returns a function that calls fn through the given
+// interceptors.
+func chainStream(fullMethod string, fn ServerStreamFunc, interceptors []grpc.StreamServerInterceptor) ServerStreamFunc {
+	if len(interceptors) == 0 {
+		return fn
+	}
+	info := &grpc.StreamServerInfo{FullMethod: fullMethod, IsServerStream: true}
+	return func(message proto.Message, stream grpc.ServerStream) error {
+		handler := func(_ any, stream grpc.ServerStream) error {
+			return fn(message, stream)
+		}
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(srv any, stream grpc.ServerStream) error {
+				return interceptor(srv, stream, info, next)
+			}
+		}
+		return handler(nil, stream)
+	}
+}
+
+// negotiate returns the protocol used by the request given its content type.
+// Connect unary requests are only accepted by unary handlers and Connect
+// streaming requests by streaming handlers.
+func negotiate(r *http.Request, streaming bool) (*protocol, bool) {
+	ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, false
+	}
+	switch ct {
+	case "application/proto", "application/json":
+		if streaming {
+			return nil, false
+		}
+		c := codecFor(strings.TrimPrefix(ct, "application/"))
+		return &protocol{codec: c, contentType: ct}, true
+	case "application/connect+proto", "application/connect+json":
+		if !streaming {
+			return nil, false
+		}
+		c := codecFor(strings.TrimPrefix(ct, "application/connect+"))
+		return &protocol{enveloped: true, codec: c, contentType: ct}, true
+	case "application/grpc-web", "application/grpc-web+proto", "application/grpc-web+json":
+		c := codecFor(strings.TrimPrefix(strings.TrimPrefix(ct, "application/grpc-web"), "+"))
+		return &protocol{web: true, enveloped: true, codec: c, contentType: "application/grpc-web+" + c.Name()}, true
+	case "application/grpc-web-text", "application/grpc-web-text+proto":
+		return &protocol{web: true, text: true, enveloped: true, codec: protoCodec{}, contentType: "application/grpc-web-text+proto"}, true
+	}
+	return nil, false
+}
+
+// codecFor returns the codec with the given name, "proto" if empty.
+func codecFor(name string) codec {
+	if name == "json" {
+		return jsonCodec{}
+	}
+	return protoCodec{}
+}
+
+// unsupported writes the response sent when the request content type does not
+// match any of the supported protocols.
+func unsupported(w http.ResponseWriter) {
+	http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+}
+
+// incomingContext returns the context given to the gRPC methods. It contains
+// the request headers as incoming metadata, the peer address, the TLS
+// connection state so that goagrpc.PeerCertificates returns the client
+// certificates verified by the server and the deadline set by the client if
+// any.
+func incomingContext(r *http.Request, p *protocol) (context.Context, context.CancelFunc, error) {
+	md := make(metadata.MD, len(r.Header))
+	for k, vs := range r.Header {
+		k = strings.ToLower(k)
+		for _, v := range vs {
+			if strings.HasSuffix(k, "-bin") {
+				b, err := decodeBinaryHeader(v)
+				if err != nil {
+					return r.Context(), func() {}, status.Errorf(codes.InvalidArgument, "invalid binary header %q", k)
+				}
+				v = string(b)
+			}
+			md[k] = append(md[k], v)
+		}
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	var pi peer.Peer
+	if ap, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		pi.Addr = net.TCPAddrFromAddrPort(ap)
+	}
+	if r.TLS != nil {
+		pi.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	if pi.Addr != nil || pi.AuthInfo != nil {
+		ctx = peer.NewContext(ctx, &pi)
+	}
+	timeout, err := requestTimeout(r, p)
+	if err != nil {
+		return ctx, func() {}, err
+	}
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, cancel, nil
+}
+
+// requestTimeout returns the timeout set in the Connect-Timeout-Ms header for
+// Connect requests and in the grpc-timeout header for gRPC-Web requests.
+func requestTimeout(r *http.Request, p *protocol) (time.Duration, error) {
+	if !p.web {
+		v := r.Header.Get("Connect-Timeout-Ms")
+		if v == "" {
+			return 0, nil
+		}
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || ms < 0 || len(v) > 10 {
+			return 0, status.Errorf(codes.InvalidArgument, "invalid Connect-Timeout-Ms header %q", v)
+		}
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+	v := r.Header.Get("Grpc-Timeout")
+	if v == "" {
+		return 0, nil
+	}
+	invalid := status.Errorf(codes.InvalidArgument, "invalid grpc-timeout header %q", v)
+	if len(v) < 2 || len(v) > 9 {
+		return 0, invalid
+	}
+	n, err := strconv.ParseInt(v[:len(v)-1], 10, 64)
+	if err != nil || n < 0 {
+		return 0, invalid
+	}
+	var unit time.Duration
+	switch v[len(v)-1] {
+	case 'H':
+		unit = time.Hour
+	case 'M':
+		unit = time.Minute
+	case 'S':
+		unit = time.Second
+	case 'm':
+		unit = time.Millisecond
+	case 'u':
+		unit = time.Microsecond
+	case 'n':
+		unit = time.Nanosecond
+	default:
+		return 0, invalid
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// checkEncoding returns an error if the request messages are compressed.
+func checkEncoding(r *http.Request, p *protocol) error {
+	var h string
+	switch {
+	case p.web:
+		h = "Grpc-Encoding"
+	case p.enveloped:
+		h = "Connect-Content-Encoding"
+	default:
+		h = "Content-Encoding"
+	}
+	if enc := r.Header.Get(h); enc != "" && enc != "identity" {
+		return status.Errorf(codes.Unimplemented, "unsupported compression %q", enc)
+	}
+	return nil
+}
+
+// Name returns "proto".
+func (protoCodec) Name() string { return "proto" }
+
+// Marshal encodes m using the protocol buffer binary format.
+func (protoCodec) Marshal(m proto.Message) ([]byte, error) { return proto.Marshal(m) }
+
+// Unmarshal decodes the protocol buffer binary data into m.
+func (protoCodec) Unmarshal(data []byte, m proto.Message) error { return proto.Unmarshal(data, m) }
+
+// Name returns "json".
+func (jsonCodec) Name() string { return "json" }
+
+// Marshal encodes m using the protocol buffer JSON mapping.
+func (jsonCodec) Marshal(m proto.Message) ([]byte, error) { return protojson.Marshal(m) }
+
+// Unmarshal decodes the JSON data into m, unknown fields are ignored.
+func (jsonCodec) Unmarshal(data []byte, m proto.Message) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, m)
+}
+
+// errMessageTooLarge is the error returned when a request message exceeds
+// MaxMessageSize.
+var errMessageTooLarge = status.Errorf(codes.ResourceExhausted, "message larger than max (%d bytes)", MaxMessageSize)
+
+// invalidMessage returns the error returned when a request message cannot be
+// decoded.
+func invalidMessage(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.InvalidArgument, fmt.Sprintf("failed to decode request message: %s", err))
+}
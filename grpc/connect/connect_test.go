@@ -0,0 +1,453 @@
+package connect
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	goagrpc "goa.design/goa/v3/grpc"
+	goapb "goa.design/goa/v3/grpc/pb"
+	"goa.design/goa/v3/security"
+)
+
+const method = "/test.Test/Echo"
+
+func newString() proto.Message { return new(wrapperspb.StringValue) }
+
+// echo returns the request message, it sets the response header and trailer
+// from the "x-echo" request metadata.
+func echo(ctx context.Context, m proto.Message) (proto.Message, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("x-echo"); len(v) > 0 {
+		grpc.SetHeader(ctx, metadata.Pairs("x-header", v[0]))   // nolint: errcheck
+		grpc.SetTrailer(ctx, metadata.Pairs("x-trailer", v[0])) // nolint: errcheck
+	}
+	msg := m.(*wrapperspb.StringValue).GetValue()
+	if msg == "fail" {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if msg == "deadline" {
+		if _, ok := ctx.Deadline(); !ok {
+			return nil, status.Error(codes.Internal, "no deadline")
+		}
+	}
+	return wrapperspb.String(msg), nil
+}
+
+func TestConnectUnary(t *testing.T) {
+	cases := map[string]struct {
+		ContentType string
+		Body        []byte
+		Headers     map[string]string
+		Status      int
+		Response    string
+	}{
+		"proto":       {"application/proto", marshal(t, "hello"), nil, http.StatusOK, "hello"},
+		"json":        {"application/json", []byte(`"hello"`), nil, http.StatusOK, "hello"},
+		"json-params": {"application/json; charset=utf-8", []byte(`"hello"`), nil, http.StatusOK, "hello"},
+		"empty":       {"application/proto", nil, nil, http.StatusOK, ""},
+		"timeout":     {"application/proto", marshal(t, "deadline"), map[string]string{"Connect-Timeout-Ms": "1000"}, http.StatusOK, "deadline"},
+		"bad-timeout": {"application/proto", marshal(t, "hello"), map[string]string{"Connect-Timeout-Ms": "soon"}, http.StatusBadRequest, ""},
+		"compressed":  {"application/proto", marshal(t, "hello"), map[string]string{"Content-Encoding": "gzip"}, http.StatusNotImplemented, ""},
+		"invalid":     {"application/json", []byte(`{`), nil, http.StatusBadRequest, ""},
+		"streaming":   {"application/connect+proto", nil, nil, http.StatusUnsupportedMediaType, ""},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", method, bytes.NewReader(c.Body))
+			req.Header.Set("Content-Type", c.ContentType)
+			for k, v := range c.Headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			NewUnaryHandler(method, newString, echo).ServeHTTP(w, req)
+
+			require.Equal(t, c.Status, w.Code, w.Body.String())
+			if c.Status != http.StatusOK {
+				return
+			}
+			assert.Equal(t, strings.Split(c.ContentType, ";")[0], w.Header().Get("Content-Type"))
+			res := new(wrapperspb.StringValue)
+			if c.ContentType == "application/proto" {
+				require.NoError(t, proto.Unmarshal(w.Body.Bytes(), res))
+			} else {
+				require.NoError(t, jsonCodec{}.Unmarshal(w.Body.Bytes(), res))
+			}
+			assert.Equal(t, c.Response, res.GetValue())
+		})
+	}
+}
+
+func TestConnectUnaryMetadata(t *testing.T) {
+	req := httptest.NewRequest("POST", method, bytes.NewReader(marshal(t, "hello")))
+	req.Header.Set("Content-Type", "application/proto")
+	req.Header.Set("X-Echo", "value")
+	w := httptest.NewRecorder()
+
+	NewUnaryHandler(method, newString, echo).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "value", w.Header().Get("X-Header"))
+	assert.Equal(t, "value", w.Header().Get("Trailer-X-Trailer"))
+}
+
+func TestConnectUnaryError(t *testing.T) {
+	details := &goapb.ErrorResponse{Name: "not_found", Msg: "not found"}
+	st, err := status.New(codes.NotFound, "not found").WithDetails(details)
+	require.NoError(t, err)
+	fn := func(context.Context, proto.Message) (proto.Message, error) { return nil, st.Err() }
+	req := httptest.NewRequest("POST", method, nil)
+	req.Header.Set("Content-Type", "application/proto")
+	w := httptest.NewRecorder()
+
+	NewUnaryHandler(method, newString, fn).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var ce connectError
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ce))
+	assert.Equal(t, "not_found", ce.Code)
+	assert.Equal(t, "not found", ce.Message)
+	require.Len(t, ce.Details, 1)
+	assert.Equal(t, "goapb.ErrorResponse", ce.Details[0].Type)
+	b, err := base64.RawStdEncoding.DecodeString(ce.Details[0].Value)
+	require.NoError(t, err)
+	var actual goapb.ErrorResponse
+	require.NoError(t, proto.Unmarshal(b, &actual))
+	assert.Equal(t, "not_found", actual.Name)
+}
+
+func TestConnectPeerCertificates(t *testing.T) {
+	ca, caKey := newCertificate(t, "ca", nil, nil)
+	client, clientKey := newCertificate(t, "client", ca, caKey)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	var certs []*x509.Certificate
+	fn := func(ctx context.Context, m proto.Message) (proto.Message, error) {
+		ctx = security.ContextWithPeerCertificates(ctx, goagrpc.PeerCertificates(ctx))
+		certs = security.PeerCertificatesFromContext(ctx)
+		return m, nil
+	}
+	srv := httptest.NewUnstartedServer(NewUnaryHandler(method, newString, fn))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	srv.StartTLS()
+	defer srv.Close()
+	c := srv.Client()
+	c.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{{Certificate: [][]byte{client.Raw}, PrivateKey: clientKey}}
+
+	resp, err := c.Post(srv.URL+method, "application/proto", bytes.NewReader(marshal(t, "hello")))
+	require.NoError(t, err)
+	defer resp.Body.Close() // nolint: errcheck
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, certs, 2)
+	assert.Equal(t, "client", certs[0].Subject.CommonName)
+	assert.Equal(t, "ca", certs[1].Subject.CommonName)
+}
+
+func TestConnectServerStream(t *testing.T) {
+	fn := func(m proto.Message, stream grpc.ServerStream) error {
+		require.NoError(t, stream.SendHeader(metadata.Pairs("x-header", "value")))
+		stream.SetTrailer(metadata.Pairs("x-trailer", "value"))
+		for i := 0; i < 2; i++ {
+			if err := stream.SendMsg(m); err != nil {
+				return err
+			}
+		}
+		return status.Error(codes.Unavailable, "bye")
+	}
+	req := httptest.NewRequest("POST", method, bytes.NewReader(frame(0, marshal(t, "hello"))))
+	req.Header.Set("Content-Type", "application/connect+proto")
+	w := httptest.NewRecorder()
+
+	NewServerStreamHandler(method, newString, fn).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/connect+proto", w.Header().Get("Content-Type"))
+	assert.Equal(t, "value", w.Header().Get("X-Header"))
+	frames := readFrames(t, w.Body)
+	require.Len(t, frames, 3)
+	for _, f := range frames[:2] {
+		assert.Equal(t, byte(0), f.flags)
+		assert.Equal(t, "hello", unmarshal(t, f.data))
+	}
+	assert.Equal(t, byte(flagEndStream), frames[2].flags)
+	var es endStream
+	require.NoError(t, json.Unmarshal(frames[2].data, &es))
+	require.NotNil(t, es.Error)
+	assert.Equal(t, "unavailable", es.Error.Code)
+	assert.Equal(t, "bye", es.Error.Message)
+	assert.Equal(t, []string{"value"}, es.Metadata["x-trailer"])
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			assert.Equal(t, method, info.FullMethod)
+			calls = append(calls, name)
+			if req.(*wrapperspb.StringValue).GetValue() == "deny" {
+				return nil, status.Error(codes.PermissionDenied, "denied")
+			}
+			return handler(ctx, req)
+		}
+	}
+	handler := NewUnaryHandler(method, newString, echo, WithUnaryInterceptors(interceptor("first"), interceptor("second")))
+
+	req := httptest.NewRequest("POST", method, bytes.NewReader(marshal(t, "hello")))
+	req.Header.Set("Content-Type", "application/proto")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello", unmarshal(t, w.Body.Bytes()))
+	assert.Equal(t, []string{"first", "second"}, calls)
+
+	req = httptest.NewRequest("POST", method, bytes.NewReader(marshal(t, "deny")))
+	req.Header.Set("Content-Type", "application/proto")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.StreamServerInterceptor {
+		return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			assert.Equal(t, method, info.FullMethod)
+			assert.True(t, info.IsServerStream)
+			calls = append(calls, name)
+			return handler(srv, stream)
+		}
+	}
+	fn := func(m proto.Message, stream grpc.ServerStream) error {
+		return stream.SendMsg(m)
+	}
+	req := httptest.NewRequest("POST", method, bytes.NewReader(frame(0, marshal(t, "hello"))))
+	req.Header.Set("Content-Type", "application/connect+proto")
+	w := httptest.NewRecorder()
+
+	NewServerStreamHandler(method, newString, fn, WithStreamInterceptors(interceptor("first"), interceptor("second"))).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	frames := readFrames(t, w.Body)
+	require.Len(t, frames, 2)
+	assert.Equal(t, "hello", unmarshal(t, frames[0].data))
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestGRPCWebUnary(t *testing.T) {
+	cases := map[string]struct {
+		Message string
+		Text    bool
+		Status  string
+		Msg     string
+	}{
+		"ok":         {Message: "hello", Status: "0"},
+		"text":       {Message: "hello", Text: true, Status: "0"},
+		"error":      {Message: "fail", Status: "5", Msg: "not found"},
+		"text-error": {Message: "fail", Text: true, Status: "5", Msg: "not found"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := frame(0, marshal(t, c.Message))
+			ct := "application/grpc-web+proto"
+			if c.Text {
+				body = []byte(base64.StdEncoding.EncodeToString(body))
+				ct = "application/grpc-web-text"
+			}
+			req := httptest.NewRequest("POST", method, bytes.NewReader(body))
+			req.Header.Set("Content-Type", ct)
+			req.Header.Set("X-Echo", "value")
+			req.Header.Set("Grpc-Timeout", "1S")
+			w := httptest.NewRecorder()
+
+			NewUnaryHandler(method, newString, echo).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var resp io.Reader = w.Body
+			if c.Text {
+				assert.Equal(t, "application/grpc-web-text+proto", w.Header().Get("Content-Type"))
+				resp = strings.NewReader(decodeChunks(t, w.Body.String()))
+			} else {
+				assert.Equal(t, ct, w.Header().Get("Content-Type"))
+			}
+			assert.Equal(t, "value", w.Header().Get("X-Header"))
+			frames := readFrames(t, resp)
+			trailer := frames[len(frames)-1]
+			assert.Equal(t, byte(flagTrailer), trailer.flags)
+			trailers := string(trailer.data)
+			assert.Contains(t, trailers, "grpc-status: "+c.Status+"\r\n")
+			assert.Contains(t, trailers, "x-trailer: value\r\n")
+			if c.Msg != "" {
+				require.Len(t, frames, 1)
+				assert.Contains(t, trailers, "grpc-message: "+c.Msg+"\r\n")
+				return
+			}
+			require.Len(t, frames, 2)
+			assert.Equal(t, c.Message, unmarshal(t, frames[0].data))
+		})
+	}
+}
+
+func TestUnsupportedContentType(t *testing.T) {
+	req := httptest.NewRequest("POST", method, nil)
+	req.Header.Set("Content-Type", "text/plain")
+	w := httptest.NewRecorder()
+
+	NewUnaryHandler(method, newString, echo).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	cases := map[string]struct {
+		Header   string
+		Value    string
+		Web      bool
+		Expected time.Duration
+		Error    bool
+	}{
+		"connect":      {"Connect-Timeout-Ms", "1500", false, 1500 * time.Millisecond, false},
+		"connect-none": {"", "", false, 0, false},
+		"web-seconds":  {"Grpc-Timeout", "2S", true, 2 * time.Second, false},
+		"web-millis":   {"Grpc-Timeout", "100m", true, 100 * time.Millisecond, false},
+		"web-unit":     {"Grpc-Timeout", "100x", true, 0, true},
+		"web-too-long": {"Grpc-Timeout", "1234567890S", true, 0, true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", method, nil)
+			if c.Header != "" {
+				req.Header.Set(c.Header, c.Value)
+			}
+
+			d, err := requestTimeout(req, &protocol{web: c.Web})
+
+			if c.Error {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.Expected, d)
+		})
+	}
+}
+
+type testFrame struct {
+	flags byte
+	data  []byte
+}
+
+// newCertificate returns a certificate with the given common name signed by
+// parent or self-signed if parent is nil.
+func newCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func marshal(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := proto.Marshal(wrapperspb.String(s))
+	require.NoError(t, err)
+	return b
+}
+
+func unmarshal(t *testing.T, b []byte) string {
+	t.Helper()
+	var v wrapperspb.StringValue
+	require.NoError(t, proto.Unmarshal(b, &v))
+	return v.GetValue()
+}
+
+func frame(flags byte, data []byte) []byte {
+	b := make([]byte, 5, 5+len(data))
+	b[0] = flags
+	binary.BigEndian.PutUint32(b[1:], uint32(len(data)))
+	return append(b, data...)
+}
+
+func readFrames(t *testing.T, r io.Reader) []testFrame {
+	t.Helper()
+	var frames []testFrame
+	for {
+		var prefix [5]byte
+		if _, err := io.ReadFull(r, prefix[:]); err == io.EOF {
+			return frames
+		} else {
+			require.NoError(t, err)
+		}
+		data := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+		_, err := io.ReadFull(r, data)
+		require.NoError(t, err)
+		frames = append(frames, testFrame{flags: prefix[0], data: data})
+	}
+}
+
+// decodeChunks decodes a gRPC-Web text response made of concatenated padded
+// base64 chunks.
+func decodeChunks(t *testing.T, s string) string {
+	t.Helper()
+	var out []byte
+	for len(s) > 0 {
+		n := strings.Index(s, "=")
+		end := len(s)
+		if n >= 0 {
+			end = n
+			for end < len(s) && s[end] == '=' {
+				end++
+			}
+		}
+		b, err := base64.StdEncoding.DecodeString(s[:end])
+		require.NoError(t, err)
+		out = append(out, b...)
+		s = s[end:]
+	}
+	return string(out)
+}
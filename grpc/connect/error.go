@@ -0,0 +1,187 @@
+package connect
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// connectError is the JSON representation of errors in the Connect
+	// protocol.
+	connectError struct {
+		Code    string         `json:"code"`
+		Message string         `json:"message,omitempty"`
+		Details []*errorDetail `json:"details,omitempty"`
+	}
+
+	// errorDetail is the JSON representation of a Connect error detail.
+	errorDetail struct {
+		// Type is the fully qualified name of the detail message.
+		Type string `json:"type"`
+		// Value is the base64 encoded detail message.
+		Value string `json:"value"`
+	}
+
+	// endStream is the JSON representation of the Connect end-of-stream
+	// message.
+	endStream struct {
+		Error    *connectError       `json:"error,omitempty"`
+		Metadata map[string][]string `json:"metadata,omitempty"`
+	}
+)
+
+// codeNames lists the Connect names of the gRPC status codes.
+var codeNames = map[codes.Code]string{
+	codes.Canceled:           "canceled",
+	codes.Unknown:            "unknown",
+	codes.InvalidArgument:    "invalid_argument",
+	codes.DeadlineExceeded:   "deadline_exceeded",
+	codes.NotFound:           "not_found",
+	codes.AlreadyExists:      "already_exists",
+	codes.PermissionDenied:   "permission_denied",
+	codes.ResourceExhausted:  "resource_exhausted",
+	codes.FailedPrecondition: "failed_precondition",
+	codes.Aborted:            "aborted",
+	codes.OutOfRange:         "out_of_range",
+	codes.Unimplemented:      "unimplemented",
+	codes.Internal:           "internal",
+	codes.Unavailable:        "unavailable",
+	codes.DataLoss:           "data_loss",
+	codes.Unauthenticated:    "unauthenticated",
+}
+
+// httpStatus returns the HTTP status code of Connect unary error responses
+// with the given gRPC status code.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// statusFromError returns the gRPC status for the given error. Context errors
+// are mapped to the Canceled and DeadlineExceeded codes, errors that are not
+// gRPC status errors to the Unknown code.
+func statusFromError(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+	return status.New(codes.Unknown, err.Error())
+}
+
+// newConnectError returns the Connect representation of the given status.
+func newConnectError(st *status.Status) *connectError {
+	name, ok := codeNames[st.Code()]
+	if !ok {
+		name = codeNames[codes.Unknown]
+	}
+	ce := &connectError{Code: name, Message: st.Message()}
+	for _, d := range st.Proto().GetDetails() {
+		typ := d.GetTypeUrl()
+		if i := strings.LastIndexByte(typ, '/'); i >= 0 {
+			typ = typ[i+1:]
+		}
+		ce.Details = append(ce.Details, &errorDetail{
+			Type:  typ,
+			Value: base64.RawStdEncoding.EncodeToString(d.GetValue()),
+		})
+	}
+	return ce
+}
+
+// marshalConnectError returns the JSON body of Connect unary error responses.
+func marshalConnectError(st *status.Status) []byte {
+	b, _ := json.Marshal(newConnectError(st)) // nolint: errchkjson
+	return b
+}
+
+// endStreamMessage returns the JSON body of the Connect end-of-stream message.
+func endStreamMessage(err error, trailer metadata.MD) []byte {
+	var es endStream
+	if err != nil {
+		es.Error = newConnectError(statusFromError(err))
+	}
+	if len(trailer) > 0 {
+		es.Metadata = make(map[string][]string, len(trailer))
+		for k, vs := range trailer {
+			for _, v := range vs {
+				es.Metadata[k] = append(es.Metadata[k], headerValue(k, v))
+			}
+		}
+	}
+	b, _ := json.Marshal(&es) // nolint: errchkjson
+	return b
+}
+
+// webTrailers returns the body of the gRPC-Web trailers frame. The frame
+// contains the status code, message and details followed by the trailers
+// formatted as HTTP/1 headers.
+func webTrailers(err error, trailer metadata.MD) []byte {
+	var buf bytes.Buffer
+	write := func(k, v string) {
+		buf.WriteString(k)
+		buf.WriteString(": ")
+		buf.WriteString(v)
+		buf.WriteString("\r\n")
+	}
+	code := codes.OK
+	if err != nil {
+		st := statusFromError(err)
+		code = st.Code()
+		if msg := st.Message(); msg != "" {
+			write("grpc-message", encodeMessage(msg))
+		}
+		if len(st.Proto().GetDetails()) > 0 {
+			if b, err := proto.Marshal(st.Proto()); err == nil {
+				write("grpc-status-details-bin", base64.RawStdEncoding.EncodeToString(b))
+			}
+		}
+	}
+	write("grpc-status", strconv.Itoa(int(code)))
+	for k, vs := range trailer {
+		for _, v := range vs {
+			write(strings.ToLower(k), headerValue(k, v))
+		}
+	}
+	return buf.Bytes()
+}
+
+// encodeMessage percent-encodes the status message as required by the gRPC
+// protocol.
+func encodeMessage(msg string) string {
+	return strings.ReplaceAll(url.PathEscape(msg), "%20", " ")
+}
@@ -0,0 +1,286 @@
+package connect
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// flagEndStream is the envelope flag of the Connect end-of-stream
+	// message.
+	flagEndStream = 0x02
+	// flagTrailer is the envelope flag of the gRPC-Web trailers frame.
+	flagTrailer = 0x80
+	// flagCompressed is the envelope flag of compressed messages.
+	flagCompressed = 0x01
+)
+
+// serverStream implements the grpc.ServerTransportStream interface used by
+// grpc.SetHeader, grpc.SendHeader and grpc.SetTrailer.
+type serverStream struct {
+	w          http.ResponseWriter
+	r          *http.Request
+	ctx        context.Context
+	method     string
+	proto      *protocol
+	streaming  bool
+	mu         sync.Mutex
+	header     metadata.MD
+	trailer    metadata.MD
+	sentHeader bool
+	// body holds the response message of Connect unary requests which
+	// is written together with the trailers once the method returns.
+	body []byte
+	// err is the error that occurred while initializing the stream.
+	err error
+}
+
+// grpcStream adapts serverStream to the grpc.ServerStream interface given to
+// the server streaming methods.
+type grpcStream struct {
+	*serverStream
+}
+
+// newServerStream initializes the stream used to serve the request. The
+// returned function must be called once the request is served.
+func newServerStream(w http.ResponseWriter, r *http.Request, method string, p *protocol) (*serverStream, context.CancelFunc) {
+	s := &serverStream{
+		w:       w,
+		r:       r,
+		method:  method,
+		proto:   p,
+		header:  metadata.MD{},
+		trailer: metadata.MD{},
+	}
+	ctx, cancel, err := incomingContext(r, p)
+	s.ctx = grpc.NewContextWithServerTransportStream(ctx, s)
+	s.err = err
+	if s.err == nil {
+		s.err = checkEncoding(r, p)
+	}
+	return s, cancel
+}
+
+// Method returns the full name of the gRPC method.
+func (s *serverStream) Method() string { return s.method }
+
+// Context returns the request context.
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// SetHeader merges md into the response headers.
+func (s *serverStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sentHeader {
+		return errors.New("connect: headers already sent")
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// SendHeader merges md into the response headers and writes them for server
+// streaming requests. The headers of unary requests are written together with
+// the response.
+func (s *serverStream) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	if !s.streaming {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeHeader(http.StatusOK, s.proto.contentType)
+	flush(s.w)
+	return nil
+}
+
+// SetTrailer merges md into the response trailers.
+func (s *serverStream) SetTrailer(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// SetTrailer merges md into the response trailers.
+func (s grpcStream) SetTrailer(md metadata.MD) {
+	s.serverStream.SetTrailer(md) // nolint: errcheck
+}
+
+// SendMsg writes a response message.
+func (s *serverStream) SendMsg(m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("connect: invalid message type %T", m)
+	}
+	data, err := s.proto.codec.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.proto.enveloped {
+		s.body = data
+		return nil
+	}
+	s.writeHeader(http.StatusOK, s.proto.contentType)
+	if err := s.writeFrame(0, data); err != nil {
+		return err
+	}
+	flush(s.w)
+	return nil
+}
+
+// RecvMsg returns io.EOF, the request message of unary and server streaming
+// methods is read before the method is called.
+func (s *serverStream) RecvMsg(any) error { return io.EOF }
+
+// readRequest reads the request message into msg.
+func (s *serverStream) readRequest(msg proto.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	limit := int64(MaxMessageSize)
+	if s.proto.enveloped {
+		limit += 5
+	}
+	if s.proto.text {
+		limit = int64(base64.StdEncoding.EncodedLen(int(limit)))
+	}
+	var body io.Reader = http.MaxBytesReader(s.w, s.r.Body, limit)
+	if !s.proto.enveloped {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			var mbe *http.MaxBytesError
+			if errors.As(err, &mbe) {
+				return errMessageTooLarge
+			}
+			return invalidMessage(err)
+		}
+		return invalidMessage(s.proto.codec.Unmarshal(data, msg))
+	}
+	if s.proto.text {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			// Empty body, no message fields set.
+			return nil
+		}
+		return invalidMessage(err)
+	}
+	if prefix[0]&flagCompressed != 0 {
+		return invalidMessage(errors.New("compressed messages are not supported"))
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > MaxMessageSize {
+		return errMessageTooLarge
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(body, data); err != nil {
+		return invalidMessage(err)
+	}
+	return invalidMessage(s.proto.codec.Unmarshal(data, msg))
+}
+
+// end writes the response status and trailers.
+func (s *serverStream) end(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.proto.web:
+		s.writeHeader(http.StatusOK, s.proto.contentType)
+		s.writeFrame(flagTrailer, webTrailers(err, s.trailer)) // nolint: errcheck
+	case s.proto.enveloped:
+		s.writeHeader(http.StatusOK, s.proto.contentType)
+		s.writeFrame(flagEndStream, endStreamMessage(err, s.trailer)) // nolint: errcheck
+	default:
+		for k, vs := range s.trailer {
+			for _, v := range vs {
+				s.w.Header().Add("Trailer-"+k, headerValue(k, v))
+			}
+		}
+		if err != nil {
+			s.writeError(err)
+			return
+		}
+		s.writeHeader(http.StatusOK, s.proto.contentType)
+		s.w.Write(s.body) // nolint: errcheck
+	}
+	flush(s.w)
+}
+
+// writeHeader writes the response headers if not already written. s.mu must
+// be held.
+func (s *serverStream) writeHeader(code int, contentType string) {
+	if s.sentHeader {
+		return
+	}
+	s.sentHeader = true
+	h := s.w.Header()
+	for k, vs := range s.header {
+		for _, v := range vs {
+			h.Add(k, headerValue(k, v))
+		}
+	}
+	h.Set("Content-Type", contentType)
+	s.w.WriteHeader(code)
+}
+
+// writeError writes a Connect unary error response. s.mu must be held.
+func (s *serverStream) writeError(err error) {
+	st := statusFromError(err)
+	body := marshalConnectError(st)
+	s.writeHeader(httpStatus(st.Code()), "application/json")
+	s.w.Write(body) // nolint: errcheck
+}
+
+// writeFrame writes a length-prefixed message. s.mu must be held.
+func (s *serverStream) writeFrame(flags byte, data []byte) error {
+	var buf bytes.Buffer
+	buf.Grow(len(data) + 5)
+	buf.WriteByte(flags)
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(data)))
+	buf.Write(size[:])
+	buf.Write(data)
+	frame := buf.Bytes()
+	if s.proto.text {
+		frame = []byte(base64.StdEncoding.EncodeToString(frame))
+	}
+	_, err := s.w.Write(frame)
+	return err
+}
+
+// headerValue returns the HTTP header value for the given metadata value,
+// binary values are base64 encoded.
+func headerValue(k, v string) string {
+	if strings.HasSuffix(k, "-bin") {
+		return base64.RawStdEncoding.EncodeToString([]byte(v))
+	}
+	return v
+}
+
+// decodeBinaryHeader decodes a padded or unpadded base64 header value.
+func decodeBinaryHeader(v string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(v, "="))
+}
+
+// flush sends any buffered data to the client.
+func flush(w http.ResponseWriter) {
+	http.NewResponseController(w).Flush() // nolint: errcheck
+}
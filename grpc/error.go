@@ -3,13 +3,19 @@ package grpc
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type (
@@ -30,8 +36,25 @@ type (
 		// Is the error a server-side fault?
 		Fault bool
 	}
+
+	// rateLimiter is implemented by errors that describe an exhausted rate
+	// limit quota, see package ratelimit.
+	rateLimiter interface {
+		RateLimit() (limit, remaining int, retryAfter time.Duration)
+	}
 )
 
+// ErrorDomain is the domain set in the google.rpc.ErrorInfo details added to
+// the gRPC status errors. It may be overridden to identify the service
+// producing the errors.
+var ErrorDomain = "goa.design"
+
+// InvalidArgumentOnFieldViolations causes EncodeError to use the
+// InvalidArgument status code instead of Unknown for the service errors that
+// describe field violations, typically validation errors. It is disabled by
+// default so that the status codes of existing services do not change.
+var InvalidArgumentOnFieldViolations = false
+
 // NewErrorResponse creates a new ErrorResponse protocol buffer message from
 // the given error. If the given error is a goa ServiceError, the ErrorResponse
 // message will be set with the corresponding Timeout, Temporary, and Fault
@@ -66,15 +89,73 @@ func NewServiceError(resp *goapb.ErrorResponse) *goa.ServiceError {
 }
 
 // NewStatusError creates a gRPC status error with the error response
-// messages added to its details.
+// messages added to its details. The standard google.rpc error details
+// computed from the error are appended so that clients that do not know about
+// the Goa error messages may interpret the error: ErrorInfo with the error
+// name as reason, BadRequest with the field violations of validation errors
+// and RetryInfo for temporary errors. The scalar fields of the error response
+// messages are also added to the ErrorInfo metadata keyed by field name, see
+// DecodeErrorMetadata.
 func NewStatusError(code codes.Code, err error, details ...protoiface.MessageV1) error {
 	st := status.New(code, err.Error())
+	errDetails := NewErrorDetails(err)
+	if len(errDetails) > 0 {
+		info := errDetails[0].(*errdetails.ErrorInfo)
+		for _, d := range details {
+			if _, ok := d.(*goapb.ErrorResponse); ok {
+				continue
+			}
+			if m, ok := d.(proto.Message); ok {
+				for k, v := range errorMetadata(m) {
+					if _, ok := info.Metadata[k]; !ok {
+						info.Metadata[k] = v
+					}
+				}
+			}
+		}
+	}
+	details = append(details, errDetails...)
 	if s, err := st.WithDetails(details...); err == nil {
 		return s.Err()
 	}
 	return st.Err()
 }
 
+// NewErrorDetails returns the standard google.rpc error details that describe
+// the given error. It returns nil if the error does not define a name.
+func NewErrorDetails(err error) []protoiface.MessageV1 {
+	var en goa.GoaErrorNamer
+	if !errors.As(err, &en) {
+		return nil
+	}
+	info := &errdetails.ErrorInfo{
+		Reason:   strings.ToUpper(en.GoaErrorName()),
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{"name": en.GoaErrorName()},
+	}
+	details := []protoiface.MessageV1{info}
+	var gerr *goa.ServiceError
+	if !errors.As(err, &gerr) {
+		return details
+	}
+	if gerr.ID != "" {
+		info.Metadata["id"] = gerr.ID
+	}
+	if violations := fieldViolations(gerr); len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if gerr.Temporary {
+		retry := &errdetails.RetryInfo{}
+		var rl rateLimiter
+		if errors.As(err, &rl) {
+			_, _, after := rl.RateLimit()
+			retry.RetryDelay = durationpb.New(after)
+		}
+		details = append(details, retry)
+	}
+	return details
+}
+
 // EncodeError returns a gRPC status error from the given error with the error
 // response encoded in the status details. If error is a goa ServiceError type
 // it implements a heuristic to compute the status code from the Timeout,
@@ -93,6 +174,9 @@ func EncodeError(err error) error {
 		// goa service error type. Compute the status code from the service error
 		// characteristics and create a new detailed gRPC status error.
 		code := codes.Unknown
+		if InvalidArgumentOnFieldViolations && len(fieldViolations(gerr)) > 0 {
+			code = codes.InvalidArgument
+		}
 		if gerr.Fault {
 			code = codes.Internal
 		}
@@ -102,16 +186,45 @@ func EncodeError(err error) error {
 		if gerr.Temporary {
 			code = codes.Unavailable
 		}
+		if gerr.Name == goa.RateLimitExceeded {
+			code = codes.ResourceExhausted
+		}
+		if gerr.Name == goa.Unauthenticated {
+			code = codes.Unauthenticated
+		}
+		if gerr.Name == goa.InsufficientScope {
+			code = codes.PermissionDenied
+		}
+		if gerr.Name == goa.PreconditionFailed {
+			code = codes.FailedPrecondition
+		}
 		return NewStatusError(code, err, NewErrorResponse(err))
 	}
 	// Return an unknown gRPC status error with fault characteristic set.
 	return NewStatusError(codes.Unknown, err, NewErrorResponse(err))
 }
 
+// fieldViolations returns the field violations described by the given error
+// and the errors merged into it, typically validation errors.
+func fieldViolations(gerr *goa.ServiceError) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, h := range gerr.History() {
+		if h.Field != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       *h.Field,
+				Description: h.Message,
+			})
+		}
+	}
+	return violations
+}
+
 // DecodeError returns the error message encoded in the status details if error
 // is a gRPC status error. It assumes that the error message is encoded as the
-// first item in the details. It returns nil if the error is not a gRPC status
-// error or if no detail is found.
+// first item in the details. If the details only contain standard google.rpc
+// error details (e.g. the error was produced by a non-Goa server) it returns
+// an ErrorResponse message built from these details. It returns nil if the
+// error is not a gRPC status error or if no detail is found.
 func DecodeError(err error) proto.Message {
 	st, ok := status.FromError(err)
 	if !ok {
@@ -121,7 +234,165 @@ func DecodeError(err error) proto.Message {
 	if len(details) == 0 {
 		return nil
 	}
-	return details[0].(proto.Message)
+	if resp := decodeErrorDetails(st); resp != nil {
+		return resp
+	}
+	msg, ok := details[0].(proto.Message)
+	if !ok {
+		// details[0] is an error if the detail type is not registered.
+		return nil
+	}
+	return msg
+}
+
+// decodeErrorDetails builds an ErrorResponse message from the standard
+// google.rpc error details of the given status. It returns nil if the details
+// contain other messages or no ErrorInfo detail.
+func decodeErrorDetails(st *status.Status) *goapb.ErrorResponse {
+	var (
+		info  *errdetails.ErrorInfo
+		retry bool
+	)
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.RetryInfo:
+			retry = true
+		case *errdetails.BadRequest:
+		default:
+			return nil
+		}
+	}
+	if info == nil {
+		return nil
+	}
+	name := info.Metadata["name"]
+	if name == "" {
+		name = strings.ToLower(info.Reason)
+	}
+	code := st.Code()
+	return &goapb.ErrorResponse{
+		Name:      name,
+		Id:        info.Metadata["id"],
+		Msg:       st.Message(),
+		Temporary: retry || code == codes.Unavailable,
+		Timeout:   code == codes.DeadlineExceeded,
+		Fault:     code == codes.Internal || code == codes.Unknown || code == codes.DataLoss,
+	}
+}
+
+// DecodeErrorMetadata initializes msg from the google.rpc.ErrorInfo detail of
+// the gRPC status error err. The scalar fields of msg are set from the
+// metadata entries keyed by their proto field names. The generated clients use
+// it to build the designed error types from the errors returned by servers
+// that only send the standard google.rpc error details. DecodeErrorMetadata
+// returns false if err is not a gRPC status error or does not contain an
+// ErrorInfo detail.
+func DecodeErrorMetadata(err error, msg proto.Message) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		m := msg.ProtoReflect()
+		fields := m.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			s, ok := info.Metadata[string(fd.Name())]
+			if !ok || fd.IsList() || fd.IsMap() {
+				continue
+			}
+			if v, ok := parseScalar(fd.Kind(), s); ok {
+				m.Set(fd, v)
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// errorMetadata returns the values of the populated scalar fields of msg
+// keyed by their proto field names.
+func errorMetadata(msg proto.Message) map[string]string {
+	md := make(map[string]string)
+	msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsList() || fd.IsMap() {
+			return true
+		}
+		switch fd.Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.BytesKind:
+			return true
+		case protoreflect.EnumKind:
+			md[string(fd.Name())] = strconv.Itoa(int(v.Enum()))
+		default:
+			md[string(fd.Name())] = v.String()
+		}
+		return true
+	})
+	return md
+}
+
+// parseScalar parses s into a value of the given scalar kind.
+func parseScalar(kind protoreflect.Kind, s string) (protoreflect.Value, bool) {
+	switch kind {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), true
+	case protoreflect.BoolKind:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return protoreflect.ValueOfBool(b), true
+		}
+	case protoreflect.EnumKind:
+		if i, err := strconv.ParseInt(s, 10, 32); err == nil {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), true
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if i, err := strconv.ParseInt(s, 10, 32); err == nil {
+			return protoreflect.ValueOfInt32(int32(i)), true
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return protoreflect.ValueOfInt64(i), true
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if i, err := strconv.ParseUint(s, 10, 32); err == nil {
+			return protoreflect.ValueOfUint32(uint32(i)), true
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if i, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return protoreflect.ValueOfUint64(i), true
+		}
+	case protoreflect.FloatKind:
+		if f, err := strconv.ParseFloat(s, 32); err == nil {
+			return protoreflect.ValueOfFloat32(float32(f)), true
+		}
+	case protoreflect.DoubleKind:
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return protoreflect.ValueOfFloat64(f), true
+		}
+	}
+	return protoreflect.Value{}, false
+}
+
+// NewClientFault returns the error returned by the generated clients when the
+// server response does not contain a Goa error. The error is flagged as
+// temporary if the gRPC status code is Unavailable or ResourceExhausted and
+// as a timeout if it is DeadlineExceeded so that the request may be retried.
+func NewClientFault(err error) *goa.ServiceError {
+	var temporary, timeout bool
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unavailable, codes.ResourceExhausted:
+			temporary = true
+		case codes.DeadlineExceeded:
+			timeout = true
+		}
+	}
+	return goa.NewServiceError(err, "fault", timeout, temporary, true)
 }
 
 // ErrInvalidType is the error returned when the wrong type is given to a
@@ -135,3 +406,9 @@ func ErrInvalidType(svc, m, expected string, actual any) error {
 func (c *ClientError) Error() string {
 	return fmt.Sprintf("[%s %s]: %s", c.Service, c.Method, c.Message)
 }
+
+// RetryFlags returns the temporary and timeout flags of the error, it is used
+// by the generated clients to decide whether the request may be retried.
+func (c *ClientError) RetryFlags() (temporary, timeout bool) {
+	return c.Temporary, c.Timeout
+}
@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	goapb "goa.design/goa/v3/grpc/pb"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type rateLimitError struct{ retryAfter time.Duration }
+
+func (e *rateLimitError) Error() string { return "rate limit exceeded" }
+
+func (e *rateLimitError) RateLimit() (int, int, time.Duration) { return 10, 0, e.retryAfter }
+
+func TestEncodeErrorDetails(t *testing.T) {
+	validation := goa.MergeErrors(
+		goa.MissingFieldError("name", "body"),
+		goa.InvalidRangeError("body.age", 200, 150, false),
+	)
+	rateLimit := goa.NewServiceError(&rateLimitError{retryAfter: 2 * time.Second}, goa.RateLimitExceeded, false, true, false)
+
+	cases := []struct {
+		Name          string
+		Error         error
+		Code          codes.Code
+		Reason        string
+		Violations    []string
+		HasRetryInfo  bool
+		ExpectedDelay *durationpb.Duration
+	}{
+		{"validation", validation, codes.Unknown, "MISSING_FIELD", []string{"name", "body.age"}, false, nil},
+		{"temporary", goa.TemporaryError("unavailable", "try again"), codes.Unavailable, "UNAVAILABLE", nil, true, nil},
+		{"rate-limit", rateLimit, codes.ResourceExhausted, "RATE_LIMIT_EXCEEDED", nil, true, durationpb.New(2 * time.Second)},
+		{"unauthenticated", goa.PermanentError(goa.Unauthenticated, "invalid token"), codes.Unauthenticated, "UNAUTHENTICATED", nil, false, nil},
+		{"insufficient-scope", goa.PermanentError(goa.InsufficientScope, "missing scopes"), codes.PermissionDenied, "INSUFFICIENT_SCOPE", nil, false, nil},
+		{"precondition-failed", goa.PermanentError(goa.PreconditionFailed, "stale entity tag"), codes.FailedPrecondition, "PRECONDITION_FAILED", nil, false, nil},
+		{"fault", goa.Fault("boom"), codes.Internal, "FAULT", nil, false, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			st, ok := status.FromError(EncodeError(c.Error))
+			require.True(t, ok)
+			assert.Equal(t, c.Code, st.Code())
+			details := st.Details()
+			require.NotEmpty(t, details)
+			assert.IsType(t, &goapb.ErrorResponse{}, details[0])
+			var (
+				info       *errdetails.ErrorInfo
+				badRequest *errdetails.BadRequest
+				retry      *errdetails.RetryInfo
+			)
+			for _, d := range details[1:] {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					info = d
+				case *errdetails.BadRequest:
+					badRequest = d
+				case *errdetails.RetryInfo:
+					retry = d
+				}
+			}
+			require.NotNil(t, info)
+			assert.Equal(t, c.Reason, info.Reason)
+			assert.Equal(t, ErrorDomain, info.Domain)
+			if c.Violations == nil {
+				assert.Nil(t, badRequest)
+			} else {
+				require.NotNil(t, badRequest)
+				var fields []string
+				for _, v := range badRequest.FieldViolations {
+					fields = append(fields, v.Field)
+				}
+				assert.Equal(t, c.Violations, fields)
+			}
+			if !c.HasRetryInfo {
+				assert.Nil(t, retry)
+				return
+			}
+			require.NotNil(t, retry)
+			assert.Equal(t, c.ExpectedDelay.AsDuration(), retry.RetryDelay.AsDuration())
+		})
+	}
+}
+
+func TestEncodeErrorFieldViolationsCode(t *testing.T) {
+	validation := goa.MissingFieldError("name", "body")
+	st, ok := status.FromError(EncodeError(validation))
+	require.True(t, ok)
+	assert.Equal(t, codes.Unknown, st.Code())
+
+	InvalidArgumentOnFieldViolations = true
+	defer func() { InvalidArgumentOnFieldViolations = false }()
+	st, ok = status.FromError(EncodeError(validation))
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestErrorMetadata(t *testing.T) {
+	res := &errdetails.ResourceInfo{ResourceType: "book", ResourceName: "dune"}
+	err := NewStatusError(codes.NotFound, goa.PermanentError("not_found", "missing"), res)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	var info *errdetails.ErrorInfo
+	for _, d := range st.Details() {
+		if i, ok := d.(*errdetails.ErrorInfo); ok {
+			info = i
+		}
+	}
+	require.NotNil(t, info)
+	assert.Equal(t, "not_found", info.Metadata["name"])
+	assert.Equal(t, "book", info.Metadata["resource_type"])
+	assert.Equal(t, "dune", info.Metadata["resource_name"])
+	assert.NotContains(t, info.Metadata, "owner")
+
+	var decoded errdetails.ResourceInfo
+	require.True(t, DecodeErrorMetadata(err, &decoded))
+	assert.Equal(t, "book", decoded.ResourceType)
+	assert.Equal(t, "dune", decoded.ResourceName)
+
+	st, serr := status.New(codes.Unavailable, "retry").WithDetails(
+		&errdetails.ErrorInfo{Reason: "RETRY", Metadata: map[string]string{"seconds": "12", "nanos": "invalid"}},
+	)
+	require.NoError(t, serr)
+	var d durationpb.Duration
+	require.True(t, DecodeErrorMetadata(st.Err(), &d))
+	assert.Equal(t, int64(12), d.Seconds)
+	assert.Equal(t, int32(0), d.Nanos)
+
+	assert.False(t, DecodeErrorMetadata(status.Error(codes.Unknown, "boom"), &d))
+}
+
+func TestDecodeErrorDetails(t *testing.T) {
+	st, err := status.New(codes.Unavailable, "backend unavailable").WithDetails(
+		&errdetails.ErrorInfo{Reason: "BACKEND_UNAVAILABLE", Domain: "example.com", Metadata: map[string]string{"id": "123"}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)},
+	)
+	require.NoError(t, err)
+
+	resp, ok := DecodeError(st.Err()).(*goapb.ErrorResponse)
+	require.True(t, ok)
+	serr := NewServiceError(resp)
+	assert.Equal(t, "backend_unavailable", serr.Name)
+	assert.Equal(t, "123", serr.ID)
+	assert.Equal(t, "backend unavailable", serr.Message)
+	assert.True(t, serr.Temporary)
+	assert.False(t, serr.Timeout)
+	assert.False(t, serr.Fault)
+
+	encoded := EncodeError(goa.PermanentError("NotFound", "missing"))
+	resp, ok = DecodeError(encoded).(*goapb.ErrorResponse)
+	require.True(t, ok)
+	assert.Equal(t, "NotFound", resp.Name)
+
+	st, err = status.New(codes.Unknown, "unknown").WithDetails(&errdetails.DebugInfo{Detail: "stack"})
+	require.NoError(t, err)
+	assert.IsType(t, &errdetails.DebugInfo{}, DecodeError(st.Err()))
+}
@@ -2,11 +2,15 @@ package grpc
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
+	"net"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	goa "goa.design/goa/v3/pkg"
@@ -161,3 +165,36 @@ func (h *streamHandler) Handle(ctx context.Context, stream any) error {
 	_, err := h.endpoint(ctx, stream)
 	return err
 }
+
+// ClientIP returns the IP address of the client that initiated the RPC as
+// reported by the gRPC peer information stored in ctx. It returns an empty
+// string if the peer information is not available.
+func ClientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// PeerCertificates returns the client certificate chain verified by the
+// server during the TLS handshake as reported by the gRPC peer information
+// stored in ctx, leaf certificate first. It returns nil if the peer
+// information is not available, the connection does not use TLS or the server
+// did not verify any client certificate, see tls.Config.ClientAuth.
+func PeerCertificates(ctx context.Context) []*x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0]
+}
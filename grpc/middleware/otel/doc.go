@@ -0,0 +1,7 @@
+/*
+Package otel provides OpenTelemetry interceptors for Goa gRPC servers and
+clients. See the goa.design/goa/v3/middleware/otel package for the options and
+for the endpoint middleware that names the spans after the Goa service and
+method.
+*/
+package otel
@@ -0,0 +1,203 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	"goa.design/goa/v3/middleware/otel"
+)
+
+type (
+	// metadataCarrier adapts gRPC metadata to the OpenTelemetry
+	// propagation.TextMapCarrier interface.
+	metadataCarrier metadata.MD
+
+	// clientStream wraps the gRPC client stream to end the call when the
+	// stream completes.
+	clientStream struct {
+		grpc.ClientStream
+		call          *otel.Call
+		serverStreams bool
+		once          sync.Once
+	}
+)
+
+// UnaryServer returns a server interceptor that creates a span for each unary
+// request and records the RED metrics. The trace context is read from the
+// W3C traceparent and tracestate request metadata by default. Mount the
+// otel.Endpoint middleware on the service endpoints to name the spans and
+// label the metrics after the Goa service and method.
+func UnaryServer(opts ...otel.Option) grpc.UnaryServerInterceptor {
+	rec := otel.NewRecorder(trace.SpanKindServer, opts...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, call := startServer(ctx, rec, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endServer(call, err)
+		return resp, err
+	}
+}
+
+// StreamServer returns a server interceptor that creates a span for each
+// streaming request and records the RED metrics. See UnaryServer.
+func StreamServer(opts ...otel.Option) grpc.StreamServerInterceptor {
+	rec := otel.NewRecorder(trace.SpanKindServer, opts...)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, call := startServer(ss.Context(), rec, info.FullMethod)
+		err := handler(srv, grpcm.NewWrappedServerStream(ctx, ss))
+		endServer(call, err)
+		return err
+	}
+}
+
+// UnaryClient returns a client interceptor that creates a span for each unary
+// request, writes the trace context to the request metadata and records the
+// RED metrics. Requests that return a non-OK status are counted as errors.
+func UnaryClient(opts ...otel.Option) grpc.UnaryClientInterceptor {
+	rec := otel.NewRecorder(trace.SpanKindClient, opts...)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, call := startClient(ctx, rec, method)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		endClient(call, err)
+		return err
+	}
+}
+
+// StreamClient returns a client interceptor that creates a span for each
+// streaming request, writes the trace context to the request metadata and
+// records the RED metrics. The call ends when the stream returns an error or
+// io.EOF or, for client streaming methods, once the response is received.
+func StreamClient(opts ...otel.Option) grpc.StreamClientInterceptor {
+	rec := otel.NewRecorder(trace.SpanKindClient, opts...)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, call := startClient(ctx, rec, method)
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			endClient(call, err)
+			return cs, err
+		}
+		return &clientStream{ClientStream: cs, call: call, serverStreams: desc.ServerStreams}, nil
+	}
+}
+
+// Header calls the wrapped stream Header and ends the call on error.
+func (s *clientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	if err != nil {
+		s.end(err)
+	}
+	return md, err
+}
+
+// SendMsg calls the wrapped stream SendMsg and ends the call on error.
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil {
+		s.end(err)
+	}
+	return err
+}
+
+// RecvMsg calls the wrapped stream RecvMsg and ends the call on error, once
+// the stream is exhausted or once the response of a client streaming method
+// is received.
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case errors.Is(err, io.EOF):
+		s.end(nil)
+	case err != nil:
+		s.end(err)
+	case !s.serverStreams:
+		s.end(nil)
+	}
+	return err
+}
+
+// end ends the call once.
+func (s *clientStream) end(err error) {
+	s.once.Do(func() { endClient(s.call, err) })
+}
+
+// Get returns the first value for the given key.
+func (c metadataCarrier) Get(key string) string {
+	return grpcm.MetadataValue(metadata.MD(c), key)
+}
+
+// Set sets the value for the given key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the metadata keys.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// startServer extracts the trace context from the incoming metadata and
+// starts the server call.
+func startServer(ctx context.Context, rec *otel.Recorder, fullMethod string) (context.Context, *otel.Call) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = rec.Propagator().Extract(ctx, metadataCarrier(md))
+	return rec.Start(ctx, strings.TrimPrefix(fullMethod, "/"), rpcAttributes(fullMethod)...)
+}
+
+// startClient starts the client call and writes the trace context to the
+// outgoing metadata.
+func startClient(ctx context.Context, rec *otel.Recorder, fullMethod string) (context.Context, *otel.Call) {
+	ctx, call := rec.Start(ctx, strings.TrimPrefix(fullMethod, "/"), rpcAttributes(fullMethod)...)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	rec.Propagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), call
+}
+
+// endServer ends the server call. Only the status codes that indicate a
+// server fault are counted as errors. The error itself is recorded by the
+// otel.Endpoint middleware.
+func endServer(call *otel.Call, err error) {
+	code := status.Code(err)
+	var failed bool
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		failed = true
+	}
+	call.End(failed, nil, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+}
+
+// endClient ends the client call, any error is counted as a failure.
+func endClient(call *otel.Call, err error) {
+	code := status.Code(err)
+	call.End(err != nil, err, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+}
+
+// rpcAttributes returns the RPC semantic convention attributes for the given
+// gRPC full method name of the form "/package.service/method".
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	svc, meth, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if ok {
+		attrs = append(attrs, semconv.RPCService(svc), semconv.RPCMethod(meth))
+	}
+	return attrs
+}
@@ -0,0 +1,180 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"goa.design/goa/v3/middleware/otel"
+)
+
+const (
+	fullMethod = "/calc.Calc/Add"
+	traceID    = "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentID   = "00f067aa0ba902b7"
+)
+
+type (
+	testServerStream struct {
+		grpc.ServerStream
+		ctx context.Context
+	}
+
+	testClientStream struct {
+		grpc.ClientStream
+		msgs int
+	}
+)
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func (s *testClientStream) RecvMsg(any) error {
+	if s.msgs == 0 {
+		return io.EOF
+	}
+	s.msgs--
+	return nil
+}
+
+func TestUnaryServer(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Failed bool
+	}{
+		"ok":        {},
+		"not-found": {Err: status.Error(codes.NotFound, "not found")},
+		"internal":  {Err: status.Error(codes.Internal, "boom"), Failed: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			md := metadata.Pairs("traceparent", "00-"+traceID+"-"+parentID+"-01")
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			handler := func(ctx context.Context, _ any) (any, error) {
+				assert.NotNil(t, otel.CallFromContext(ctx))
+				return nil, c.Err
+			}
+
+			_, err := UnaryServer(otel.WithTracerProvider(tp))(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+
+			assert.Equal(t, c.Err, err)
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, "calc.Calc/Add", span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+			assert.Equal(t, parentID, span.Parent().SpanID().String())
+			attrs := attribute.NewSet(span.Attributes()...)
+			v, _ := attrs.Value(semconv.RPCServiceKey)
+			assert.Equal(t, "calc.Calc", v.AsString())
+			v, _ = attrs.Value(semconv.RPCMethodKey)
+			assert.Equal(t, "Add", v.AsString())
+			v, _ = attrs.Value(semconv.RPCGRPCStatusCodeKey)
+			assert.Equal(t, int64(status.Code(c.Err)), v.AsInt64())
+			if c.Failed {
+				assert.Equal(t, otelcodes.Error, span.Status().Code)
+			} else {
+				assert.Equal(t, otelcodes.Unset, span.Status().Code)
+			}
+		})
+	}
+}
+
+func TestStreamServer(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ss := &testServerStream{ctx: context.Background()}
+	handler := func(_ any, stream grpc.ServerStream) error {
+		assert.NotNil(t, otel.CallFromContext(stream.Context()))
+		return nil
+	}
+
+	err := StreamServer(otel.WithTracerProvider(tp))(nil, ss, &grpc.StreamServerInfo{FullMethod: fullMethod}, handler)
+
+	assert.NoError(t, err)
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "calc.Calc/Add", spans[0].Name())
+}
+
+func TestUnaryClient(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Failed bool
+	}{
+		"ok":        {},
+		"not-found": {Err: status.Error(codes.NotFound, "not found"), Failed: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			var traceparent string
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				traceparent = md.Get("traceparent")[0]
+				return c.Err
+			}
+
+			err := UnaryClient(otel.WithTracerProvider(tp))(context.Background(), fullMethod, nil, nil, nil, invoker)
+
+			assert.Equal(t, c.Err, err)
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+			assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", traceparent)
+			if c.Failed {
+				assert.Equal(t, otelcodes.Error, span.Status().Code)
+			} else {
+				assert.Equal(t, otelcodes.Unset, span.Status().Code)
+			}
+		})
+	}
+}
+
+func TestStreamClient(t *testing.T) {
+	cases := map[string]struct {
+		ServerStreams bool
+		Recv          int
+	}{
+		"server-streams": {ServerStreams: true, Recv: 3},
+		"client-streams": {Recv: 1},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+				return &testClientStream{msgs: 2}, nil
+			}
+			desc := &grpc.StreamDesc{ServerStreams: c.ServerStreams, ClientStreams: true}
+
+			cs, err := StreamClient(otel.WithTracerProvider(tp))(context.Background(), desc, nil, fullMethod, streamer)
+			require.NoError(t, err)
+			for i := 0; i < c.Recv-1; i++ {
+				require.NoError(t, cs.RecvMsg(nil))
+				assert.Empty(t, sr.Ended())
+			}
+			cs.RecvMsg(nil) // nolint: errcheck
+			cs.RecvMsg(nil) // nolint: errcheck
+
+			require.Len(t, sr.Ended(), 1)
+		})
+	}
+}
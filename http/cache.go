@@ -0,0 +1,181 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+type (
+	// conditions holds the conditional headers of a GET or HEAD request.
+	conditions struct {
+		ifNoneMatch     string
+		ifModifiedSince string
+	}
+
+	// conditionsKey is the context key used to store the request
+	// conditions.
+	conditionsKey struct{}
+
+	// fieldSelectionKey is the context key used to store the field
+	// selection of the request.
+	fieldSelectionKey struct{}
+)
+
+// ContextWithConditions returns a copy of ctx that holds the If-None-Match and
+// If-Modified-Since headers of r if r is a GET or HEAD request that defines
+// any. The generated handlers of the endpoints whose responses define cache
+// validators call it so that the response encoders may reply with 304 Not
+// Modified, see CheckNotModified.
+func ContextWithConditions(ctx context.Context, r *http.Request) context.Context {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return ctx
+	}
+	c := conditions{
+		ifNoneMatch:     r.Header.Get("If-None-Match"),
+		ifModifiedSince: r.Header.Get("If-Modified-Since"),
+	}
+	if c == (conditions{}) {
+		return ctx
+	}
+	return context.WithValue(ctx, conditionsKey{}, c)
+}
+
+// ContextWithFieldSelection returns a copy of ctx that holds the field paths
+// selected by the request. The generated handlers of the endpoints that define
+// cache validators and support field selection call it so that
+// CheckNotModified marks the entity tags of partial responses as weak. It
+// returns ctx unchanged if paths is empty.
+func ContextWithFieldSelection(ctx context.Context, paths []string) context.Context {
+	if len(paths) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, fieldSelectionKey{}, paths)
+}
+
+// CheckNotModified sets the ETag and Last-Modified headers of w with the given
+// validators unless they are empty. It then evaluates the conditions stored in
+// ctx by ContextWithConditions as described in RFC 9110 section 13.2.2 and
+// writes a 304 Not Modified response if the client cached representation is
+// up-to-date. It returns true if it wrote the response in which case the
+// caller must not write the response body. The entity tag is made weak if ctx
+// holds a field selection set with ContextWithFieldSelection as the response
+// only contains part of the result.
+func CheckNotModified(ctx context.Context, w http.ResponseWriter, etag string, lastModified time.Time) bool {
+	if etag != "" {
+		if _, ok := ctx.Value(fieldSelectionKey{}).([]string); ok && strings.HasPrefix(etag, `"`) {
+			etag = "W/" + etag
+		}
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	c, ok := ctx.Value(conditionsKey{}).(conditions)
+	if !ok || !c.notModified(etag, lastModified) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// FormatETag returns the entity tag with the given opaque value, that is v
+// enclosed in double quotes. It returns v unchanged if it is empty, "*" or
+// already a (possibly weak) entity tag.
+func FormatETag(v string) string {
+	if v == "" || v == "*" {
+		return v
+	}
+	if tag, rest := scanETag(v); tag != "" && rest == "" {
+		return v
+	}
+	return `"` + v + `"`
+}
+
+// ParseETag returns the opaque value of the first entity tag listed in the
+// given If-Match or If-None-Match header value, that is the entity tag
+// without its weakness indicator and quotes. It returns "*" if v is "*" and v
+// unchanged if it does not start with an entity tag.
+func ParseETag(v string) string {
+	v = textproto.TrimString(v)
+	if v == "*" {
+		return v
+	}
+	tag, _ := scanETag(v)
+	if tag == "" {
+		return v
+	}
+	return strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+}
+
+// notModified returns true if the given validators satisfy the conditions.
+// If-Modified-Since is only evaluated when If-None-Match is absent.
+func (c conditions) notModified(etag string, lastModified time.Time) bool {
+	if c.ifNoneMatch != "" {
+		if etag == "" {
+			return false
+		}
+		buf := c.ifNoneMatch
+		for {
+			buf = textproto.TrimString(buf)
+			if len(buf) == 0 {
+				return false
+			}
+			if buf[0] == ',' {
+				buf = buf[1:]
+				continue
+			}
+			if buf[0] == '*' {
+				return true
+			}
+			tag, rest := scanETag(buf)
+			if tag == "" {
+				return false
+			}
+			if weakMatch(tag, etag) {
+				return true
+			}
+			buf = rest
+		}
+	}
+	if c.ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	t, err := http.ParseTime(c.ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(t)
+}
+
+// scanETag returns the entity tag at the start of s and the remainder of s.
+// It returns empty strings if s does not start with a valid entity tag.
+func scanETag(s string) (etag, rest string) {
+	s = textproto.TrimString(s)
+	start := 0
+	if strings.HasPrefix(s, "W/") {
+		start = 2
+	}
+	if len(s[start:]) < 2 || s[start] != '"' {
+		return "", ""
+	}
+	for i := start + 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			return s[:i+1], s[i+1:]
+		case c == 0x21 || c >= 0x23 && c <= 0x7E || c >= 0x80:
+		default:
+			return "", ""
+		}
+	}
+	return "", ""
+}
+
+// weakMatch returns true if the given entity tags match using the weak
+// comparison function of RFC 9110 section 8.8.3.2.
+func weakMatch(a, b string) bool {
+	return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckNotModified(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		Method       string
+		Headers      map[string]string
+		ETag         string
+		LastModified time.Time
+		NotModified  bool
+	}{
+		"no condition":             {"GET", nil, `"v1"`, modified, false},
+		"etag match":               {"GET", map[string]string{"If-None-Match": `"v1"`}, `"v1"`, time.Time{}, true},
+		"etag list match":          {"HEAD", map[string]string{"If-None-Match": `"v0", W/"v1"`}, `"v1"`, time.Time{}, true},
+		"etag wildcard":            {"GET", map[string]string{"If-None-Match": "*"}, `"v1"`, time.Time{}, true},
+		"etag mismatch":            {"GET", map[string]string{"If-None-Match": `"v0"`}, `"v1"`, time.Time{}, false},
+		"etag takes precedence":    {"GET", map[string]string{"If-None-Match": `"v0"`, "If-Modified-Since": modified.Format(http.TimeFormat)}, `"v1"`, modified, false},
+		"not modified since":       {"GET", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, "", modified.Add(500 * time.Millisecond), true},
+		"modified since":           {"GET", map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)}, "", modified, false},
+		"invalid modified since":   {"GET", map[string]string{"If-Modified-Since": "yesterday"}, "", modified, false},
+		"unconditional method":     {"PUT", map[string]string{"If-None-Match": `"v1"`}, `"v1"`, time.Time{}, false},
+		"no validator for etag":    {"GET", map[string]string{"If-None-Match": `"v1"`}, "", modified, false},
+		"no validator for modtime": {"GET", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, `"v1"`, time.Time{}, false},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest(c.Method, "/", nil)
+			for h, v := range c.Headers {
+				r.Header.Set(h, v)
+			}
+			w := httptest.NewRecorder()
+			notModified := CheckNotModified(ContextWithConditions(r.Context(), r), w, c.ETag, c.LastModified)
+			assert.Equal(t, c.NotModified, notModified)
+			if c.NotModified {
+				assert.Equal(t, http.StatusNotModified, w.Code)
+			}
+			assert.Equal(t, c.ETag, w.Header().Get("ETag"))
+			if !c.LastModified.IsZero() {
+				assert.Equal(t, c.LastModified.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
+
+func TestCheckNotModifiedFieldSelection(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", `W/"v1"`)
+	ctx := ContextWithConditions(r.Context(), r)
+
+	w := httptest.NewRecorder()
+	assert.True(t, CheckNotModified(ContextWithFieldSelection(ctx, []string{"name"}), w, `"v1"`, time.Time{}))
+	assert.Equal(t, `W/"v1"`, w.Header().Get("ETag"))
+
+	w = httptest.NewRecorder()
+	assert.True(t, CheckNotModified(ContextWithFieldSelection(ctx, nil), w, `"v1"`, time.Time{}))
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"), "empty selection must not weaken the entity tag")
+}
+
+func TestFormatETag(t *testing.T) {
+	cases := map[string]string{
+		"":       "",
+		"*":      "*",
+		"v1":     `"v1"`,
+		`"v1"`:   `"v1"`,
+		`W/"v1"`: `W/"v1"`,
+	}
+	for v, want := range cases {
+		assert.Equal(t, want, FormatETag(v), v)
+	}
+}
+
+func TestParseETag(t *testing.T) {
+	cases := map[string]string{
+		"":             "",
+		"*":            "*",
+		"v1":           "v1",
+		`"v1"`:         "v1",
+		` W/"v1" `:     "v1",
+		`"v1", "v2"`:   "v1",
+		`"v1` + "\x7f": `"v1` + "\x7f",
+	}
+	for v, want := range cases {
+		assert.Equal(t, want, ParseETag(v), v)
+	}
+}
@@ -59,6 +59,12 @@ func (c ClientError) Unwrap() error {
 	return c.Err
 }
 
+// RetryFlags returns the temporary and timeout flags of the error, it is used
+// by the generated clients to decide whether the request may be retried.
+func (c ClientError) RetryFlags() (temporary, timeout bool) {
+	return c.Temporary, c.Timeout
+}
+
 // NewDebugDoer wraps the given doer and captures the request and response so
 // they can be printed.
 func NewDebugDoer(d Doer) DebugDoer {
@@ -0,0 +1,279 @@
+package http
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// NewDecoderFunc creates a decoder that reads a body of the given media
+	// type from r.
+	NewDecoderFunc func(r io.Reader, mediaType string) Decoder
+
+	// NewEncoderFunc creates an encoder that writes a body of the given media
+	// type to w.
+	NewEncoderFunc func(w io.Writer, mediaType string) Encoder
+
+	// codecRegistry maps mime types and mime type patterns to decoder and
+	// encoder constructors.
+	codecRegistry struct {
+		mu sync.RWMutex
+		// decoders indexes the decoder constructors by mime type.
+		decoders map[string]NewDecoderFunc
+		// encoders indexes the encoder constructors by mime type.
+		encoders map[string]NewEncoderFunc
+		// decoderPatterns lists the decoder mime type patterns in
+		// registration order.
+		decoderPatterns []string
+		// encoderPatterns lists the encoder mime type patterns in
+		// registration order.
+		encoderPatterns []string
+		// encoderTypes lists the exact encoder mime types in registration
+		// order, it is used to resolve Accept header wildcards.
+		encoderTypes []string
+	}
+
+	// acceptRange is a media range parsed from an Accept header.
+	acceptRange struct {
+		mediaType string
+		q         float64
+	}
+)
+
+// codecs is the registry consulted by RequestDecoder, ResponseEncoder and
+// ResponseDecoder.
+var codecs = newCodecRegistry()
+
+func init() {
+	jsonDec := func(r io.Reader, _ string) Decoder { return json.NewDecoder(r) }
+	jsonEnc := func(w io.Writer, _ string) Encoder { return json.NewEncoder(w) }
+	xmlDec := func(r io.Reader, _ string) Decoder { return xml.NewDecoder(r) }
+	xmlEnc := func(w io.Writer, _ string) Encoder { return xml.NewEncoder(w) }
+	gobDec := func(r io.Reader, _ string) Decoder { return gob.NewDecoder(r) }
+	gobEnc := func(w io.Writer, _ string) Encoder { return gob.NewEncoder(w) }
+	textDec := func(r io.Reader, ct string) Decoder { return newTextDecoder(r, ct) }
+	textEnc := func(w io.Writer, ct string) Encoder { return newTextEncoder(w, ct) }
+
+	for _, mt := range []string{"application/json", "*/*+json"} {
+		RegisterDecoder(mt, jsonDec)
+		RegisterEncoder(mt, jsonEnc)
+	}
+	for _, mt := range []string{"application/xml", "*/*+xml"} {
+		RegisterDecoder(mt, xmlDec)
+		RegisterEncoder(mt, xmlEnc)
+	}
+	for _, mt := range []string{"application/gob", "*/*+gob"} {
+		RegisterDecoder(mt, gobDec)
+		RegisterEncoder(mt, gobEnc)
+	}
+	for _, mt := range []string{"text/plain", "text/html", "*/*+html", "*/*+txt"} {
+		RegisterDecoder(mt, textDec)
+		RegisterEncoder(mt, textEnc)
+	}
+}
+
+// RegisterDecoder registers the decoder constructor used by RequestDecoder
+// and ResponseDecoder to decode bodies of the given mime type. The mime type
+// may be a pattern of the form "type/*+suffix" or "*/*+suffix" which matches
+// any mime type using the structured syntax suffix, for example
+// "application/*+json" matches "application/problem+json". Exact matches take
+// precedence over patterns, patterns are matched in registration order.
+// Registering a mime type that is already registered replaces the existing
+// constructor.
+func RegisterDecoder(mimeType string, fn NewDecoderFunc) {
+	codecs.mu.Lock()
+	defer codecs.mu.Unlock()
+	mimeType = strings.ToLower(mimeType)
+	if _, ok := codecs.decoders[mimeType]; !ok && isMimePattern(mimeType) {
+		codecs.decoderPatterns = append(codecs.decoderPatterns, mimeType)
+	}
+	codecs.decoders[mimeType] = fn
+}
+
+// RegisterEncoder registers the encoder constructor used by ResponseEncoder to
+// encode bodies of the given mime type. The mime type may be a pattern as
+// described in RegisterDecoder. Registering a mime type that is already
+// registered replaces the existing constructor.
+func RegisterEncoder(mimeType string, fn NewEncoderFunc) {
+	codecs.mu.Lock()
+	defer codecs.mu.Unlock()
+	mimeType = strings.ToLower(mimeType)
+	if _, ok := codecs.encoders[mimeType]; !ok {
+		if isMimePattern(mimeType) {
+			codecs.encoderPatterns = append(codecs.encoderPatterns, mimeType)
+		} else {
+			codecs.encoderTypes = append(codecs.encoderTypes, mimeType)
+		}
+	}
+	codecs.encoders[mimeType] = fn
+}
+
+// newCodecRegistry returns an empty registry.
+func newCodecRegistry() *codecRegistry {
+	return &codecRegistry{
+		decoders: make(map[string]NewDecoderFunc),
+		encoders: make(map[string]NewEncoderFunc),
+	}
+}
+
+// decoder returns the decoder constructor registered for the given media type
+// if any.
+func (c *codecRegistry) decoder(mt string) NewDecoderFunc {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if fn, ok := c.decoders[mt]; ok && !isMimePattern(mt) {
+		return fn
+	}
+	for _, p := range c.decoderPatterns {
+		if matchMimePattern(p, mt) {
+			return c.decoders[p]
+		}
+	}
+	return nil
+}
+
+// encoder returns the encoder constructor registered for the given media type
+// if any.
+func (c *codecRegistry) encoder(mt string) NewEncoderFunc {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if fn, ok := c.encoders[mt]; ok && !isMimePattern(mt) {
+		return fn
+	}
+	for _, p := range c.encoderPatterns {
+		if matchMimePattern(p, mt) {
+			return c.encoders[p]
+		}
+	}
+	return nil
+}
+
+// negotiate returns the encoder constructor and media type that best match
+// the given Accept header value following the rules of RFC 9110 section
+// 12.5.1: media ranges are considered by decreasing quality then decreasing
+// specificity and ranges with a quality of 0 are not acceptable. negotiate
+// returns nil if none of the registered encoders is acceptable.
+func (c *codecRegistry) negotiate(accept string) (NewEncoderFunc, string) {
+	ranges := parseAccept(accept)
+	for _, r := range ranges {
+		if r.q <= 0 {
+			break
+		}
+		if !strings.Contains(r.mediaType, "*") {
+			if fn := c.encoder(r.mediaType); fn != nil {
+				return fn, r.mediaType
+			}
+			continue
+		}
+		c.mu.RLock()
+		types := c.encoderTypes
+		c.mu.RUnlock()
+		for _, mt := range types {
+			if matchMediaRange(r.mediaType, mt) && quality(ranges, mt) > 0 {
+				return c.encoder(mt), mt
+			}
+		}
+	}
+	return nil, ""
+}
+
+// parseAccept parses the given Accept header value and returns the media
+// ranges sorted by decreasing quality and specificity. Invalid ranges are
+// ignored.
+func parseAccept(accept string) []*acceptRange {
+	var ranges []*acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		mt, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 && f <= 1 {
+				q = f
+			}
+		}
+		ranges = append(ranges, &acceptRange{mediaType: mt, q: q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].q != ranges[j].q {
+			return ranges[i].q > ranges[j].q
+		}
+		return specificity(ranges[i].mediaType) > specificity(ranges[j].mediaType)
+	})
+	return ranges
+}
+
+// quality returns the quality of the given media type, that is the quality of
+// the most specific range that matches it.
+func quality(ranges []*acceptRange, mt string) float64 {
+	var (
+		q    float64
+		best = -1
+	)
+	for _, r := range ranges {
+		if !matchMediaRange(r.mediaType, mt) {
+			continue
+		}
+		if s := specificity(r.mediaType); s > best {
+			best = s
+			q = r.q
+		}
+	}
+	return q
+}
+
+// specificity returns 0 for "*/*", 1 for "type/*" and 2 for any other media
+// range.
+func specificity(mediaRange string) int {
+	switch {
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// matchMediaRange returns true if mt is matched by the given Accept header
+// media range.
+func matchMediaRange(mediaRange, mt string) bool {
+	if mediaRange == "*/*" || mediaRange == mt {
+		return true
+	}
+	if typ, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(mt, typ+"/")
+	}
+	return false
+}
+
+// isMimePattern returns true if mt is a registration pattern of the form
+// "type/*+suffix".
+func isMimePattern(mt string) bool {
+	_, sub, _ := strings.Cut(mt, "/")
+	return strings.HasPrefix(sub, "*+")
+}
+
+// matchMimePattern returns true if mt matches the registration pattern p. A
+// pattern type of "*" matches any type including media types that consist of
+// a suffix only, e.g. "+json".
+func matchMimePattern(p, mt string) bool {
+	typ, sub, _ := strings.Cut(p, "/")
+	suffix := strings.TrimPrefix(sub, "*")
+	if !strings.HasSuffix(mt, suffix) {
+		return false
+	}
+	return typ == "*" || strings.HasPrefix(mt, typ+"/")
+}
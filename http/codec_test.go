@@ -0,0 +1,86 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCodec struct {
+	mt string
+}
+
+func (c *testCodec) Decode(any) error { return nil }
+func (c *testCodec) Encode(any) error { return nil }
+
+func TestRegisterCodec(t *testing.T) {
+	RegisterDecoder("application/x-test", func(_ io.Reader, mt string) Decoder { return &testCodec{mt} })
+	RegisterEncoder("application/x-test", func(_ io.Writer, mt string) Encoder { return &testCodec{mt} })
+	RegisterDecoder("application/*+test", func(_ io.Reader, mt string) Decoder { return &testCodec{mt} })
+	RegisterEncoder("application/*+test", func(_ io.Writer, mt string) Encoder { return &testCodec{mt} })
+
+	cases := []struct {
+		name        string
+		contentType string
+		expected    any
+	}{
+		{"exact", "application/x-test", &testCodec{"application/x-test"}},
+		{"exact with params", "application/x-test; charset=utf-8", &testCodec{"application/x-test"}},
+		{"suffix", "application/vnd.foo+test", &testCodec{"application/vnd.foo+test"}},
+		{"suffix other type", "text/vnd.foo+test", "*http.unsupportedDecoder"},
+		{"default suffix", "application/problem+json", "*json.Decoder"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := &http.Request{Header: http.Header{"Content-Type": {c.contentType}}, Body: io.NopCloser(&bytes.Buffer{})}
+			dec := RequestDecoder(r)
+			if s, ok := c.expected.(string); ok {
+				assert.Equal(t, s, fmt.Sprintf("%T", dec))
+				return
+			}
+			assert.Equal(t, c.expected, dec)
+		})
+	}
+
+	t.Run("encoder", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ContentTypeKey, "application/vnd.foo+test")
+		w := httptest.NewRecorder()
+		assert.Equal(t, &testCodec{"application/vnd.foo+test"}, ResponseEncoder(ctx, w))
+		assert.Equal(t, "application/vnd.foo+test", w.Header().Get("Content-Type"))
+	})
+}
+
+func TestResponseEncoderNegotiation(t *testing.T) {
+	cases := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{"empty", "", "application/json"},
+		{"any", "*/*", "application/json"},
+		{"unsupported", "application/foo", "application/json"},
+		{"first supported", "application/foo, application/xml", "application/xml"},
+		{"quality", "application/json;q=0.5, application/xml", "application/xml"},
+		{"quality order", "text/plain;q=0.2, application/gob;q=0.9, application/xml;q=0.4", "application/gob"},
+		{"type wildcard", "text/*", "text/plain"},
+		{"specificity", "text/*, text/html", "text/html"},
+		{"excluded", "application/json;q=0, */*", "application/xml"},
+		{"excluded wildcard", "application/*;q=0, text/*;q=0.1", "text/plain"},
+		{"suffix", "application/problem+json", "application/problem+json"},
+		{"invalid quality", "application/xml;q=abc", "application/xml"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.accept)
+			w := httptest.NewRecorder()
+			ResponseEncoder(ctx, w)
+			assert.Equal(t, c.contentType, w.Header().Get("Content-Type"))
+		})
+	}
+}
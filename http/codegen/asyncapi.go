@@ -0,0 +1,17 @@
+package codegen
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/asyncapi"
+)
+
+// AsyncAPIFiles returns the files for the AsyncAPI document describing the
+// streaming methods of the HTTP services.
+func AsyncAPIFiles(root *expr.RootExpr) ([]*codegen.File, error) {
+	// Only create a AsyncAPI document if there are HTTP services.
+	if len(root.API.HTTP.Services) == 0 {
+		return nil, nil
+	}
+	return asyncapi.Files(root)
+}
@@ -0,0 +1,380 @@
+package asyncapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+const (
+	// Version is the AsyncAPI specification version of the generated
+	// documents.
+	Version = "3.0.0"
+
+	// wsBindingVersion is the version of the WebSockets channel binding.
+	wsBindingVersion = "0.1.0"
+
+	// sseSuffix is appended to the keys of the servers used by the
+	// Server-Sent Events channels.
+	sseSuffix = "_sse"
+)
+
+// New returns the AsyncAPI document describing the streaming methods of the
+// HTTP services defined in root. It returns nil if there are none or if the
+// "asyncapi:generate" meta of the API is set to "false".
+func New(root *expr.RootExpr) *AsyncAPI {
+	if root == nil || root.API == nil || !mustGenerate(root.API.Meta) {
+		return nil
+	}
+
+	// Use a separate set of definitions so that the references produced
+	// by the JSON schema algorithms only cover the streamed types.
+	defs := openapi.Definitions
+	openapi.Definitions = make(map[string]*openapi.Schema)
+	defer func() { openapi.Definitions = defs }()
+
+	var (
+		channels   = make(map[string]*Channel)
+		operations = make(map[string]*Operation)
+		messages   = make(map[string]*Message)
+		hasWS      bool
+		hasSSE     bool
+	)
+	for _, svc := range root.API.HTTP.Services {
+		if !mustGenerate(svc.ServiceExpr.Meta) {
+			continue
+		}
+		for _, e := range svc.HTTPEndpoints {
+			if !e.MethodExpr.IsStreaming() || !mustGenerate(e.MethodExpr.Meta) || !mustGenerate(e.Meta) {
+				continue
+			}
+			id := channelID(e)
+			ch := buildChannel(root.API, e)
+			prefix := codegen.Goify(svc.Name(), true)
+			if e.MethodExpr.StreamingPayload.Type != expr.Empty && e.StreamingBody != nil {
+				key := id + ".payload"
+				messages[key] = &Message{
+					Name:        codegen.Goify(e.Name(), true) + "StreamingPayload",
+					Title:       fmt.Sprintf("%s %s streaming payload", svc.Name(), e.Name()),
+					Description: e.MethodExpr.StreamingPayload.Description,
+					Payload:     bodySchema(root.API, e.StreamingBody, prefix),
+				}
+				ch.Messages["payload"] = &Ref{Ref: "#/components/messages/" + key}
+				operations[id+".receive"] = &Operation{
+					Action:      "receive",
+					Channel:     &Ref{Ref: "#/channels/" + id},
+					Summary:     fmt.Sprintf("Streaming payload of the %s %s method", svc.Name(), e.Name()),
+					Description: e.Description(),
+					Messages:    []*Ref{{Ref: "#/channels/" + id + "/messages/payload"}},
+				}
+			}
+			if body := resultBody(e); body != nil {
+				key := id + ".result"
+				messages[key] = &Message{
+					Name:        codegen.Goify(e.Name(), true) + "Result",
+					Title:       fmt.Sprintf("%s %s result", svc.Name(), e.Name()),
+					Description: e.MethodExpr.Result.Description,
+					Payload:     bodySchema(root.API, body, prefix),
+				}
+				ch.Messages["result"] = &Ref{Ref: "#/components/messages/" + key}
+				operations[id+".send"] = &Operation{
+					Action:      "send",
+					Channel:     &Ref{Ref: "#/channels/" + id},
+					Summary:     fmt.Sprintf("Results of the %s %s method", svc.Name(), e.Name()),
+					Description: e.Description(),
+					Messages:    []*Ref{{Ref: "#/channels/" + id + "/messages/result"}},
+				}
+			}
+			for _, key := range serverKeys(root.API, svc.Name(), e.SSE != nil) {
+				ch.Servers = append(ch.Servers, &Ref{Ref: "#/servers/" + key})
+			}
+			channels[id] = ch
+			if e.SSE != nil {
+				hasSSE = true
+			} else {
+				hasWS = true
+			}
+		}
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	for _, s := range openapi.Definitions {
+		rewriteRefs(s)
+	}
+	for _, m := range messages {
+		rewriteRefs(m.Payload)
+	}
+	var schemas map[string]*openapi.Schema
+	if len(openapi.Definitions) > 0 {
+		schemas = openapi.Definitions
+	}
+	return &AsyncAPI{
+		AsyncAPI:           Version,
+		Info:               buildInfo(root.API),
+		Servers:            buildServers(root.API, hasWS, hasSSE),
+		DefaultContentType: "application/json",
+		Channels:           channels,
+		Operations:         operations,
+		Components:         &Components{Schemas: schemas, Messages: messages},
+	}
+}
+
+// mustGenerate returns false if the "asyncapi:generate" meta is set to
+// "false".
+func mustGenerate(meta expr.MetaExpr) bool {
+	if m, ok := meta.Last("asyncapi:generate"); ok && m == "false" {
+		return false
+	}
+	return true
+}
+
+// channelID returns the identifier of the channel of the given endpoint.
+func channelID(e *expr.HTTPEndpointExpr) string {
+	return codegen.SnakeCase(codegen.Goify(e.Service.Name(), true)) + "." + codegen.SnakeCase(codegen.Goify(e.Name(), true))
+}
+
+// buildChannel returns the channel describing the stream of the given
+// endpoint. The channel address is the path of the first endpoint route.
+func buildChannel(api *expr.APIExpr, e *expr.HTTPEndpointExpr) *Channel {
+	path := e.Routes[0].FullPaths()[0]
+	ch := &Channel{
+		Address:     strings.ReplaceAll(path, "{*", "{"),
+		Title:       fmt.Sprintf("%s %s", e.Service.Name(), e.Name()),
+		Description: e.Description(),
+		Messages:    make(map[string]*Ref),
+	}
+	if wcs := expr.ExtractHTTPWildcards(path); len(wcs) > 0 {
+		params := e.PathParams()
+		obj := expr.AsObject(params.Type)
+		ch.Parameters = make(map[string]*Parameter, len(wcs))
+		for _, wc := range wcs {
+			p := &Parameter{}
+			if att := obj.Attribute(params.KeyName(wc)); att != nil {
+				p.Description = att.Description
+				if att.DefaultValue != nil {
+					p.Default = fmt.Sprint(att.DefaultValue)
+				}
+				if att.Validation != nil {
+					for _, v := range att.Validation.Values {
+						p.Enum = append(p.Enum, fmt.Sprint(v))
+					}
+				}
+			}
+			ch.Parameters[wc] = p
+		}
+	}
+	if e.SSE == nil {
+		ch.Bindings = &ChannelBindings{WS: &WebSocketBinding{
+			Method:         "GET",
+			Query:          paramsSchema(api, e.QueryParams()),
+			Headers:        paramsSchema(api, e.Headers),
+			BindingVersion: wsBindingVersion,
+		}}
+	}
+	return ch
+}
+
+// resultBody returns the body of the successful response of the endpoint, nil
+// if the endpoint does not stream results.
+func resultBody(e *expr.HTTPEndpointExpr) *expr.AttributeExpr {
+	if e.MethodExpr.Result.Type == expr.Empty {
+		return nil
+	}
+	for _, r := range e.Responses {
+		if r.StatusCode < 400 && r.Body != nil && r.Body.Type != expr.Empty {
+			return r.Body
+		}
+	}
+	return nil
+}
+
+// bodySchema returns the JSON schema of the given message body.
+func bodySchema(api *expr.APIExpr, body *expr.AttributeExpr, prefix string) *openapi.Schema {
+	if mt, ok := body.Type.(*expr.ResultTypeExpr); ok {
+		view := expr.DefaultView
+		if v, ok := body.Meta.Last(expr.ViewMetaKey); ok {
+			view = v
+		}
+		s := openapi.NewSchema()
+		s.Ref = openapi.ResultTypeRefWithPrefix(api, mt, view, prefix)
+		return s
+	}
+	return openapi.AttributeTypeSchemaWithPrefix(api, body, prefix)
+}
+
+// paramsSchema returns the JSON schema of the object made of the given query
+// string parameters or headers, nil if there are none.
+func paramsSchema(api *expr.APIExpr, params *expr.MappedAttributeExpr) *openapi.Schema {
+	if params == nil || params.IsEmpty() {
+		return nil
+	}
+	s := openapi.NewSchema()
+	s.Type = openapi.Object
+	for _, nat := range *expr.AsObject(params.Type) {
+		name := params.ElemName(nat.Name)
+		s.Properties[name] = openapi.AttributeTypeSchema(api, nat.Attribute)
+		if params.IsRequired(nat.Name) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// serverKeys returns the keys of the servers that host the given service.
+func serverKeys(api *expr.APIExpr, svc string, sse bool) []string {
+	var keys []string
+	for _, s := range api.Servers {
+		if !mustGenerate(s.Meta) || !hosts(s, svc) {
+			continue
+		}
+		for _, h := range s.Hosts {
+			if _, ok := httpURI(h); !ok || !mustGenerate(h.Meta) {
+				continue
+			}
+			key := codegen.SnakeCase(h.Name)
+			if sse {
+				key += sseSuffix
+			}
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hosts returns true if the server hosts the given service.
+func hosts(s *expr.ServerExpr, svc string) bool {
+	for _, n := range s.Services {
+		if n == svc {
+			return true
+		}
+	}
+	return false
+}
+
+// buildServers returns the servers exposing the channels, one per server host
+// with a HTTP URI and transport.
+func buildServers(api *expr.APIExpr, ws, sse bool) map[string]*Server {
+	servers := make(map[string]*Server)
+	for _, s := range api.Servers {
+		if !mustGenerate(s.Meta) {
+			continue
+		}
+		for _, h := range s.Hosts {
+			u, ok := httpURI(h)
+			if !ok || !mustGenerate(h.Meta) {
+				continue
+			}
+			scheme, rest, _ := strings.Cut(string(u), "://")
+			host, pathname, _ := strings.Cut(rest, "/")
+			if pathname != "" {
+				pathname = "/" + pathname
+			}
+			vars := serverVariables(h)
+			key := codegen.SnakeCase(h.Name)
+			if ws {
+				proto := "ws"
+				if scheme == "https" {
+					proto = "wss"
+				}
+				servers[key] = &Server{Host: host, Protocol: proto, Pathname: pathname, Description: s.Description, Variables: vars}
+			}
+			if sse {
+				servers[key+sseSuffix] = &Server{Host: host, Protocol: scheme, Pathname: pathname, Description: s.Description, Variables: vars}
+			}
+		}
+	}
+	if len(servers) == 0 {
+		return nil
+	}
+	return servers
+}
+
+// httpURI returns the first HTTP or HTTPS URI of the given host.
+func httpURI(h *expr.HostExpr) (expr.URIExpr, bool) {
+	for _, u := range h.URIs {
+		if s := u.Scheme(); s == "http" || s == "https" {
+			return u, true
+		}
+	}
+	return "", false
+}
+
+// serverVariables returns the variables of the given host.
+func serverVariables(h *expr.HostExpr) map[string]*ServerVariable {
+	if h.Variables == nil {
+		return nil
+	}
+	obj := expr.AsObject(h.Variables.Type)
+	if obj == nil || len(*obj) == 0 {
+		return nil
+	}
+	vars := make(map[string]*ServerVariable, len(*obj))
+	for _, nat := range *obj {
+		v := &ServerVariable{Description: nat.Attribute.Description}
+		if nat.Attribute.DefaultValue != nil {
+			v.Default = fmt.Sprint(nat.Attribute.DefaultValue)
+		}
+		if nat.Attribute.Validation != nil {
+			for _, val := range nat.Attribute.Validation.Values {
+				v.Enum = append(v.Enum, fmt.Sprint(val))
+			}
+		}
+		if v.Default == "" && len(v.Enum) > 0 {
+			v.Default = v.Enum[0]
+		}
+		vars[nat.Name] = v
+	}
+	return vars
+}
+
+// buildInfo returns the document info object.
+func buildInfo(api *expr.APIExpr) *Info {
+	title := api.Title
+	if title == "" {
+		title = api.Name
+	}
+	info := &Info{
+		Title:          title,
+		Version:        api.Version,
+		Description:    api.Description,
+		TermsOfService: api.TermsOfService,
+	}
+	if c := api.Contact; c != nil {
+		info.Contact = &Contact{Name: c.Name, URL: c.URL, Email: c.Email}
+	}
+	if l := api.License; l != nil {
+		info.License = &License{Name: l.Name, URL: l.URL}
+	}
+	return info
+}
+
+// rewriteRefs changes the references to the JSON schema definitions produced
+// by the openapi package into references to the document component schemas.
+func rewriteRefs(s *openapi.Schema) {
+	if s == nil {
+		return
+	}
+	if strings.HasPrefix(s.Ref, "#/definitions/") {
+		s.Ref = "#/components/schemas/" + strings.TrimPrefix(s.Ref, "#/definitions/")
+	}
+	rewriteRefs(s.Items)
+	for _, p := range s.Properties {
+		rewriteRefs(p)
+	}
+	for _, d := range s.Definitions {
+		rewriteRefs(d)
+	}
+	for _, a := range s.AnyOf {
+		rewriteRefs(a)
+	}
+	if ap, ok := s.AdditionalProperties.(*openapi.Schema); ok {
+		rewriteRefs(ap)
+	}
+}
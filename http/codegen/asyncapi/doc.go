@@ -0,0 +1,10 @@
+/*
+Package asyncapi produces an AsyncAPI 3.0 document describing the streaming
+methods of the HTTP services. Each streaming method is described as a channel
+whose messages are the streaming payload received by the service and the
+results sent by the service. The message schemas are produced with the same
+JSON schema algorithms as the OpenAPI specifications.
+
+See https://www.asyncapi.com/docs/reference/specification/v3.0.0.
+*/
+package asyncapi
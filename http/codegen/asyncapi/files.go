@@ -0,0 +1,59 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// Files returns the AsyncAPI document files in JSON and YAML formats, nil if
+// the HTTP services do not define streaming methods.
+func Files(root *expr.RootExpr) ([]*codegen.File, error) {
+	spec := New(root)
+	if spec == nil {
+		return nil, nil
+	}
+	jsonSection := &codegen.SectionTemplate{
+		Name:    "asyncapi",
+		FuncMap: template.FuncMap{"toJSON": toJSON},
+		Source:  "{{ toJSON .}}",
+		Data:    spec,
+	}
+	yamlSection := &codegen.SectionTemplate{
+		Name:    "asyncapi",
+		FuncMap: template.FuncMap{"toYAML": toYAML},
+		Source:  "{{ toYAML .}}",
+		Data:    spec,
+	}
+	return []*codegen.File{
+		{
+			Path:             filepath.Join(codegen.Gendir, "http", "asyncapi.json"),
+			SectionTemplates: []*codegen.SectionTemplate{jsonSection},
+		},
+		{
+			Path:             filepath.Join(codegen.Gendir, "http", "asyncapi.yaml"),
+			SectionTemplates: []*codegen.SectionTemplate{yamlSection},
+		},
+	}, nil
+}
+
+func toJSON(d any) string {
+	b, err := json.Marshal(d)
+	if err != nil {
+		panic("asyncapi: " + err.Error()) // bug
+	}
+	return string(b)
+}
+
+func toYAML(d any) string {
+	b, err := yaml.Marshal(d)
+	if err != nil {
+		panic("asyncapi: " + err.Error()) // bug
+	}
+	return string(b)
+}
@@ -0,0 +1,75 @@
+package asyncapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/asyncapi"
+	"goa.design/goa/v3/http/codegen/openapi"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+func TestFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"websocket", testdata.AsyncAPIWebSocketDSL},
+		{"sse", testdata.ServerSentEventsDSL},
+		{"sse-with-views", testdata.ServerSentEventsWithViewsDSL},
+		{"disabled-method", testdata.AsyncAPIDisabledMethodDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			defs := map[string]*openapi.Schema{"Existing": openapi.NewSchema()}
+			openapi.Definitions = defs
+			root := httpgen.RunHTTPDSL(t, c.DSL)
+
+			files, err := asyncapi.Files(root)
+
+			require.NoError(t, err)
+			require.Len(t, files, 2)
+			assert.Equal(t, defs, openapi.Definitions, "definitions not restored")
+			for i, f := range files {
+				s := f.SectionTemplates
+				require.Len(t, s, 1)
+				var buf bytes.Buffer
+				tmpl := template.Must(template.New("asyncapi").Funcs(s[0].FuncMap).Parse(s[0].Source))
+				require.NoError(t, tmpl.Execute(&buf, s[0].Data))
+				if filepath.Ext(f.Path) == ".json" {
+					var doc map[string]any
+					require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+					assert.Equal(t, asyncapi.Version, doc["asyncapi"])
+				}
+				golden := filepath.Join("testdata", "golden", fmt.Sprintf("%s_file%d.golden", c.Name, i))
+				if *update {
+					require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0644))
+				}
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				assert.Equal(t, string(bytes.ReplaceAll(want, []byte{'\r', '\n'}, []byte{'\n'})), buf.String())
+			}
+		})
+	}
+}
+
+func TestFilesNoStreaming(t *testing.T) {
+	root := httpgen.RunHTTPDSL(t, testdata.AsyncAPINoStreamingDSL)
+
+	files, err := asyncapi.Files(root)
+
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
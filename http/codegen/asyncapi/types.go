@@ -0,0 +1,129 @@
+package asyncapi
+
+import "goa.design/goa/v3/http/codegen/openapi"
+
+type (
+	// AsyncAPI is the root object of an AsyncAPI document.
+	AsyncAPI struct {
+		// AsyncAPI is the AsyncAPI specification version.
+		AsyncAPI string `json:"asyncapi" yaml:"asyncapi"`
+		// Info provides metadata about the API.
+		Info *Info `json:"info" yaml:"info"`
+		// Servers lists the servers exposing the channels.
+		Servers map[string]*Server `json:"servers,omitempty" yaml:"servers,omitempty"`
+		// DefaultContentType is the content type of the messages that
+		// do not define one.
+		DefaultContentType string `json:"defaultContentType,omitempty" yaml:"defaultContentType,omitempty"`
+		// Channels lists the channels indexed by identifier.
+		Channels map[string]*Channel `json:"channels,omitempty" yaml:"channels,omitempty"`
+		// Operations lists the operations indexed by identifier.
+		Operations map[string]*Operation `json:"operations,omitempty" yaml:"operations,omitempty"`
+		// Components holds the messages and schemas referenced by the
+		// channels and operations.
+		Components *Components `json:"components,omitempty" yaml:"components,omitempty"`
+	}
+
+	// Info provides metadata about the API.
+	Info struct {
+		Title          string   `json:"title" yaml:"title"`
+		Version        string   `json:"version" yaml:"version"`
+		Description    string   `json:"description,omitempty" yaml:"description,omitempty"`
+		TermsOfService string   `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"`
+		Contact        *Contact `json:"contact,omitempty" yaml:"contact,omitempty"`
+		License        *License `json:"license,omitempty" yaml:"license,omitempty"`
+	}
+
+	// Contact is the API contact information.
+	Contact struct {
+		Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+		URL   string `json:"url,omitempty" yaml:"url,omitempty"`
+		Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	}
+
+	// License is the API license information.
+	License struct {
+		Name string `json:"name" yaml:"name"`
+		URL  string `json:"url,omitempty" yaml:"url,omitempty"`
+	}
+
+	// Server describes a message broker or in the case of Goa a HTTP
+	// server the clients connect to.
+	Server struct {
+		Host        string                     `json:"host" yaml:"host"`
+		Protocol    string                     `json:"protocol" yaml:"protocol"`
+		Pathname    string                     `json:"pathname,omitempty" yaml:"pathname,omitempty"`
+		Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+		Variables   map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+	}
+
+	// ServerVariable describes a server host variable.
+	ServerVariable struct {
+		Enum        []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+		Default     string   `json:"default,omitempty" yaml:"default,omitempty"`
+		Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	// Channel describes a communication channel, in the case of Goa the
+	// websocket connection or Server-Sent Events stream of a method.
+	Channel struct {
+		Address     string                `json:"address" yaml:"address"`
+		Title       string                `json:"title,omitempty" yaml:"title,omitempty"`
+		Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+		Servers     []*Ref                `json:"servers,omitempty" yaml:"servers,omitempty"`
+		Messages    map[string]*Ref       `json:"messages,omitempty" yaml:"messages,omitempty"`
+		Parameters  map[string]*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		Bindings    *ChannelBindings      `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+	}
+
+	// Parameter describes a channel address parameter.
+	Parameter struct {
+		Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+		Enum        []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+		Default     string   `json:"default,omitempty" yaml:"default,omitempty"`
+	}
+
+	// ChannelBindings contains the protocol specific channel properties.
+	ChannelBindings struct {
+		WS *WebSocketBinding `json:"ws,omitempty" yaml:"ws,omitempty"`
+	}
+
+	// WebSocketBinding describes the HTTP request used to establish the
+	// websocket connection.
+	WebSocketBinding struct {
+		Method         string          `json:"method,omitempty" yaml:"method,omitempty"`
+		Query          *openapi.Schema `json:"query,omitempty" yaml:"query,omitempty"`
+		Headers        *openapi.Schema `json:"headers,omitempty" yaml:"headers,omitempty"`
+		BindingVersion string          `json:"bindingVersion,omitempty" yaml:"bindingVersion,omitempty"`
+	}
+
+	// Operation describes an action performed by the application on a
+	// channel: "send" for the results streamed by the service and
+	// "receive" for the streaming payloads.
+	Operation struct {
+		Action      string `json:"action" yaml:"action"`
+		Channel     *Ref   `json:"channel" yaml:"channel"`
+		Summary     string `json:"summary,omitempty" yaml:"summary,omitempty"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		Messages    []*Ref `json:"messages,omitempty" yaml:"messages,omitempty"`
+	}
+
+	// Message describes a message sent or received on a channel.
+	Message struct {
+		Name        string          `json:"name,omitempty" yaml:"name,omitempty"`
+		Title       string          `json:"title,omitempty" yaml:"title,omitempty"`
+		Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+		ContentType string          `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+		Payload     *openapi.Schema `json:"payload,omitempty" yaml:"payload,omitempty"`
+	}
+
+	// Components holds the reusable objects of the document.
+	Components struct {
+		Schemas  map[string]*openapi.Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+		Messages map[string]*Message        `json:"messages,omitempty" yaml:"messages,omitempty"`
+	}
+
+	// Ref is a JSON reference.
+	Ref struct {
+		Ref string `json:"$ref" yaml:"$ref"`
+	}
+)
@@ -0,0 +1,94 @@
+package codegen
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// CacheData contains the data needed to render the code that writes
+	// the cache headers of the responses and handles the conditional
+	// requests.
+	CacheData struct {
+		// CacheControl is the value of the Cache-Control response header
+		// if any.
+		CacheControl string
+		// ETag describes the result field that holds the entity tag if
+		// any.
+		ETag *CacheFieldData
+		// LastModified describes the result field that holds the last
+		// modification time if any.
+		LastModified *CacheFieldData
+		// IfMatch is the data of the request header that holds the
+		// entity tag of the If-Match header if any.
+		IfMatch *HeaderData
+		// FieldSelection describes the field selection of the method if
+		// it supports it and the response defines validators, the
+		// handler records the selection in the request context so that
+		// the entity tags of partial responses are weak.
+		FieldSelection *service.FieldSelectionData
+	}
+
+	// CacheFieldData describes a result field used as cache validator.
+	CacheFieldData struct {
+		// Ref is the reference to the field in the response encoder,
+		// e.g. "res.Version".
+		Ref string
+		// Pointer is true if the field is a pointer.
+		Pointer bool
+		// DateTime is true if the field holds a time.Time value rather
+		// than a RFC 3339 string.
+		DateTime bool
+	}
+)
+
+// HasValidators returns true if the responses define an entity tag or a last
+// modification time.
+func (c *CacheData) HasValidators() bool {
+	return c.ETag != nil || c.LastModified != nil
+}
+
+// initCacheData initializes the cache related data in ed.
+func initCacheData(ed *EndpointData, e *expr.HTTPEndpointExpr) {
+	var (
+		c      = e.Cache
+		res    = e.MethodExpr.Result
+		viewed = ed.Method.ViewedResult != nil
+	)
+	field := func(name string) *CacheFieldData {
+		if name == "" {
+			return nil
+		}
+		att := res.Find(name)
+		ref := "res."
+		if viewed {
+			ref += "Projected."
+		}
+		return &CacheFieldData{
+			Ref:      ref + codegen.GoifyAtt(att, name, true),
+			Pointer:  viewed || res.IsPrimitivePointer(name, true),
+			DateTime: att.Type == expr.DateTime,
+		}
+	}
+	data := &CacheData{
+		CacheControl: strings.Join(c.Directives, ", "),
+		ETag:         field(c.ETag),
+		LastModified: field(c.LastModified),
+	}
+	if ifMatch := c.IfMatch(); ifMatch != "" {
+		for _, h := range ed.Payload.Request.Headers {
+			if h.Name == ifMatch && h.CanonicalName == "If-Match" {
+				data.IfMatch = h
+				break
+			}
+		}
+	}
+	if data.HasValidators() {
+		svc := service.Services.Get(e.Service.Name())
+		data.FieldSelection = service.BuildFieldSelectionData(svc, ed.Method)
+	}
+	ed.Cache = data
+}
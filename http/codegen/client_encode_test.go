@@ -31,6 +31,7 @@ func TestClientEncode(t *testing.T) {
 		{"query-uint32", testdata.PayloadQueryUInt32DSL, testdata.PayloadQueryUInt32EncodeCode},
 		{"query-uint32-validate", testdata.PayloadQueryUInt32ValidateDSL, testdata.PayloadQueryUInt32ValidateEncodeCode},
 		{"query-uint64", testdata.PayloadQueryUInt64DSL, testdata.PayloadQueryUInt64EncodeCode},
+		{"query-time-types", testdata.PayloadQueryTimeTypesDSL, testdata.PayloadQueryTimeTypesEncodeCode},
 		{"query-uint64-validate", testdata.PayloadQueryUInt64ValidateDSL, testdata.PayloadQueryUInt64ValidateEncodeCode},
 		{"query-float32", testdata.PayloadQueryFloat32DSL, testdata.PayloadQueryFloat32EncodeCode},
 		{"query-float32-validate", testdata.PayloadQueryFloat32ValidateDSL, testdata.PayloadQueryFloat32ValidateEncodeCode},
@@ -178,6 +179,8 @@ func TestClientEncode(t *testing.T) {
 		{"query-custom-name", testdata.PayloadQueryCustomNameDSL, testdata.PayloadQueryCustomNameEncodeCode},
 		{"header-custom-name", testdata.PayloadHeaderCustomNameDSL, testdata.PayloadHeaderCustomNameEncodeCode},
 		{"cookie-custom-name", testdata.PayloadCookieCustomNameDSL, testdata.PayloadCookieCustomNameEncodeCode},
+		{"header-if-match", testdata.PayloadHeaderIfMatchDSL, testdata.PayloadHeaderIfMatchEncodeCode},
+		{"header-if-match-optional", testdata.PayloadHeaderIfMatchOptionalDSL, testdata.PayloadHeaderIfMatchOptionalEncodeCode},
 	}
 	golden := makeGolden(t, "testdata/payload_encode_functions.go")
 	if golden != nil {
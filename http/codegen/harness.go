@@ -0,0 +1,192 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// harnessData contains the data needed to render the HTTP test harness
+	// and contract tests of a service.
+	harnessData struct {
+		// Service is the HTTP service data.
+		Service *ServiceData
+		// ServerPkg is the name of the generated HTTP server package.
+		ServerPkg string
+		// ClientPkg is the name of the generated HTTP client package.
+		ClientPkg string
+		// ServerArgs lists the arguments given to the server constructor
+		// after the error formatter.
+		ServerArgs []string
+		// ClientArgs lists the arguments given to the client constructor
+		// after restoreBody.
+		ClientArgs []string
+		// Endpoints lists the Go expressions of the endpoints given to
+		// the service client constructor in method order.
+		Endpoints []string
+		// HasServerInterceptors is true if the service endpoints
+		// constructor requires server interceptors.
+		HasServerInterceptors bool
+		// HasClientInterceptors is true if the service client
+		// constructor requires client interceptors.
+		HasClientInterceptors bool
+		// Cases lists the contract test cases.
+		Cases []*httpContractCaseData
+		// NeedsPtr is true if the contract test cases use the generic
+		// function that returns a pointer to a value.
+		NeedsPtr bool
+	}
+
+	// httpContractCaseData contains the data needed to render an HTTP
+	// contract test case.
+	httpContractCaseData struct {
+		*service.ContractCaseData
+		// StatusCode is the expected response status code.
+		StatusCode int
+	}
+)
+
+// HarnessFiles returns the files that contain the in-process HTTP test
+// harness and the contract tests of each service. The contract tests call
+// each method through the generated HTTP client and server using the service
+// mock and check that the responses and errors defined in the design are
+// produced. Contract tests are not generated for services that use
+// interceptors since the tests cannot provide the interceptor implementations.
+func HarnessFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		data := HTTPServices.Get(svc.Name())
+		if len(data.Endpoints) == 0 {
+			continue
+		}
+		files = append(files, harnessFiles(genpkg, svc, data)...)
+	}
+	return files
+}
+
+// harnessFiles returns the HTTP test harness and contract test files of the
+// given service.
+func harnessFiles(genpkg string, svc *expr.HTTPServiceExpr, data *ServiceData) []*codegen.File {
+	hd := buildHarnessData(svc, data)
+	sd := data.Service
+	dir := filepath.Join(codegen.Gendir, sd.PathName, service.TestPkgName(sd))
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "errors"},
+		{Path: "net/http"},
+		{Path: "net/http/httptest"},
+		{Path: "net/url"},
+		{Path: "sync"},
+		{Path: "testing"},
+		{Path: "github.com/gorilla/websocket"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		{Path: path.Join(genpkg, sd.PathName), Name: sd.PkgName},
+		{Path: path.Join(genpkg, "http", sd.PathName, "server"), Name: hd.ServerPkg},
+		{Path: path.Join(genpkg, "http", sd.PathName, "client"), Name: hd.ClientPkg},
+	}
+	imports = append(imports, sd.UserTypeImports...)
+	harness := &codegen.File{
+		Path: filepath.Join(dir, "http.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(svc.Name()+" HTTP test harness", service.TestPkgName(sd), imports),
+			{Name: "http-harness", Source: readTemplate("harness"), Data: hd},
+		},
+	}
+	if len(hd.Cases) == 0 || hd.HasServerInterceptors || hd.HasClientInterceptors {
+		return []*codegen.File{harness}
+	}
+	contract := &codegen.File{
+		Path: filepath.Join(dir, "http_contract_test.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(svc.Name()+" HTTP contract tests", service.TestPkgName(sd), imports),
+			{Name: "http-contract-test", Source: readTemplate("contract_test"), Data: hd},
+		},
+	}
+	return []*codegen.File{harness, contract}
+}
+
+// buildHarnessData builds the data needed to render the HTTP test harness and
+// contract tests of the given service.
+func buildHarnessData(svc *expr.HTTPServiceExpr, data *ServiceData) *harnessData {
+	sd := data.Service
+	hd := &harnessData{
+		Service:               data,
+		ServerPkg:             sd.PkgName + "svr",
+		ClientPkg:             sd.PkgName + "c",
+		HasServerInterceptors: len(sd.ServerInterceptors) > 0,
+		HasClientInterceptors: len(sd.ClientInterceptors) > 0,
+	}
+	if hasWebSocket(data) {
+		hd.ServerArgs = append(hd.ServerArgs, "&websocket.Upgrader{}", "nil")
+		hd.ClientArgs = append(hd.ClientArgs, "websocket.DefaultDialer", "nil")
+	}
+	for _, e := range data.Endpoints {
+		if e.MultipartRequestDecoder != nil {
+			hd.ServerArgs = append(hd.ServerArgs, "nil")
+		}
+	}
+	for range data.FileServers {
+		hd.ServerArgs = append(hd.ServerArgs, "nil")
+	}
+	for _, m := range sd.Methods {
+		e := data.Endpoint(m.Name)
+		if e == nil {
+			hd.Endpoints = append(hd.Endpoints, "nil")
+			continue
+		}
+		init := "c." + e.EndpointInit + "()"
+		if e.MultipartRequestEncoder != nil {
+			init = "c." + e.EndpointInit + "(nil)"
+		}
+		hd.Endpoints = append(hd.Endpoints, init)
+	}
+	for _, e := range svc.HTTPEndpoints {
+		ed := data.Endpoint(e.Name())
+		if ed == nil || ed.MultipartRequestDecoder != nil || isStreamingEndpoint(ed) || e.Redirect != nil {
+			continue
+		}
+		var params []string
+		codegen.WalkMappedAttr(e.PathParams(), func(name, _ string, _ bool, _ *expr.AttributeExpr) error { // nolint: errcheck
+			params = append(params, name)
+			return nil
+		})
+		if status := successStatus(e); status != 0 {
+			if c := service.ContractCase(sd, e.MethodExpr, nil, params...); c != nil {
+				hd.Cases = append(hd.Cases, &httpContractCaseData{ContractCaseData: c, StatusCode: status})
+			}
+		}
+		for _, he := range e.HTTPErrors {
+			if c := service.ContractCase(sd, e.MethodExpr, he.ErrorExpr, params...); c != nil {
+				hd.Cases = append(hd.Cases, &httpContractCaseData{ContractCaseData: c, StatusCode: he.Response.StatusCode})
+			}
+		}
+	}
+	for _, c := range hd.Cases {
+		if strings.Contains(c.Payload, "ptr[") {
+			hd.NeedsPtr = true
+			break
+		}
+	}
+	return hd
+}
+
+// successStatus returns the status code of the response sent when the
+// endpoint method succeeds, 0 if the response depends on the value of a result
+// attribute.
+func successStatus(e *expr.HTTPEndpointExpr) int {
+	for _, r := range e.Responses {
+		if r.Tag[0] != "" {
+			return 0
+		}
+	}
+	if len(e.Responses) == 0 {
+		return 0
+	}
+	return e.Responses[0].StatusCode
+}
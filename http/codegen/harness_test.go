@@ -0,0 +1,44 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestHarnessFiles(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Harness  string
+		Contract string
+	}{
+		{"payload-result-error", testdata.ServerPayloadResultErrorDSL, testdata.HarnessPayloadResultErrorCode, testdata.ContractPayloadResultErrorCode},
+		{"mixed", testdata.ServerMixedDSL, testdata.HarnessMixedCode, testdata.ContractMixedCode},
+		{"multipart", testdata.ServerMultipartDSL, testdata.HarnessMultipartCode, ""},
+		{"streaming", testdata.StreamingResultDSL, testdata.HarnessStreamingCode, ""},
+		{"retry", testdata.ServerRetryDSL, testdata.HarnessRetryCode, testdata.ContractRetryCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := HarnessFiles("gen", expr.Root)
+			if c.Contract == "" {
+				require.Len(t, fs, 1)
+			} else {
+				require.Len(t, fs, 2)
+			}
+			require.Len(t, fs[0].SectionTemplates, 2)
+			assert.Equal(t, c.Harness, codegen.SectionCode(t, fs[0].SectionTemplates[1]))
+			if c.Contract != "" {
+				require.Len(t, fs[1].SectionTemplates, 2)
+				assert.Equal(t, c.Contract, codegen.SectionCode(t, fs[1].SectionTemplates[1]))
+			}
+		})
+	}
+}
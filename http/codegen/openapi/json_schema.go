@@ -24,6 +24,7 @@ type (
 		Description  string             `json:"description,omitempty" yaml:"description,omitempty"`
 		DefaultValue any                `json:"default,omitempty" yaml:"default,omitempty"`
 		Example      any                `json:"example,omitempty" yaml:"example,omitempty"`
+		Examples     []any              `json:"examples,omitempty" yaml:"examples,omitempty"`
 
 		// Hyper schema
 		Media     *Media  `json:"media,omitempty" yaml:"media,omitempty"`
@@ -339,6 +340,16 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 		case expr.BytesKind:
 			s.Type = Type("string")
 			s.Format = "byte"
+		case expr.DateTimeKind:
+			s.Type = Type("string")
+			s.Format = "date-time"
+		case expr.DurationKind:
+			// Durations are encoded as a number of nanoseconds.
+			s.Type = Type("integer")
+			s.Format = "int64"
+		case expr.DecimalKind:
+			s.Type = Type("string")
+			s.Format = "decimal"
 		}
 	case *expr.Array:
 		s.Type = Array
@@ -149,6 +149,9 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 					}
 
 					switch s.Kind {
+					case expr.MutualTLSKind:
+						// OpenAPI V2 spec does not support mutual TLS.
+						continue
 					case expr.BasicAuthKind:
 						sd.Type = "basic"
 						addScopeDescription(s.Scopes, &sd)
@@ -348,6 +351,15 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 	case expr.Bytes:
 		p.Type = "string"
 		p.Format = "byte"
+	case expr.DateTime:
+		p.Type = "string"
+		p.Format = "date-time"
+	case expr.Duration:
+		p.Type = "integer"
+		p.Format = "int64"
+	case expr.Decimal:
+		p.Type = "string"
+		p.Format = "decimal"
 	}
 	p.Extensions = openapi.ExtensionsFromExpr(at.Meta)
 	initValidations(alias, p)
@@ -365,6 +377,15 @@ func itemsFromExpr(at *expr.AttributeExpr) *Items {
 			items.Type = "number"
 		case expr.BytesKind:
 			items.Type = "string"
+		case expr.DateTimeKind:
+			items.Type = "string"
+			items.Format = "date-time"
+		case expr.DurationKind:
+			items.Type = "integer"
+			items.Format = "int64"
+		case expr.DecimalKind:
+			items.Type = "string"
+			items.Format = "decimal"
 		}
 	}
 	initValidations(at, items)
@@ -413,6 +434,17 @@ func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 			Description: at.Description,
 			Type:        at.Type.Name(),
 		}
+		switch at.Type {
+		case expr.DateTime:
+			header.Type = "string"
+			header.Format = "date-time"
+		case expr.Duration:
+			header.Type = "integer"
+			header.Format = "int64"
+		case expr.Decimal:
+			header.Type = "string"
+			header.Format = "decimal"
+		}
 		initValidations(at, header)
 		res[n] = header
 		return nil
@@ -509,7 +541,14 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 
 		responses := make(map[string]*Response, len(endpoint.Responses))
 		for _, r := range endpoint.Responses {
-			if endpoint.MethodExpr.IsStreaming() {
+			if endpoint.SSE != nil {
+				// Server-sent events are streamed in the body of the
+				// successful response.
+				if r.StatusCode < 400 {
+					r = r.Dup()
+					r.ContentType = "text/event-stream"
+				}
+			} else if endpoint.MethodExpr.IsStreaming() {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -580,7 +619,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		}
 
 		// replace http with ws for streaming endpoints
-		if endpoint.MethodExpr.IsStreaming() {
+		if endpoint.MethodExpr.IsStreaming() && endpoint.SSE == nil {
 			for i := len(schemes) - 1; i >= 0; i-- {
 				if schemes[i] == "http" {
 					news := append([]string{"ws"}, schemes[i+1:]...)
@@ -595,19 +634,23 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 
 		description := endpoint.Description()
 
-		requirements := make([]map[string][]string, len(endpoint.Requirements))
-		for i, req := range endpoint.Requirements {
+		requirements := make([]map[string][]string, 0, len(endpoint.Requirements))
+		for _, req := range endpoint.Requirements {
 			requirement := make(map[string][]string)
 			for _, s := range req.Schemes {
+				if s.Kind == expr.MutualTLSKind {
+					continue
+				}
 				requirement[s.Hash()] = []string{}
+				scopes := req.RequiredScopes(s.SchemeName)
 				switch s.Kind {
 				case expr.OAuth2Kind:
-					if len(req.Scopes) > 0 {
-						requirement[s.Hash()] = req.Scopes
+					if len(scopes) > 0 {
+						requirement[s.Hash()] = scopes
 					}
 				case expr.BasicAuthKind, expr.APIKeyKind, expr.JWTKind:
-					lines := make([]string, 0, len(req.Scopes))
-					for _, scope := range req.Scopes {
+					lines := make([]string, 0, len(scopes))
+					for _, scope := range scopes {
 						lines = append(lines, fmt.Sprintf("  * `%s`", scope))
 					}
 					// List scopes only if they are defined
@@ -619,7 +662,9 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 					}
 				}
 			}
-			requirements[i] = requirement
+			if len(requirement) > 0 {
+				requirements = append(requirements, requirement)
+			}
 		}
 		_, deprecated := endpoint.MethodExpr.Meta.Last("openapi:deprecated")
 		operation := &Operation{
@@ -637,6 +682,8 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			Extensions:   openapi.ExtensionsFromExpr(endpoint.MethodExpr.Meta),
 			Security:     requirements,
 		}
+		addRateLimit(operation, endpoint.MethodExpr)
+		addCache(operation, endpoint, route)
 
 		if key == "" {
 			key = "/"
@@ -823,3 +870,112 @@ func initValidations(attr *expr.AttributeExpr, def any) {
 		initMaxLengthValidation(def, expr.IsArray(attr.Type), val.MaxLength)
 	}
 }
+
+// addRateLimit documents the rate limit that applies to the method of the
+// given operation if any. It adds the 429 response unless the design already
+// defines one and the x-ratelimit extension.
+func addRateLimit(op *Operation, m *expr.MethodExpr) {
+	rl := m.EffectiveRateLimit()
+	if rl == nil {
+		return
+	}
+	code := strconv.Itoa(expr.StatusTooManyRequests)
+	if _, ok := op.Responses[code]; !ok {
+		header := func(desc string) *Header {
+			return &Header{Description: desc, Type: "integer"}
+		}
+		op.Responses[code] = &Response{
+			Description: fmt.Sprintf("rate_limit_exceeded: More than %d requests per %s.", rl.Requests, rl.Period),
+			Headers: map[string]*Header{
+				"Retry-After":           header("Number of seconds to wait before making a new request."),
+				"X-RateLimit-Limit":     header("Maximum number of requests allowed in the current period."),
+				"X-RateLimit-Remaining": header("Number of requests remaining in the current period."),
+				"X-RateLimit-Reset":     header("Number of seconds until the quota resets."),
+			},
+		}
+	}
+	ext := map[string]any{
+		"requests": rl.Requests,
+		"period":   rl.Period.String(),
+	}
+	switch rl.Key {
+	case "":
+	case expr.RateLimitKeyClientIP:
+		ext["key"] = "client_ip"
+	case expr.RateLimitKeyPrincipal:
+		ext["key"] = "principal"
+	default:
+		ext["key"] = rl.Key
+	}
+	if op.Extensions == nil {
+		op.Extensions = make(map[string]any)
+	}
+	op.Extensions["x-ratelimit"] = ext
+}
+
+// addCache documents the cache headers written with the successful responses
+// of the given operation. It also documents the If-None-Match and
+// If-Modified-Since request headers and the 304 response of GET and HEAD
+// routes whose responses define cache validators.
+func addCache(op *Operation, e *expr.HTTPEndpointExpr, r *expr.RouteExpr) {
+	c := e.Cache
+	if c == nil {
+		return
+	}
+	headers := make(map[string]*Header)
+	if len(c.Directives) > 0 {
+		headers["Cache-Control"] = &Header{Description: "Caching directives of the response.", Type: "string"}
+	}
+	if c.ETag != "" {
+		headers["ETag"] = &Header{Description: "Entity tag of the returned representation.", Type: "string"}
+	}
+	if c.LastModified != "" {
+		headers["Last-Modified"] = &Header{Description: "Date and time at which the returned representation was last modified.", Type: "string"}
+	}
+	if len(headers) == 0 {
+		return
+	}
+	for _, resp := range e.Responses {
+		if resp.StatusCode >= 300 {
+			continue
+		}
+		res, ok := op.Responses[strconv.Itoa(resp.StatusCode)]
+		if !ok || res.Ref != "" {
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = make(map[string]*Header)
+		}
+		for name, h := range headers {
+			if _, ok := res.Headers[name]; !ok {
+				res.Headers[name] = h
+			}
+		}
+	}
+	if !c.HasValidators() || r.Method != "GET" && r.Method != "HEAD" {
+		return
+	}
+	param := func(name, desc string) {
+		for _, p := range op.Parameters {
+			if p.In == "header" && strings.EqualFold(p.Name, name) {
+				return
+			}
+		}
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        name,
+			In:          "header",
+			Description: desc,
+			Type:        "string",
+		})
+	}
+	if c.ETag != "" {
+		param("If-None-Match", "Entity tags of the representations cached by the client.")
+	}
+	if c.LastModified != "" {
+		param("If-Modified-Since", "Modification date of the representation cached by the client.")
+	}
+	code := strconv.Itoa(expr.StatusNotModified)
+	if _, ok := op.Responses[code]; !ok {
+		op.Responses[code] = &Response{Description: "Not Modified response.", Headers: headers}
+	}
+}
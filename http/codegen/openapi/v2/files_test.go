@@ -38,6 +38,7 @@ func TestSections(t *testing.T) {
 		{"multiple-views", testdata.MultipleViewsDSL},
 		{"explicit-view", testdata.ExplicitViewDSL},
 		{"security", testdata.SecurityDSL},
+		{"composed-security", testdata.ComposedSecurityDSL},
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
@@ -53,6 +54,8 @@ func TestSections(t *testing.T) {
 		{"json-prefix", testdata.JSONPrefixDSL},
 		{"json-indent", testdata.JSONIndentDSL},
 		{"json-prefix-indent", testdata.JSONPrefixIndentDSL},
+		{"rate-limit", testdata.RateLimitDSL},
+		{"cache", testdata.CacheDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
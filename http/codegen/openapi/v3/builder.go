@@ -14,6 +14,19 @@ import (
 // OpenAPIVersion is the OpenAPI specification version targeted by this package.
 const OpenAPIVersion = "3.0.3"
 
+// OpenAPIMutualTLSVersion is the OpenAPI specification version of the
+// documents generated for the designs that use mutual TLS security schemes.
+// The mutualTLS security scheme type was introduced in OpenAPI 3.1 so adding
+// a MutualTLSSecurity requirement to a design upgrades its whole document:
+// the schemas are then described with the JSON Schema 2020-12 dialect of
+// OpenAPI 3.1, see JSONSchemaDialect. Designs that do not use mutual TLS keep
+// producing OpenAPIVersion documents.
+const OpenAPIMutualTLSVersion = "3.1.0"
+
+// JSONSchemaDialect is the JSON Schema dialect of the schemas of the
+// OpenAPIMutualTLSVersion documents.
+const JSONSchemaDialect = "https://spec.openapis.org/oas/3.1/dialect/base"
+
 var (
 	routeIndexReplacementRegExp = regexp.MustCompile(`\((.*){routeIndex}\)`)
 )
@@ -49,7 +62,7 @@ func New(root *expr.RootExpr) *OpenAPI {
 		tags     = buildTags(root.API)
 	)
 
-	return &OpenAPI{
+	spec := &OpenAPI{
 		OpenAPI:    OpenAPIVersion,
 		Info:       info,
 		Components: comps,
@@ -58,6 +71,10 @@ func New(root *expr.RootExpr) *OpenAPI {
 		Security:   security,
 		Tags:       tags,
 	}
+	if usesMutualTLS(root) {
+		upgrade(spec)
+	}
+	return spec
 }
 
 // buildInfo builds the OpenAPI Info object.
@@ -108,6 +125,14 @@ func buildComponents(root *expr.RootExpr, types map[string]*openapi.Schema) *Com
 			}
 		}
 	}
+	if usesProblemDetails(root.API.HTTP) {
+		if _, ok := types[problemDetailsSchemaName]; !ok {
+			if types == nil {
+				types = make(map[string]*openapi.Schema)
+			}
+			types[problemDetailsSchemaName] = problemDetailsSchema()
+		}
+	}
 	return &Components{
 		SecuritySchemes: schemesRef,
 		Schemas:         types,
@@ -285,7 +310,14 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	{
 		responses = make(map[string]*ResponseRef, len(e.Responses))
 		for _, r := range e.Responses {
-			if e.MethodExpr.IsStreaming() {
+			if e.SSE != nil {
+				// Server-sent events are streamed in the body of the
+				// successful response.
+				if r.StatusCode < 400 {
+					r = r.Dup()
+					r.ContentType = "text/event-stream"
+				}
+			} else if e.MethodExpr.IsStreaming() {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -315,6 +347,9 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 					content.Example = nil
 				}
 			}
+			if er.Type == expr.ErrorResult && svc.UsesProblemDetails() {
+				problemDetailsResponse(resp)
+			}
 			responses[strconv.Itoa(er.Response.StatusCode)] = &ResponseRef{Value: resp}
 		}
 	}
@@ -341,7 +376,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 
 	// An endpoint may be marked as deprecated. if the openapi:deprecated tag is present, we populate it to true
 	_, deprecated := e.Meta.Last("openapi:deprecated")
-	return &Operation{
+	op := &Operation{
 		Tags:         tagNames,
 		Summary:      summary,
 		Description:  e.Description(),
@@ -354,6 +389,10 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 		ExternalDocs: openapi.DocsFromExpr(m.Docs, m.Meta),
 		Extensions:   openapi.ExtensionsFromExpr(m.Meta),
 	}
+	addRateLimit(op, m, svc.UsesProblemDetails())
+	addPagination(op, e)
+	addCache(op, e, r)
+	return op
 }
 
 // buildFileServerOperation builds the OpenAPI Operation object for the given file server.
@@ -546,24 +585,129 @@ func buildServers(servers []*expr.ServerExpr) []*Server {
 // buildSecurityRequirements builds the OpenAPI security requirements for the
 // given security expressions.
 func buildSecurityRequirements(reqs []*expr.SecurityExpr) []map[string][]string {
-	srs := make([]map[string][]string, len(reqs))
-	for i, req := range reqs {
+	srs := make([]map[string][]string, 0, len(reqs))
+	for _, req := range reqs {
 		sr := make(map[string][]string, len(req.Schemes))
 		for _, sch := range req.Schemes {
 			scopes := make([]string, 0)
 			switch sch.Kind {
 			case expr.OAuth2Kind, expr.JWTKind:
-				if len(req.Scopes) > 0 {
-					scopes = req.Scopes
+				if rs := req.RequiredScopes(sch.SchemeName); len(rs) > 0 {
+					scopes = rs
 				}
 			}
 			sr[sch.Hash()] = scopes
 		}
-		srs[i] = sr
+		if len(sr) > 0 {
+			srs = append(srs, sr)
+		}
 	}
 	return srs
 }
 
+// upgrade turns spec into an OpenAPIMutualTLSVersion document. The schema
+// example keyword deprecated by OpenAPI 3.1 is replaced with the JSON Schema
+// examples keyword.
+func upgrade(spec *OpenAPI) {
+	spec.OpenAPI = OpenAPIMutualTLSVersion
+	spec.JSONSchemaDialect = JSONSchemaDialect
+	seen := make(map[*openapi.Schema]bool)
+	var schema func(*openapi.Schema)
+	schema = func(s *openapi.Schema) {
+		if s == nil || seen[s] {
+			return
+		}
+		seen[s] = true
+		if s.Example != nil {
+			s.Examples = []any{s.Example}
+			s.Example = nil
+		}
+		schema(s.Items)
+		for _, p := range s.Properties {
+			schema(p)
+		}
+		for _, d := range s.Definitions {
+			schema(d)
+		}
+		for _, a := range s.AnyOf {
+			schema(a)
+		}
+		if ap, ok := s.AdditionalProperties.(*openapi.Schema); ok {
+			schema(ap)
+		}
+	}
+	content := func(c map[string]*MediaType) {
+		for _, mt := range c {
+			schema(mt.Schema)
+		}
+	}
+	headers := func(hs map[string]*HeaderRef) {
+		for _, h := range hs {
+			if h.Value != nil {
+				schema(h.Value.Schema)
+				content(h.Value.Content)
+			}
+		}
+	}
+	params := func(ps []*ParameterRef) {
+		for _, p := range ps {
+			if p.Value != nil {
+				schema(p.Value.Schema)
+				content(p.Value.Content)
+			}
+		}
+	}
+	if spec.Components != nil {
+		for _, s := range spec.Components.Schemas {
+			schema(s)
+		}
+	}
+	for _, item := range spec.Paths {
+		params(item.Parameters)
+		for _, o := range []*Operation{item.Connect, item.Delete, item.Get, item.Head, item.Options, item.Patch, item.Post, item.Put, item.Trace} {
+			if o == nil {
+				continue
+			}
+			params(o.Parameters)
+			if o.RequestBody != nil && o.RequestBody.Value != nil {
+				content(o.RequestBody.Value.Content)
+			}
+			for _, r := range o.Responses {
+				if r.Value != nil {
+					headers(r.Value.Headers)
+					content(r.Value.Content)
+				}
+			}
+		}
+	}
+}
+
+// usesMutualTLS returns true if the API or any of its HTTP endpoints requires
+// a mutual TLS security scheme.
+func usesMutualTLS(root *expr.RootExpr) bool {
+	requires := func(reqs []*expr.SecurityExpr) bool {
+		for _, r := range reqs {
+			for _, s := range r.Schemes {
+				if s.Kind == expr.MutualTLSKind {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if requires(root.API.Requirements) {
+		return true
+	}
+	for _, s := range root.API.HTTP.Services {
+		for _, e := range s.HTTPEndpoints {
+			if requires(e.Requirements) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // buildSecurityScheme builds the OpenAPI SecurityScheme object from the
 // top-level security scheme definition.
 func buildSecurityScheme(se *expr.SchemeExpr) *SecurityScheme {
@@ -591,6 +735,12 @@ func buildSecurityScheme(se *expr.SchemeExpr) *SecurityScheme {
 			Description: se.Description,
 			Extensions:  openapi.ExtensionsFromExpr(se.Meta),
 		}
+	case expr.MutualTLSKind:
+		scheme = &SecurityScheme{
+			Type:        "mutualTLS",
+			Description: se.Description,
+			Extensions:  openapi.ExtensionsFromExpr(se.Meta),
+		}
 	case expr.OAuth2Kind:
 		scopes := make(map[string]string, len(se.Scopes))
 		for _, scope := range se.Scopes {
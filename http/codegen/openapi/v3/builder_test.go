@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
 	"goa.design/goa/v3/http/codegen/openapi"
 	"goa.design/goa/v3/http/codegen/openapi/v3/testdata/dsls"
+	"goa.design/goa/v3/http/codegen/testdata"
 )
 
 func TestBuildInfo(t *testing.T) {
@@ -258,6 +262,95 @@ func TestBuildOperation(t *testing.T) {
 	}
 }
 
+func TestSecurityRequirements(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+
+		ExpectedVersion      string
+		ExpectedRequirements []map[string][]string
+		ExpectedSchemeTypes  map[string]string
+	}{{
+		Name: "mutual-tls",
+		DSL:  testdata.MutualTLSSecurityDSL,
+
+		ExpectedVersion:      OpenAPIMutualTLSVersion,
+		ExpectedRequirements: []map[string][]string{{"mtls__": {}}},
+		ExpectedSchemeTypes:  map[string]string{"mtls__": "mutualTLS"},
+	}, {
+		Name: "composed",
+		DSL:  testdata.ComposedSecurityDSL,
+
+		ExpectedVersion: OpenAPIMutualTLSVersion,
+		ExpectedRequirements: []map[string][]string{
+			{"jwt_header_Authorization": {"api:admin"}, "api_key_query_k": {}},
+			{"mtls__": {}},
+		},
+		ExpectedSchemeTypes: map[string]string{"jwt_header_Authorization": "http", "api_key_query_k": "apiKey", "mtls__": "mutualTLS"},
+	}, {
+		Name: "basic",
+		DSL:  testdata.SecurityDSL,
+
+		ExpectedVersion: OpenAPIVersion,
+	}}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := codegen.RunDSL(t, c.DSL)
+			spec := New(root)
+			assert.Equal(t, c.ExpectedVersion, spec.OpenAPI)
+			if c.ExpectedRequirements == nil {
+				return
+			}
+			item := spec.Paths["/"]
+			require.NotNil(t, item)
+			op := item.Get
+			if op == nil {
+				op = item.Delete
+			}
+			require.NotNil(t, op)
+			assert.Equal(t, c.ExpectedRequirements, op.Security)
+			types := make(map[string]string, len(spec.Components.SecuritySchemes))
+			for name, s := range spec.Components.SecuritySchemes {
+				types[name] = s.Value.Type
+			}
+			assert.Equal(t, c.ExpectedSchemeTypes, types)
+		})
+	}
+}
+
+func TestMutualTLSDocument(t *testing.T) {
+	root := codegen.RunDSL(t, testdata.MixedMutualTLSDSL)
+	spec := New(root)
+
+	assert.Equal(t, OpenAPIMutualTLSVersion, spec.OpenAPI)
+	assert.Equal(t, JSONSchemaDialect, spec.JSONSchemaDialect)
+	item := spec.Components.Schemas["Item"]
+	require.NotNil(t, item)
+	assert.Nil(t, item.Example)
+	assert.Equal(t, []any{map[string]any{"name": "widget", "price": 9.99}}, item.Examples)
+	price := item.Properties["price"]
+	require.NotNil(t, price)
+	assert.Nil(t, price.Example)
+	assert.Equal(t, []any{9.99}, price.Examples)
+	require.NotNil(t, price.ExclusiveMinimum)
+	assert.Equal(t, 0.0, *price.ExclusiveMinimum)
+	list := spec.Paths["/"].Get.Responses["200"].Value.Content["application/json"].Schema
+	assert.Nil(t, list.Example)
+	assert.Len(t, list.Examples, 1)
+	param := spec.Paths["/"].Post.Parameters[0].Value
+	assert.Nil(t, param.Schema.Example)
+	assert.Equal(t, []any{"widget"}, param.Schema.Examples)
+	assert.Equal(t, "widget", param.Example, "parameter examples are not JSON schema examples")
+
+	root = codegen.RunDSL(t, testdata.SecurityDSL)
+	spec = New(root)
+	assert.Equal(t, OpenAPIVersion, spec.OpenAPI)
+	assert.Empty(t, spec.JSONSchemaDialect)
+	for name, s := range spec.Components.Schemas {
+		assert.Empty(t, s.Examples, name)
+	}
+}
+
 func TestBuildOperationID(t *testing.T) {
 	const svcName = "test service"
 
@@ -0,0 +1,87 @@
+package openapiv3
+
+import (
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+// addCache documents the cache headers written with the successful responses
+// of the given operation. It also documents the If-None-Match and
+// If-Modified-Since request headers and the 304 response of GET and HEAD
+// routes whose responses define cache validators.
+func addCache(op *Operation, e *expr.HTTPEndpointExpr, r *expr.RouteExpr) {
+	c := e.Cache
+	if c == nil {
+		return
+	}
+	header := func(desc string) *HeaderRef {
+		return &HeaderRef{Value: &Header{
+			Description: desc,
+			Schema:      &openapi.Schema{Type: openapi.String},
+		}}
+	}
+	headers := make(map[string]*HeaderRef)
+	if len(c.Directives) > 0 {
+		headers["Cache-Control"] = header("Caching directives of the response.")
+	}
+	if c.ETag != "" {
+		headers["ETag"] = header("Entity tag of the returned representation.")
+	}
+	if c.LastModified != "" {
+		headers["Last-Modified"] = header("Date and time at which the returned representation was last modified.")
+	}
+	if len(headers) == 0 {
+		return
+	}
+	for _, resp := range e.Responses {
+		if resp.StatusCode >= 300 {
+			continue
+		}
+		ref, ok := op.Responses[strconv.Itoa(resp.StatusCode)]
+		if !ok || ref.Value == nil {
+			continue
+		}
+		if ref.Value.Headers == nil {
+			ref.Value.Headers = make(map[string]*HeaderRef)
+		}
+		for name, h := range headers {
+			if _, ok := ref.Value.Headers[name]; !ok {
+				ref.Value.Headers[name] = h
+			}
+		}
+	}
+	if !c.HasValidators() || r.Method != "GET" && r.Method != "HEAD" {
+		return
+	}
+	param := func(name, desc string) {
+		for _, p := range op.Parameters {
+			if p.Value != nil && p.Value.In == "header" && strings.EqualFold(p.Value.Name, name) {
+				return
+			}
+		}
+		op.Parameters = append(op.Parameters, &ParameterRef{Value: &Parameter{
+			Name:        name,
+			In:          "header",
+			Description: desc,
+			Schema:      &openapi.Schema{Type: openapi.String},
+		}})
+	}
+	if c.ETag != "" {
+		param("If-None-Match", "Entity tags of the representations cached by the client.")
+	}
+	if c.LastModified != "" {
+		param("If-Modified-Since", "Modification date of the representation cached by the client.")
+	}
+	code := strconv.Itoa(expr.StatusNotModified)
+	if _, ok := op.Responses[code]; !ok {
+		desc := "Not Modified response."
+		resp := &Response{Description: &desc, Headers: make(map[string]*HeaderRef)}
+		for name, h := range headers {
+			resp.Headers[name] = h
+		}
+		op.Responses[code] = &ResponseRef{Value: resp}
+	}
+}
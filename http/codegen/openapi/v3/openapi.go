@@ -7,15 +7,16 @@ type (
 	// generate an OpenAPI specification as defined in
 	// https://github.com/OAI/OpenAPI-Specification/blob/master/versions/3.0.3.md
 	OpenAPI struct {
-		OpenAPI      string                `json:"openapi" yaml:"openapi"` // Required
-		Info         *Info                 `json:"info" yaml:"info"`       // Required
-		Servers      []*Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
-		Paths        map[string]*PathItem  `json:"paths" yaml:"paths"` // Required
-		Components   *Components           `json:"components,omitempty" yaml:"components,omitempty"`
-		Tags         []*openapi.Tag        `json:"tags,omitempty" yaml:"tags,omitempty"`
-		Security     []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
-		ExternalDocs *openapi.ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
-		Extensions   map[string]any        `json:"-" yaml:"-"`
+		OpenAPI           string                `json:"openapi" yaml:"openapi"` // Required
+		Info              *Info                 `json:"info" yaml:"info"`       // Required
+		JSONSchemaDialect string                `json:"jsonSchemaDialect,omitempty" yaml:"jsonSchemaDialect,omitempty"`
+		Servers           []*Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
+		Paths             map[string]*PathItem  `json:"paths" yaml:"paths"` // Required
+		Components        *Components           `json:"components,omitempty" yaml:"components,omitempty"`
+		Tags              []*openapi.Tag        `json:"tags,omitempty" yaml:"tags,omitempty"`
+		Security          []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+		ExternalDocs      *openapi.ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+		Extensions        map[string]any        `json:"-" yaml:"-"`
 	}
 
 	// Info represents an OpenAPI Info object as defined in
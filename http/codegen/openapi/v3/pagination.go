@@ -0,0 +1,67 @@
+package openapiv3
+
+import (
+	"strconv"
+
+	"goa.design/goa/v3/expr"
+)
+
+// nextPageLink is the name of the link added to the successful responses of
+// paginated operations.
+const nextPageLink = "next"
+
+// addPagination documents the link to the next page in the successful
+// responses of the given operation if the endpoint method uses cursor based
+// pagination. The link maps the next page token found in the response to the
+// request parameter that holds the page token.
+func addPagination(op *Operation, e *expr.HTTPEndpointExpr) {
+	p := e.MethodExpr.Pagination
+	if p == nil || p.Kind != expr.CursorPaginationKind {
+		return
+	}
+	param := requestParam(e, p.Token)
+	if param == "" {
+		return
+	}
+	for _, r := range e.Responses {
+		if r.StatusCode >= 400 {
+			continue
+		}
+		resp, ok := op.Responses[strconv.Itoa(r.StatusCode)]
+		if !ok || resp.Value == nil {
+			continue
+		}
+		var value string
+		if name, ok := r.Headers.FindKey(p.NextToken); ok {
+			value = "$response.header." + name
+		} else if r.Body != nil && r.Body.Find(p.NextToken) != nil {
+			value = "$response.body#/" + p.NextToken
+		} else {
+			continue
+		}
+		if resp.Value.Links == nil {
+			resp.Value.Links = make(map[string]*LinkRef)
+		}
+		resp.Value.Links[nextPageLink] = &LinkRef{Value: &Link{
+			OperationID: op.OperationID,
+			Description: "Next page of results",
+			Parameters:  map[string]any{param: value},
+		}}
+	}
+}
+
+// requestParam returns the OpenAPI link parameter name of the request
+// parameter or header that holds the given payload attribute, an empty string
+// if the attribute is not mapped to a parameter or header.
+func requestParam(e *expr.HTTPEndpointExpr, att string) string {
+	if name, ok := e.PathParams().FindKey(att); ok {
+		return "path." + name
+	}
+	if name, ok := e.QueryParams().FindKey(att); ok {
+		return "query." + name
+	}
+	if name, ok := e.Headers.FindKey(att); ok {
+		return "header." + name
+	}
+	return ""
+}
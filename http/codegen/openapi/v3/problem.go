@@ -0,0 +1,67 @@
+package openapiv3
+
+import (
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+const (
+	// problemDetailsSchemaName is the name of the component schema that
+	// describes RFC 9457 problem details responses.
+	problemDetailsSchemaName = "ProblemDetails"
+
+	// problemDetailsContentType is the content type of RFC 9457 problem
+	// details responses.
+	problemDetailsContentType = "application/problem+json"
+)
+
+// usesProblemDetails returns true if any of the services in the given HTTP
+// expression encodes error responses as RFC 9457 problem details.
+func usesProblemDetails(h *expr.HTTPExpr) bool {
+	for _, svc := range h.Services {
+		if svc.UsesProblemDetails() {
+			return true
+		}
+	}
+	return false
+}
+
+// problemDetailsResponse changes the content of the given error response to
+// describe a RFC 9457 problem details document.
+func problemDetailsResponse(resp *Response) {
+	resp.Content = map[string]*MediaType{
+		problemDetailsContentType: {
+			Schema: &openapi.Schema{Ref: toRef(problemDetailsSchemaName)},
+		},
+	}
+}
+
+// problemDetailsSchema returns the schema of RFC 9457 problem details
+// documents including the Goa error extension members.
+func problemDetailsSchema() *openapi.Schema {
+	prop := func(t openapi.Type, desc string) *openapi.Schema {
+		return &openapi.Schema{Type: t, Description: desc}
+	}
+	s := &openapi.Schema{
+		Type:        openapi.Object,
+		Description: "Problem details as defined by RFC 9457.",
+		Properties: map[string]*openapi.Schema{
+			"type":      prop(openapi.String, "URI reference that identifies the problem type."),
+			"title":     prop(openapi.String, "Short, human-readable summary of the problem type."),
+			"status":    prop(openapi.Integer, "HTTP status code generated by the origin server for this occurrence of the problem."),
+			"detail":    prop(openapi.String, "Human-readable explanation specific to this occurrence of the problem."),
+			"instance":  prop(openapi.String, "URI reference that identifies the specific occurrence of the problem."),
+			"name":      prop(openapi.String, "Name is the name of this class of errors."),
+			"id":        prop(openapi.String, "ID is a unique identifier for this particular occurrence of the problem."),
+			"message":   prop(openapi.String, "Message is a human-readable explanation specific to this occurrence of the problem."),
+			"temporary": prop(openapi.Boolean, "Is the error temporary?"),
+			"timeout":   prop(openapi.Boolean, "Is the error a timeout?"),
+			"fault":     prop(openapi.Boolean, "Is the error a server-side fault?"),
+		},
+		Required:             []string{"type", "name", "id", "message", "temporary", "timeout", "fault"},
+		AdditionalProperties: true,
+	}
+	s.Properties["type"].Format = "uri-reference"
+	s.Properties["instance"].Format = "uri-reference"
+	return s
+}
@@ -0,0 +1,70 @@
+package openapiv3
+
+import (
+	"fmt"
+	"strconv"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+// rateLimitExtension is the name of the operation extension that describes
+// the rate limit applied to the operation.
+const rateLimitExtension = "x-ratelimit"
+
+// rateLimitResponse returns the response returned when the given rate limit
+// is exceeded. The response body is described as a problem details document
+// if the service uses problem details.
+func rateLimitResponse(rl *expr.RateLimitExpr, problemDetails bool) *Response {
+	header := func(desc string) *HeaderRef {
+		return &HeaderRef{Value: &Header{
+			Description: desc,
+			Schema:      &openapi.Schema{Type: openapi.Integer},
+		}}
+	}
+	desc := fmt.Sprintf("rate_limit_exceeded: More than %d requests per %s.", rl.Requests, rl.Period)
+	resp := &Response{
+		Description: &desc,
+		Headers: map[string]*HeaderRef{
+			"Retry-After":           header("Number of seconds to wait before making a new request."),
+			"X-RateLimit-Limit":     header("Maximum number of requests allowed in the current period."),
+			"X-RateLimit-Remaining": header("Number of requests remaining in the current period."),
+			"X-RateLimit-Reset":     header("Number of seconds until the quota resets."),
+		},
+	}
+	if problemDetails {
+		problemDetailsResponse(resp)
+	}
+	return resp
+}
+
+// addRateLimit documents the rate limit that applies to the method of the
+// given operation if any. It adds the 429 response unless the design already
+// defines one and the x-ratelimit extension.
+func addRateLimit(op *Operation, m *expr.MethodExpr, problemDetails bool) {
+	rl := m.EffectiveRateLimit()
+	if rl == nil {
+		return
+	}
+	code := strconv.Itoa(expr.StatusTooManyRequests)
+	if _, ok := op.Responses[code]; !ok {
+		op.Responses[code] = &ResponseRef{Value: rateLimitResponse(rl, problemDetails)}
+	}
+	ext := map[string]any{
+		"requests": rl.Requests,
+		"period":   rl.Period.String(),
+	}
+	switch rl.Key {
+	case "":
+	case expr.RateLimitKeyClientIP:
+		ext["key"] = "client_ip"
+	case expr.RateLimitKeyPrincipal:
+		ext["key"] = "principal"
+	default:
+		ext["key"] = rl.Key
+	}
+	if op.Extensions == nil {
+		op.Extensions = make(map[string]any)
+	}
+	op.Extensions[rateLimitExtension] = ext
+}
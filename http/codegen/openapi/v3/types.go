@@ -183,6 +183,16 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 			// A schema without a type matches any data type.
 			// See https://swagger.io/docs/specification/data-models/data-types/#any.
 			s.Type = openapi.Type("")
+		case expr.DateTimeKind:
+			s.Type = openapi.Type("string")
+			s.Format = "date-time"
+		case expr.DurationKind:
+			// Durations are encoded as a number of nanoseconds.
+			s.Type = openapi.Type("integer")
+			s.Format = "int64"
+		case expr.DecimalKind:
+			s.Type = openapi.Type("string")
+			s.Format = "decimal"
 		default:
 			s.Type = openapi.Type(t.Name())
 		}
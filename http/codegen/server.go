@@ -20,6 +20,9 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if f := websocketServerFile(genpkg, svc); f != nil {
 			files = append(files, f)
 		}
+		if f := sseServerFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
 	}
 	for _, svc := range root.API.HTTP.Services {
 		if f := serverEncodeDecodeFile(genpkg, svc); f != nil {
@@ -39,6 +42,8 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		"join":                strings.Join,
 		"hasWebSocket":        hasWebSocket,
 		"isWebSocketEndpoint": isWebSocketEndpoint,
+		"isSSEEndpoint":       isSSEEndpoint,
+		"isStreamingEndpoint": isStreamingEndpoint,
 		"viewedServerBody":    viewedServerBody,
 		"mustDecodeRequest":   mustDecodeRequest,
 		"addLeadingSlash":     addLeadingSlash,
@@ -59,6 +64,9 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 		{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 	}
+	if data.Service.Schemes.HasType("MutualTLS") {
+		imports = append(imports, codegen.GoaImport("security"))
+	}
 	imports = append(imports, data.Service.UserTypeImports...)
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", imports),
@@ -125,6 +133,7 @@ func serverEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.F
 		{Path: "net/http"},
 		{Path: "strconv"},
 		{Path: "strings"},
+		{Path: "time"},
 		{Path: "encoding/json"},
 		{Path: "mime/multipart"},
 		{Path: "unicode/utf8"},
@@ -137,11 +146,11 @@ func serverEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.F
 	sections := []*codegen.SectionTemplate{codegen.Header(title, "server", imports)}
 
 	for _, e := range data.Endpoints {
-		if e.Redirect == nil && !isWebSocketEndpoint(e) {
+		if e.Redirect == nil && !isStreamingEndpoint(e) {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "response-encoder",
 				FuncMap: transTmplFuncs(svc),
-				Source:  readTemplate("response_encoder", "response", "header_conversion"),
+				Source:  readTemplate("response_encoder", "response", "header_conversion", "cache_validators"),
 				Data:    e,
 			})
 		}
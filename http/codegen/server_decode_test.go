@@ -39,6 +39,7 @@ func TestDecode(t *testing.T) {
 		{"decode-query-uint32", testdata.PayloadQueryUInt32DSL, testdata.PayloadQueryUInt32DecodeCode},
 		{"decode-query-uint32-validate", testdata.PayloadQueryUInt32ValidateDSL, testdata.PayloadQueryUInt32ValidateDecodeCode},
 		{"decode-query-uint64", testdata.PayloadQueryUInt64DSL, testdata.PayloadQueryUInt64DecodeCode},
+		{"decode-query-time-types", testdata.PayloadQueryTimeTypesDSL, testdata.PayloadQueryTimeTypesDecodeCode},
 		{"decode-query-uint64-validate", testdata.PayloadQueryUInt64ValidateDSL, testdata.PayloadQueryUInt64ValidateDecodeCode},
 		{"decode-query-float32", testdata.PayloadQueryFloat32DSL, testdata.PayloadQueryFloat32DecodeCode},
 		{"decode-query-float32-validate", testdata.PayloadQueryFloat32ValidateDSL, testdata.PayloadQueryFloat32ValidateDecodeCode},
@@ -218,6 +219,8 @@ func TestDecode(t *testing.T) {
 		{"decode-query-custom-name", testdata.PayloadQueryCustomNameDSL, testdata.PayloadQueryCustomNameDecodeCode},
 		{"decode-header-custom-name", testdata.PayloadHeaderCustomNameDSL, testdata.PayloadHeaderCustomNameDecodeCode},
 		{"decode-cookie-custom-name", testdata.PayloadCookieCustomNameDSL, testdata.PayloadCookieCustomNameDecodeCode},
+		{"decode-header-if-match", testdata.PayloadHeaderIfMatchDSL, testdata.PayloadHeaderIfMatchDecodeCode},
+		{"decode-header-if-match-optional", testdata.PayloadHeaderIfMatchOptionalDSL, testdata.PayloadHeaderIfMatchOptionalDecodeCode},
 	}
 	golden := makeGolden(t, "testdata/payload_decode_functions.go")
 	if golden != nil {
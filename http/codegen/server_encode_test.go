@@ -87,6 +87,10 @@ func TestEncode(t *testing.T) {
 
 		{"result-with-custom-pkg-type", testdata.ResultWithCustomPkgTypeDSL, testdata.ResultWithCustomPkgTypeEncodeCode},
 		{"result-with-embedded-custom-pkg-type", testdata.EmbeddedCustomPkgTypeDSL, testdata.ResultWithEmbeddedCustomPkgTypeEncodeCode},
+
+		{"cache-validators", testdata.ResultCacheValidatorsDSL, testdata.ResultCacheValidatorsEncodeCode},
+		{"cache-date-time", testdata.ResultCacheDateTimeDSL, testdata.ResultCacheDateTimeEncodeCode},
+		{"cache-control", testdata.ResultCacheControlDSL, testdata.ResultCacheControlEncodeCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -152,8 +152,22 @@ type (
 		// ServerWebSocket holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerWebSocket *WebSocketData
+		// ServerSSE holds the data to render the server struct which
+		// implements the server stream interface using Server-Sent Events.
+		ServerSSE *SSEData
 		// Redirect defines a redirect for the endpoint.
 		Redirect *RedirectData
+		// ProblemDetails is true if the endpoint error responses use the
+		// RFC 9457 problem details format unless a formatter is provided.
+		ProblemDetails bool
+		// ClientIP is true if the endpoint rate limit is keyed by client
+		// IP address or by principal (unauthenticated requests are
+		// limited per address) in which case the handler stores the
+		// address in the request context.
+		ClientIP bool
+		// Cache holds the data needed to render the cache headers and to
+		// handle the conditional requests if the endpoint defines any.
+		Cache *CacheData
 
 		// client
 
@@ -174,6 +188,9 @@ type (
 		// ClientWebSocket holds the data to render the client struct which
 		// implements the client stream interface.
 		ClientWebSocket *WebSocketData
+		// ClientSSE holds the data to render the client struct which
+		// implements the client stream interface using Server-Sent Events.
+		ClientSSE *SSEData
 		// BuildStreamPayload is the name of the function used to create the
 		// payload for endpoints that use SkipRequestBodyEncodeDecode.
 		BuildStreamPayload string
@@ -327,6 +344,9 @@ type (
 		// ErrorHeader contains the value of the response "goa-error"
 		// header if any.
 		ErrorHeader string
+		// ProblemDetails is true if the error response body is formatted
+		// as RFC 9457 problem details unless a formatter is provided.
+		ProblemDetails bool
 		// ServerBody is the type of the response body used by server
 		// code, nil if body should be empty. The type does NOT use
 		// pointers for all fields. If the method result is a result
@@ -744,6 +764,9 @@ func (ServicesData) analyze(httpSvc *expr.HTTPServiceExpr) *ServiceData {
 				switch s.Type {
 				case "Basic":
 					basch = s
+				case "MutualTLS":
+					// The client certificates are provided by the
+					// TLS configuration of the HTTP client.
 				default:
 					switch s.In {
 					case "query":
@@ -755,7 +778,7 @@ func (ServicesData) analyze(httpSvc *expr.HTTPServiceExpr) *ServiceData {
 					}
 				}
 			}
-			reqs = append(reqs, &service.RequirementData{Schemes: rs, Scopes: req.Scopes})
+			reqs = append(reqs, &service.RequirementData{Schemes: rs, Scopes: req.Scopes, SchemeScopes: req.SchemeScopes})
 		}
 
 		var requestEncoder string
@@ -794,7 +817,7 @@ func (ServicesData) analyze(httpSvc *expr.HTTPServiceExpr) *ServiceData {
 			"Args":         args,
 			"PathInit":     routes[0].PathInit,
 			"Verb":         routes[0].Verb,
-			"IsStreaming":  httpEndpoint.MethodExpr.IsStreaming(),
+			"IsStreaming":  httpEndpoint.MethodExpr.IsStreaming() && httpEndpoint.SSE == nil,
 		}
 		if httpEndpoint.SkipRequestBodyEncodeDecode {
 			data["RequestStruct"] = pkg + "." + method.RequestStruct
@@ -835,9 +858,20 @@ func (ServicesData) analyze(httpSvc *expr.HTTPServiceExpr) *ServiceData {
 			RequestEncoder:  requestEncoder,
 			ResponseDecoder: fmt.Sprintf("Decode%sResponse", method.VarName),
 			Requirements:    reqs,
+			ProblemDetails:  httpEndpoint.Service.UsesProblemDetails(),
+		}
+		if rl := httpEndpoint.MethodExpr.EffectiveRateLimit(); rl != nil && (rl.Key == expr.RateLimitKeyClientIP || rl.Key == expr.RateLimitKeyPrincipal) {
+			ed.ClientIP = true
+		}
+		if httpEndpoint.Cache != nil {
+			initCacheData(ed, httpEndpoint)
 		}
 		if httpEndpoint.MethodExpr.IsStreaming() {
-			initWebSocketData(ed, httpEndpoint, sd)
+			if httpEndpoint.SSE != nil {
+				initSSEData(ed, httpEndpoint, sd)
+			} else {
+				initWebSocketData(ed, httpEndpoint, sd)
+			}
 		}
 
 		if httpEndpoint.MultipartRequest {
@@ -1902,6 +1936,9 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 				ResultInit:   init,
 				MustValidate: mustValidate,
 			}
+			if e.Service.UsesProblemDetails() && v.ErrorExpr.Type == expr.ErrorResult && len(serverBodyData) > 0 && serverBodyData[0].Init != nil {
+				responseData.ProblemDetails = true
+			}
 		}
 
 		ref := svc.Scope.GoFullTypeRef(v.ErrorExpr.AttributeExpr, pkg)
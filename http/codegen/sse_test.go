@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestSSEFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"sse", testdata.ServerSentEventsDSL},
+		{"sse-with-views", testdata.ServerSentEventsWithViewsDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			svc := expr.Root.API.HTTP.Services[0]
+			for _, f := range []struct {
+				name string
+				file *codegen.File
+			}{
+				{"server", sseServerFile("", svc)},
+				{"client", sseClientFile("", svc)},
+			} {
+				require.NotNil(t, f.file)
+				var buf bytes.Buffer
+				for _, s := range f.file.SectionTemplates[1:] {
+					require.NoError(t, s.Write(&buf))
+				}
+				code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+				golden := filepath.Join("testdata", f.name+"-"+c.Name+".golden")
+				compareOrUpdateGolden(t, code, golden)
+			}
+		})
+	}
+}
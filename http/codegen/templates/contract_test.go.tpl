@@ -0,0 +1,63 @@
+{{ printf "TestHTTPContract calls the %q service methods through the generated HTTP transport and checks that each designed response and error produces the expected status code." .Service.Service.Name | comment }}
+func TestHTTPContract(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Mock   func(*Mock)
+		Call   func(context.Context, *{{ .Service.Service.PkgName }}.Client) error
+		Status int
+		Error  string
+	}{
+{{- range .Cases }}
+		{
+			Name: {{ printf "%q" .Name }},
+			Mock: func(m *Mock) {
+				m.Expect{{ .Method.VarName }}(func({{ .Method.Params }}) ({{ .Method.Results }}) {
+			{{- if .Err }}
+					err = {{ .Err }}
+					return
+			{{- else }}
+					return {{ .Returns }}
+			{{- end }}
+				})
+			},
+			Call: func(ctx context.Context, c *{{ $.Service.Service.PkgName }}.Client) error {
+				{{ if .Method.Result }}_, {{ end }}err := c.{{ .Method.VarName }}(ctx{{ if .Payload }}, {{ .Payload }}{{ end }})
+				return err
+			},
+			Status: {{ .StatusCode }},
+			Error:  {{ printf "%q" .Error }},
+		},
+{{- end }}
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMock(t)
+			c.Mock(m)
+			h := NewHTTPHarness(t, m)
+			err := c.Call(context.Background(), h.Client)
+			if got := h.StatusCode(); got != c.Status {
+				t.Errorf("got status code %d, expected %d", got, c.Status)
+			}
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var en goa.GoaErrorNamer
+			if !errors.As(err, &en) {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			if en.GoaErrorName() != c.Error {
+				t.Errorf("got error %q, expected %q", en.GoaErrorName(), c.Error)
+			}
+		})
+	}
+}
+{{- if .NeedsPtr }}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+{{- end }}
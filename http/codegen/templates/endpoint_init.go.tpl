@@ -58,6 +58,25 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			{{- end }}
 		{{- end }}
 		return stream, nil
+	{{- else if isSSEEndpoint . }}
+		req.Header.Set("Accept", goahttp.EventStreamContentType)
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if resp.StatusCode != {{ .ClientSSE.Response.StatusCode }} {
+			return decodeResponse(resp)
+		}
+		stream := &{{ .ClientSSE.VarName }}{
+			body:   resp.Body,
+			reader: goahttp.NewServerSentEventReader(resp.Body),
+		{{- if .Method.ViewedResult }}
+			{{- if not .Method.ViewedResult.ViewName }}
+			view:   resp.Header.Get("goa-view"),
+			{{- end }}
+		{{- end }}
+		}
+		return stream, nil
 	{{- else }}
 		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
 		if err != nil {
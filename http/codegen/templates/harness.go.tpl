@@ -0,0 +1,56 @@
+{{ printf "HTTPHarness serves the %q service in-process using the generated HTTP server and client." .Service.Service.Name | comment }}
+type HTTPHarness struct {
+	// Server is the test HTTP server.
+	Server *httptest.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *{{ .Service.Service.PkgName }}.Client
+
+	mu     sync.Mutex
+	status int
+}
+
+{{ printf "NewHTTPHarness starts a test HTTP server that serves the %q service implemented by s and returns a harness whose client sends requests to the server. The server is closed when the test completes." .Service.Service.Name | comment }}
+func NewHTTPHarness(t testing.TB, s {{ .Service.Service.PkgName }}.Service{{ if .HasServerInterceptors }}, si {{ .Service.Service.PkgName }}.ServerInterceptors{{ end }}{{ if .HasClientInterceptors }}, ci {{ .Service.Service.PkgName }}.ClientInterceptors{{ end }}) *HTTPHarness {
+	t.Helper()
+	mux := goahttp.NewMuxer()
+	endpoints := {{ .Service.Service.PkgName }}.NewEndpoints(s{{ if .HasServerInterceptors }}, si{{ end }})
+	server := {{ .ServerPkg }}.{{ .Service.ServerInit }}(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, nil, nil{{ range .ServerArgs }}, {{ . }}{{ end }})
+	{{ .ServerPkg }}.{{ .Service.MountServer }}(mux, server)
+	h := &HTTPHarness{Server: httptest.NewServer(mux)}
+	t.Cleanup(h.Server.Close)
+	u, err := url.Parse(h.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := {{ .ClientPkg }}.New{{ .Service.ClientStruct }}(u.Scheme, u.Host, h, goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ range .ClientArgs }}, {{ . }}{{ end }})
+	h.Client = &{{ .Service.Service.PkgName }}.Client{
+	{{- range $i, $e := .Endpoints }}
+		{{- with index $.Service.Service.Methods $i }}
+		{{ .VarName }}Endpoint: {{ if .ClientInterceptors }}{{ $.Service.Service.PkgName }}.Wrap{{ .VarName }}ClientEndpoint({{ $e }}, ci){{ else }}{{ $e }}{{ end }},
+		{{- end }}
+	{{- end }}
+	}
+	return h
+}
+
+// Do sends the request to the test server and records the status code of the
+// response. It implements the HTTP client doer used by Client.
+func (h *HTTPHarness) Do(req *http.Request) (*http.Response, error) {
+	resp, err := h.Server.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.status = resp.StatusCode
+	h.mu.Unlock()
+	return resp, nil
+}
+
+// StatusCode returns the status code of the last response received by Client.
+func (h *HTTPHarness) StatusCode() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status
+}
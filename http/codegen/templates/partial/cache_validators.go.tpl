@@ -0,0 +1,31 @@
+{{- with .ETag }}
+	{{- if .Pointer }}
+		var etag string
+		if {{ .Ref }} != nil {
+			etag = goahttp.FormatETag(*{{ .Ref }})
+		}
+	{{- else }}
+		etag := goahttp.FormatETag({{ .Ref }})
+	{{- end }}
+{{- end }}
+{{- with .LastModified }}
+	{{- if and .DateTime (not .Pointer) }}
+		lastModified := {{ .Ref }}
+	{{- else }}
+		var lastModified time.Time
+		{{- if .Pointer }}
+		if {{ .Ref }} != nil {
+		{{- end }}
+		{{- if .DateTime }}
+			lastModified = *{{ .Ref }}
+		{{- else }}
+			lastModified, _ = time.Parse(time.RFC3339, {{ if .Pointer }}*{{ end }}{{ .Ref }})
+		{{- end }}
+		{{- if .Pointer }}
+		}
+		{{- end }}
+	{{- end }}
+{{- end }}
+		if goahttp.CheckNotModified(ctx, w, {{ if .ETag }}etag{{ else }}""{{ end }}, {{ if .LastModified }}lastModified{{ else }}time.Time{}{{ end }}) {
+			return nil
+		}
\ No newline at end of file
@@ -22,6 +22,12 @@
     {{ .VarName }} := string({{ .Target }})
   {{- else if eq .Type.Name "any" -}}
     {{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
+  {{- else if eq .Type.Name "datetime" -}}
+    {{ .VarName }} := {{ if .IsAliased }}time.Time({{ end }}{{ .Target }}{{ if .IsAliased }}){{ end }}.Format(time.RFC3339Nano)
+  {{- else if eq .Type.Name "duration" -}}
+    {{ .VarName }} := strconv.FormatInt(int64({{ .Target }}), 10)
+  {{- else if eq .Type.Name "decimal" -}}
+    {{ .VarName }} := string({{ .Target }})
   {{- else }}
     // unsupported type {{ .Type.Name }} for field {{ .FieldName }}
   {{- end }}
\ No newline at end of file
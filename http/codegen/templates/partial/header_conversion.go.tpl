@@ -22,6 +22,12 @@
 		{{ .VarName }} := string({{ .Target }})
 	{{- else if eq .Type.Name "any" -}}
 		{{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
+	{{- else if eq .Type.Name "datetime" -}}
+		{{ .VarName }} := {{ .Target }}.Format(time.RFC3339Nano)
+	{{- else if eq .Type.Name "duration" -}}
+		{{ .VarName }} := strconv.FormatInt(int64({{ if not .Required }}*{{ end }}{{ .Target }}), 10)
+	{{- else if eq .Type.Name "decimal" -}}
+		{{ .VarName }} := string({{ if not .Required }}*{{ end }}{{ .Target }})
 	{{- else if eq .Type.Name "array" -}}
 		{{- if eq .Type.ElemType.Type.Name "string" -}}
 		{{ .VarName }} := strings.Join({{ .Target }}, ", ")
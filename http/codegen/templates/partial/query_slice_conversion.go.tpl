@@ -7,5 +7,8 @@
 	{{- else if eq . "float64" }} strconv.FormatFloat(v, 'f', -1, 64)
 	{{- else if eq . "boolean" }} strconv.FormatBool(v)
 	{{- else if eq . "bytes" }} url.QueryEscape(string(v))
+	{{- else if eq . "datetime" }} url.QueryEscape(v.Format(time.RFC3339Nano))
+	{{- else if eq . "duration" }} strconv.FormatInt(int64(v), 10)
+	{{- else if eq . "decimal" }} url.QueryEscape(string(v))
 	{{- else }} url.QueryEscape(fmt.Sprintf("%v", v))
 	{{- end }}
\ No newline at end of file
@@ -79,6 +79,29 @@
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ if and (ne .TypeRef nil) (and (ne .TypeRef "bool") (ne .TypeRef "*bool")) }}{{ .VarName }} = ({{.TypeRef}})({{ if .Pointer }}&{{ end }}v){{ else }}{{ .VarName }} = {{ if .Pointer }}&{{ end }}v{{ end }}
+	{{- else if eq .Type.Name "datetime" }}
+		v, err2 := time.Parse(time.RFC3339, {{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "datetime"))
+		}
+		{{ if and (ne .TypeRef nil) (and (ne .TypeRef "time.Time") (ne .TypeRef "*time.Time")) }}{{ .VarName }} = ({{.TypeRef}})({{ if .Pointer }}&{{ end }}v){{ else }}{{ .VarName }} = {{ if .Pointer }}&{{ end }}v{{ end }}
+	{{- else if eq .Type.Name "duration" }}
+		v, err2 := strconv.ParseInt({{ .VarName }}Raw, 10, 64)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "duration"))
+		}
+		{{- if .Pointer }}
+		pv := {{ if .TypeRef }}{{ slice .TypeRef 1 (len .TypeRef) }}{{ else }}time.Duration{{ end }}(v)
+		{{ .VarName }} = &pv
+		{{- else }}
+		{{ .VarName }} = {{ if .TypeRef }}{{ .TypeRef }}{{ else }}time.Duration{{ end }}(v)
+		{{- end }}
+	{{- else if eq .Type.Name "decimal" }}
+		v, err2 := goa.ParseDecimal({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "decimal"))
+		}
+		{{ if and (ne .TypeRef nil) (and (ne .TypeRef "goa.Decimal") (ne .TypeRef "*goa.Decimal")) }}{{ .VarName }} = ({{.TypeRef}})({{ if .Pointer }}&{{ end }}v){{ else }}{{ .VarName }} = {{ if .Pointer }}&{{ end }}v{{ end }}
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
\ No newline at end of file
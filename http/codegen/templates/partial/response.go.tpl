@@ -17,7 +17,11 @@
 		body = formatter(ctx, {{ (index (index .ServerBody 0).Init.ServerArgs 0).Ref }})
 	} else {
 			{{- end }}
+			{{- if .ProblemDetails }}
+	body = goahttp.NewProblemDetails(ctx, {{ (index (index .ServerBody 0).Init.ServerArgs 0).Ref }})
+			{{- else }}
 	body {{ if not .ErrorHeader}}:{{ end }}= {{ (index .ServerBody 0).Init.Name }}({{ range (index .ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+			{{- end }}
 			{{- if .ErrorHeader }}
 	}
 			{{- end }}
@@ -113,6 +117,12 @@
 
 	{{- end }}
 
+	{{- if .ProblemDetails }}
+	if pd, ok := body.(*goahttp.ProblemDetails); ok {
+		pd.SetStatus({{ .StatusCode }})
+		goahttp.SetProblemContentType(w)
+	}
+	{{- end }}
 	{{- if .ErrorHeader }}
 	w.Header().Set("goa-error", res.GoaErrorName())
 	{{- end }}
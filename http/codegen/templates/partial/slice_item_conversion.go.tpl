@@ -58,6 +58,24 @@
 			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "any" }}
 			{{ .VarName }}[i] = rv
+		{{- else if eq .Type.ElemType.Type.Name "datetime" }}
+			v, err2 := time.Parse(time.RFC3339, rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "array of datetimes"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "duration" }}
+			v, err2 := strconv.ParseInt(rv, 10, 64)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "array of durations"))
+			}
+			{{ .VarName }}[i] = time.Duration(v)
+		{{- else if eq .Type.ElemType.Type.Name "decimal" }}
+			v, err2 := goa.ParseDecimal(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "array of decimals"))
+			}
+			{{ .VarName }}[i] = v
 		{{- else }}
 			// unsupported slice type {{ .Type.ElemType.Type.Name }} for var {{ .VarName }}
 		{{- end }}
\ No newline at end of file
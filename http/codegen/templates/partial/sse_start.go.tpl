@@ -0,0 +1,12 @@
+{{ comment "Write the response header only once, when the first event is sent or when the stream is closed." }}
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", goahttp.EventStreamContentType)
+		s.w.Header().Set("Cache-Control", "no-cache")
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+		s.w.Header().Set("goa-view", s.view)
+		{{- end }}
+	{{- end }}
+		s.w.WriteHeader({{ .Response.StatusCode }})
+		s.started = true
+	})
\ No newline at end of file
@@ -11,6 +11,8 @@
 	{{- end }}
 	return fmt.Sprintf("{{ .PathFormat }}", {{ range $i, $arg := .Args }}
 	{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .VarName }}Slice, ",")
+	{{- else if eq (index $.PathParams $i).Attribute.Type.Name "datetime" }}{{ .VarName }}.Format(time.RFC3339Nano)
+	{{- else if eq (index $.PathParams $i).Attribute.Type.Name "duration" }}int64({{ .VarName }})
 	{{- else }}{{ .VarName }}
 	{{- end }}, {{ end }})
 {{- else }}
@@ -80,6 +80,16 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 	}
 	{{- end }}
 {{- end }}
+{{- if .Cache }}{{ with .Cache.IfMatch }}
+	{{- if .FieldPointer }}
+	if payload.{{ .FieldName }} != nil {
+		etag := goahttp.ParseETag(*payload.{{ .FieldName }})
+		payload.{{ .FieldName }} = &etag
+	}
+	{{- else }}
+	payload.{{ .FieldName }} = goahttp.ParseETag(payload.{{ .FieldName }})
+	{{- end }}
+{{- end }}{{ end }}
 
 	return payload, nil
 	}
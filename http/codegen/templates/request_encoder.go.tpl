@@ -39,6 +39,8 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			}
 			{{- else if (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }}
 			req.Header.Set({{ printf "%q" .HTTPName }}, string(head))
+			{{- else if (and $.Cache $.Cache.IfMatch (eq .Name $.Cache.IfMatch.Name)) }}
+			req.Header.Set({{ printf "%q" .HTTPName }}, goahttp.FormatETag(head))
 			{{- else if eq .Type.Name "string" }}
 			req.Header.Set({{ printf "%q" .HTTPName }}, head)
 			{{- else }}
@@ -120,11 +122,15 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 		if p.{{ .FieldName }} != nil {
 			{{- end }}
 		values.Add("{{ .HTTPName }}",
-			{{- if or (eq .Type.Name "bytes") (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }} string(
+			{{- if or (eq .Type.Name "bytes") (eq .Type.Name "decimal") (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }} string(
+			{{- else if eq .Type.Name "duration" }} strconv.FormatInt(int64(
+			{{- else if eq .Type.Name "datetime" }} (
 			{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v",
 			{{- end }}
 			{{- if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
-			{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }})
+			{{- if eq .Type.Name "duration" }}), 10)
+			{{- else if eq .Type.Name "datetime" }}).Format(time.RFC3339Nano)
+			{{- else if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }})
 			{{- end }})
 			{{- if .FieldPointer }}
 		}
@@ -1,6 +1,9 @@
 {{ printf "%s returns an encoder for responses returned by the %s %s endpoint." .ResponseEncoder .ServiceName .Method.Name | comment }}
 func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+	{{- if and .Cache .Cache.CacheControl }}
+		w.Header().Set("Cache-Control", {{ printf "%q" .Cache.CacheControl }})
+	{{- end }}
 	{{- if .Result.MustInit }}
 		{{- if .Method.ViewedResult }}
 			res := v.({{ .Method.ViewedResult.FullRef }})
@@ -10,6 +13,9 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 		{{- else }}
 			res, _ := v.({{ .Result.Ref }})
 		{{- end }}
+		{{- if and .Cache .Cache.HasValidators }}
+			{{- template "partial_cache_validators" .Cache }}
+		{{- end }}
 		{{- range .Result.Responses }}
 			{{- if .ContentType }}
 				ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "{{ .ContentType }}")
@@ -11,25 +11,34 @@ func {{ .HandlerInit }}(
 	configurer goahttp.ConnConfigureFunc,
 	{{- end }}
 ) http.Handler {
-	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isWebSocketEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
+	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isStreamingEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 	var (
 	{{- end }}
 		{{- if mustDecodeRequest . }}
 		decodeRequest  = {{ .RequestDecoder }}(mux, decoder)
 		{{- end }}
-		{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
+		{{- if not (or .Redirect (isStreamingEndpoint .)) }}
 		encodeResponse = {{ .ResponseEncoder }}(encoder)
 		{{- end }}
 		{{- if (or (mustDecodeRequest .) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
-		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else }}goahttp.ErrorEncoder{{ end }}(encoder, formatter)
+		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else if .ProblemDetails }}goahttp.ProblemErrorEncoder{{ else }}goahttp.ErrorEncoder{{ end }}(encoder, formatter)
 		{{- end }}
-	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isWebSocketEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
+	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isStreamingEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 	)
 	{{- end }}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .ClientIP }}
+		ctx = context.WithValue(ctx, goa.ClientIPKey, goahttp.ClientIP(r))
+	{{- end }}
+	{{- if .Method.Schemes.HasType "MutualTLS" }}
+		ctx = security.ContextWithPeerCertificates(ctx, goahttp.PeerCertificates(r))
+	{{- end }}
+	{{- if and .Cache .Cache.HasValidators }}
+		ctx = goahttp.ContextWithConditions(ctx, r)
+	{{- end }}
 
 	{{- if mustDecodeRequest . }}
 		{{ if .Redirect }}_{{ else }}payload{{ end }}, err := decodeRequest(r)
@@ -42,6 +51,17 @@ func {{ .HandlerInit }}(
 	{{- else if not .Redirect }}
 		var err error
 	{{- end }}
+	{{- with and .Cache .Cache.FieldSelection }}
+		{{- if eq .Kind "array" }}
+		ctx = goahttp.ContextWithFieldSelection(ctx, goa.FieldPaths(payload.({{ $.Payload.Ref }}).{{ .Field }}...))
+		{{- else if and (eq .Kind "string") (not .Pointer) }}
+		ctx = goahttp.ContextWithFieldSelection(ctx, goa.FieldPaths(payload.({{ $.Payload.Ref }}).{{ .Field }}))
+		{{- else }}
+		if sel := payload.({{ $.Payload.Ref }}).{{ .Field }}; sel != nil {
+			ctx = goahttp.ContextWithFieldSelection(ctx, goa.FieldPaths({{ if eq .Kind "mask" }}sel.{{ .PathsField }}...{{ else }}*sel{{ end }}))
+		}
+		{{- end }}
+	{{- end }}
 	{{- if isWebSocketEndpoint . }}
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithCancel(ctx)
@@ -58,6 +78,14 @@ func {{ .HandlerInit }}(
 		{{- end }}
 		}
 		_, err = endpoint(ctx, v)
+	{{- else if isSSEEndpoint . }}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: &{{ .ServerSSE.VarName }}{w: w},
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err = endpoint(ctx, v)
 	{{- else if .Method.SkipRequestBodyEncodeDecode }}
 		data := &{{ .ServicePkgName }}.{{ .Method.RequestStruct }}{ {{ if .Payload.Ref }}Payload: payload.({{ .Payload.Ref }}), {{ end }}Body: r.Body }
 		res, err := endpoint(ctx, data)
@@ -74,6 +102,12 @@ func {{ .HandlerInit }}(
 				errhandler(ctx, w, err)
 				return
 			}
+			{{- else if isSSEEndpoint . }}
+			if v.Stream.(*{{ .ServerSSE.VarName }}).started {
+				// Response header has been written, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
 			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -85,7 +119,7 @@ func {{ .HandlerInit }}(
 		o := res.(*{{ .ServicePkgName }}.{{ .Method.ResponseStruct }})
 		defer o.Body.Close()
 		if wt, ok := o.Body.(io.WriterTo); ok {
-			{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
+			{{- if not (or .Redirect (isStreamingEndpoint .)) }}
 			if err := encodeResponse(ctx, w, {{ if and .Method.SkipResponseBodyEncodeDecode .Result.Ref }}o.Result{{ else }}res{{ end }}); err != nil {
 				errhandler(ctx, w, err)
 				return
@@ -115,7 +149,7 @@ func {{ .HandlerInit }}(
 			return
 		}
 	{{- end }}
-	{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
+	{{- if not (or .Redirect (isStreamingEndpoint .)) }}
 		if err := encodeResponse(ctx, w, {{ if and .Method.SkipResponseBodyEncodeDecode .Result.Ref }}o.Result{{ else }}res{{ end }}); err != nil {
 			errhandler(ctx, w, err)
 			{{- if .Method.SkipResponseBodyEncodeDecode }}
@@ -0,0 +1,5 @@
+{{ printf "Close closes the %q endpoint server-sent events stream. The response is complete once the handler returns, Close makes sure the response header is written even if no event was sent." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	{{- template "partial_sse_start" . }}
+	return nil
+}
@@ -0,0 +1,56 @@
+{{ comment .SendDesc }}
+func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+	{{- template "partial_sse_start" . }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if .Endpoint.Method.ViewedResult.ViewName }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, {{ printf "%q" .Endpoint.Method.ViewedResult.ViewName }})
+		{{- else }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, s.view)
+		{{- end }}
+	{{- else }}
+	res := v
+	{{- end }}
+	{{- $servBodyLen := len .Response.ServerBody }}
+	{{- if and (gt $servBodyLen 0) (index .Response.ServerBody 0).Init }}
+		{{- if .Endpoint.Method.ViewedResult }}
+			{{- if .Endpoint.Method.ViewedResult.ViewName }}
+				{{- $vsb := (viewedServerBody $.Response.ServerBody .Endpoint.Method.ViewedResult.ViewName) }}
+	body := {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+			{{- else }}
+	var body any
+	switch s.view {
+				{{- range .Endpoint.Method.ViewedResult.Views }}
+	case {{ printf "%q" .Name }}{{ if eq .Name "default" }}, ""{{ end }}:
+					{{- $vsb := (viewedServerBody $.Response.ServerBody .Name) }}
+		body = {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+				{{- end }}
+	}
+			{{- end }}
+		{{- else }}
+	body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- end }}
+	{{- else }}
+	body := res
+	{{- end }}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	{{- range .EventFields }}
+		{{- if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		ev.{{ .EventField }} = {{ if .Convert }}int(*v.{{ .FieldName }}){{ else }}*v.{{ .FieldName }}{{ end }}
+	}
+		{{- else }}
+	ev.{{ .EventField }} = {{ if .Convert }}int(v.{{ .FieldName }}){{ else }}v.{{ .FieldName }}{{ end }}
+		{{- end }}
+	{{- end }}
+	if err := goahttp.WriteServerSentEvent(s.w, ev); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint server-sent events stream." .SendTypeName .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) SetView(view string) {
+	s.view = view
+}
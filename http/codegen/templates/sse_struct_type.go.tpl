@@ -0,0 +1,21 @@
+{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+{{- if eq .Type "server" }}
+	once sync.Once
+	{{ comment "w is the HTTP response writer used to write the events." }}
+	w http.ResponseWriter
+	{{ comment "started is true once the response header has been written." }}
+	started bool
+{{- else }}
+	{{ comment "body is the HTTP response body the events are read from." }}
+	body io.ReadCloser
+	{{ comment "reader parses the events from the response body." }}
+	reader *goahttp.ServerSentEventReader
+{{- end }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+	{{ printf "view is the view to render %s result type." .Endpoint.Result.Name | comment }}
+	view string
+		{{- end }}
+	{{- end }}
+}
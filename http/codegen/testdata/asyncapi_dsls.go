@@ -0,0 +1,101 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AsyncAPIWebSocketDSL = func() {
+	var Message = Type("Message", func() {
+		Attribute("text", String, "Message text")
+		Attribute("sent_at", String, func() {
+			Format(FormatDateTime)
+		})
+		Required("text")
+	})
+	var Reply = ResultType("application/vnd.reply", func() {
+		TypeName("Reply")
+		Attributes(func() {
+			Attribute("from", String)
+			Attribute("message", Message)
+		})
+	})
+	API("chat", func() {
+		Title("Chat API")
+		Version("1.0")
+		Server("chat", func() {
+			Description("Chat server")
+			Host("dev", func() {
+				URI("http://localhost:8080/api")
+			})
+			Host("prod", func() {
+				URI("https://{domain}")
+				Variable("domain", String, "Chat domain", func() {
+					Default("chat.example.com")
+				})
+			})
+		})
+	})
+	Service("chat", func() {
+		Method("talk", func() {
+			Description("Talk in a room")
+			Payload(func() {
+				Attribute("room", String, "Room name", func() {
+					Enum("general", "random")
+				})
+				Attribute("token", String, "Auth token")
+				Attribute("since", Int)
+				Required("room", "token")
+			})
+			StreamingPayload(Message, "Messages sent to the room")
+			StreamingResult(Reply, "Replies posted in the room")
+			HTTP(func() {
+				GET("/rooms/{room}")
+				Header("token:Authorization")
+				Param("since")
+			})
+		})
+		Method("upload", func() {
+			StreamingPayload(Message)
+			Result(Int)
+			HTTP(func() {
+				GET("/upload")
+			})
+		})
+		Method("history", func() {
+			Result(ArrayOf(Message))
+			HTTP(func() {
+				GET("/history")
+			})
+		})
+	})
+}
+
+var AsyncAPINoStreamingDSL = func() {
+	Service("NoStreaming", func() {
+		Method("Method", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var AsyncAPIDisabledMethodDSL = func() {
+	Service("DisabledMethod", func() {
+		Method("Enabled", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/enabled")
+			})
+		})
+		Method("Disabled", func() {
+			Meta("asyncapi:generate", "false")
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/disabled")
+			})
+		})
+	})
+}
@@ -390,3 +390,51 @@ func EncodeMethodEmptyCustomErrorResponseBodyError(encoder func(context.Context,
 	}
 }
 `
+
+var ProblemDetailsErrorResponseEncoderCode = `// EncodeMethodProblemDetailsErrorResponseError returns an encoder for errors
+// returned by the MethodProblemDetailsErrorResponse
+// ServiceProblemDetailsErrorResponse endpoint.
+func EncodeMethodProblemDetailsErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ProblemErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "not_found":
+			var res *goa.ServiceError
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = goahttp.NewProblemDetails(ctx, res)
+			}
+			if pd, ok := body.(*goahttp.ProblemDetails); ok {
+				pd.SetStatus(http.StatusNotFound)
+				goahttp.SetProblemContentType(w)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "custom":
+			var res *serviceproblemdetailserrorresponse.CustomError
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewMethodProblemDetailsErrorResponseCustomResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
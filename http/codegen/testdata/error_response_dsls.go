@@ -254,3 +254,24 @@ var ErrorExamplesDSL = func() {
 		})
 	})
 }
+
+var ProblemDetailsErrorResponseDSL = func() {
+	var CustomError = Type("CustomError", func() {
+		Attribute("reason", String)
+		Required("reason")
+	})
+	Service("ServiceProblemDetailsErrorResponse", func() {
+		HTTP(func() {
+			ProblemDetails()
+		})
+		Method("MethodProblemDetailsErrorResponse", func() {
+			Error("not_found")
+			Error("custom", CustomError)
+			HTTP(func() {
+				GET("/one/two")
+				Response("not_found", StatusNotFound)
+				Response("custom", StatusConflict)
+			})
+		})
+	})
+}
@@ -312,3 +312,48 @@ func NewMethodSkipResponseBodyEncodeDecodeHandler(
 	})
 }
 `
+
+var ServerCacheFieldSelectionHandlerConstructorCode = `// NewMethodCacheFieldSelectionHandler creates a HTTP handler which loads the
+// HTTP request and calls the "ServiceCacheFieldSelection" service
+// "MethodCacheFieldSelection" endpoint.
+func NewMethodCacheFieldSelectionHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodCacheFieldSelectionRequest(mux, decoder)
+		encodeResponse = EncodeMethodCacheFieldSelectionResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodCacheFieldSelection")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceCacheFieldSelection")
+		ctx = goahttp.ContextWithConditions(ctx, r)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if sel := payload.(*servicecachefieldselection.MethodCacheFieldSelectionPayload).Fields; sel != nil {
+			ctx = goahttp.ContextWithFieldSelection(ctx, goa.FieldPaths(*sel))
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
@@ -0,0 +1,468 @@
+package testdata
+
+const HarnessPayloadResultErrorCode = `// HTTPHarness serves the "ServicePayloadResultError" service in-process using
+// the generated HTTP server and client.
+type HTTPHarness struct {
+	// Server is the test HTTP server.
+	Server *httptest.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *servicepayloadresulterror.Client
+
+	mu     sync.Mutex
+	status int
+}
+
+// NewHTTPHarness starts a test HTTP server that serves the
+// "ServicePayloadResultError" service implemented by s and returns a harness
+// whose client sends requests to the server. The server is closed when the
+// test completes.
+func NewHTTPHarness(t testing.TB, s servicepayloadresulterror.Service) *HTTPHarness {
+	t.Helper()
+	mux := goahttp.NewMuxer()
+	endpoints := servicepayloadresulterror.NewEndpoints(s)
+	server := servicepayloadresulterrorsvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, nil, nil)
+	servicepayloadresulterrorsvr.Mount(mux, server)
+	h := &HTTPHarness{Server: httptest.NewServer(mux)}
+	t.Cleanup(h.Server.Close)
+	u, err := url.Parse(h.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := servicepayloadresulterrorc.NewClient(u.Scheme, u.Host, h, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+	h.Client = &servicepayloadresulterror.Client{
+		MethodPayloadResultErrorEndpoint: c.MethodPayloadResultError(),
+	}
+	return h
+}
+
+// Do sends the request to the test server and records the status code of the
+// response. It implements the HTTP client doer used by Client.
+func (h *HTTPHarness) Do(req *http.Request) (*http.Response, error) {
+	resp, err := h.Server.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.status = resp.StatusCode
+	h.mu.Unlock()
+	return resp, nil
+}
+
+// StatusCode returns the status code of the last response received by Client.
+func (h *HTTPHarness) StatusCode() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status
+}
+`
+
+const ContractPayloadResultErrorCode = `// TestHTTPContract calls the "ServicePayloadResultError" service methods
+// through the generated HTTP transport and checks that each designed response
+// and error produces the expected status code.
+func TestHTTPContract(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Mock   func(*Mock)
+		Call   func(context.Context, *servicepayloadresulterror.Client) error
+		Status int
+		Error  string
+	}{
+		{
+			Name: "MethodPayloadResultError",
+			Mock: func(m *Mock) {
+				m.ExpectMethodPayloadResultError(func(ctx context.Context, p *servicepayloadresulterror.MethodPayloadResultErrorPayload) (res *servicepayloadresulterror.MethodPayloadResultErrorResult, err error) {
+					return &servicepayloadresulterror.MethodPayloadResultErrorResult{}, nil
+				})
+			},
+			Call: func(ctx context.Context, c *servicepayloadresulterror.Client) error {
+				_, err := c.MethodPayloadResultError(ctx, &servicepayloadresulterror.MethodPayloadResultErrorPayload{})
+				return err
+			},
+			Status: 200,
+			Error:  "",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMock(t)
+			c.Mock(m)
+			h := NewHTTPHarness(t, m)
+			err := c.Call(context.Background(), h.Client)
+			if got := h.StatusCode(); got != c.Status {
+				t.Errorf("got status code %d, expected %d", got, c.Status)
+			}
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var en goa.GoaErrorNamer
+			if !errors.As(err, &en) {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			if en.GoaErrorName() != c.Error {
+				t.Errorf("got error %q, expected %q", en.GoaErrorName(), c.Error)
+			}
+		})
+	}
+}
+`
+
+const HarnessMixedCode = `// HTTPHarness serves the "ServerMixed" service in-process using the generated
+// HTTP server and client.
+type HTTPHarness struct {
+	// Server is the test HTTP server.
+	Server *httptest.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *servermixed.Client
+
+	mu     sync.Mutex
+	status int
+}
+
+// NewHTTPHarness starts a test HTTP server that serves the "ServerMixed"
+// service implemented by s and returns a harness whose client sends requests
+// to the server. The server is closed when the test completes.
+func NewHTTPHarness(t testing.TB, s servermixed.Service) *HTTPHarness {
+	t.Helper()
+	mux := goahttp.NewMuxer()
+	endpoints := servermixed.NewEndpoints(s)
+	server := servermixedsvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, nil, nil, nil, nil)
+	servermixedsvr.Mount(mux, server)
+	h := &HTTPHarness{Server: httptest.NewServer(mux)}
+	t.Cleanup(h.Server.Close)
+	u, err := url.Parse(h.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := servermixedc.NewClient(u.Scheme, u.Host, h, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+	h.Client = &servermixed.Client{
+		MethodMixed1Endpoint: c.MethodMixed1(),
+		MethodMixed2Endpoint: c.MethodMixed2(),
+	}
+	return h
+}
+
+// Do sends the request to the test server and records the status code of the
+// response. It implements the HTTP client doer used by Client.
+func (h *HTTPHarness) Do(req *http.Request) (*http.Response, error) {
+	resp, err := h.Server.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.status = resp.StatusCode
+	h.mu.Unlock()
+	return resp, nil
+}
+
+// StatusCode returns the status code of the last response received by Client.
+func (h *HTTPHarness) StatusCode() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status
+}
+`
+
+const ContractMixedCode = `// TestHTTPContract calls the "ServerMixed" service methods through the
+// generated HTTP transport and checks that each designed response and error
+// produces the expected status code.
+func TestHTTPContract(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Mock   func(*Mock)
+		Call   func(context.Context, *servermixed.Client) error
+		Status int
+		Error  string
+	}{
+		{
+			Name: "MethodMixed1",
+			Mock: func(m *Mock) {
+				m.ExpectMethodMixed1(func(ctx context.Context, p *servermixed.MethodMixed1Payload) (err error) {
+					return nil
+				})
+			},
+			Call: func(ctx context.Context, c *servermixed.Client) error {
+				err := c.MethodMixed1(ctx, &servermixed.MethodMixed1Payload{ID: ptr[string]("Voluptates eaque.")})
+				return err
+			},
+			Status: 204,
+			Error:  "",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMock(t)
+			c.Mock(m)
+			h := NewHTTPHarness(t, m)
+			err := c.Call(context.Background(), h.Client)
+			if got := h.StatusCode(); got != c.Status {
+				t.Errorf("got status code %d, expected %d", got, c.Status)
+			}
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var en goa.GoaErrorNamer
+			if !errors.As(err, &en) {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			if en.GoaErrorName() != c.Error {
+				t.Errorf("got error %q, expected %q", en.GoaErrorName(), c.Error)
+			}
+		})
+	}
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+`
+
+const HarnessMultipartCode = `// HTTPHarness serves the "ServiceMultipart" service in-process using the
+// generated HTTP server and client.
+type HTTPHarness struct {
+	// Server is the test HTTP server.
+	Server *httptest.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *servicemultipart.Client
+
+	mu     sync.Mutex
+	status int
+}
+
+// NewHTTPHarness starts a test HTTP server that serves the "ServiceMultipart"
+// service implemented by s and returns a harness whose client sends requests
+// to the server. The server is closed when the test completes.
+func NewHTTPHarness(t testing.TB, s servicemultipart.Service) *HTTPHarness {
+	t.Helper()
+	mux := goahttp.NewMuxer()
+	endpoints := servicemultipart.NewEndpoints(s)
+	server := servicemultipartsvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, nil, nil, nil)
+	servicemultipartsvr.Mount(mux, server)
+	h := &HTTPHarness{Server: httptest.NewServer(mux)}
+	t.Cleanup(h.Server.Close)
+	u, err := url.Parse(h.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := servicemultipartc.NewClient(u.Scheme, u.Host, h, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+	h.Client = &servicemultipart.Client{
+		MethodMultiBasesEndpoint: c.MethodMultiBases(nil),
+	}
+	return h
+}
+
+// Do sends the request to the test server and records the status code of the
+// response. It implements the HTTP client doer used by Client.
+func (h *HTTPHarness) Do(req *http.Request) (*http.Response, error) {
+	resp, err := h.Server.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.status = resp.StatusCode
+	h.mu.Unlock()
+	return resp, nil
+}
+
+// StatusCode returns the status code of the last response received by Client.
+func (h *HTTPHarness) StatusCode() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status
+}
+`
+
+const HarnessStreamingCode = `// HTTPHarness serves the "StreamingResultService" service in-process using the
+// generated HTTP server and client.
+type HTTPHarness struct {
+	// Server is the test HTTP server.
+	Server *httptest.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *streamingresultservice.Client
+
+	mu     sync.Mutex
+	status int
+}
+
+// NewHTTPHarness starts a test HTTP server that serves the
+// "StreamingResultService" service implemented by s and returns a harness
+// whose client sends requests to the server. The server is closed when the
+// test completes.
+func NewHTTPHarness(t testing.TB, s streamingresultservice.Service) *HTTPHarness {
+	t.Helper()
+	mux := goahttp.NewMuxer()
+	endpoints := streamingresultservice.NewEndpoints(s)
+	server := streamingresultservicesvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, nil, nil, &websocket.Upgrader{}, nil)
+	streamingresultservicesvr.Mount(mux, server)
+	h := &HTTPHarness{Server: httptest.NewServer(mux)}
+	t.Cleanup(h.Server.Close)
+	u, err := url.Parse(h.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := streamingresultservicec.NewClient(u.Scheme, u.Host, h, goahttp.RequestEncoder, goahttp.ResponseDecoder, false, websocket.DefaultDialer, nil)
+	h.Client = &streamingresultservice.Client{
+		StreamingResultMethodEndpoint: c.StreamingResultMethod(),
+	}
+	return h
+}
+
+// Do sends the request to the test server and records the status code of the
+// response. It implements the HTTP client doer used by Client.
+func (h *HTTPHarness) Do(req *http.Request) (*http.Response, error) {
+	resp, err := h.Server.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.status = resp.StatusCode
+	h.mu.Unlock()
+	return resp, nil
+}
+
+// StatusCode returns the status code of the last response received by Client.
+func (h *HTTPHarness) StatusCode() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status
+}
+`
+
+const HarnessRetryCode = `// HTTPHarness serves the "ServiceRetry" service in-process using the generated
+// HTTP server and client.
+type HTTPHarness struct {
+	// Server is the test HTTP server.
+	Server *httptest.Server
+	// Client is the service client that sends requests to Server. It
+	// uses the transport endpoints without the design retry policies so
+	// that each call reaches the server exactly once.
+	Client *serviceretry.Client
+
+	mu     sync.Mutex
+	status int
+}
+
+// NewHTTPHarness starts a test HTTP server that serves the "ServiceRetry"
+// service implemented by s and returns a harness whose client sends requests
+// to the server. The server is closed when the test completes.
+func NewHTTPHarness(t testing.TB, s serviceretry.Service) *HTTPHarness {
+	t.Helper()
+	mux := goahttp.NewMuxer()
+	endpoints := serviceretry.NewEndpoints(s)
+	server := serviceretrysvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, nil, nil)
+	serviceretrysvr.Mount(mux, server)
+	h := &HTTPHarness{Server: httptest.NewServer(mux)}
+	t.Cleanup(h.Server.Close)
+	u, err := url.Parse(h.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := serviceretryc.NewClient(u.Scheme, u.Host, h, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+	h.Client = &serviceretry.Client{
+		MethodRetryEndpoint: c.MethodRetry(),
+	}
+	return h
+}
+
+// Do sends the request to the test server and records the status code of the
+// response. It implements the HTTP client doer used by Client.
+func (h *HTTPHarness) Do(req *http.Request) (*http.Response, error) {
+	resp, err := h.Server.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.status = resp.StatusCode
+	h.mu.Unlock()
+	return resp, nil
+}
+
+// StatusCode returns the status code of the last response received by Client.
+func (h *HTTPHarness) StatusCode() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status
+}
+`
+
+const ContractRetryCode = `// TestHTTPContract calls the "ServiceRetry" service methods through the
+// generated HTTP transport and checks that each designed response and error
+// produces the expected status code.
+func TestHTTPContract(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Mock   func(*Mock)
+		Call   func(context.Context, *serviceretry.Client) error
+		Status int
+		Error  string
+	}{
+		{
+			Name: "MethodRetry",
+			Mock: func(m *Mock) {
+				m.ExpectMethodRetry(func(ctx context.Context, p string) (res string, err error) {
+					return "Veritatis dolorum cumque cumque distinctio.", nil
+				})
+			},
+			Call: func(ctx context.Context, c *serviceretry.Client) error {
+				_, err := c.MethodRetry(ctx, "Et aut ut aspernatur est.")
+				return err
+			},
+			Status: 200,
+			Error:  "",
+		},
+		{
+			Name: "MethodRetry unavailable",
+			Mock: func(m *Mock) {
+				m.ExpectMethodRetry(func(ctx context.Context, p string) (res string, err error) {
+					err = serviceretry.MakeUnavailable(errors.New("unavailable"))
+					return
+				})
+			},
+			Call: func(ctx context.Context, c *serviceretry.Client) error {
+				_, err := c.MethodRetry(ctx, "Et aut ut aspernatur est.")
+				return err
+			},
+			Status: 503,
+			Error:  "unavailable",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMock(t)
+			c.Mock(m)
+			h := NewHTTPHarness(t, m)
+			err := c.Call(context.Background(), h.Client)
+			if got := h.StatusCode(); got != c.Status {
+				t.Errorf("got status code %d, expected %d", got, c.Status)
+			}
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var en goa.GoaErrorNamer
+			if !errors.As(err, &en) {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			if en.GoaErrorName() != c.Error {
+				t.Errorf("got error %q, expected %q", en.GoaErrorName(), c.Error)
+			}
+		})
+	}
+}
+`
@@ -1,6 +1,10 @@
 package testdata
 
-import . "goa.design/goa/v3/dsl"
+import (
+	"time"
+
+	. "goa.design/goa/v3/dsl"
+)
 
 var SimpleDSL = func() {
 	var PayloadT = Type("Payload", func() {
@@ -426,6 +430,81 @@ var SecurityDSL = func() {
 	})
 }
 
+var ComposedSecurityDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Scope("api:read", "Read-only access")
+		Scope("api:admin", "Admin access")
+	})
+
+	var APIKeyAuth = APIKeySecurity("api_key")
+
+	var MutualTLSAuth = MutualTLSSecurity("mtls", func() {
+		Description("Secures endpoint by requiring a client certificate.")
+	})
+
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			AuthErrors()
+			Security(JWTAuth, APIKeyAuth, func() {
+				SchemeScope("api:admin", "jwt")
+			})
+			Security(MutualTLSAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+				Token("token", String)
+			})
+			HTTP(func() {
+				DELETE("/")
+				Param("key:k")
+			})
+		})
+	})
+}
+
+var MutualTLSSecurityDSL = func() {
+	var MutualTLSAuth = MutualTLSSecurity("mtls")
+
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Security(MutualTLSAuth)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var MixedMutualTLSDSL = func() {
+	var MutualTLSAuth = MutualTLSSecurity("mtls")
+	var Item = Type("Item", func() {
+		Attribute("name", String, func() {
+			Example("widget")
+		})
+		Attribute("price", Float64, func() {
+			ExclusiveMinimum(0)
+			Example(9.99)
+		})
+		Required("name")
+	})
+
+	Service("testService", func() {
+		Method("list", func() {
+			Result(ArrayOf(Item))
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("create", func() {
+			Security(MutualTLSAuth)
+			Payload(Item)
+			HTTP(func() {
+				POST("/")
+				Header("name:X-Name")
+			})
+		})
+	})
+}
+
 var ServerHostWithVariablesDSL = func() {
 	var _ = API("test", func() {
 		Server("test", func() {
@@ -945,3 +1024,128 @@ var JSONPrefixIndentDSL = func() {
 		})
 	})
 }
+
+var RateLimitDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	var _ = Service("test service", func() {
+		RateLimit(100, time.Minute, RateLimitByClientIP)
+		Method("inherited", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("tenant", func() {
+			RateLimit(10, time.Second, "tenant")
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+			HTTP(func() {
+				GET("/{tenant}")
+			})
+		})
+	})
+}
+
+var PaginationDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	var _ = Service("test service", func() {
+		Method("list", func() {
+			Payload(func() {
+				Attribute("page_token", String)
+				Attribute("page_size", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(Item))
+				Attribute("next_page_token", String)
+			})
+			Paginated(func() {
+				PageItems("items")
+				PageCursor("page_token", "next_page_token")
+				PageSize("page_size", 100)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("page_token:pageToken")
+				Param("page_size")
+			})
+		})
+		Method("names", func() {
+			Payload(func() {
+				Attribute("offset", Int)
+				Attribute("limit", Int)
+			})
+			Result(func() {
+				Attribute("names", ArrayOf(String))
+			})
+			Paginated(func() {
+				PageItems("names")
+				PageOffset("offset")
+				PageSize("limit", 50)
+			})
+			HTTP(func() {
+				GET("/names")
+				Param("offset")
+				Param("limit")
+			})
+		})
+	})
+}
+
+var CacheDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("id", String)
+		Attribute("version", String)
+		Attribute("updated_at", String, func() {
+			Format(FormatDateTime)
+		})
+		Required("id", "version", "updated_at")
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	var _ = Service("test service", func() {
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(Item)
+			HTTP(func() {
+				GET("/{id}")
+				Cache("private", "max-age=60")
+				ETag("version")
+				LastModified("updated_at")
+			})
+		})
+		Method("update", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("version", String)
+				Required("version")
+			})
+			Result(Item)
+			HTTP(func() {
+				PUT("/{id}")
+				ETag("version")
+			})
+		})
+	})
+}
@@ -392,6 +392,72 @@ func DecodeMethodQueryUInt64Request(mux goahttp.Muxer, decoder func(*http.Reques
 }
 `
 
+var PayloadQueryTimeTypesDecodeCode = `// DecodeMethodQueryTimeTypesRequest returns a decoder for requests sent to the
+// ServiceQueryTimeTypes MethodQueryTimeTypes endpoint.
+func DecodeMethodQueryTimeTypesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			t   *time.Time
+			d   *time.Duration
+			n   *goa.Decimal
+			ts  []time.Time
+			err error
+		)
+		qp := r.URL.Query()
+		{
+			tRaw := qp.Get("t")
+			if tRaw != "" {
+				v, err2 := time.Parse(time.RFC3339, tRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("t", tRaw, "datetime"))
+				}
+				t = &v
+			}
+		}
+		{
+			dRaw := qp.Get("d")
+			if dRaw != "" {
+				v, err2 := strconv.ParseInt(dRaw, 10, 64)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("d", dRaw, "duration"))
+				}
+				pv := time.Duration(v)
+				d = &pv
+			}
+		}
+		{
+			nRaw := qp.Get("n")
+			if nRaw != "" {
+				v, err2 := goa.ParseDecimal(nRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("n", nRaw, "decimal"))
+				}
+				n = &v
+			}
+		}
+		{
+			tsRaw := qp["ts"]
+			if tsRaw != nil {
+				ts = make([]time.Time, len(tsRaw))
+				for i, rv := range tsRaw {
+					v, err2 := time.Parse(time.RFC3339, rv)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("ts", tsRaw, "array of datetimes"))
+					}
+					ts[i] = v
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryTimeTypesPayload(t, d, n, ts)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadQueryUInt64ValidateDecodeCode = `// DecodeMethodQueryUInt64ValidateRequest returns a decoder for requests sent
 // to the ServiceQueryUInt64Validate MethodQueryUInt64Validate endpoint.
 func DecodeMethodQueryUInt64ValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
@@ -6214,3 +6280,56 @@ func DecodeMethodCookieCustomNameRequest(mux goahttp.Muxer, decoder func(*http.R
 	}
 }
 `
+
+var PayloadHeaderIfMatchDecodeCode = `// DecodeMethodHeaderIfMatchRequest returns a decoder for requests sent to the
+// ServiceHeaderIfMatch MethodHeaderIfMatch endpoint.
+func DecodeMethodHeaderIfMatchRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			id      string
+			version string
+			err     error
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		version = r.Header.Get("If-Match")
+		if version == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("version", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodHeaderIfMatchPayload(id, version)
+		payload.Version = goahttp.ParseETag(payload.Version)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadHeaderIfMatchOptionalDecodeCode = `// DecodeMethodHeaderIfMatchOptionalRequest returns a decoder for requests sent
+// to the ServiceHeaderIfMatchOptional MethodHeaderIfMatchOptional endpoint.
+func DecodeMethodHeaderIfMatchOptionalRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			id      string
+			version *string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		versionRaw := r.Header.Get("If-Match")
+		if versionRaw != "" {
+			version = &versionRaw
+		}
+		payload := NewMethodHeaderIfMatchOptionalPayload(id, version)
+		if payload.Version != nil {
+			etag := goahttp.ParseETag(*payload.Version)
+			payload.Version = &etag
+		}
+
+		return payload, nil
+	}
+}
+`
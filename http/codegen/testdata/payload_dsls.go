@@ -210,6 +210,26 @@ var PayloadQueryUInt64DSL = func() {
 	})
 }
 
+var PayloadQueryTimeTypesDSL = func() {
+	Service("ServiceQueryTimeTypes", func() {
+		Method("MethodQueryTimeTypes", func() {
+			Payload(func() {
+				Attribute("t", DateTime)
+				Attribute("d", Duration)
+				Attribute("n", Decimal)
+				Attribute("ts", ArrayOf(DateTime))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("t")
+				Param("d")
+				Param("n")
+				Param("ts")
+			})
+		})
+	})
+}
+
 var PayloadQueryUInt64ValidateDSL = func() {
 	Service("ServiceQueryUInt64Validate", func() {
 		Method("MethodQueryUInt64Validate", func() {
@@ -3648,3 +3668,40 @@ var PayloadCookieCustomNameDSL = func() {
 		})
 	})
 }
+
+var PayloadHeaderIfMatchDSL = func() {
+	Service("ServiceHeaderIfMatch", func() {
+		Method("MethodHeaderIfMatch", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("version", String)
+				Required("version")
+			})
+			Result(func() {
+				Attribute("version", String)
+			})
+			HTTP(func() {
+				PUT("/{id}")
+				ETag("version")
+			})
+		})
+	})
+}
+
+var PayloadHeaderIfMatchOptionalDSL = func() {
+	Service("ServiceHeaderIfMatchOptional", func() {
+		Method("MethodHeaderIfMatchOptional", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("version", String)
+			})
+			Result(func() {
+				Attribute("version", String)
+			})
+			HTTP(func() {
+				PATCH("/{id}")
+				ETag("version")
+			})
+		})
+	})
+}
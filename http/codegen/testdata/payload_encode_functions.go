@@ -222,6 +222,34 @@ func EncodeMethodQueryUInt64Request(encoder func(*http.Request) goahttp.Encoder)
 }
 `
 
+var PayloadQueryTimeTypesEncodeCode = `// EncodeMethodQueryTimeTypesRequest returns an encoder for requests sent to
+// the ServiceQueryTimeTypes MethodQueryTimeTypes server.
+func EncodeMethodQueryTimeTypesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*servicequerytimetypes.MethodQueryTimeTypesPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryTimeTypes", "MethodQueryTimeTypes", "*servicequerytimetypes.MethodQueryTimeTypesPayload", v)
+		}
+		values := req.URL.Query()
+		if p.T != nil {
+			values.Add("t", (*p.T).Format(time.RFC3339Nano))
+		}
+		if p.D != nil {
+			values.Add("d", strconv.FormatInt(int64(*p.D), 10))
+		}
+		if p.N != nil {
+			values.Add("n", string(*p.N))
+		}
+		for _, value := range p.Ts {
+			valueStr := value.Format(time.RFC3339Nano)
+			values.Add("ts", valueStr)
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
 var PayloadQueryUInt64ValidateEncodeCode = `// EncodeMethodQueryUInt64ValidateRequest returns an encoder for requests sent
 // to the ServiceQueryUInt64Validate MethodQueryUInt64Validate server.
 func EncodeMethodQueryUInt64ValidateRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
@@ -2685,3 +2713,37 @@ func EncodeMethodCookieCustomNameRequest(encoder func(*http.Request) goahttp.Enc
 	}
 }
 `
+
+var PayloadHeaderIfMatchEncodeCode = `// EncodeMethodHeaderIfMatchRequest returns an encoder for requests sent to the
+// ServiceHeaderIfMatch MethodHeaderIfMatch server.
+func EncodeMethodHeaderIfMatchRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*serviceheaderifmatch.MethodHeaderIfMatchPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceHeaderIfMatch", "MethodHeaderIfMatch", "*serviceheaderifmatch.MethodHeaderIfMatchPayload", v)
+		}
+		{
+			head := p.Version
+			req.Header.Set("If-Match", goahttp.FormatETag(head))
+		}
+		return nil
+	}
+}
+`
+
+var PayloadHeaderIfMatchOptionalEncodeCode = `// EncodeMethodHeaderIfMatchOptionalRequest returns an encoder for requests
+// sent to the ServiceHeaderIfMatchOptional MethodHeaderIfMatchOptional server.
+func EncodeMethodHeaderIfMatchOptionalRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*serviceheaderifmatchoptional.MethodHeaderIfMatchOptionalPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceHeaderIfMatchOptional", "MethodHeaderIfMatchOptional", "*serviceheaderifmatchoptional.MethodHeaderIfMatchOptionalPayload", v)
+		}
+		if p.Version != nil {
+			head := *p.Version
+			req.Header.Set("If-Match", goahttp.FormatETag(head))
+		}
+		return nil
+	}
+}
+`
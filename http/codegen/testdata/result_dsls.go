@@ -1596,3 +1596,52 @@ var ValidateErrorResponseTypeDSL = func() {
 		})
 	})
 }
+
+var ResultCacheValidatorsDSL = func() {
+	var Item = ResultType("application/vnd.item", func() {
+		Attribute("version", String)
+		Attribute("updated_at", String, func() {
+			Format(FormatDateTime)
+		})
+		Required("version")
+	})
+	Service("ServiceCacheValidators", func() {
+		Method("MethodCacheValidators", func() {
+			Result(Item)
+			HTTP(func() {
+				GET("/")
+				Cache("private", "max-age=60")
+				ETag("version")
+				LastModified("updated_at")
+			})
+		})
+	})
+}
+
+var ResultCacheDateTimeDSL = func() {
+	Service("ServiceCacheDateTime", func() {
+		Method("MethodCacheDateTime", func() {
+			Result(func() {
+				Attribute("version", String)
+				Attribute("modified", DateTime)
+				Required("version", "modified")
+			})
+			HTTP(func() {
+				GET("/")
+				ETag("version")
+				LastModified("modified")
+			})
+		})
+	})
+}
+
+var ResultCacheControlDSL = func() {
+	Service("ServiceCacheControl", func() {
+		Method("MethodCacheControl", func() {
+			HTTP(func() {
+				GET("/")
+				Cache("no-store")
+			})
+		})
+	})
+}
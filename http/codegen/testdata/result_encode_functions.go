@@ -1063,3 +1063,57 @@ func EncodeMethodResultWithEmbeddedCustomPkgTypeDSLResponse(encoder func(context
 	}
 }
 `
+
+var ResultCacheValidatorsEncodeCode = `// EncodeMethodCacheValidatorsResponse returns an encoder for responses
+// returned by the ServiceCacheValidators MethodCacheValidators endpoint.
+func EncodeMethodCacheValidatorsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.Header().Set("Cache-Control", "private, max-age=60")
+		res := v.(*servicecachevalidatorsviews.Item)
+		var etag string
+		if res.Projected.Version != nil {
+			etag = goahttp.FormatETag(*res.Projected.Version)
+		}
+		var lastModified time.Time
+		if res.Projected.UpdatedAt != nil {
+			lastModified, _ = time.Parse(time.RFC3339, *res.Projected.UpdatedAt)
+		}
+		if goahttp.CheckNotModified(ctx, w, etag, lastModified) {
+			return nil
+		}
+		enc := encoder(ctx, w)
+		body := NewMethodCacheValidatorsResponseBody(res.Projected)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultCacheDateTimeEncodeCode = `// EncodeMethodCacheDateTimeResponse returns an encoder for responses returned
+// by the ServiceCacheDateTime MethodCacheDateTime endpoint.
+func EncodeMethodCacheDateTimeResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*servicecachedatetime.MethodCacheDateTimeResult)
+		etag := goahttp.FormatETag(res.Version)
+		lastModified := res.Modified
+		if goahttp.CheckNotModified(ctx, w, etag, lastModified) {
+			return nil
+		}
+		enc := encoder(ctx, w)
+		body := NewMethodCacheDateTimeResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultCacheControlEncodeCode = `// EncodeMethodCacheControlResponse returns an encoder for responses returned
+// by the ServiceCacheControl MethodCacheControl endpoint.
+func EncodeMethodCacheControlResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+`
@@ -282,3 +282,44 @@ var ServerSkipResponseBodyEncodeDecodeDSL = func() {
 		})
 	})
 }
+
+var ServerCacheFieldSelectionDSL = func() {
+	var Item = ResultType("application/vnd.item", func() {
+		Attribute("id", String)
+		Attribute("name", String)
+		Attribute("version", String)
+		Required("id")
+	})
+	Service("ServiceCacheFieldSelection", func() {
+		Method("MethodCacheFieldSelection", func() {
+			Payload(func() {
+				Attribute("fields", String)
+			})
+			Result(Item)
+			FieldSelection()
+			HTTP(func() {
+				GET("/")
+				Param("fields")
+				ETag("version")
+			})
+		})
+	})
+}
+
+var ServerRetryDSL = func() {
+	Service("ServiceRetry", func() {
+		Retry(3)
+		Method("MethodRetry", func() {
+			Payload(String)
+			Result(String)
+			Error("unavailable", func() {
+				Temporary()
+			})
+			HTTP(func() {
+				GET("/{p}")
+				Response(StatusOK)
+				Response("unavailable", StatusServiceUnavailable)
+			})
+		})
+	})
+}
@@ -0,0 +1,59 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ServerSentEventsDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+		Attribute("kind", String)
+		Attribute("retry", Int64)
+		Attribute("message", String)
+		Required("id", "message")
+	})
+	Service("ServerSentEventsService", func() {
+		Method("ServerSentEventsMethod", func() {
+			Payload(func() {
+				Attribute("topic", String)
+				Attribute("last_id", String)
+			})
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/{topic}")
+				Header("last_id:Last-Event-ID")
+				ServerSentEvents(func() {
+					SSEEventID("id")
+					SSEEventType("kind")
+					SSEEventRetry("retry")
+				})
+			})
+		})
+	})
+}
+
+var ServerSentEventsWithViewsDSL = func() {
+	var Event = ResultType("application/vnd.event", func() {
+		TypeName("Event")
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("message", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("message")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("ServerSentEventsWithViewsService", func() {
+		Method("ServerSentEventsWithViewsMethod", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
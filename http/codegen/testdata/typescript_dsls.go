@@ -0,0 +1,297 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TypeScriptTypesDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Scope("api:read")
+	})
+	var Address = Type("Address", func() {
+		Description("Postal address")
+		Attribute("street", String, "Street name")
+		Attribute("zip-code", String)
+		Required("street")
+	})
+	var Pet = Type("Pet", func() {
+		Attribute("name", String, "Name of the pet")
+		Attribute("kind", String, func() {
+			Enum("cat", "dog")
+		})
+		Attribute("age", Int)
+		Attribute("born_at", String, func() {
+			Format(FormatDateTime)
+		})
+		Attribute("tags", ArrayOf(String))
+		Attribute("ratings", MapOf(String, Float64))
+		Attribute("owner", func() {
+			Attribute("name", String)
+			Attribute("address", Address)
+			Required("name")
+		})
+		OneOf("collar", func() {
+			Attribute("color", String)
+			Attribute("size", Int)
+		})
+		Required("name", "kind")
+	})
+	var NotFound = Type("NotFound", func() {
+		Attribute("id", String)
+		Attribute("message", String)
+		Required("id")
+	})
+	API("petstore", func() {
+		Meta("typescript:generate", "true")
+	})
+	Service("pets", func() {
+		Description("The pets service manages pets.")
+		Security(JWTAuth)
+		Error("unauthorized")
+		Method("show", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", Int)
+				Attribute("fields", ArrayOf(String))
+				Attribute("verbose", Boolean)
+				Required("id")
+			})
+			Result(Pet)
+			Error("not_found", NotFound)
+			HTTP(func() {
+				GET("/pets/{id}")
+				Param("fields")
+				Header("verbose:X-Verbose")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+			})
+		})
+		Method("create", func() {
+			Description("Create a new pet.")
+			NoSecurity()
+			Payload(func() {
+				Attribute("owner_id", String)
+				Attribute("pet", Pet)
+				Attribute("note", String)
+				Required("owner_id", "pet")
+			})
+			Result(func() {
+				Attribute("id", Int)
+				Attribute("location", String)
+				Required("id", "location")
+			})
+			Error("invalid", ErrorResult)
+			Error("conflict", ErrorResult)
+			HTTP(func() {
+				POST("/owners/{owner_id}/pets")
+				Body(func() {
+					Attribute("pet")
+					Attribute("note")
+				})
+				Response(StatusCreated, func() {
+					Header("location:Location")
+				})
+				Response("invalid", StatusBadRequest)
+				Response("conflict", StatusBadRequest)
+			})
+		})
+		Method("list", func() {
+			NoSecurity()
+			Payload(func() {
+				Attribute("filter", MapOf(String, String))
+			})
+			Result(ArrayOf(Pet))
+			HTTP(func() {
+				GET("/pets")
+				MapParams("filter")
+			})
+		})
+		Method("rename", func() {
+			NoSecurity()
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("name", String)
+				Required("id", "name")
+			})
+			HTTP(func() {
+				PUT("/pets/{id}/name")
+				Body("name")
+				Response(StatusNoContent)
+			})
+		})
+		Method("count", func() {
+			NoSecurity()
+			Result(Int)
+			HTTP(func() {
+				GET("/pets/count")
+				Response(StatusOK, func() {
+					Header("X-Count")
+				})
+			})
+		})
+	})
+}
+
+var TypeScriptViewsDSL = func() {
+	var Bottle = ResultType("application/vnd.bottle", func() {
+		TypeName("Bottle")
+		Attributes(func() {
+			Attribute("id", Int)
+			Attribute("name", String)
+			Attribute("vintage", Int)
+			Required("id", "name")
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+			Attribute("vintage")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	API("cellar", func() {
+		Meta("typescript:generate", "true")
+	})
+	Service("cellar", func() {
+		Method("show", func() {
+			Payload(String)
+			Result(Bottle)
+			HTTP(func() {
+				GET("/bottles/{id}")
+			})
+		})
+		Method("show_tiny", func() {
+			Payload(String)
+			Result(Bottle, func() {
+				View("tiny")
+			})
+			HTTP(func() {
+				GET("/bottles/{id}/tiny")
+			})
+		})
+		Method("list", func() {
+			Result(CollectionOf(Bottle), func() {
+				View("tiny")
+			})
+			HTTP(func() {
+				GET("/bottles")
+			})
+		})
+	})
+}
+
+var TypeScriptStreamingDSL = func() {
+	var Message = Type("Message", func() {
+		Attribute("text", String)
+		OneOf("content", func() {
+			Attribute("text", String)
+			Attribute("code", Int)
+		})
+		Required("text")
+	})
+	Service("chat", func() {
+		Meta("typescript:generate", "true")
+		Method("talk", func() {
+			Payload(func() {
+				Attribute("room", String)
+				Attribute("since", Int)
+				Required("room")
+			})
+			StreamingPayload(Message)
+			StreamingResult(Message)
+			HTTP(func() {
+				GET("/rooms/{room}")
+				Param("since")
+			})
+		})
+		Method("upload", func() {
+			StreamingPayload(Message)
+			Result(Int)
+			HTTP(func() {
+				GET("/upload")
+			})
+		})
+		Method("listen", func() {
+			StreamingResult(Message)
+			HTTP(func() {
+				GET("/listen")
+			})
+		})
+		Method("events", func() {
+			Payload(func() {
+				Attribute("room", String)
+				Required("room")
+			})
+			StreamingResult(Message)
+			HTTP(func() {
+				GET("/rooms/{room}/events")
+				ServerSentEvents()
+			})
+		})
+	})
+	Service("other", func() {
+		Method("ping", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+	})
+}
+
+var TypeScriptDisabledDSL = func() {
+	API("disabled", func() {
+		Meta("typescript:generate", "true")
+	})
+	Service("enabled", func() {
+		Method("ping", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+		Method("hidden", func() {
+			Meta("typescript:generate", "false")
+			HTTP(func() {
+				GET("/hidden")
+			})
+		})
+	})
+	Service("disabled", func() {
+		Meta("typescript:generate", "false")
+		Method("ping", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+	})
+}
+
+var TypeScriptNotEnabledDSL = func() {
+	Service("service", func() {
+		Method("ping", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+	})
+}
+
+var TypeScriptProblemDetailsDSL = func() {
+	API("problems", func() {
+		Meta("typescript:generate", "true")
+		HTTP(func() {
+			ProblemDetails()
+		})
+	})
+	Service("items", func() {
+		Method("show", func() {
+			Payload(String)
+			Result(String)
+			Error("not_found")
+			HTTP(func() {
+				GET("/items/{id}")
+				Response("not_found", StatusNotFound)
+			})
+		})
+	})
+}
@@ -0,0 +1,16 @@
+package codegen
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/typescript"
+)
+
+// TypeScriptFiles returns the files of the TypeScript clients of the HTTP
+// services.
+func TypeScriptFiles(root *expr.RootExpr) ([]*codegen.File, error) {
+	if len(root.API.HTTP.Services) == 0 {
+		return nil, nil
+	}
+	return typescript.Files(root)
+}
@@ -0,0 +1,561 @@
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// fileData contains the data needed to render the client file of a
+	// service.
+	fileData struct {
+		// Comment is the comment of the client class.
+		Comment string
+		// Types lists the declarations of the types used by the client.
+		Types []*typeDecl
+		// Errors lists the error classes of the service.
+		Errors []*errorData
+		// Methods lists the client methods.
+		Methods []*methodData
+	}
+
+	// errorData describes the class of an error defined in the design.
+	errorData struct {
+		// Comment is the comment of the class.
+		Comment string
+		// Class is the name of the class.
+		Class string
+		// Name is the name of the error in the design.
+		Name string
+		// Type is the TypeScript type of the error response body.
+		Type string
+	}
+
+	// methodData describes a client method.
+	methodData struct {
+		// Comment is the comment of the method.
+		Comment string
+		// Name is the name of the method.
+		Name string
+		// Params is the list of the method parameters.
+		Params string
+		// Result is the type of the value returned by the method, the
+		// type of the promise value for unary methods and the type of the
+		// generated values for server-sent events.
+		Result string
+		// Verb is the HTTP method of the request.
+		Verb string
+		// Path is the TypeScript template literal of the request path.
+		Path string
+		// Query lists the request query parameters.
+		Query []*paramData
+		// Headers lists the request headers.
+		Headers []*paramData
+		// Body is the TypeScript expression of the request body encoded
+		// in JSON if any.
+		Body string
+		// RawBody is the name of the parameter that holds the request
+		// body if the body is not encoded by the client.
+		RawBody string
+		// Accept is the value of the Accept header if any.
+		Accept string
+		// Responses lists the successful responses.
+		Responses []*responseData
+		// Errors lists the error responses indexed by status code.
+		Errors []*statusData
+		// RawResponse is true if the response body is returned as is.
+		RawResponse bool
+		// SSE is true if the method uses server-sent events.
+		SSE bool
+		// WebSocket describes the stream if the method uses websockets.
+		WebSocket *webSocketData
+	}
+
+	// paramData describes a request query parameter or header.
+	paramData struct {
+		// Name is the name of the parameter or header, empty if Value is
+		// a map whose entries are the parameters.
+		Name string
+		// Value is the TypeScript expression of the parameter value.
+		Value string
+	}
+
+	// responseData describes a successful response.
+	responseData struct {
+		// Status is the response status code.
+		Status int
+		// ReadBody is true if Value uses the response body.
+		ReadBody bool
+		// Value is the TypeScript expression that computes the method
+		// result from the response and its body, empty if the method has
+		// no result.
+		Value string
+	}
+
+	// statusData lists the errors that use the same status code.
+	statusData struct {
+		// Status is the response status code.
+		Status int
+		// Errors lists the errors.
+		Errors []*errorResponseData
+	}
+
+	// errorResponseData describes an error response.
+	errorResponseData struct {
+		// Name is the name of the error in the design.
+		Name string
+		// Class is the name of the error class.
+		Class string
+		// Value is the TypeScript expression that computes the error
+		// value from the response body.
+		Value string
+	}
+
+	// webSocketData describes the stream of a method that uses websockets.
+	webSocketData struct {
+		// Send is the type of the messages sent by the client.
+		Send string
+		// Recv is the type of the messages received by the client.
+		Recv string
+		// Encode is the TypeScript expression that encodes the message v
+		// sent by the client.
+		Encode string
+		// Decode is the TypeScript expression that decodes the message v
+		// received by the client.
+		Decode string
+	}
+
+	// builder builds the client file data of a service.
+	builder struct {
+		svc     *expr.HTTPServiceExpr
+		types   *typeScope
+		methods *codegen.NameScope
+		errors  map[string]*errorData
+		data    *fileData
+	}
+)
+
+// buildFile returns the data needed to render the client file of the given
+// service.
+func buildFile(svc *expr.HTTPServiceExpr) *fileData {
+	b := &builder{
+		svc:     svc,
+		types:   newTypeScope(svc.UsesProblemDetails()),
+		methods: codegen.NewNameScope(),
+		errors:  make(map[string]*errorData),
+		data: &fileData{
+			Comment: comment(fmt.Sprintf("Client is the %q service HTTP client.", svc.Name()), ""),
+		},
+	}
+	if svc.ServiceExpr.Description != "" {
+		b.data.Comment = comment(fmt.Sprintf("Client is the %q service HTTP client.\n%s", svc.Name(), svc.ServiceExpr.Description), "")
+	}
+	for _, e := range svc.HTTPEndpoints {
+		if !mustGenerate(e.MethodExpr.Meta) || !mustGenerate(e.Meta) {
+			continue
+		}
+		b.data.Methods = append(b.data.Methods, b.method(e))
+	}
+	b.data.Types = b.types.Decls()
+	return b.data
+}
+
+// method returns the data of the client method of the given endpoint.
+func (b *builder) method(e *expr.HTTPEndpointExpr) *methodData {
+	m := e.MethodExpr
+	name := b.methods.Unique(codegen.Goify(m.Name, false))
+	prefix := codegen.Goify(m.Name, true)
+	desc := m.Description
+	if desc == "" {
+		desc = fmt.Sprintf("%s calls the %q endpoint of the %q service.", name, m.Name, b.svc.Name())
+	}
+	md := &methodData{
+		Comment: comment(desc, "\t"),
+		Name:    name,
+		Verb:    e.Routes[0].Method,
+		Path:    b.path(e),
+	}
+
+	var params []string
+	if m.Payload.Type != expr.Empty {
+		params = append(params, "p: "+b.types.Name(m.Payload, prefix+"Payload"))
+	}
+
+	md.Query = b.params(e, e.QueryParams())
+	if e.MapQueryParams != nil {
+		v := "p"
+		if *e.MapQueryParams != "" {
+			v = b.payloadField(m.Payload, *e.MapQueryParams) + " ?? {}"
+		}
+		md.Query = append(md.Query, &paramData{Value: "Object.entries(" + v + ")"})
+	}
+
+	if m.IsStreaming() && e.SSE == nil {
+		// Websocket connections cannot carry headers in browsers and
+		// the payload is sent in the path and query parameters.
+		ws := &webSocketData{Send: "never", Recv: "never", Encode: "v", Decode: "v"}
+		if m.StreamingPayload.Type != expr.Empty {
+			ws.Send = b.types.Name(m.StreamingPayload, prefix+"StreamingPayload")
+			ws.Encode = b.types.Encode(m.StreamingPayload, "v")
+		}
+		if m.Result.Type != expr.Empty {
+			var res *expr.AttributeExpr
+			res, ws.Recv = b.result(m.Result, prefix+"Result")
+			ws.Decode = b.types.Decode(res, "v")
+		}
+		md.WebSocket = ws
+		md.Params = strings.Join(params, ", ")
+		return md
+	}
+
+	md.Headers = b.headers(e)
+	switch {
+	case e.MultipartRequest:
+		md.RawBody = "form"
+		params = append(params, "form: FormData")
+	case e.SkipRequestBodyEncodeDecode:
+		md.RawBody = "data"
+		params = append(params, "data: BodyInit")
+	default:
+		md.Body = b.body(e)
+	}
+	md.Params = strings.Join(append(params, "opts?: goa.CallOptions"), ", ")
+
+	md.Result = "void"
+	var res *expr.AttributeExpr
+	if m.Result.Type != expr.Empty {
+		res, md.Result = b.result(m.Result, prefix+"Result")
+	}
+	resType := md.Result
+	switch {
+	case e.SSE != nil:
+		md.SSE = true
+		md.Accept = "text/event-stream"
+	case e.SkipResponseBodyEncodeDecode:
+		md.RawResponse = true
+		md.Result = "goa.RawResponse<" + md.Result + ">"
+	}
+	for _, resp := range e.Responses {
+		md.Responses = append(md.Responses, b.response(e, resp, res, resType, md.RawResponse))
+	}
+	md.Errors = b.errorResponses(e)
+	return md
+}
+
+// path returns the TypeScript template literal that computes the path of the
+// first route of the given endpoint.
+func (b *builder) path(e *expr.HTTPEndpointExpr) string {
+	path := e.Routes[0].FullPaths()[0]
+	codegen.WalkMappedAttr(e.PathParams(), func(name, elem string, _ bool, _ *expr.AttributeExpr) error { // nolint: errcheck
+		v := b.payloadField(e.MethodExpr.Payload, name)
+		path = strings.ReplaceAll(path, "{*"+elem+"}", "${goa.pathWildcard("+v+")}")
+		path = strings.ReplaceAll(path, "{"+elem+"}", "${goa.pathParam("+v+")}")
+		return nil
+	})
+	return "`" + strings.ReplaceAll(path, "`", "\\`") + "`"
+}
+
+// params returns the request parameters mapped by ma.
+func (b *builder) params(e *expr.HTTPEndpointExpr, ma *expr.MappedAttributeExpr) []*paramData {
+	var params []*paramData
+	codegen.WalkMappedAttr(ma, func(name, elem string, _ bool, _ *expr.AttributeExpr) error { // nolint: errcheck
+		params = append(params, &paramData{Name: elem, Value: b.payloadField(e.MethodExpr.Payload, name)})
+		return nil
+	})
+	return params
+}
+
+// headers returns the request headers of the given endpoint including the
+// Authorization header computed from the security attributes.
+func (b *builder) headers(e *expr.HTTPEndpointExpr) []*paramData {
+	var bearer, basic bool
+	for _, req := range e.Requirements {
+		for _, s := range req.Schemes {
+			switch s.Kind {
+			case expr.BasicAuthKind:
+				basic = true
+			case expr.JWTKind, expr.OAuth2Kind:
+				bearer = bearer || s.In == "header" && s.Name == "Authorization"
+			}
+		}
+	}
+	headers := b.params(e, e.Headers)
+	for _, h := range headers {
+		if bearer && h.Name == "Authorization" {
+			h.Value = "goa.bearer(" + h.Value + ")"
+		}
+	}
+	if basic {
+		user := expr.TaggedAttribute(e.MethodExpr.Payload, "security:username")
+		pass := expr.TaggedAttribute(e.MethodExpr.Payload, "security:password")
+		if user != "" && pass != "" {
+			headers = append(headers, &paramData{
+				Name:  "Authorization",
+				Value: fmt.Sprintf("goa.basicAuth(%s, %s)", b.payloadField(e.MethodExpr.Payload, user), b.payloadField(e.MethodExpr.Payload, pass)),
+			})
+		}
+	}
+	return headers
+}
+
+// body returns the TypeScript expression of the request body encoded in
+// JSON, empty if the endpoint request has no body.
+func (b *builder) body(e *expr.HTTPEndpointExpr) string {
+	payload := e.MethodExpr.Payload
+	if e.Body == nil || e.Body.Type == expr.Empty {
+		return ""
+	}
+	if origin, ok := e.Body.Meta["origin:attribute"]; ok {
+		return b.encodeField(payload, origin[0])
+	}
+	if !expr.IsObject(payload.Type) || !expr.IsObject(e.Body.Type) {
+		return b.types.Encode(payload, "p")
+	}
+	var fields []string
+	for _, nat := range *expr.AsObject(e.Body.Type) {
+		fields = append(fields, fmt.Sprintf("%s: %s", PropertyKey(JSONName(nat)), b.encodeField(payload, nat.Name)))
+	}
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
+
+// result returns the attribute used to decode the values returned by the
+// client for the given method result and their TypeScript type. The result
+// types with views are projected using the view set in the design or each of
+// their views.
+func (b *builder) result(res *expr.AttributeExpr, prefix string) (*expr.AttributeExpr, string) {
+	rt, ok := res.Type.(*expr.ResultTypeExpr)
+	if !ok || len(rt.Views) == 0 {
+		return res, b.types.Name(res, prefix)
+	}
+	views := make([]string, len(rt.Views))
+	for i, v := range rt.Views {
+		views[i] = v.Name
+	}
+	if view, ok := res.Meta.Last(expr.ViewMetaKey); ok {
+		views = []string{view}
+	}
+	refs := make([]string, len(views))
+	var att *expr.AttributeExpr
+	for i, view := range views {
+		p, err := expr.Project(rt, view)
+		if err != nil {
+			panic(err) // bug, the view is validated by the DSL
+		}
+		att = &expr.AttributeExpr{Type: p}
+		refs[i] = b.types.Ref(att, prefix)
+	}
+	if len(views) > 1 {
+		// The projected types are subsets of the result type.
+		att = res
+	}
+	return att, strings.Join(refs, " | ")
+}
+
+// response returns the data of the given successful response.
+// res is the attribute used to decode the result and resType its TypeScript
+// type, raw is true if the response body is returned as is.
+func (b *builder) response(e *expr.HTTPEndpointExpr, resp *expr.HTTPResponseExpr, res *expr.AttributeExpr, resType string, raw bool) *responseData {
+	rd := &responseData{Status: resp.StatusCode}
+	if res == nil {
+		if raw {
+			rd.Value = "{ result: undefined, body: resp.body }"
+		}
+		return rd
+	}
+	var (
+		result = e.MethodExpr.Result
+		body   string
+		fields []string
+	)
+	if !raw && resp.Body != nil && resp.Body.Type != expr.Empty {
+		rd.ReadBody = true
+		if origin, ok := resp.Body.Meta["origin:attribute"]; ok {
+			v := b.types.Decode(result.Find(origin[0]), "body")
+			fields = append(fields, fmt.Sprintf("%s: %s", PropertyKey(b.jsonName(result, origin[0])), v))
+		} else {
+			body = b.types.Decode(res, "body")
+		}
+	}
+	var headers []string
+	codegen.WalkMappedAttr(resp.Headers, func(name, elem string, _ bool, att *expr.AttributeExpr) error { // nolint: errcheck
+		v := parseHeader(elem, att)
+		if !expr.IsObject(result.Type) {
+			headers = append(headers, v)
+			return nil
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", PropertyKey(b.jsonName(result, name)), v))
+		return nil
+	})
+	switch {
+	case !expr.IsObject(result.Type) && len(headers) > 0:
+		rd.Value = headers[0] + " as " + resType
+	case !expr.IsObject(result.Type) || len(fields) == 0:
+		rd.Value = body
+		if body == "" && expr.IsObject(result.Type) {
+			rd.Value = "{} as " + resType
+		} else if body == "" {
+			rd.Value = "undefined as unknown as " + resType
+		}
+	default:
+		if body != "" {
+			fields = append([]string{"..." + body}, fields...)
+		}
+		rd.Value = "{ " + strings.Join(fields, ", ") + " } as " + resType
+	}
+	if raw {
+		rd.Value = "{ result: " + rd.Value + ", body: resp.body }"
+	}
+	return rd
+}
+
+// errorResponses returns the error responses of the given endpoint grouped
+// by status code.
+func (b *builder) errorResponses(e *expr.HTTPEndpointExpr) []*statusData {
+	byStatus := make(map[int]*statusData)
+	for _, herr := range e.HTTPErrors {
+		ed := b.error(herr.ErrorExpr)
+		status := herr.Response.StatusCode
+		sd, ok := byStatus[status]
+		if !ok {
+			sd = &statusData{Status: status}
+			byStatus[status] = sd
+		}
+		sd.Errors = append(sd.Errors, &errorResponseData{
+			Name:  herr.Name,
+			Class: ed.Class,
+			Value: b.types.Decode(herr.ErrorExpr.AttributeExpr, "body"),
+		})
+	}
+	res := make([]*statusData, 0, len(byStatus))
+	for _, sd := range byStatus {
+		res = append(res, sd)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Status < res[j].Status })
+	return res
+}
+
+// error returns the class of the given error, declaring it if needed.
+func (b *builder) error(err *expr.ErrorExpr) *errorData {
+	if ed, ok := b.errors[err.Name]; ok {
+		return ed
+	}
+	class := codegen.Goify(err.Name, true)
+	if !strings.HasSuffix(class, "Error") {
+		class += "Error"
+	}
+	class = b.types.scope.Unique(class)
+	desc := err.Description
+	if desc == "" {
+		desc = fmt.Sprintf("%s is thrown when the server returns the %q error.", class, err.Name)
+	}
+	ed := &errorData{
+		Comment: comment(desc, ""),
+		Class:   class,
+		Name:    err.Name,
+		Type:    b.types.Name(err.AttributeExpr, class+"Body"),
+	}
+	b.errors[err.Name] = ed
+	b.data.Errors = append(b.data.Errors, ed)
+	return ed
+}
+
+// payloadField returns the TypeScript expression that accesses the payload
+// attribute with the given name.
+func (b *builder) payloadField(payload *expr.AttributeExpr, name string) string {
+	if !expr.IsObject(payload.Type) {
+		return "p"
+	}
+	return Access("p", b.jsonName(payload, name))
+}
+
+// encodeField returns the TypeScript expression that encodes the payload
+// attribute with the given name.
+func (b *builder) encodeField(payload *expr.AttributeExpr, name string) string {
+	v := b.payloadField(payload, name)
+	att := payload
+	if expr.IsObject(payload.Type) {
+		att = payload.Find(name)
+	}
+	enc := b.types.Encode(att, v)
+	if enc == v || v == "p" {
+		return enc
+	}
+	return fmt.Sprintf("%s == null ? %s : %s", v, v, enc)
+}
+
+// jsonName returns the JSON name of the attribute of obj with the given name.
+func (b *builder) jsonName(obj *expr.AttributeExpr, name string) string {
+	if o := expr.AsObject(obj.Type); o != nil {
+		for _, nat := range *o {
+			if nat.Name == name {
+				return JSONName(nat)
+			}
+		}
+	}
+	return name
+}
+
+// parseHeader returns the TypeScript expression that parses the response
+// header with the given name.
+func parseHeader(name string, att *expr.AttributeExpr) string {
+	h := fmt.Sprintf("goa.header(resp, %q)", name)
+	if arr := expr.AsArray(att.Type); arr != nil {
+		return fmt.Sprintf("goa.parseList(%s, %s)", h, parseFunc(arr.ElemType))
+	}
+	switch primitive(primitiveOf(att.Type)) {
+	case "number":
+		return "goa.parseNumber(" + h + ")"
+	case "boolean":
+		return "goa.parseBoolean(" + h + ")"
+	default:
+		return h
+	}
+}
+
+// parseFunc returns the TypeScript function that parses the elements of an
+// array header.
+func parseFunc(att *expr.AttributeExpr) string {
+	switch primitive(primitiveOf(att.Type)) {
+	case "number":
+		return "Number"
+	case "boolean":
+		return `(s: string) => s === "true"`
+	default:
+		return "(s: string) => s"
+	}
+}
+
+// primitiveOf returns the primitive type underlying dt, expr.Any if dt is not
+// a primitive type.
+func primitiveOf(dt expr.DataType) expr.Primitive {
+	if ut, ok := dt.(expr.UserType); ok {
+		return primitiveOf(ut.Attribute().Type)
+	}
+	if p, ok := dt.(expr.Primitive); ok {
+		return p
+	}
+	return expr.Any
+}
+
+// comment returns the given text formatted as a TypeScript comment indented
+// with indent.
+func comment(text, indent string) string {
+	lines := strings.Split(codegen.Comment(text), "\n")
+	return indent + strings.Join(lines, "\n"+indent)
+}
+
+// mustGenerate returns false if the "typescript:generate" meta is set to
+// "false".
+func mustGenerate(meta expr.MetaExpr) bool {
+	if m, ok := meta.Last("typescript:generate"); ok && m == "false" {
+		return false
+	}
+	return true
+}
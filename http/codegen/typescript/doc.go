@@ -0,0 +1,13 @@
+/*
+Package typescript produces typed TypeScript clients for the HTTP services.
+Each client is a module that declares the interfaces of the design types used
+by the service methods, a class per error defined in the design and a Client
+class whose methods build the requests from the payloads and decode the
+responses. Streaming methods return websocket streams or iterate over the
+server-sent events. The modules import a shared runtime module that relies on
+the fetch and WebSocket APIs only.
+
+Clients are only generated for the services that set the "typescript:generate"
+meta to "true", either directly or via the API.
+*/
+package typescript
@@ -0,0 +1,91 @@
+package typescript
+
+import (
+	"embed"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+//go:embed templates/*
+var tmplFS embed.FS
+
+// headerT is the template of the header of the TypeScript files.
+const headerT = `// Code generated by goa {{ .ToolVersion }}, DO NOT EDIT.
+//
+// {{ .Title }}
+//
+// Command:
+{{ comment commandLine }}
+
+`
+
+// Files returns the TypeScript client files of the HTTP services: a runtime
+// module shared by the clients and a module per service. Clients are
+// generated for the services whose "typescript:generate" meta or the API
+// "typescript:generate" meta is set to "true".
+func Files(root *expr.RootExpr) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		if !enabled(root.API.Meta, svc.ServiceExpr.Meta) {
+			continue
+		}
+		name := codegen.SnakeCase(codegen.Goify(svc.Name(), true))
+		files = append(files, &codegen.File{
+			Path: filepath.Join(codegen.Gendir, "http", "typescript", name+".ts"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				header(svc.Name() + " TypeScript client"),
+				{
+					Name:   "typescript-client",
+					Source: readTemplate("client"),
+					Data:   buildFile(svc),
+				},
+			},
+		})
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	runtime := &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "http", "typescript", "goa.ts"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			header("TypeScript clients runtime"),
+			{Name: "typescript-runtime", Source: readTemplate("runtime")},
+		},
+	}
+	return append([]*codegen.File{runtime}, files...), nil
+}
+
+// enabled returns true if the TypeScript client of the service with the given
+// meta must be generated.
+func enabled(api, svc expr.MetaExpr) bool {
+	if m, ok := svc.Last("typescript:generate"); ok {
+		return m == "true"
+	}
+	m, ok := api.Last("typescript:generate")
+	return ok && m == "true"
+}
+
+// header returns the header section of a TypeScript file.
+func header(title string) *codegen.SectionTemplate {
+	return &codegen.SectionTemplate{
+		Name:   "source-header",
+		Source: headerT,
+		Data: map[string]any{
+			"Title":       title,
+			"ToolVersion": goa.Version(),
+		},
+	}
+}
+
+// readTemplate returns the TypeScript template with the given name.
+func readTemplate(name string) string {
+	content, err := tmplFS.ReadFile(path.Join("templates", name) + ".ts.tpl")
+	if err != nil {
+		panic("failed to load template " + name + ": " + err.Error()) // Should never happen, bug if it does
+	}
+	return string(content)
+}
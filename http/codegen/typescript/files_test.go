@@ -0,0 +1,74 @@
+package typescript_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/testdata"
+	"goa.design/goa/v3/http/codegen/typescript"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+func TestFiles(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Paths []string
+	}{
+		{"types", testdata.TypeScriptTypesDSL, []string{"goa.ts", "pets.ts"}},
+		{"views", testdata.TypeScriptViewsDSL, []string{"goa.ts", "cellar.ts"}},
+		{"streaming", testdata.TypeScriptStreamingDSL, []string{"goa.ts", "chat.ts"}},
+		{"disabled", testdata.TypeScriptDisabledDSL, []string{"goa.ts", "enabled.ts"}},
+		{"problem-details", testdata.TypeScriptProblemDetailsDSL, []string{"goa.ts", "items.ts"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := httpgen.RunHTTPDSL(t, c.DSL)
+
+			files, err := typescript.Files(root)
+
+			require.NoError(t, err)
+			require.Len(t, files, len(c.Paths))
+			for i, f := range files {
+				assert.Equal(t, filepath.Join(codegen.Gendir, "http", "typescript", c.Paths[i]), f.Path)
+				s := f.SectionTemplates
+				require.Len(t, s, 2)
+				assert.Equal(t, "source-header", s[0].Name)
+				if i == 0 {
+					// The runtime module does not depend on the design.
+					continue
+				}
+				var buf bytes.Buffer
+				tmpl := template.Must(template.New("typescript").Funcs(codegen.TemplateFuncs()).Parse(s[1].Source))
+				require.NoError(t, tmpl.Execute(&buf, s[1].Data))
+				golden := filepath.Join("testdata", "golden", fmt.Sprintf("%s.ts.golden", c.Name))
+				if *update {
+					require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0644))
+				}
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				assert.Equal(t, string(bytes.ReplaceAll(want, []byte{'\r', '\n'}, []byte{'\n'})), buf.String())
+			}
+		})
+	}
+}
+
+func TestFilesNotEnabled(t *testing.T) {
+	root := httpgen.RunHTTPDSL(t, testdata.TypeScriptNotEnabledDSL)
+
+	files, err := typescript.Files(root)
+
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
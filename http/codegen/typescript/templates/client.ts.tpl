@@ -0,0 +1,103 @@
+import * as goa from "./goa";
+{{- range .Types }}
+
+{{ .Def }}
+{{- with .Codec }}
+
+{{ . }}
+{{- end }}
+{{- end }}
+{{- range .Errors }}
+
+{{ .Comment }}
+export class {{ .Class }} extends goa.ServiceError<{{ .Type }}> {
+	constructor(status: number, body: {{ .Type }}) {
+		super({{ printf "%q" .Name }}, status, body);
+	}
+}
+{{- end }}
+
+{{ .Comment }}
+export class Client {
+	private readonly http: goa.HTTPClient;
+
+	constructor(options: goa.ClientOptions) {
+		this.http = new goa.HTTPClient(options);
+	}
+{{- range $m := .Methods }}
+
+{{ .Comment }}
+{{- if .WebSocket }}
+	{{ .Name }}({{ .Params }}): goa.WebSocketStream<{{ .WebSocket.Send }}, {{ .WebSocket.Recv }}> {
+		return this.http.webSocket(
+			{{ .Path }},
+			[{{ template "params" .Query }}],
+			(v: {{ .WebSocket.Send }}) => {{ .WebSocket.Encode }},
+			(v: any) => {{ .WebSocket.Decode }},
+		);
+	}
+{{- else }}
+	{{ if .SSE }}async *{{ else }}async {{ end }}{{ .Name }}({{ .Params }}): {{ if .SSE }}AsyncGenerator{{ else }}Promise{{ end }}<{{ .Result }}> {
+		const resp = await this.http.send({
+			method: {{ printf "%q" .Verb }},
+			path: {{ .Path }},
+		{{- if .Query }}
+			query: [{{ template "params" .Query }}],
+		{{- end }}
+		{{- if .Headers }}
+			headers: [{{ template "params" .Headers }}],
+		{{- end }}
+		{{- if .Body }}
+			body: {{ .Body }},
+		{{- end }}
+		{{- if .RawBody }}
+			rawBody: {{ .RawBody }},
+		{{- end }}
+		{{- if .Accept }}
+			accept: {{ printf "%q" .Accept }},
+		{{- end }}
+		}, opts);
+		switch (resp.status) {
+	{{- range .Responses }}
+		case {{ .Status }}: {
+		{{- if and .ReadBody (not $m.SSE) }}
+			const body = await goa.readBody(resp);
+		{{- end }}
+		{{- if $m.SSE }}
+			yield* goa.events(resp, (body: any) => {{ .Value }});
+			return;
+		{{- else if .Value }}
+			return {{ .Value }};
+		{{- else }}
+			return;
+		{{- end }}
+		}
+	{{- end }}
+		}
+		const body = await goa.readBody(resp);
+	{{- if .Errors }}
+		switch (resp.status) {
+		{{- range .Errors }}
+		case {{ .Status }}:
+			{{- if eq (len .Errors) 1 }}
+			{{- with index .Errors 0 }}
+			throw new {{ .Class }}(resp.status, {{ .Value }});
+			{{- end }}
+			{{- else }}
+			switch (goa.header(resp, "goa-error")) {
+			{{- range .Errors }}
+			case {{ printf "%q" .Name }}:
+				throw new {{ .Class }}(resp.status, {{ .Value }});
+			{{- end }}
+			}
+			break;
+			{{- end }}
+		{{- end }}
+		}
+	{{- end }}
+		throw new goa.HTTPError(resp.status, body);
+	}
+{{- end }}
+{{- end }}
+}
+{{- define "params" }}{{ range $i, $p := . }}{{ if $i }}, {{ end }}{{ if .Name }}[{{ printf "%q" .Name }}, {{ .Value }}]{{ else }}...{{ .Value }}{{ end }}{{ end }}{{ end }}
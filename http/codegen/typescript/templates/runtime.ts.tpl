@@ -0,0 +1,398 @@
+// ClientOptions configures the service clients.
+export interface ClientOptions {
+	// baseURL is the URL of the server including the scheme, the host and
+	// the base path if any, e.g. "https://api.example.com".
+	baseURL: string;
+	// fetch is the function used to send the requests, defaults to the
+	// global fetch function.
+	fetch?: typeof fetch;
+	// headers lists the headers added to all the requests.
+	headers?: Record<string, string>;
+	// credentials is the credentials mode of the requests.
+	credentials?: RequestCredentials;
+	// webSocket opens the websocket connections used by the streaming
+	// methods, defaults to the global WebSocket constructor.
+	webSocket?: (url: string) => WebSocket;
+}
+
+// CallOptions configures a single method call.
+export interface CallOptions {
+	// signal aborts the request.
+	signal?: AbortSignal;
+	// headers lists additional request headers.
+	headers?: Record<string, string>;
+}
+
+// Params lists the names and values of request parameters or headers.
+// Undefined and null values are skipped and arrays produce one parameter per
+// element.
+export type Params = Array<[string, unknown]>;
+
+// RequestData describes a request sent by a service client.
+export interface RequestData {
+	method: string;
+	path: string;
+	query?: Params;
+	headers?: Params;
+	// body is the request body encoded in JSON.
+	body?: unknown;
+	// rawBody is the request body sent as is.
+	rawBody?: BodyInit;
+	accept?: string;
+}
+
+// RawResponse is the value returned by the methods whose response body is
+// not decoded by the client.
+export interface RawResponse<R> {
+	// result is the method result built from the response headers.
+	result: R;
+	// body is the response body.
+	body: ReadableStream<Uint8Array> | null;
+}
+
+// ErrorBody is the body of the error responses that use the default error
+// type.
+export interface ErrorBody {
+	// name is the name of the error.
+	name: string;
+	// id is the unique error instance identifier.
+	id: string;
+	// message describes the specific error occurrence.
+	message: string;
+	// temporary indicates whether the error is temporary.
+	temporary: boolean;
+	// timeout indicates whether the error is a timeout.
+	timeout: boolean;
+	// fault indicates whether the error is a server-side fault.
+	fault: boolean;
+}
+
+// ProblemDetails is the body of the error responses that use the default
+// error type when the service uses the RFC 9457 problem details format.
+export interface ProblemDetails {
+	// type is a URI reference that identifies the problem type.
+	type: string;
+	// title is a short summary of the problem type.
+	title: string;
+	// status is the HTTP status code of the response.
+	status: number;
+	// detail describes the specific error occurrence.
+	detail?: string;
+	// instance is a URI reference that identifies the specific
+	// occurrence of the problem.
+	instance?: string;
+	// name is the name of the error, set by the default formatter.
+	name?: string;
+	// id is the unique error instance identifier, set by the default
+	// formatter.
+	id?: string;
+	// temporary indicates whether the error is temporary, set by the
+	// default formatter.
+	temporary?: boolean;
+	// timeout indicates whether the error is a timeout, set by the
+	// default formatter.
+	timeout?: boolean;
+	// fault indicates whether the error is a server-side fault, set by
+	// the default formatter.
+	fault?: boolean;
+	// The other extension members.
+	[member: string]: unknown;
+}
+
+// HTTPError is thrown when the server returns a response that does not
+// correspond to any response defined in the design.
+export class HTTPError extends Error {
+	constructor(readonly status: number, readonly body: unknown) {
+		super(`unexpected HTTP response status ${status}`);
+		this.name = "HTTPError";
+	}
+}
+
+// ServiceError is the base class of the errors defined in the design.
+export class ServiceError<T = unknown> extends Error {
+	constructor(readonly errorName: string, readonly status: number, readonly body: T) {
+		super(errorMessage(errorName, body));
+		this.name = errorName;
+	}
+}
+
+// HTTPClient sends the requests of the service clients.
+export class HTTPClient {
+	constructor(readonly options: ClientOptions) {}
+
+	// url returns the URL of the given path and query parameters.
+	url(path: string, query?: Params): string {
+		let url = this.options.baseURL.replace(/\/+$/, "") + path;
+		if (query) {
+			const qs = new URLSearchParams();
+			appendParams(query, (k, v) => qs.append(k, v));
+			const s = qs.toString();
+			if (s !== "") {
+				url += "?" + s;
+			}
+		}
+		return url;
+	}
+
+	// send sends the given request and returns the response.
+	send(req: RequestData, opts?: CallOptions): Promise<Response> {
+		const headers = new Headers(this.options.headers);
+		for (const [k, v] of Object.entries(opts?.headers ?? {})) {
+			headers.set(k, v);
+		}
+		appendParams(req.headers ?? [], (k, v) => headers.append(k, v));
+		let body: BodyInit | undefined = req.rawBody;
+		if (req.body !== undefined) {
+			headers.set("Content-Type", "application/json");
+			body = JSON.stringify(req.body);
+		}
+		if (req.accept) {
+			headers.set("Accept", req.accept);
+		}
+		const f = this.options.fetch ?? fetch;
+		return f(this.url(req.path, req.query), {
+			method: req.method,
+			headers,
+			body,
+			credentials: this.options.credentials,
+			signal: opts?.signal,
+		});
+	}
+
+	// webSocket opens a websocket connection to the given path. Browsers do
+	// not support setting headers on websocket connections so the payload
+	// may only be sent in the path and query parameters.
+	webSocket<S, R>(path: string, query: Params, encode: (v: S) => unknown, decode: (v: any) => R): WebSocketStream<S, R> {
+		const url = this.url(path, query).replace(/^http/, "ws");
+		const ws = this.options.webSocket ? this.options.webSocket(url) : new WebSocket(url);
+		return new WebSocketStream(ws, encode, decode);
+	}
+}
+
+// WebSocketStream is the stream of a method that uses websockets. It sends
+// and receives messages encoded in JSON.
+export class WebSocketStream<S, R> implements AsyncIterable<R> {
+	private readonly opened: Promise<void>;
+	private readonly messages: R[] = [];
+	private readonly waiting: Array<{ resolve: (v: R | undefined) => void; reject: (err: unknown) => void }> = [];
+	private closed = false;
+	private error: unknown;
+
+	constructor(private readonly ws: WebSocket, private readonly encode: (v: S) => unknown, private readonly decode: (v: any) => R) {
+		this.opened = new Promise((resolve, reject) => {
+			ws.addEventListener("open", () => resolve());
+			ws.addEventListener("error", () => reject(new Error("websocket connection failed")));
+		});
+		this.opened.catch(() => undefined);
+		ws.addEventListener("message", (ev: MessageEvent) => {
+			let v: R;
+			try {
+				v = this.decode(JSON.parse(String(ev.data)));
+			} catch (err) {
+				this.fail(err);
+				return;
+			}
+			const w = this.waiting.shift();
+			if (w) {
+				w.resolve(v);
+			} else {
+				this.messages.push(v);
+			}
+		});
+		ws.addEventListener("close", (ev: CloseEvent) => {
+			if (ev.code !== 1000 && ev.code !== 1005) {
+				this.fail(new Error(`websocket closed with code ${ev.code}: ${ev.reason}`));
+				return;
+			}
+			this.closed = true;
+			for (const w of this.waiting.splice(0)) {
+				w.resolve(undefined);
+			}
+		});
+		ws.addEventListener("error", () => this.fail(new Error("websocket error")));
+	}
+
+	// send sends the given message to the server.
+	async send(v: S): Promise<void> {
+		await this.opened;
+		this.ws.send(JSON.stringify(this.encode(v)));
+	}
+
+	// recv returns the next message sent by the server, undefined once the
+	// server closed the stream.
+	recv(): Promise<R | undefined> {
+		if (this.messages.length > 0) {
+			return Promise.resolve(this.messages.shift());
+		}
+		if (this.error !== undefined) {
+			return Promise.reject(this.error);
+		}
+		if (this.closed) {
+			return Promise.resolve(undefined);
+		}
+		return new Promise((resolve, reject) => this.waiting.push({ resolve, reject }));
+	}
+
+	// closeAndRecv notifies the server that the client is done sending
+	// messages, returns the message sent by the server in response and
+	// closes the connection.
+	async closeAndRecv(): Promise<R | undefined> {
+		await this.opened;
+		this.ws.send("null");
+		try {
+			return await this.recv();
+		} finally {
+			this.close();
+		}
+	}
+
+	// close closes the connection.
+	close(): void {
+		this.ws.close(1000);
+	}
+
+	async *[Symbol.asyncIterator](): AsyncGenerator<R> {
+		for (;;) {
+			const v = await this.recv();
+			if (v === undefined) {
+				return;
+			}
+			yield v;
+		}
+	}
+
+	private fail(err: unknown): void {
+		if (this.error !== undefined || this.closed) {
+			return;
+		}
+		this.error = err;
+		for (const w of this.waiting.splice(0)) {
+			w.reject(err);
+		}
+	}
+}
+
+// events returns the server-sent events of the given response decoded with
+// decode. Each event data must be a JSON document.
+export async function* events<R>(resp: Response, decode: (v: any) => R): AsyncGenerator<R> {
+	if (!resp.body) {
+		return;
+	}
+	const reader = resp.body.pipeThrough(new TextDecoderStream()).getReader();
+	let buf = "";
+	let data: string[] = [];
+	for (;;) {
+		const { value, done } = await reader.read();
+		if (done) {
+			return;
+		}
+		buf += value;
+		let i: number;
+		while ((i = buf.search(/\r\n|\r|\n/)) >= 0) {
+			const line = buf.slice(0, i);
+			buf = buf.slice(buf.startsWith("\r\n", i) ? i + 2 : i + 1);
+			if (line === "") {
+				if (data.length > 0) {
+					yield decode(JSON.parse(data.join("\n")));
+					data = [];
+				}
+			} else if (line.startsWith("data:")) {
+				data.push(line.slice(5).replace(/^ /, ""));
+			}
+		}
+	}
+}
+
+// readBody returns the body of the given response decoded from JSON if
+// possible, the body text otherwise and undefined if the body is empty.
+export async function readBody(resp: Response): Promise<any> {
+	const text = await resp.text();
+	if (text === "") {
+		return undefined;
+	}
+	try {
+		return JSON.parse(text);
+	} catch {
+		return text;
+	}
+}
+
+// header returns the value of the response header with the given name if
+// any.
+export function header(resp: Response, name: string): string | undefined {
+	return resp.headers.get(name) ?? undefined;
+}
+
+// parseNumber parses the given header value as a number.
+export function parseNumber(v: string | undefined): number | undefined {
+	return v === undefined ? undefined : Number(v);
+}
+
+// parseBoolean parses the given header value as a boolean.
+export function parseBoolean(v: string | undefined): boolean | undefined {
+	return v === undefined ? undefined : v === "true";
+}
+
+// parseList parses the given comma separated header value.
+export function parseList<T>(v: string | undefined, parse: (s: string) => T | undefined): T[] | undefined {
+	return v === undefined ? undefined : v.split(",").map((s) => parse(s.trim()) as T);
+}
+
+// pathParam encodes the given path parameter value.
+export function pathParam(v: unknown): string {
+	if (Array.isArray(v)) {
+		return v.map((e) => encodeURIComponent(String(e))).join(",");
+	}
+	return encodeURIComponent(String(v));
+}
+
+// pathWildcard encodes the given path wildcard value, slashes are kept.
+export function pathWildcard(v: unknown): string {
+	return String(v).split("/").map(encodeURIComponent).join("/");
+}
+
+// bearer returns the value of the Authorization header for the given token,
+// the token is returned as is if it already contains the scheme.
+export function bearer(token: string | undefined): string | undefined {
+	if (token === undefined || token.includes(" ")) {
+		return token;
+	}
+	return "Bearer " + token;
+}
+
+// basicAuth returns the value of the Authorization header for the given
+// credentials.
+export function basicAuth(user: string | undefined, pass: string | undefined): string | undefined {
+	if (user === undefined && pass === undefined) {
+		return undefined;
+	}
+	return "Basic " + btoa(`${user ?? ""}:${pass ?? ""}`);
+}
+
+function appendParams(params: Params, append: (k: string, v: string) => void): void {
+	for (const [k, v] of params) {
+		if (v === undefined || v === null) {
+			continue;
+		}
+		if (Array.isArray(v)) {
+			for (const e of v) {
+				append(k, String(e));
+			}
+		} else {
+			append(k, String(v));
+		}
+	}
+}
+
+function errorMessage(name: string, body: unknown): string {
+	if (body !== null && typeof body === "object") {
+		const { message, detail } = body as { message?: unknown; detail?: unknown };
+		if (typeof message === "string") {
+			return message;
+		}
+		if (typeof detail === "string") {
+			return detail;
+		}
+	}
+	return name;
+}
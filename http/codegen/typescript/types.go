@@ -0,0 +1,406 @@
+package typescript
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// typeScope computes the TypeScript declarations of the design types
+	// used by the client of a service.
+	typeScope struct {
+		// scope makes sure the declared names are unique.
+		scope *codegen.NameScope
+		// decls lists the declarations in the order they were created.
+		decls []*typeDecl
+		// declared indexes the declarations by type hash.
+		declared map[string]*typeDecl
+		// conv caches whether values of a type must be converted to and
+		// from their JSON representation indexed by type hash.
+		conv map[string]bool
+		// problemDetails is true if the service error responses use the
+		// RFC 9457 problem details format.
+		problemDetails bool
+	}
+
+	// typeDecl is the declaration of a TypeScript type.
+	typeDecl struct {
+		// Name is the TypeScript type name.
+		Name string
+		// Def is the TypeScript code declaring the type.
+		Def string
+		// Codec is the TypeScript code of the functions that convert
+		// values of the type to and from their JSON representation if
+		// needed.
+		Codec string
+		// att is the attribute describing the type.
+		att *expr.AttributeExpr
+	}
+)
+
+// reserved lists the names that cannot be used for the declared types as
+// they are used by the generated code or are global TypeScript types.
+var reserved = []string{
+	"goa", "Client", "Array", "Date", "Error", "Headers", "Map", "Object",
+	"Promise", "Record", "Request", "Response", "Set", "URL", "WebSocket",
+}
+
+// identRegex matches valid JavaScript identifiers.
+var identRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// newTypeScope returns an empty type scope. problemDetails is true if the
+// error responses of the service use the RFC 9457 problem details format.
+func newTypeScope(problemDetails bool) *typeScope {
+	scope := codegen.NewNameScope()
+	for _, n := range reserved {
+		scope.Unique(n)
+	}
+	return &typeScope{
+		scope:          scope,
+		declared:       make(map[string]*typeDecl),
+		conv:           make(map[string]bool),
+		problemDetails: problemDetails,
+	}
+}
+
+// Ref returns the TypeScript type of the given attribute. It declares the
+// user types used by the attribute as needed. prefix is used to name the
+// anonymous union types.
+func (ts *typeScope) Ref(att *expr.AttributeExpr, prefix string) string {
+	switch dt := att.Type.(type) {
+	case expr.Primitive:
+		if lits := literals(att); lits != "" {
+			return lits
+		}
+		return primitive(dt)
+	case *expr.Array:
+		elem := ts.Ref(dt.ElemType, prefix)
+		if strings.Contains(elem, " | ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case *expr.Map:
+		return "Record<string, " + ts.Ref(dt.ElemType, prefix) + ">"
+	case *expr.Object:
+		return ts.object(att, prefix, "")
+	case *expr.Union:
+		return ts.union(dt, prefix)
+	case expr.UserType:
+		if dt == expr.ErrorResult {
+			if ts.problemDetails {
+				return "goa.ProblemDetails"
+			}
+			return "goa.ErrorBody"
+		}
+		return ts.declare(dt).Name
+	default:
+		panic(fmt.Sprintf("unknown data type %T", dt)) // bug
+	}
+}
+
+// Name returns the TypeScript type of the given attribute like Ref but
+// declares anonymous object types using the given name.
+func (ts *typeScope) Name(att *expr.AttributeExpr, name string) string {
+	if _, ok := att.Type.(*expr.Object); ok {
+		return ts.declare(&expr.UserTypeExpr{AttributeExpr: att, TypeName: name}).Name
+	}
+	return ts.Ref(att, name)
+}
+
+// Decls returns the type declarations in the order they were created.
+func (ts *typeScope) Decls() []*typeDecl {
+	return ts.decls
+}
+
+// Encode returns the TypeScript expression that converts the value v of the
+// given type to its JSON representation, v itself if no conversion is needed.
+func (ts *typeScope) Encode(att *expr.AttributeExpr, v string) string {
+	return ts.convert(att, v, true, 0)
+}
+
+// Decode returns the TypeScript expression that converts the JSON
+// representation v of the given type to a value, v itself if no conversion is
+// needed.
+func (ts *typeScope) Decode(att *expr.AttributeExpr, v string) string {
+	return ts.convert(att, v, false, 0)
+}
+
+// declare declares the given user type if not already declared and returns
+// its declaration.
+func (ts *typeScope) declare(ut expr.UserType) *typeDecl {
+	if d, ok := ts.declared[ut.Hash()]; ok {
+		return d
+	}
+	name := ts.scope.HashedUnique(ut, codegen.Goify(ut.Name(), true))
+	d := &typeDecl{Name: name, att: ut.Attribute()}
+	ts.declared[ut.Hash()] = d
+	ts.decls = append(ts.decls, d)
+	att := ut.Attribute()
+	var def strings.Builder
+	if att.Description != "" {
+		def.WriteString(codegen.Comment(att.Description) + "\n")
+	}
+	switch dt := att.Type.(type) {
+	case *expr.Object:
+		def.WriteString("export interface " + name + " " + ts.object(att, name, ""))
+	case *expr.Union:
+		def.WriteString("export type " + name + " =")
+		for _, nat := range dt.Values {
+			def.WriteString(fmt.Sprintf("\n\t| { Type: %q; Value: %s }", nat.Name, ts.Ref(nat.Attribute, name)))
+		}
+		def.WriteString(";")
+	default:
+		def.WriteString("export type " + name + " = " + ts.Ref(att, name) + ";")
+	}
+	d.Def = def.String()
+	if ts.needsConversion(ut, make(map[string]bool)) {
+		d.Codec = ts.codec(d, att)
+	}
+	return d
+}
+
+// object returns the TypeScript definition of the given object attribute.
+// indent is the indentation of the enclosing definition.
+func (ts *typeScope) object(att *expr.AttributeExpr, prefix, indent string) string {
+	obj := expr.AsObject(att.Type)
+	if len(*obj) == 0 {
+		return "{}"
+	}
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, nat := range *obj {
+		if nat.Attribute.Description != "" {
+			for _, l := range strings.Split(codegen.Comment(nat.Attribute.Description), "\n") {
+				b.WriteString(indent + "\t" + l + "\n")
+			}
+		}
+		opt := "?"
+		if att.IsRequired(nat.Name) {
+			opt = ""
+		}
+		var ref string
+		if expr.IsObject(nat.Attribute.Type) && !isUserType(nat.Attribute.Type) {
+			ref = ts.object(nat.Attribute, prefix+codegen.Goify(nat.Name, true), indent+"\t")
+		} else {
+			ref = ts.Ref(nat.Attribute, prefix+codegen.Goify(nat.Name, true))
+		}
+		b.WriteString(fmt.Sprintf("%s\t%s%s: %s;\n", indent, PropertyKey(JSONName(nat)), opt, ref))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// union declares the given anonymous union type and returns its name. The
+// name is the given prefix if any, the union type name otherwise.
+func (ts *typeScope) union(u *expr.Union, prefix string) string {
+	if d, ok := ts.declared[u.Hash()]; ok {
+		return d.Name
+	}
+	if prefix == "" {
+		prefix = codegen.Goify(u.TypeName, true)
+	}
+	ut := &expr.UserTypeExpr{
+		AttributeExpr: &expr.AttributeExpr{Type: u},
+		TypeName:      prefix,
+	}
+	d := ts.declare(ut)
+	ts.declared[u.Hash()] = d
+	return d.Name
+}
+
+// codec returns the TypeScript functions that convert the values of the
+// declared type to and from their JSON representation.
+func (ts *typeScope) codec(d *typeDecl, att *expr.AttributeExpr) string {
+	var b strings.Builder
+	for _, enc := range []bool{true, false} {
+		if !enc {
+			b.WriteString("\n")
+		}
+		fn, in, out := "decode", "any", d.Name
+		if enc {
+			fn, in, out = "encode", d.Name, "any"
+		}
+		b.WriteString(fmt.Sprintf("export function %s%s(v: %s): %s {\n", fn, d.Name, in, out))
+		if u, ok := att.Type.(*expr.Union); ok {
+			b.WriteString("\tswitch (v.Type) {\n")
+			for _, nat := range u.Values {
+				b.WriteString(fmt.Sprintf("\tcase %q:\n", nat.Name))
+				if enc {
+					b.WriteString(fmt.Sprintf("\t\treturn { Type: v.Type, Value: JSON.stringify(%s) };\n", ts.convert(nat.Attribute, "v.Value", true, 1)))
+				} else {
+					b.WriteString(fmt.Sprintf("\t\treturn { Type: v.Type, Value: %s };\n", ts.convert(nat.Attribute, "JSON.parse(v.Value)", false, 1)))
+				}
+			}
+			b.WriteString("\t}\n")
+			if enc {
+				b.WriteString("\tthrow new Error(`unknown union type ${(v as any).Type}`);\n")
+			} else {
+				b.WriteString("\tthrow new Error(`unknown union type ${v.Type}`);\n")
+			}
+		} else {
+			b.WriteString("\treturn " + ts.convertType(att, "v", enc, 1) + ";\n")
+		}
+		b.WriteString("}\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// convert returns the TypeScript expression that converts v, see Encode and
+// Decode. depth is used to compute unique variable names.
+func (ts *typeScope) convert(att *expr.AttributeExpr, v string, enc bool, depth int) string {
+	if !ts.needsConversion(att.Type, make(map[string]bool)) {
+		return v
+	}
+	if ut, ok := att.Type.(expr.UserType); ok {
+		fn := "decode"
+		if enc {
+			fn = "encode"
+		}
+		return fn + ts.declare(ut).Name + "(" + v + ")"
+	}
+	if u, ok := att.Type.(*expr.Union); ok {
+		fn := "decode"
+		if enc {
+			fn = "encode"
+		}
+		return fn + ts.union(u, "") + "(" + v + ")"
+	}
+	return ts.convertType(att, v, enc, depth)
+}
+
+// convertType returns the TypeScript expression that converts v whose type is
+// not a user type.
+func (ts *typeScope) convertType(att *expr.AttributeExpr, v string, enc bool, depth int) string {
+	e := fmt.Sprintf("e%d", depth)
+	switch dt := att.Type.(type) {
+	case *expr.Array:
+		return fmt.Sprintf("%s.map((%s: any) => %s)", v, e, ts.convert(dt.ElemType, e, enc, depth+1))
+	case *expr.Map:
+		return fmt.Sprintf("Object.fromEntries(Object.entries(%s).map(([k%d, %s]: [string, any]) => [k%d, %s]))",
+			v, depth, e, depth, ts.convert(dt.ElemType, e, enc, depth+1))
+	case *expr.Object:
+		var fields []string
+		for _, nat := range *dt {
+			if !ts.needsConversion(nat.Attribute.Type, make(map[string]bool)) {
+				continue
+			}
+			key := PropertyKey(JSONName(nat))
+			field := Access(v, JSONName(nat))
+			fields = append(fields, fmt.Sprintf("%s: %s == null ? %s : %s", key, field, field, ts.convert(nat.Attribute, field, enc, depth+1)))
+		}
+		return fmt.Sprintf("{ ...%s, %s }", v, strings.Join(fields, ", "))
+	default:
+		return v
+	}
+}
+
+// needsConversion returns true if the values of the given type must be
+// converted to and from their JSON representation, that is if the type
+// contains union types.
+func (ts *typeScope) needsConversion(dt expr.DataType, seen map[string]bool) bool {
+	if c, ok := ts.conv[dt.Hash()]; ok {
+		return c
+	}
+	if seen[dt.Hash()] {
+		return false
+	}
+	seen[dt.Hash()] = true
+	var res bool
+	switch t := dt.(type) {
+	case *expr.Union:
+		res = true
+	case *expr.Array:
+		res = ts.needsConversion(t.ElemType.Type, seen)
+	case *expr.Map:
+		res = ts.needsConversion(t.ElemType.Type, seen)
+	case *expr.Object:
+		for _, nat := range *t {
+			if ts.needsConversion(nat.Attribute.Type, seen) {
+				res = true
+				break
+			}
+		}
+	case expr.UserType:
+		res = ts.needsConversion(t.Attribute().Type, seen)
+	}
+	if _, ok := dt.(expr.UserType); ok || res {
+		ts.conv[dt.Hash()] = res
+	}
+	return res
+}
+
+// JSONName returns the name of the JSON object field that holds the value of
+// the given attribute.
+func JSONName(nat *expr.NamedAttributeExpr) string {
+	if tags, ok := nat.Attribute.Meta["struct:tag:json"]; ok && len(tags) > 0 {
+		if n := strings.Split(tags[0], ",")[0]; n != "" && n != "-" {
+			return n
+		}
+	}
+	return nat.Name
+}
+
+// PropertyKey returns the TypeScript property key for the given name, quoted
+// if not a valid identifier.
+func PropertyKey(name string) string {
+	if identRegex.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// Access returns the TypeScript expression that accesses the property with
+// the given name of v.
+func Access(v, name string) string {
+	if identRegex.MatchString(name) {
+		return v + "." + name
+	}
+	return fmt.Sprintf("%s[%q]", v, name)
+}
+
+// primitive returns the TypeScript type of the given primitive type.
+func primitive(p expr.Primitive) string {
+	switch p.Kind() {
+	case expr.BooleanKind:
+		return "boolean"
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind,
+		expr.UInt32Kind, expr.UInt64Kind, expr.Float32Kind, expr.Float64Kind,
+		expr.DurationKind:
+		return "number"
+	case expr.StringKind, expr.BytesKind, expr.DateTimeKind, expr.DecimalKind:
+		return "string"
+	default:
+		return "unknown"
+	}
+}
+
+// literals returns the TypeScript union of literal types corresponding to
+// the enum validation of the given attribute if any, an empty string
+// otherwise.
+func literals(att *expr.AttributeExpr) string {
+	if att.Validation == nil || len(att.Validation.Values) == 0 {
+		return ""
+	}
+	lits := make([]string, len(att.Validation.Values))
+	for i, v := range att.Validation.Values {
+		switch val := v.(type) {
+		case string:
+			lits[i] = fmt.Sprintf("%q", val)
+		case bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+			lits[i] = fmt.Sprintf("%v", val)
+		default:
+			return ""
+		}
+	}
+	return strings.Join(lits, " | ")
+}
+
+// isUserType returns true if dt is a user type.
+func isUserType(dt expr.DataType) bool {
+	_, ok := dt.(expr.UserType)
+	return ok
+}
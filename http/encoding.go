@@ -3,9 +3,7 @@ package http
 import (
 	"bytes"
 	"context"
-	"encoding/gob"
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"mime"
@@ -51,16 +49,18 @@ type (
 )
 
 // RequestDecoder returns a HTTP request body decoder suitable for the given
-// request. The decoder handles the following mime types:
+// request. The decoder is created using the constructor registered for the
+// request "Content-Type" header mime type, see RegisterDecoder. The following
+// mime types are registered by default:
 //
-//   - application/json using package encoding/json
-//   - application/xml using package encoding/xml
-//   - application/gob using package encoding/gob
-//   - text/html and text/plain for strings
+//   - application/json and */*+json using package encoding/json
+//   - application/xml and */*+xml using package encoding/xml
+//   - application/gob and */*+gob using package encoding/gob
+//   - text/html, text/plain, */*+html and */*+txt for strings
 //
 // RequestDecoder defaults to the JSON decoder if the request "Content-Type"
-// header does not match any of the supported mime type or is missing
-// altogether.
+// header is missing. It returns a decoder that fails with an unsupported
+// media type error if the header does not match any registered mime type.
 func RequestDecoder(r *http.Request) Decoder {
 	contentType := r.Header.Get("Content-Type")
 	if contentType == "" {
@@ -72,47 +72,29 @@ func RequestDecoder(r *http.Request) Decoder {
 			contentType = mediaType
 		}
 	}
-	switch contentType {
-	case "application/json":
-		return json.NewDecoder(r.Body)
-	case "application/gob":
-		return gob.NewDecoder(r.Body)
-	case "application/xml":
-		return xml.NewDecoder(r.Body)
-	case "text/html", "text/plain":
-		return newTextDecoder(r.Body, contentType)
-	default:
-		return newUnsupportedDecoder(contentType)
+	if fn := codecs.decoder(contentType); fn != nil {
+		return fn(r.Body, contentType)
 	}
+	return newUnsupportedDecoder(contentType)
 }
 
 // ResponseEncoder returns a HTTP response encoder leveraging the mime type
-// set in the context under the AcceptTypeKey or the ContentTypeKey if any.
-// The encoder supports the following mime types:
+// set in the context under the ContentTypeKey if any or negotiated from the
+// request Accept header value stored under the AcceptTypeKey otherwise. The
+// negotiation takes into account the media ranges quality values and
+// wildcards. The encoder is created using the constructor registered for the
+// resulting mime type, see RegisterEncoder. The following mime types are
+// registered by default:
 //
-//   - application/json using package encoding/json
-//   - application/xml using package encoding/xml
-//   - application/gob using package encoding/gob
-//   - text/html and text/plain for strings
+//   - application/json and */*+json using package encoding/json
+//   - application/xml and */*+xml using package encoding/xml
+//   - application/gob and */*+gob using package encoding/gob
+//   - text/html, text/plain, */*+html and */*+txt for strings
 //
 // ResponseEncoder defaults to the JSON encoder if the context AcceptTypeKey or
-// ContentTypeKey value does not match any of the supported mime types or is
+// ContentTypeKey value does not match any of the registered mime types or is
 // missing altogether.
 func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
-	negotiate := func(a string) (Encoder, string) {
-		switch a {
-		case "", "application/json":
-			// default to JSON
-			return json.NewEncoder(w), "application/json"
-		case "application/xml":
-			return xml.NewEncoder(w), "application/xml"
-		case "application/gob":
-			return gob.NewEncoder(w), "application/gob"
-		case "text/html", "text/plain":
-			return newTextEncoder(w, a), a
-		}
-		return nil, ""
-	}
 	var accept string
 	{
 		if a := ctx.Value(AcceptTypeKey); a != nil {
@@ -126,46 +108,35 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 		}
 	}
 	var (
-		enc Encoder
-		mt  string
-		err error
+		fn NewEncoderFunc
+		mt string
 	)
 	{
 		if ct != "" {
 			// If content type explicitly set in the DSL, infer the response encoder
 			// from the content type context key.
+			var err error
 			if mt, _, err = mime.ParseMediaType(ct); err == nil {
-				switch {
-				case mt == "application/json" || strings.HasSuffix(mt, "+json"):
-					enc = json.NewEncoder(w)
-				case mt == "application/xml" || strings.HasSuffix(mt, "+xml"):
-					enc = xml.NewEncoder(w)
-				case mt == "application/gob" || strings.HasSuffix(mt, "+gob"):
-					enc = gob.NewEncoder(w)
-				case mt == "text/html" || mt == "text/plain" ||
-					strings.HasSuffix(mt, "+html") || strings.HasSuffix(mt, "+txt"):
-					enc = newTextEncoder(w, mt)
-				default:
-					enc = json.NewEncoder(w)
+				if fn = codecs.encoder(mt); fn == nil {
+					fn = codecs.encoder("application/json")
 				}
 			}
 			SetContentType(w, mt)
-			return enc
+			if fn == nil {
+				return nil
+			}
+			return fn(w, mt)
 		}
 		// If Accept header exists in the request, infer the response encoder
 		// from the header value.
-		if enc, mt = negotiate(accept); enc == nil {
-			// attempt to normalize
-			if mt, _, err = mime.ParseMediaType(accept); err == nil {
-				enc, mt = negotiate(mt)
-			}
-		}
-		if enc == nil {
-			enc, mt = negotiate("")
+		if fn, mt = codecs.negotiate(accept); fn == nil {
+			// default to JSON
+			mt = "application/json"
+			fn = codecs.encoder(mt)
 		}
 	}
 	SetContentType(w, mt)
-	return enc
+	return fn(w, mt)
 }
 
 // RequestEncoder returns a HTTP request encoder.
@@ -180,13 +151,18 @@ func RequestEncoder(r *http.Request) Encoder {
 	return json.NewEncoder(&buf)
 }
 
-// ResponseDecoder returns a HTTP response decoder.
-// The decoder handles the following content types:
+// ResponseDecoder returns a HTTP response decoder. The decoder is created
+// using the constructor registered for the response "Content-Type" header mime
+// type, see RegisterDecoder. The following mime types are registered by
+// default:
 //
-//   - application/json using package encoding/json (default)
-//   - application/xml using package encoding/xml
-//   - application/gob using package encoding/gob
-//   - text/html and text/plain for strings
+//   - application/json and */*+json using package encoding/json (default)
+//   - application/xml and */*+xml using package encoding/xml
+//   - application/gob and */*+gob using package encoding/gob
+//   - text/html, text/plain, */*+html and */*+txt for strings
+//
+// ResponseDecoder defaults to the JSON decoder if the header is missing or
+// does not match any registered mime type.
 func ResponseDecoder(resp *http.Response) Decoder {
 	ct := resp.Header.Get("Content-Type")
 	if ct == "" {
@@ -195,19 +171,10 @@ func ResponseDecoder(resp *http.Response) Decoder {
 	if mediaType, _, err := mime.ParseMediaType(ct); err == nil {
 		ct = mediaType
 	}
-	switch {
-	case ct == "application/json" || strings.HasSuffix(ct, "+json"):
-		return json.NewDecoder(resp.Body)
-	case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
-		return xml.NewDecoder(resp.Body)
-	case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
-		return gob.NewDecoder(resp.Body)
-	case ct == "text/html" || ct == "text/plain" ||
-		strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
-		return newTextDecoder(resp.Body, ct)
-	default:
-		return json.NewDecoder(resp.Body)
+	if fn := codecs.decoder(ct); fn != nil {
+		return fn(resp.Body, ct)
 	}
+	return json.NewDecoder(resp.Body)
 }
 
 // ErrorEncoder returns an encoder that encodes errors returned by service
@@ -224,11 +191,25 @@ func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, fo
 			formatter = NewErrorResponse
 		}
 		resp := formatter(ctx, err)
+		if _, ok := resp.(*ProblemDetails); ok {
+			SetProblemContentType(w)
+		}
+		setRateLimitHeaders(w, err)
 		w.WriteHeader(resp.StatusCode())
 		return enc.Encode(resp)
 	}
 }
 
+// ProblemErrorEncoder is similar to ErrorEncoder but encodes errors using the
+// RFC 9457 problem details format (see NewProblemDetails) when formatter is
+// nil.
+func ProblemErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
+	if formatter == nil {
+		formatter = NewProblemDetails
+	}
+	return ErrorEncoder(encoder, formatter)
+}
+
 // Decode implements the Decoder interface. It simply calls f(v).
 func (f EncodingFunc) Decode(v any) error { return f(v) }
 
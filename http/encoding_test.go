@@ -3,6 +3,8 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -10,11 +12,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/ratelimit"
 )
 
 var (
@@ -196,6 +200,61 @@ func TestResponseEncoder_Encode_ErrorResponse(t *testing.T) {
 	}
 }
 
+func TestErrorEncoder_RateLimit(t *testing.T) {
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json")
+	w := httptest.NewRecorder()
+	err := goa.NewServiceError(&ratelimit.ExceededError{
+		Key:    "svc",
+		Limit:  ratelimit.Limit{Requests: 10, Period: time.Minute},
+		Result: &ratelimit.Result{RetryAfter: 1500 * time.Millisecond},
+	}, goa.RateLimitExceeded, false, true, false)
+
+	require.NoError(t, ErrorEncoder(ResponseEncoder, nil)(ctx, w, err))
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, "10", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Reset"))
+}
+
+func TestErrorEncoder_Auth(t *testing.T) {
+	cases := map[string]struct {
+		Name string
+		Code int
+	}{
+		"unauthenticated":    {goa.Unauthenticated, http.StatusUnauthorized},
+		"insufficient scope": {goa.InsufficientScope, http.StatusForbidden},
+	}
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json")
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			require.NoError(t, ErrorEncoder(ResponseEncoder, nil)(ctx, w, goa.PermanentError(c.Name, "denied")))
+			assert.Equal(t, c.Code, w.Code)
+		})
+	}
+}
+
+func TestPeerCertificates(t *testing.T) {
+	leaf, ca := &x509.Certificate{Raw: []byte("leaf")}, &x509.Certificate{Raw: []byte("ca")}
+	cases := map[string]struct {
+		TLS  *tls.ConnectionState
+		Want []*x509.Certificate
+	}{
+		"no TLS":     {nil, nil},
+		"unverified": {&tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}, nil},
+		"verified":   {&tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}, VerifiedChains: [][]*x509.Certificate{{leaf, ca}}}, []*x509.Certificate{leaf, ca}},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.TLS = c.TLS
+			assert.Equal(t, c.Want, PeerCertificates(r))
+		})
+	}
+}
+
 func TestResponseDecoder(t *testing.T) {
 	cases := []struct {
 		contentType string
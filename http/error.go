@@ -2,9 +2,13 @@ package http
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/xml"
 	"errors"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
 	goa "goa.design/goa/v3/pkg"
 )
@@ -28,6 +32,12 @@ type (
 		Fault bool `json:"fault" xml:"fault" form:"fault"`
 	}
 
+	// rateLimiter is implemented by errors that describe an exhausted rate
+	// limit quota, see package ratelimit.
+	rateLimiter interface {
+		RateLimit() (limit, remaining int, retryAfter time.Duration)
+	}
+
 	// Statuser is implemented by error response object to provide the response
 	// HTTP status code.
 	Statuser interface {
@@ -86,6 +96,18 @@ func (resp *ErrorResponse) StatusCode() int {
 	if resp.Name == goa.UnsupportedMediaType {
 		return http.StatusUnsupportedMediaType
 	}
+	if resp.Name == goa.RateLimitExceeded {
+		return http.StatusTooManyRequests
+	}
+	if resp.Name == goa.Unauthenticated {
+		return http.StatusUnauthorized
+	}
+	if resp.Name == goa.InsufficientScope {
+		return http.StatusForbidden
+	}
+	if resp.Name == goa.PreconditionFailed {
+		return http.StatusPreconditionFailed
+	}
 	if resp.Fault {
 		return http.StatusInternalServerError
 	}
@@ -100,3 +122,40 @@ func (resp *ErrorResponse) StatusCode() int {
 	}
 	return http.StatusBadRequest
 }
+
+// ClientIP returns the IP address of the client that sent the request as
+// reported by the request RemoteAddr field. Headers such as X-Forwarded-For
+// are not taken into account as they may be set by the client.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// PeerCertificates returns the client certificate chain verified by the
+// server during the TLS handshake, leaf certificate first. It returns nil if
+// the request was not received over TLS or if the server did not verify any
+// client certificate, see tls.Config.ClientAuth.
+func PeerCertificates(r *http.Request) []*x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return nil
+	}
+	return r.TLS.VerifiedChains[0]
+}
+
+// setRateLimitHeaders sets the Retry-After and X-RateLimit-* response headers
+// if err describes an exhausted rate limit quota.
+func setRateLimitHeaders(w http.ResponseWriter, err error) {
+	var rl rateLimiter
+	if !errors.As(err, &rl) {
+		return
+	}
+	limit, remaining, retryAfter := rl.RateLimit()
+	secs := strconv.Itoa(int((retryAfter + time.Second - 1) / time.Second))
+	w.Header().Set("Retry-After", secs)
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", secs)
+}
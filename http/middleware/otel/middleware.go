@@ -0,0 +1,80 @@
+package otel
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"goa.design/goa/v3/middleware/otel"
+)
+
+// statusWriter is a http.ResponseWriter that records the response status
+// code.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// New returns a HTTP server middleware that creates a span for each request
+// and records the RED metrics. The trace context is read from the W3C
+// traceparent and tracestate request headers by default.
+//
+// The spans are named after the HTTP method until the Goa service and method
+// are known, mount the otel.Endpoint middleware on the service endpoints to
+// name the spans and label the metrics after the Goa service and method.
+// Requests that result in a 5xx response are counted as errors.
+//
+// Example:
+//
+//	handler = otel.New()(handler)
+//
+//	// with explicit providers
+//	handler = otel.New(
+//	    otel.WithTracerProvider(tp),
+//	    otel.WithMeterProvider(mp))(handler)
+func New(opts ...otel.Option) func(http.Handler) http.Handler {
+	rec := otel.NewRecorder(trace.SpanKindServer, opts...)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := rec.Propagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, call := rec.Start(ctx, r.Method, semconv.HTTPRequestMethodKey.String(r.Method))
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(sw, r.WithContext(ctx))
+			call.End(sw.status >= http.StatusInternalServerError, nil, semconv.HTTPResponseStatusCode(sw.status))
+		})
+	}
+}
+
+// WriteHeader records the status code before writing it.
+func (w *statusWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush implements the http.Flusher interface if the underlying response
+// writer supports it.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface if the underlying response
+// writer supports it.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", w.ResponseWriter)
+}
+
+// Unwrap returns the underlying response writer, it is used by
+// http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,129 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"goa.design/goa/v3/middleware/otel"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestNew(t *testing.T) {
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+	cases := map[string]struct {
+		Status      int
+		TraceParent string
+		Endpoint    bool
+		Name        string
+		Failed      bool
+	}{
+		"basic":        {Status: http.StatusOK, Name: "GET"},
+		"endpoint":     {Status: http.StatusOK, Endpoint: true, Name: "svc/meth"},
+		"client-error": {Status: http.StatusNotFound, Name: "GET"},
+		"server-error": {Status: http.StatusInternalServerError, Name: "GET", Failed: true},
+		"traceparent":  {Status: http.StatusOK, TraceParent: "00-" + traceID + "-" + parentID + "-01", Name: "GET"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if c.Endpoint {
+					ctx := context.WithValue(r.Context(), goa.ServiceKey, "svc")
+					ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+					otel.Endpoint(func(context.Context, any) (any, error) { return nil, nil })(ctx, nil) // nolint: errcheck
+				}
+				w.WriteHeader(c.Status)
+			})
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.TraceParent != "" {
+				req.Header.Set("traceparent", c.TraceParent)
+			}
+
+			New(otel.WithTracerProvider(tp))(h).ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, c.Name, span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			if c.Failed {
+				assert.Equal(t, codes.Error, span.Status().Code)
+			} else {
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			}
+			attrs := attribute.NewSet(span.Attributes()...)
+			v, _ := attrs.Value(semconv.HTTPResponseStatusCodeKey)
+			assert.Equal(t, int64(c.Status), v.AsInt64())
+			if c.TraceParent != "" {
+				assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+				assert.Equal(t, parentID, span.Parent().SpanID().String())
+				assert.True(t, span.Parent().IsRemote())
+			} else {
+				assert.False(t, span.Parent().IsValid())
+			}
+		})
+	}
+}
+
+func TestWrapDoer(t *testing.T) {
+	cases := map[string]struct {
+		Status int
+		Err    error
+		Failed bool
+	}{
+		"ok":           {Status: http.StatusOK},
+		"client-error": {Status: http.StatusBadRequest, Failed: true},
+		"transport":    {Err: errors.New("connection refused"), Failed: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			var traceparent string
+			doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+				traceparent = req.Header.Get("traceparent")
+				if c.Err != nil {
+					return nil, c.Err
+				}
+				return &http.Response{StatusCode: c.Status}, nil
+			})
+			req := httptest.NewRequest("POST", "http://example.com/", nil)
+
+			_, err := WrapDoer(doer, otel.WithTracerProvider(tp)).Do(req)
+
+			assert.Equal(t, c.Err, err)
+			assert.Empty(t, req.Header.Get("traceparent"), "original request modified")
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, "POST", span.Name())
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+			assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", traceparent)
+			if c.Failed {
+				assert.Equal(t, codes.Error, span.Status().Code)
+			} else {
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			}
+		})
+	}
+}
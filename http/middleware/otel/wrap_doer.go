@@ -0,0 +1,42 @@
+package otel
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware/otel"
+)
+
+// otelDoer is a goahttp.Doer middleware that creates client spans.
+type otelDoer struct {
+	wrapped goahttp.Doer
+	rec     *otel.Recorder
+}
+
+// WrapDoer wraps a goa HTTP Doer so that it creates a client span for each
+// request, writes the trace context to the W3C traceparent and tracestate
+// request headers and records the RED metrics. Requests that fail or result
+// in a 4xx or 5xx response are counted as errors.
+func WrapDoer(doer goahttp.Doer, opts ...otel.Option) goahttp.Doer {
+	return &otelDoer{wrapped: doer, rec: otel.NewRecorder(trace.SpanKindClient, opts...)}
+}
+
+// Do creates the client span and calls through to the wrapped Doer.
+func (d *otelDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx, call := d.rec.Start(req.Context(), req.Method,
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()))
+	req = req.Clone(ctx)
+	d.rec.Propagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := d.wrapped.Do(req)
+	if err != nil {
+		call.End(true, err)
+		return resp, err
+	}
+	call.End(resp.StatusCode >= http.StatusBadRequest, nil, semconv.HTTPResponseStatusCode(resp.StatusCode))
+	return resp, nil
+}
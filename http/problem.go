@@ -0,0 +1,220 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"mime"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// ProblemDetailsContentType is the content type of RFC 9457 problem details
+// JSON documents.
+const ProblemDetailsContentType = "application/problem+json"
+
+// ProblemDetails is an error response data structure that follows RFC 9457
+// (Problem Details for HTTP APIs). It may be used in lieu of ErrorResponse by
+// providing NewProblemDetails as the error formatter to the generated server
+// constructors or by using the ProblemDetails DSL in the design.
+type ProblemDetails struct {
+	// Type is a URI reference that identifies the problem type. It
+	// defaults to "about:blank" when empty.
+	Type string
+	// Title is a short, human-readable summary of the problem type.
+	Title string
+	// Status is the HTTP status code generated by the origin server for
+	// this occurrence of the problem.
+	Status int
+	// Detail is a human-readable explanation specific to this occurrence
+	// of the problem.
+	Detail string
+	// Instance is a URI reference that identifies the specific occurrence
+	// of the problem.
+	Instance string
+	// Extensions contains the problem type extension members. Members
+	// using the name of one of the standard members are ignored.
+	Extensions map[string]any
+}
+
+// ProblemTypeBaseURI is the base URI used to build the problem details type
+// from the error name. The type is set to "about:blank" if empty.
+var ProblemTypeBaseURI string
+
+// problemMembers lists the standard problem details members.
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// NewProblemDetails creates a RFC 9457 problem details HTTP response from the
+// given error. The status is computed using the same heuristic as
+// ErrorResponse. The Goa error name, id, message, temporary, timeout and
+// fault attributes are added as extension members so that clients
+// generated by Goa may decode the response.
+func NewProblemDetails(ctx context.Context, err error) Statuser {
+	var gerr *goa.ServiceError
+	if !errors.As(err, &gerr) {
+		var en goa.GoaErrorNamer
+		if errors.As(err, &en) {
+			gerr = &goa.ServiceError{Name: en.GoaErrorName(), ID: goa.NewErrorID(), Message: err.Error()}
+		} else {
+			gerr = goa.Fault("%s", err.Error())
+		}
+	}
+	resp := &ErrorResponse{
+		Name:      gerr.Name,
+		ID:        gerr.ID,
+		Message:   gerr.Message,
+		Temporary: gerr.Temporary,
+		Timeout:   gerr.Timeout,
+		Fault:     gerr.Fault,
+	}
+	status := resp.StatusCode()
+	typ := "about:blank"
+	if ProblemTypeBaseURI != "" {
+		typ = ProblemTypeBaseURI + gerr.Name
+	}
+	return &ProblemDetails{
+		Type:   typ,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: gerr.Message,
+		Extensions: map[string]any{
+			"name":      resp.Name,
+			"id":        resp.ID,
+			"message":   resp.Message,
+			"temporary": resp.Temporary,
+			"timeout":   resp.Timeout,
+			"fault":     resp.Fault,
+		},
+	}
+}
+
+// SetProblemContentType sets the response Content-Type header to the problem
+// details media type matching the encoding already set in the header, that is
+// "application/problem+json" for JSON and "application/problem+xml" for XML.
+// The header is left unchanged for other encodings.
+func SetProblemContentType(w http.ResponseWriter) {
+	mt, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil {
+		return
+	}
+	switch mt {
+	case "application/json":
+		w.Header().Set("Content-Type", ProblemDetailsContentType)
+	case "application/xml":
+		w.Header().Set("Content-Type", "application/problem+xml")
+	}
+}
+
+// SetStatus sets the problem details status. It also sets the title to the
+// status text if the problem type is "about:blank" as recommended by RFC 9457.
+func (p *ProblemDetails) SetStatus(code int) {
+	p.Status = code
+	if p.Type == "" || p.Type == "about:blank" {
+		p.Title = http.StatusText(code)
+	}
+}
+
+// StatusCode implements Statuser.
+func (p *ProblemDetails) StatusCode() int {
+	if p.Status == 0 {
+		return http.StatusInternalServerError
+	}
+	return p.Status
+}
+
+// Error implements the error interface so that decoded problem details may be
+// returned as errors.
+func (p *ProblemDetails) Error() string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+	return p.Title
+}
+
+// MarshalJSON encodes the problem details as a JSON object that includes the
+// extension members.
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+len(problemMembers))
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	for _, k := range problemMembers {
+		delete(m, k)
+	}
+	typ := p.Type
+	if typ == "" {
+		typ = "about:blank"
+	}
+	m["type"] = typ
+	if p.Title != "" {
+		m["title"] = p.Title
+	}
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// MarshalXML encodes the problem details using the XML format described in
+// RFC 9457 appendix B. Extension members are omitted.
+func (p *ProblemDetails) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	typ := p.Type
+	if typ == "" {
+		typ = "about:blank"
+	}
+	return e.Encode(struct {
+		XMLName  xml.Name `xml:"urn:ietf:rfc:7807 problem"`
+		Type     string   `xml:"type"`
+		Title    string   `xml:"title,omitempty"`
+		Status   int      `xml:"status,omitempty"`
+		Detail   string   `xml:"detail,omitempty"`
+		Instance string   `xml:"instance,omitempty"`
+	}{
+		Type:     typ,
+		Title:    p.Title,
+		Status:   p.Status,
+		Detail:   p.Detail,
+		Instance: p.Instance,
+	})
+}
+
+// UnmarshalJSON decodes a JSON problem details object, members other than the
+// standard ones are stored in Extensions.
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	var std struct {
+		Type     string `json:"type"`
+		Title    string `json:"title"`
+		Status   int    `json:"status"`
+		Detail   string `json:"detail"`
+		Instance string `json:"instance"`
+	}
+	if err := json.Unmarshal(data, &std); err != nil {
+		return err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	for _, k := range problemMembers {
+		delete(m, k)
+	}
+	*p = ProblemDetails{
+		Type:     std.Type,
+		Title:    std.Title,
+		Status:   std.Status,
+		Detail:   std.Detail,
+		Instance: std.Instance,
+	}
+	if len(m) > 0 {
+		p.Extensions = m
+	}
+	return nil
+}
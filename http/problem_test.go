@@ -0,0 +1,80 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestNewProblemDetails(t *testing.T) {
+	serr := goa.NewServiceError(errors.New("boom"), "bad", false, false, false)
+	pd := NewProblemDetails(context.Background(), serr).(*ProblemDetails)
+	assert.Equal(t, "about:blank", pd.Type)
+	assert.Equal(t, "Bad Request", pd.Title)
+	assert.Equal(t, http.StatusBadRequest, pd.Status)
+	assert.Equal(t, "boom", pd.Detail)
+	assert.Equal(t, "bad", pd.Extensions["name"])
+	assert.Equal(t, serr.ID, pd.Extensions["id"])
+
+	pd = NewProblemDetails(context.Background(), errors.New("oops")).(*ProblemDetails)
+	assert.Equal(t, http.StatusInternalServerError, pd.StatusCode())
+	assert.Equal(t, true, pd.Extensions["fault"])
+
+	pd.SetStatus(http.StatusNotFound)
+	assert.Equal(t, http.StatusNotFound, pd.Status)
+	assert.Equal(t, "Not Found", pd.Title)
+}
+
+func TestProblemDetailsJSON(t *testing.T) {
+	pd := &ProblemDetails{
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Detail:     "no such thing",
+		Instance:   "/things/1",
+		Extensions: map[string]any{"name": "not_found", "status": "ignored"},
+	}
+	b, err := json.Marshal(pd)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"about:blank","title":"Not Found","status":404,"detail":"no such thing","instance":"/things/1","name":"not_found"}`, string(b))
+
+	var decoded ProblemDetails
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, &ProblemDetails{
+		Type:       "about:blank",
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Detail:     "no such thing",
+		Instance:   "/things/1",
+		Extensions: map[string]any{"name": "not_found"},
+	}, &decoded)
+}
+
+func TestProblemErrorEncoder(t *testing.T) {
+	cases := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{"json", "application/json", "application/problem+json"},
+		{"xml", "application/xml", "application/problem+xml"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.accept)
+			w := httptest.NewRecorder()
+			encodeError := ProblemErrorEncoder(ResponseEncoder, nil)
+			require.NoError(t, encodeError(ctx, w, goa.PermanentError("bad", "invalid value")))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, c.contentType, w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Body.String(), "invalid value")
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// EventStreamContentType is the content type of Server-Sent Events
+	// streams.
+	EventStreamContentType = "text/event-stream"
+
+	// MaxServerSentEventSize is the maximum size in bytes of an event read
+	// by ServerSentEventReader including the field names and line
+	// terminators.
+	MaxServerSentEventSize = 1 << 20
+)
+
+// ErrServerSentEventTooLarge is the error returned by ServerSentEventReader
+// when an event exceeds MaxServerSentEventSize.
+var ErrServerSentEventTooLarge = fmt.Errorf("server-sent event larger than max (%d bytes)", MaxServerSentEventSize)
+
+type (
+	// ServerSentEvent is a single event of a Server-Sent Events stream as
+	// defined in the HTML Living Standard. See
+	// https://html.spec.whatwg.org/multipage/server-sent-events.html.
+	ServerSentEvent struct {
+		// ID is the event ID, written in the "id" field if not empty.
+		ID string
+		// Event is the event type, written in the "event" field if not
+		// empty.
+		Event string
+		// Data is the event payload, written as one or more "data"
+		// fields.
+		Data []byte
+		// Retry is the reconnection time in milliseconds, written in the
+		// "retry" field if greater than zero.
+		Retry int
+	}
+
+	// ServerSentEventReader reads Server-Sent Events from a text/event-stream
+	// body.
+	ServerSentEventReader struct {
+		r *bufio.Reader
+		// lastID is the last event ID received from the stream. Per the
+		// specification the last event ID persists across events.
+		lastID string
+	}
+)
+
+// WriteServerSentEvent writes ev to w using the text/event-stream format. The
+// caller is responsible for flushing w if needed.
+func WriteServerSentEvent(w io.Writer, ev *ServerSentEvent) error {
+	if strings.ContainsAny(ev.ID, "\r\n\x00") {
+		return fmt.Errorf("invalid server-sent event ID %q", ev.ID)
+	}
+	if strings.ContainsAny(ev.Event, "\r\n") {
+		return fmt.Errorf("invalid server-sent event type %q", ev.Event)
+	}
+	var buf bytes.Buffer
+	if ev.ID != "" {
+		buf.WriteString("id: ")
+		buf.WriteString(ev.ID)
+		buf.WriteByte('\n')
+	}
+	if ev.Event != "" {
+		buf.WriteString("event: ")
+		buf.WriteString(ev.Event)
+		buf.WriteByte('\n')
+	}
+	if ev.Retry > 0 {
+		buf.WriteString("retry: ")
+		buf.WriteString(strconv.Itoa(ev.Retry))
+		buf.WriteByte('\n')
+	}
+	data := bytes.ReplaceAll(ev.Data, []byte("\r\n"), []byte("\n"))
+	data = bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// NewServerSentEventReader returns a reader that reads events from r.
+func NewServerSentEventReader(r io.Reader) *ServerSentEventReader {
+	return &ServerSentEventReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event read from the stream. It skips comments and
+// events that do not carry any data as required by the specification. Next
+// returns io.EOF when the stream ends, any partially read event is discarded.
+// Next returns ErrServerSentEventTooLarge if the event exceeds
+// MaxServerSentEventSize, the stream cannot be read further in this case.
+func (r *ServerSentEventReader) Next() (*ServerSentEvent, error) {
+	var (
+		ev      = &ServerSentEvent{}
+		data    bytes.Buffer
+		hasData bool
+		size    int
+	)
+	for {
+		line, n, err := r.readLine(MaxServerSentEventSize - size)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+		if line == "" {
+			if !hasData {
+				ev = &ServerSentEvent{}
+				size = 0
+				continue
+			}
+			ev.ID = r.lastID
+			ev.Data = data.Bytes()
+			return ev, nil
+		}
+		size += n
+		if line[0] == ':' {
+			continue // comment
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				r.lastID = value
+			}
+		case "event":
+			ev.Event = value
+		case "retry":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				ev.Retry = n
+			}
+		}
+	}
+}
+
+// readLine reads a single line terminated by CRLF, LF or CR and returns it
+// without the terminator along with the number of bytes read. It returns
+// ErrServerSentEventTooLarge if the line is longer than limit bytes.
+func (r *ServerSentEventReader) readLine(limit int) (string, int, error) {
+	var line []byte
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return "", 0, err
+		}
+		switch b {
+		case '\n':
+			return string(line), len(line) + 1, nil
+		case '\r':
+			if next, err := r.r.Peek(1); err == nil && next[0] == '\n' {
+				r.r.ReadByte() // nolint: errcheck
+				return string(line), len(line) + 2, nil
+			}
+			return string(line), len(line) + 1, nil
+		}
+		if len(line) >= limit {
+			return "", 0, ErrServerSentEventTooLarge
+		}
+		line = append(line, b)
+	}
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteServerSentEvent(t *testing.T) {
+	cases := []struct {
+		name     string
+		event    *ServerSentEvent
+		expected string
+	}{
+		{"data-only", &ServerSentEvent{Data: []byte(`{"a":1}`)}, "data: {\"a\":1}\n\n"},
+		{"all-fields", &ServerSentEvent{ID: "1", Event: "update", Retry: 1000, Data: []byte("x")}, "id: 1\nevent: update\nretry: 1000\ndata: x\n\n"},
+		{"multiline", &ServerSentEvent{Data: []byte("a\r\nb\rc\nd")}, "data: a\ndata: b\ndata: c\ndata: d\n\n"},
+		{"empty-data", &ServerSentEvent{Event: "ping"}, "event: ping\ndata: \n\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteServerSentEvent(&buf, c.event))
+			assert.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestWriteServerSentEventInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, WriteServerSentEvent(&buf, &ServerSentEvent{ID: "a\nb"}))
+	assert.Error(t, WriteServerSentEvent(&buf, &ServerSentEvent{Event: "a\rb"}))
+	assert.Empty(t, buf.String())
+}
+
+func TestServerSentEventReader(t *testing.T) {
+	stream := ": comment\n" +
+		"retry: 500\n" +
+		"id: 1\n" +
+		"event: update\n" +
+		"data: first\n" +
+		"data: second\n" +
+		"\n" +
+		"event: ignored\n" +
+		"\n" +
+		"data:no-space\r\n" +
+		"\r\n" +
+		"id: 2\r" +
+		"data: third\r" +
+		"\r" +
+		"data: partial"
+	r := NewServerSentEventReader(strings.NewReader(stream))
+
+	ev, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "1", Event: "update", Retry: 500, Data: []byte("first\nsecond")}, ev)
+
+	ev, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "1", Data: []byte("no-space")}, ev)
+
+	ev, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "2", Data: []byte("third")}, ev)
+
+	_, err = r.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestServerSentEventReaderTooLarge(t *testing.T) {
+	line := "data: " + strings.Repeat("a", MaxServerSentEventSize/2) + "\n"
+	r := NewServerSentEventReader(strings.NewReader(line + "\n" + line + line + "\n"))
+
+	ev, err := r.Next()
+	require.NoError(t, err)
+	assert.Len(t, ev.Data, MaxServerSentEventSize/2)
+
+	_, err = r.Next()
+	assert.ErrorIs(t, err, ErrServerSentEventTooLarge)
+}
+
+func TestServerSentEventRoundTrip(t *testing.T) {
+	events := []*ServerSentEvent{
+		{ID: "a", Event: "e1", Data: []byte(`{"value":"line1"}`)},
+		{ID: "b", Retry: 10, Data: []byte("multi\nline")},
+	}
+	var buf bytes.Buffer
+	for _, ev := range events {
+		require.NoError(t, WriteServerSentEvent(&buf, ev))
+	}
+	r := NewServerSentEventReader(&buf)
+	for _, expected := range events {
+		ev, err := r.Next()
+		require.NoError(t, err)
+		assert.Equal(t, expected, ev)
+	}
+	_, err := r.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,285 @@
+/*
+Package otel contains the transport independent parts of the OpenTelemetry
+middlewares implemented in the http/middleware/otel and grpc/middleware/otel
+packages. The transport middlewares create a span for each request, propagate
+the trace context using the W3C Trace Context headers and record the request
+rate, error rate and duration (RED) metrics.
+
+The Goa service and method names are only known once the request reaches the
+generated handlers. Mounting the Endpoint middleware on the service endpoints
+makes it possible for the server middlewares to name the spans and label the
+metrics after the Goa service and method:
+
+	endpoints := calc.NewEndpoints(svc)
+	endpoints.Use(otel.Endpoint)
+*/
+package otel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	gootel "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+const (
+	// ScopeName is the instrumentation scope name used to create the
+	// tracers and meters.
+	ScopeName = "goa.design/goa/v3/middleware/otel"
+
+	// ServiceKey is the span and metric attribute key that holds the Goa
+	// service name.
+	ServiceKey = attribute.Key("goa.service")
+
+	// MethodKey is the span and metric attribute key that holds the Goa
+	// method name.
+	MethodKey = attribute.Key("goa.method")
+
+	// ErrorNameKey is the span attribute key that holds the name of the Goa
+	// error returned by the method if any.
+	ErrorNameKey = attribute.Key("goa.error.name")
+)
+
+type (
+	// Option is a constructor option that makes it possible to customize
+	// the middlewares.
+	Option func(*Options) *Options
+
+	// Options is the struct storing all the options of the middlewares.
+	Options struct {
+		tracerProvider trace.TracerProvider
+		meterProvider  metric.MeterProvider
+		propagator     propagation.TextMapPropagator
+	}
+
+	// Recorder creates the spans and records the metrics for the requests
+	// handled or made by a transport middleware.
+	Recorder struct {
+		kind       trace.SpanKind
+		tracer     trace.Tracer
+		propagator propagation.TextMapPropagator
+		requests   metric.Int64Counter
+		errors     metric.Int64Counter
+		duration   metric.Float64Histogram
+	}
+
+	// Call tracks a single request from the time it is received or sent to
+	// the time it completes.
+	Call struct {
+		rec   *Recorder
+		span  trace.Span
+		start time.Time
+		mu    sync.Mutex
+		attrs []attribute.KeyValue
+		named bool
+		ended bool
+	}
+
+	// ctxKey is the private type used to store the call in the context.
+	ctxKey struct{}
+)
+
+// NewOptions returns the middleware options by running the given
+// constructors. The options default to the global tracer and meter providers
+// and to the W3C Trace Context and Baggage propagators.
+func NewOptions(opts ...Option) *Options {
+	o := &Options{
+		tracerProvider: gootel.GetTracerProvider(),
+		meterProvider:  gootel.GetMeterProvider(),
+		propagator: propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		),
+	}
+	for _, opt := range opts {
+		o = opt(o)
+	}
+	return o
+}
+
+// WithTracerProvider sets the tracer provider used to create spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *Options) *Options {
+		o.tracerProvider = tp
+		return o
+	}
+}
+
+// WithMeterProvider sets the meter provider used to record metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *Options) *Options {
+		o.meterProvider = mp
+		return o
+	}
+}
+
+// WithPropagator sets the propagator used to read and write the trace context
+// in the request headers or metadata.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(o *Options) *Options {
+		o.propagator = p
+		return o
+	}
+}
+
+// NewRecorder returns a recorder for server or client requests depending on
+// kind. The metrics are named "goa.server.requests", "goa.server.errors" and
+// "goa.server.duration" for servers and use the "goa.client" prefix for
+// clients.
+func NewRecorder(kind trace.SpanKind, opts ...Option) *Recorder {
+	o := NewOptions(opts...)
+	prefix := "goa.server"
+	if kind == trace.SpanKindClient {
+		prefix = "goa.client"
+	}
+	meter := o.meterProvider.Meter(ScopeName)
+	requests, err := meter.Int64Counter(prefix+".requests",
+		metric.WithDescription("Number of requests."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		gootel.Handle(err)
+	}
+	errs, err := meter.Int64Counter(prefix+".errors",
+		metric.WithDescription("Number of failed requests."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		gootel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram(prefix+".duration",
+		metric.WithDescription("Duration of requests."),
+		metric.WithUnit("s"))
+	if err != nil {
+		gootel.Handle(err)
+	}
+	return &Recorder{
+		kind:       kind,
+		tracer:     o.tracerProvider.Tracer(ScopeName),
+		propagator: o.propagator,
+		requests:   requests,
+		errors:     errs,
+		duration:   duration,
+	}
+}
+
+// Propagator returns the propagator used to read and write the trace context.
+func (r *Recorder) Propagator() propagation.TextMapPropagator {
+	return r.propagator
+}
+
+// Start starts a span for a request. name is the span name used until the
+// Goa service and method are known. The attributes are set on both the span
+// and the metrics so they must have a low cardinality. Server calls are
+// stored in the returned context so that the Endpoint middleware may name
+// them, the span is named right away if the context already holds the Goa
+// service and method.
+func (r *Recorder) Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, *Call) {
+	c := &Call{rec: r, start: time.Now(), attrs: attrs}
+	ctx, c.span = r.tracer.Start(ctx, name, trace.WithSpanKind(r.kind), trace.WithAttributes(attrs...))
+	if r.kind != trace.SpanKindServer {
+		return ctx, c
+	}
+	svc, _ := ctx.Value(goa.ServiceKey).(string)
+	meth, _ := ctx.Value(goa.MethodKey).(string)
+	if svc != "" && meth != "" {
+		c.SetMethod(svc, meth)
+	}
+	return context.WithValue(ctx, ctxKey{}, c), c
+}
+
+// CallFromContext returns the call stored in the context by a server
+// middleware, nil if there isn't one.
+func CallFromContext(ctx context.Context) *Call {
+	c, _ := ctx.Value(ctxKey{}).(*Call)
+	return c
+}
+
+// SetMethod names the span after the given Goa service and method and adds
+// the corresponding attributes to the span and metrics. Only the first call
+// has an effect.
+func (c *Call) SetMethod(service, method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.named {
+		return
+	}
+	c.named = true
+	c.span.SetName(service + "/" + method)
+	kvs := []attribute.KeyValue{ServiceKey.String(service), MethodKey.String(method)}
+	c.span.SetAttributes(kvs...)
+	c.attrs = append(c.attrs, kvs...)
+}
+
+// RecordError records the given error on the span. The name of the error is
+// added as an attribute if the error is a Goa error.
+func (c *Call) RecordError(err error) {
+	c.span.RecordError(err)
+	var en goa.GoaErrorNamer
+	if errors.As(err, &en) {
+		c.span.SetAttributes(ErrorNameKey.String(en.GoaErrorName()))
+	}
+}
+
+// End ends the span and records the metrics. failed indicates whether the
+// request failed, err is recorded on the span if not nil. The attributes are
+// set on both the span and the metrics. End is a no-op if the call already
+// ended.
+func (c *Call) End(failed bool, err error, attrs ...attribute.KeyValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ended {
+		return
+	}
+	c.ended = true
+	if err != nil {
+		c.span.RecordError(err)
+	}
+	if failed {
+		desc := ""
+		if err != nil {
+			desc = err.Error()
+		}
+		c.span.SetStatus(codes.Error, desc)
+	}
+	c.span.SetAttributes(attrs...)
+	c.attrs = append(c.attrs, attrs...)
+
+	ctx := context.Background()
+	set := metric.WithAttributes(c.attrs...)
+	c.rec.requests.Add(ctx, 1, set)
+	if failed {
+		c.rec.errors.Add(ctx, 1, set)
+	}
+	c.rec.duration.Record(ctx, time.Since(c.start).Seconds(), set)
+	c.span.End()
+}
+
+// Endpoint is a Goa endpoint middleware that names the span created by the
+// server middleware after the Goa service and method and records the errors
+// returned by the endpoint on the span.
+func Endpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		c := CallFromContext(ctx)
+		if c == nil {
+			return e(ctx, req)
+		}
+		svc, _ := ctx.Value(goa.ServiceKey).(string)
+		meth, _ := ctx.Value(goa.MethodKey).(string)
+		if svc != "" && meth != "" {
+			c.SetMethod(svc, meth)
+		}
+		res, err := e(ctx, req)
+		if err != nil {
+			c.RecordError(err)
+		}
+		return res, err
+	}
+}
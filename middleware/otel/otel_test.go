@@ -0,0 +1,109 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		Err      error
+		Failed   bool
+		ErrorAtt string
+	}{
+		"success":     {},
+		"goa-error":   {Err: goa.PermanentError("not_found", "not found"), ErrorAtt: "not_found"},
+		"fault":       {Err: goa.Fault("boom"), Failed: true, ErrorAtt: "fault"},
+		"plain-error": {Err: errors.New("boom"), Failed: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			reader := sdkmetric.NewManualReader()
+			rec := NewRecorder(trace.SpanKindServer,
+				WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))),
+				WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+
+			ctx, call := rec.Start(context.Background(), "GET")
+			assert.Same(t, call, CallFromContext(ctx))
+			ctx = context.WithValue(ctx, goa.ServiceKey, "svc")
+			ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+			_, err := Endpoint(func(context.Context, any) (any, error) { return nil, c.Err })(ctx, nil)
+			assert.Equal(t, c.Err, err)
+			call.End(c.Failed, nil)
+			call.End(c.Failed, nil) // no-op
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, "svc/meth", span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			if c.Failed {
+				assert.Equal(t, codes.Error, span.Status().Code)
+			} else {
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			}
+			attrs := attribute.NewSet(span.Attributes()...)
+			v, _ := attrs.Value(ServiceKey)
+			assert.Equal(t, "svc", v.AsString())
+			v, ok := attrs.Value(ErrorNameKey)
+			assert.Equal(t, c.ErrorAtt != "", ok)
+			assert.Equal(t, c.ErrorAtt, v.AsString())
+			if c.Err != nil {
+				require.Len(t, span.Events(), 1)
+				assert.Equal(t, "exception", span.Events()[0].Name)
+			}
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			assert.Equal(t, ScopeName, rm.ScopeMetrics[0].Scope.Name)
+			counts := make(map[string]int64)
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+					for _, dp := range sum.DataPoints {
+						counts[m.Name] += dp.Value
+						v, _ := dp.Attributes.Value(MethodKey)
+						assert.Equal(t, "meth", v.AsString())
+					}
+				}
+			}
+			assert.Equal(t, int64(1), counts["goa.server.requests"])
+			if c.Failed {
+				assert.Equal(t, int64(1), counts["goa.server.errors"])
+			} else {
+				assert.Zero(t, counts["goa.server.errors"])
+			}
+		})
+	}
+}
+
+func TestStartClient(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	rec := NewRecorder(trace.SpanKindClient,
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))))
+	ctx := context.WithValue(context.Background(), goa.ServiceKey, "caller")
+	ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+
+	ctx, call := rec.Start(ctx, "POST")
+	assert.Nil(t, CallFromContext(ctx))
+	call.End(false, nil)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "POST", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+}
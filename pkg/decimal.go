@@ -0,0 +1,112 @@
+package goa
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Decimal is an arbitrary-precision decimal number. It is the Go type
+// generated for attributes of type Decimal. Decimal values hold their textual
+// representation so that they round trip without loss of precision, e.g. "1.50"
+// is encoded as "1.50". Use Rat to perform arithmetic.
+type Decimal string
+
+// ParseDecimal parses s as a decimal number. s consists of an optional sign,
+// digits with an optional decimal point and an optional exponent, e.g. "-1.5"
+// or "2.5e3".
+func ParseDecimal(s string) (Decimal, error) {
+	if !isDecimal(s) {
+		return "", fmt.Errorf("invalid decimal value %q", s)
+	}
+	return Decimal(s), nil
+}
+
+// DecimalFromRat returns the decimal representation of r rounded to the given
+// number of digits after the decimal point.
+func DecimalFromRat(r *big.Rat, scale int) Decimal {
+	return Decimal(r.FloatString(scale))
+}
+
+// String returns the textual representation of d.
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// Rat returns the value of d as a rational number. The boolean is false if d
+// is not a valid decimal number.
+func (d Decimal) Rat() (*big.Rat, bool) {
+	if !isDecimal(string(d)) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(string(d))
+}
+
+// Float64 returns the float64 value nearest to d.
+func (d Decimal) Float64() (float64, error) {
+	return strconv.ParseFloat(string(d), 64)
+}
+
+// UnmarshalText parses the decimal number contained in text.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// UnmarshalJSON parses the decimal number contained in the given JSON string
+// or number.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return d.UnmarshalText(data)
+}
+
+// isDecimal returns true if s is a valid decimal number.
+func isDecimal(s string) bool {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	digits := 0
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		start := i
+		for ; i < len(s) && isDigit(s[i]); i++ {
+		}
+		if i == start {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+// isDigit returns true if c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,100 @@
+package goa
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"math/big"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]bool{
+		"0":       true,
+		"-1.50":   true,
+		"+.5":     true,
+		"1.":      true,
+		"2.5e3":   true,
+		"2.5E-3":  true,
+		"":        false,
+		"-":       false,
+		".":       false,
+		"1e":      false,
+		"1.2.3":   false,
+		"0x10":    false,
+		"1/2":     false,
+		" 1":      false,
+		"NaN":     false,
+		"1_000.5": false,
+	}
+	for s, valid := range cases {
+		t.Run(s, func(t *testing.T) {
+			d, err := ParseDecimal(s)
+			if !valid {
+				if err == nil {
+					t.Errorf("got no error for %q", s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v for %q", err, s)
+			}
+			if d.String() != s {
+				t.Errorf("got %q, expected %q", d, s)
+			}
+		})
+	}
+}
+
+func TestDecimalRat(t *testing.T) {
+	r, ok := Decimal("-1.25").Rat()
+	if !ok {
+		t.Fatal("got invalid decimal")
+	}
+	if r.Cmp(big.NewRat(-5, 4)) != 0 {
+		t.Errorf("got %s, expected -5/4", r)
+	}
+	if _, ok := Decimal("1/2").Rat(); ok {
+		t.Error("got valid rational for invalid decimal")
+	}
+	if d := DecimalFromRat(big.NewRat(1, 3), 4); d != "0.3333" {
+		t.Errorf("got %q, expected 0.3333", d)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"1.50","b":2.25}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A != "1.50" || v.B != "2.25" {
+		t.Errorf("got %q and %q, expected 1.50 and 2.25", v.A, v.B)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"a":"1.50","b":"2.25"}` {
+		t.Errorf("got %s", b)
+	}
+	if err := json.Unmarshal([]byte(`{"a":"foo"}`), &v); err == nil {
+		t.Error("got no error for invalid decimal")
+	}
+}
+
+func TestDecimalXML(t *testing.T) {
+	var v struct {
+		A Decimal `xml:"a"`
+	}
+	if err := xml.Unmarshal([]byte(`<v><a>-0.001</a></v>`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A != "-0.001" {
+		t.Errorf("got %q, expected -0.001", v.A)
+	}
+	if err := xml.Unmarshal([]byte(`<v><a>x</a></v>`), &v); err == nil {
+		t.Error("got no error for invalid decimal")
+	}
+}
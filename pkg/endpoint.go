@@ -12,6 +12,12 @@ const (
 	// service as defined in the design. The generated transport code
 	// initializes the corresponding value prior to invoking the endpoint.
 	ServiceKey
+
+	// ClientIPKey is the request context key used to store the IP address
+	// of the client. The generated transport code initializes the
+	// corresponding value prior to invoking the endpoints of methods rate
+	// limited by client IP.
+	ClientIPKey
 )
 
 type (
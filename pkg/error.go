@@ -56,9 +56,28 @@ const (
 	InvalidRange = "invalid_range"
 	// InvalidLength is the error name for invalid length errors.
 	InvalidLength = "invalid_length"
+	// InvalidFieldSelection is the error name for field selections that
+	// refer to fields not defined in the result type.
+	InvalidFieldSelection = "invalid_field_selection"
 	// UnsupportedMediaType is the error name returned by the Goa decoder
 	// when the content type of the HTTP request body is not supported.
 	UnsupportedMediaType = "unsupported_media_type"
+	// RateLimitExceeded is the error name returned by the generated code
+	// when a request exceeds the rate limit defined in the design.
+	RateLimitExceeded = "rate_limit_exceeded"
+	// Unauthenticated is the error name returned by the generated code when
+	// a request does not satisfy any of the method security requirements
+	// because its credentials are missing or invalid.
+	Unauthenticated = "unauthenticated"
+	// InsufficientScope is the error name returned by the generated code
+	// when a request is authenticated but lacks the scopes required by the
+	// method security requirements.
+	InsufficientScope = "insufficient_scope"
+	// PreconditionFailed is the name of the error added to the PUT and PATCH
+	// methods whose HTTP endpoint defines an entity tag with ETag. Service
+	// implementations return it when the entity tag of the If-Match request
+	// header does not match the current entity tag of the resource.
+	PreconditionFailed = "precondition_failed"
 )
 
 // NewServiceError creates an error.
@@ -191,6 +210,14 @@ func InvalidLengthError(name string, target any, ln, value int, min bool) error
 		InvalidLength, "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln))
 }
 
+// InvalidFieldSelectionError is the error produced by the generated code when
+// a field selection refers to a field that is not defined in the method result
+// type.
+func InvalidFieldSelectionError(name, path string) error {
+	return withField(name, PermanentError(
+		InvalidFieldSelection, "%s selects field %q which is not defined in the result", name, path))
+}
+
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
 // error identifier.
 func NewErrorID() string {
@@ -0,0 +1,36 @@
+package goa
+
+import "strings"
+
+// FieldPaths returns the field paths listed in the given field selections.
+// Each selection may list multiple paths separated with commas. Nested fields
+// are selected using dots, for example "owner.name". Blank paths are ignored.
+func FieldPaths(selections ...string) []string {
+	var paths []string
+	for _, s := range selections {
+		for _, p := range strings.Split(s, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// SelectField reports whether the field with the given name is selected by
+// paths, that is whether paths contain the name or a path nested under it.
+// It also returns the paths of the nested fields selected under the field,
+// nil if the field itself is selected in which case all its nested fields are
+// selected as well.
+func SelectField(paths []string, name string) (selected bool, nested []string) {
+	prefix := name + "."
+	for _, p := range paths {
+		if p == name {
+			return true, nil
+		}
+		if strings.HasPrefix(p, prefix) {
+			nested = append(nested, p[len(prefix):])
+		}
+	}
+	return len(nested) > 0, nested
+}
@@ -0,0 +1,53 @@
+package goa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldPaths(t *testing.T) {
+	cases := map[string]struct {
+		selections []string
+		want       []string
+	}{
+		"none":            {selections: nil, want: nil},
+		"single":          {selections: []string{"id"}, want: []string{"id"}},
+		"comma-separated": {selections: []string{"id, name,owner.name"}, want: []string{"id", "name", "owner.name"}},
+		"multiple":        {selections: []string{"id", "name"}, want: []string{"id", "name"}},
+		"blank":           {selections: []string{" , id,,"}, want: []string{"id"}},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			got := FieldPaths(tc.selections...)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSelectField(t *testing.T) {
+	cases := map[string]struct {
+		paths    []string
+		field    string
+		selected bool
+		nested   []string
+	}{
+		"not selected": {paths: []string{"id"}, field: "name"},
+		"prefix":       {paths: []string{"names"}, field: "name"},
+		"selected":     {paths: []string{"id", "owner"}, field: "owner", selected: true},
+		"nested":       {paths: []string{"owner.name", "owner.address.city"}, field: "owner", selected: true, nested: []string{"name", "address.city"}},
+		"whole":        {paths: []string{"owner.name", "owner"}, field: "owner", selected: true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			selected, nested := SelectField(tc.paths, tc.field)
+			if selected != tc.selected {
+				t.Errorf("got selected %v, want %v", selected, tc.selected)
+			}
+			if !reflect.DeepEqual(nested, tc.nested) {
+				t.Errorf("got nested %#v, want %#v", nested, tc.nested)
+			}
+		})
+	}
+}
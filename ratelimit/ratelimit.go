@@ -0,0 +1,271 @@
+/*
+Package ratelimit contains the types used by the code generated for methods
+that define a rate limit in the design. The generated endpoint middlewares
+enforce the quotas using a Limiter. The package provides an in-memory token
+bucket implementation suitable for single instance deployments, distributed
+deployments should provide a Limiter backed by a shared store.
+*/
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+)
+
+type (
+	// Limit describes a quota of requests.
+	Limit struct {
+		// Requests is the maximum number of requests allowed during
+		// Period.
+		Requests int
+		// Period is the duration of the rate limit window.
+		Period time.Duration
+	}
+
+	// Result is the outcome of a rate limit check.
+	Result struct {
+		// Allowed is true if the request may proceed.
+		Allowed bool
+		// Remaining is the number of requests that may still be made
+		// before the quota is exhausted.
+		Remaining int
+		// RetryAfter is the duration after which the next request is
+		// allowed when Allowed is false.
+		RetryAfter time.Duration
+	}
+
+	// Limiter is the interface implemented by rate limiters. Allow
+	// consumes one request from the quota identified by key and reports
+	// whether the request may proceed. Allow returns an error if the quota
+	// could not be checked, in which case the request fails.
+	Limiter interface {
+		Allow(ctx context.Context, key string, limit Limit) (*Result, error)
+	}
+
+	// TokenBucket is an in-memory Limiter that uses the token bucket
+	// algorithm: each key has a bucket holding up to Limit.Requests tokens
+	// refilled continuously at the rate of Limit.Requests per
+	// Limit.Period. Each request consumes one token.
+	TokenBucket struct {
+		mu      sync.Mutex
+		buckets map[string]*bucket
+		calls   int
+		// now returns the current time, overridden in tests.
+		now func() time.Time
+	}
+
+	// ExceededError is the error wrapped in the goa.ServiceError returned by
+	// Enforce when the quota is exhausted.
+	ExceededError struct {
+		// Key identifies the exhausted quota.
+		Key string
+		// Limit is the exhausted quota.
+		Limit Limit
+		// Result is the result of the rate limit check.
+		Result *Result
+	}
+
+	// principalQuota is the quota stored in the context by
+	// WithPrincipalQuota.
+	principalQuota struct {
+		limiter Limiter
+		key     string
+		limit   Limit
+	}
+
+	// principalQuotaKey is the context key used to store the principal
+	// quota.
+	principalQuotaKey struct{}
+
+	// principalKey is the context key used to store the principal
+	// identity.
+	principalKey struct{}
+
+	// bucket holds the state of a single key.
+	bucket struct {
+		tokens float64
+		last   time.Time
+		limit  Limit
+	}
+)
+
+// sweepInterval is the number of calls to Allow between two removals of the
+// full buckets.
+const sweepInterval = 1024
+
+// NewTokenBucket returns an in-memory token bucket Limiter.
+func NewTokenBucket() *TokenBucket {
+	return &TokenBucket{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Allow implements Limiter.
+func (tb *TokenBucket) Allow(_ context.Context, key string, limit Limit) (*Result, error) {
+	if limit.Requests <= 0 || limit.Period <= 0 {
+		return nil, fmt.Errorf("invalid rate limit %d/%s", limit.Requests, limit.Period)
+	}
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	now := tb.now()
+	tb.calls++
+	if tb.calls%sweepInterval == 0 {
+		tb.sweep(now)
+	}
+	b, ok := tb.buckets[key]
+	if !ok || b.limit != limit {
+		b = &bucket{tokens: float64(limit.Requests), last: now, limit: limit}
+		tb.buckets[key] = b
+	}
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return &Result{Allowed: true, Remaining: int(b.tokens)}, nil
+	}
+	wait := time.Duration(math.Ceil((1 - b.tokens) / b.rate()))
+	return &Result{RetryAfter: wait}, nil
+}
+
+// sweep removes the buckets that are full, they are recreated as needed.
+func (tb *TokenBucket) sweep(now time.Time) {
+	for k, b := range tb.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Requests) {
+			delete(tb.buckets, k)
+		}
+	}
+}
+
+// rate returns the number of tokens added per nanosecond.
+func (b *bucket) rate() float64 {
+	return float64(b.limit.Requests) / float64(b.limit.Period)
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(float64(b.limit.Requests), b.tokens+float64(elapsed)*b.rate())
+	b.last = now
+}
+
+// Enforce consumes one request from the quota identified by key using l. It
+// returns a goa.ServiceError named goa.RateLimitExceeded wrapping an
+// ExceededError if the quota is exhausted.
+func Enforce(ctx context.Context, l Limiter, key string, limit Limit) error {
+	res, err := l.Allow(ctx, key, limit)
+	if err != nil {
+		return err
+	}
+	if res.Allowed {
+		return nil
+	}
+	return goa.NewServiceError(&ExceededError{Key: key, Limit: limit, Result: res}, goa.RateLimitExceeded, false, true, false)
+}
+
+// WithPrincipalQuota returns a copy of ctx holding the quota identified by key.
+// Methods rate limited by principal cannot compute the key before the request
+// is authenticated: the generated middlewares store the quota in the context
+// and the generated endpoints consume it with EnforcePrincipal once the
+// security schemes have been verified or with EnforceAnonymous if the request
+// could not be authenticated.
+func WithPrincipalQuota(ctx context.Context, l Limiter, key string, limit Limit) context.Context {
+	return context.WithValue(ctx, principalQuotaKey{}, &principalQuota{limiter: l, key: key, limit: limit})
+}
+
+// ContextWithPrincipal returns a copy of ctx that holds the identity of the
+// authenticated caller, e.g. a user or account ID. Auth functions may call it
+// so that the methods rate limited by principal key their quotas by this
+// identity.
+func ContextWithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// EnforcePrincipal consumes one request from the quota stored in ctx by
+// WithPrincipalQuota once the request is authenticated. The quota is keyed by
+// the identity of the caller which is, in order of precedence: the principal
+// stored in ctx with ContextWithPrincipal, the "sub" claim of the JWT stored
+// in ctx with security.ContextWithJWTClaims, a SHA-256 hash of cred, the
+// credential verified by the security requirement that succeeded, or a
+// SHA-256 hash of the client certificate stored in ctx with
+// security.ContextWithPeerCertificates. The credential itself is never used
+// as key so that secrets are not retained by the limiter. cred may be empty,
+// e.g. for mutual TLS requirements. The quota is keyed by client IP address
+// only if none of these identify the caller. EnforcePrincipal does nothing if
+// ctx does not hold a quota.
+func EnforcePrincipal(ctx context.Context, cred string) error {
+	q, ok := ctx.Value(principalQuotaKey{}).(*principalQuota)
+	if !ok {
+		return nil
+	}
+	key := q.key + ":ip:" + ClientIP(ctx)
+	if p := principal(ctx, cred); p != "" {
+		key = q.key + ":principal:" + p
+	}
+	return Enforce(ctx, q.limiter, key, q.limit)
+}
+
+// EnforceAnonymous consumes one request from the quota stored in ctx by
+// WithPrincipalQuota for a request that could not be authenticated. The
+// quota is keyed by client IP address. EnforceAnonymous does nothing if ctx
+// does not hold a quota.
+func EnforceAnonymous(ctx context.Context) error {
+	q, ok := ctx.Value(principalQuotaKey{}).(*principalQuota)
+	if !ok {
+		return nil
+	}
+	return Enforce(ctx, q.limiter, q.key+":ip:"+ClientIP(ctx), q.limit)
+}
+
+// principal returns the identity of the authenticated caller, empty if
+// neither ctx nor cred identify it. Each source of identity uses its own key
+// prefix so that an explicit principal cannot share a quota with a JWT
+// subject or a hash.
+func principal(ctx context.Context, cred string) string {
+	if p, ok := ctx.Value(principalKey{}).(string); ok && p != "" {
+		return "id:" + p
+	}
+	if claims, ok := security.JWTClaimsFromContext(ctx); ok {
+		if sub, ok := claims["sub"].(string); ok && sub != "" {
+			return "sub:" + sub
+		}
+	}
+	if cred != "" {
+		sum := sha256.Sum256([]byte(cred))
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+	if certs := security.PeerCertificatesFromContext(ctx); len(certs) > 0 {
+		sum := sha256.Sum256(certs[0].Raw)
+		return "cert:" + hex.EncodeToString(sum[:])
+	}
+	return ""
+}
+
+// ClientIP returns the client IP address stored in the context by the
+// generated transport code under goa.ClientIPKey.
+func ClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(goa.ClientIPKey).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// Error implements the error interface.
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("rate limit of %d requests per %s exceeded, retry in %s", e.Limit.Requests, e.Limit.Period, e.Result.RetryAfter.Round(time.Millisecond))
+}
+
+// RateLimit returns the quota size, the number of remaining requests and the
+// duration after which the next request is allowed. It is used by the HTTP
+// transport to set the corresponding response headers.
+func (e *ExceededError) RateLimit() (limit, remaining int, retryAfter time.Duration) {
+	return e.Limit.Requests, e.Result.Remaining, e.Result.RetryAfter
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	tb := NewTokenBucket()
+	tb.now = func() time.Time { return now }
+	limit := Limit{Requests: 2, Period: time.Second}
+	ctx := context.Background()
+
+	res, err := tb.Allow(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Allowed: true, Remaining: 1}, res)
+	res, err = tb.Allow(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Allowed: true, Remaining: 0}, res)
+	res, err = tb.Allow(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+
+	res, err = tb.Allow(ctx, "b", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "keys must not share quotas")
+
+	now = now.Add(500 * time.Millisecond)
+	res, err = tb.Allow(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "bucket must refill over time")
+
+	_, err = tb.Allow(ctx, "a", Limit{})
+	assert.Error(t, err)
+}
+
+func TestEnforce(t *testing.T) {
+	tb := NewTokenBucket()
+	limit := Limit{Requests: 1, Period: time.Minute}
+	ctx := context.Background()
+
+	require.NoError(t, Enforce(ctx, tb, "k", limit))
+	err := Enforce(ctx, tb, "k", limit)
+	require.Error(t, err)
+
+	var serr *goa.ServiceError
+	require.True(t, errors.As(err, &serr))
+	assert.Equal(t, goa.RateLimitExceeded, serr.Name)
+	assert.True(t, serr.Temporary)
+
+	var eerr *ExceededError
+	require.True(t, errors.As(err, &eerr))
+	l, remaining, retryAfter := eerr.RateLimit()
+	assert.Equal(t, 1, l)
+	assert.Equal(t, 0, remaining)
+	assert.Greater(t, retryAfter, time.Duration(0))
+}
+
+func TestEnforcePrincipal(t *testing.T) {
+	limit := Limit{Requests: 1, Period: time.Minute}
+	require.NoError(t, EnforcePrincipal(context.Background(), "alice"), "no quota in context")
+
+	ctx := context.WithValue(context.Background(), goa.ClientIPKey, "10.0.0.1")
+	ctx = WithPrincipalQuota(ctx, NewTokenBucket(), "svc.m", limit)
+	require.NoError(t, EnforcePrincipal(ctx, "alice"))
+	err := EnforcePrincipal(ctx, "alice")
+	require.Error(t, err)
+	var eerr *ExceededError
+	require.True(t, errors.As(err, &eerr))
+	assert.NotContains(t, eerr.Key, "alice", "credentials must not be used as keys")
+	require.NoError(t, EnforcePrincipal(ctx, "bob"), "principals must not share quotas")
+	require.NoError(t, EnforceAnonymous(ctx))
+	err = EnforceAnonymous(ctx)
+	require.Error(t, err)
+	require.True(t, errors.As(err, &eerr))
+	assert.Equal(t, "svc.m:ip:10.0.0.1", eerr.Key)
+	require.NoError(t, EnforceAnonymous(context.Background()), "no quota in context")
+}
+
+func TestEnforcePrincipalIdentity(t *testing.T) {
+	limit := Limit{Requests: 1, Period: time.Minute}
+	ctx := WithPrincipalQuota(context.Background(), NewTokenBucket(), "svc.m", limit)
+
+	jwtCtx := security.ContextWithJWTClaims(ctx, security.JWTClaims{"sub": "alice"})
+	require.NoError(t, EnforcePrincipal(jwtCtx, "token1"))
+	err := EnforcePrincipal(jwtCtx, "token2")
+	require.Error(t, err, "rotating tokens must not reset the quota")
+	var eerr *ExceededError
+	require.True(t, errors.As(err, &eerr))
+	assert.Equal(t, "svc.m:principal:sub:alice", eerr.Key)
+
+	idCtx := ContextWithPrincipal(jwtCtx, "account-1")
+	require.NoError(t, EnforcePrincipal(idCtx, "token3"))
+	err = EnforcePrincipal(idCtx, "token4")
+	require.Error(t, err)
+	require.True(t, errors.As(err, &eerr))
+	assert.Equal(t, "svc.m:principal:id:account-1", eerr.Key)
+
+	spoofCtx := ContextWithPrincipal(ctx, "sub:alice")
+	require.NoError(t, EnforcePrincipal(spoofCtx, "token5"), "explicit principals must not share quotas with JWT subjects")
+
+	ipCtx := context.WithValue(ctx, goa.ClientIPKey, "10.0.0.1")
+	explicitCtx := ContextWithPrincipal(ipCtx, "account-2")
+	require.NoError(t, EnforcePrincipal(explicitCtx, ""))
+	err = EnforcePrincipal(explicitCtx, "")
+	require.Error(t, err, "explicit principals must be used without credential")
+	require.True(t, errors.As(err, &eerr))
+	assert.Equal(t, "svc.m:principal:id:account-2", eerr.Key)
+
+	alice := &x509.Certificate{Raw: []byte("alice")}
+	bob := &x509.Certificate{Raw: []byte("bob")}
+	aliceCtx := security.ContextWithPeerCertificates(ipCtx, []*x509.Certificate{alice})
+	require.NoError(t, EnforcePrincipal(aliceCtx, ""))
+	err = EnforcePrincipal(aliceCtx, "")
+	require.Error(t, err)
+	require.True(t, errors.As(err, &eerr))
+	assert.Contains(t, eerr.Key, "svc.m:principal:cert:")
+	bobCtx := security.ContextWithPeerCertificates(ipCtx, []*x509.Certificate{bob})
+	require.NoError(t, EnforcePrincipal(bobCtx, ""), "clients sharing an IP address must not share quotas")
+
+	require.NoError(t, EnforcePrincipal(ipCtx, ""))
+	err = EnforcePrincipal(ipCtx, "")
+	require.Error(t, err)
+	require.True(t, errors.As(err, &eerr))
+	assert.Equal(t, "svc.m:ip:10.0.0.1", eerr.Key)
+}
+
+func TestClientIP(t *testing.T) {
+	assert.Equal(t, "", ClientIP(context.Background()))
+	ctx := context.WithValue(context.Background(), goa.ClientIPKey, "10.0.0.1")
+	assert.Equal(t, "10.0.0.1", ClientIP(ctx))
+}
@@ -0,0 +1,126 @@
+/*
+Package retry contains the types used by the code generated for methods that
+define a retry policy in the design. The generated service clients wrap the
+client endpoints so that requests failing with temporary errors are retried
+with an exponential backoff.
+*/
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Policy describes how requests are retried.
+	Policy struct {
+		// MaxAttempts is the maximum number of attempts including the
+		// initial request.
+		MaxAttempts int
+		// InitialBackoff is the delay before the first retry.
+		InitialBackoff time.Duration
+		// MaxBackoff caps the delay between two attempts.
+		MaxBackoff time.Duration
+		// Idempotent is true if requests that fail with timeout errors
+		// may be retried.
+		Idempotent bool
+	}
+
+	// flagger is implemented by the transport client errors that carry the
+	// temporary and timeout flags.
+	flagger interface {
+		RetryFlags() (temporary, timeout bool)
+	}
+)
+
+// Endpoint returns an endpoint that calls e and retries the calls that fail
+// with an error retryable according to p. Retries stop when the maximum
+// number of attempts is reached, when the context is canceled or when the
+// context deadline would expire before the next attempt. The error returned
+// by the last attempt is returned in this case.
+func Endpoint(p Policy, e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		for attempt := 1; ; attempt++ {
+			res, err := e(ctx, req)
+			if err == nil || attempt >= p.MaxAttempts || !p.Retryable(err) {
+				return res, err
+			}
+			wait := p.Backoff(attempt)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= wait {
+				return res, err
+			}
+			t := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return res, err
+			case <-t.C:
+			}
+		}
+	}
+}
+
+// Backoff returns the delay before the attempt following the given one. The
+// delay doubles after each attempt up to MaxBackoff and is randomized between
+// half and all of its value to avoid synchronized retries.
+func (p Policy) Backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	half := d / 2
+	return half + jitter(d-half)
+}
+
+// Retryable returns true if the request that failed with err may be retried:
+// err must be temporary and, if it is also a timeout, the policy must be
+// idempotent. The flags are read from goa.ServiceError, from the transport
+// client errors and from errors implementing Temporary or Timeout such as
+// net.Error.
+func (p Policy) Retryable(err error) bool {
+	temporary, timeout := flags(err)
+	if timeout {
+		return p.Idempotent
+	}
+	return temporary
+}
+
+// flags returns the temporary and timeout flags of err.
+func flags(err error) (temporary, timeout bool) {
+	var serr *goa.ServiceError
+	if errors.As(err, &serr) {
+		if serr.Temporary || serr.Timeout {
+			return serr.Temporary, serr.Timeout
+		}
+	}
+	var f flagger
+	if errors.As(err, &f) {
+		if temporary, timeout = f.RetryFlags(); temporary || timeout {
+			return
+		}
+	}
+	var tmp interface{ Temporary() bool }
+	if errors.As(err, &tmp) {
+		temporary = tmp.Temporary()
+	}
+	var to interface{ Timeout() bool }
+	if errors.As(err, &to) {
+		timeout = to.Timeout()
+	}
+	return
+}
+
+// jitter returns a random duration in [0, d).
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
@@ -0,0 +1,128 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type transportError struct{ temporary, timeout bool }
+
+func (e *transportError) Error() string                         { return "transport error" }
+func (e *transportError) RetryFlags() (temporary, timeout bool) { return e.temporary, e.timeout }
+
+func TestRetryable(t *testing.T) {
+	var (
+		temporary = goa.NewServiceError(errors.New("temporary"), "unavailable", false, true, false)
+		timeout   = goa.NewServiceError(errors.New("timeout"), "timeout", true, true, false)
+		permanent = goa.PermanentError("bad", "bad request")
+	)
+	cases := []struct {
+		Name       string
+		Err        error
+		Idempotent bool
+		Expected   bool
+	}{
+		{"temporary", temporary, false, true},
+		{"timeout", timeout, false, false},
+		{"timeout-idempotent", timeout, true, true},
+		{"permanent", permanent, true, false},
+		{"transport-temporary", &transportError{temporary: true}, false, true},
+		{"transport-timeout", &transportError{timeout: true}, false, false},
+		{"transport-timeout-idempotent", &transportError{timeout: true}, true, true},
+		{"wrapped", goa.NewServiceError(&transportError{temporary: true}, "fault", false, false, true), false, true},
+		{"other", errors.New("other"), true, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			p := Policy{Idempotent: c.Idempotent}
+			assert.Equal(t, c.Expected, p.Retryable(c.Err))
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		for i := 0; i < 10; i++ {
+			d := p.Backoff(attempt + 1)
+			assert.GreaterOrEqual(t, d, max/2)
+			assert.Less(t, d, max)
+		}
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	temporary := goa.NewServiceError(errors.New("temporary"), "unavailable", false, true, false)
+	p := Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	t.Run("success", func(t *testing.T) {
+		var calls int
+		e := Endpoint(p, func(context.Context, any) (any, error) {
+			calls++
+			if calls < 3 {
+				return nil, temporary
+			}
+			return "ok", nil
+		})
+		res, err := e(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", res)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("max-attempts", func(t *testing.T) {
+		var calls int
+		e := Endpoint(p, func(context.Context, any) (any, error) {
+			calls++
+			return nil, temporary
+		})
+		_, err := e(context.Background(), nil)
+		assert.Equal(t, temporary, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		var calls int
+		e := Endpoint(p, func(context.Context, any) (any, error) {
+			calls++
+			return nil, goa.PermanentError("bad", "bad request")
+		})
+		_, err := e(context.Background(), nil)
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		var calls int
+		slow := Policy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+		e := Endpoint(slow, func(context.Context, any) (any, error) {
+			calls++
+			return nil, temporary
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		_, err := e(ctx, nil)
+		assert.Equal(t, temporary, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		var calls int
+		slow := Policy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+		ctx, cancel := context.WithCancel(context.Background())
+		e := Endpoint(slow, func(context.Context, any) (any, error) {
+			calls++
+			cancel()
+			return nil, temporary
+		})
+		_, err := e(ctx, nil)
+		assert.Equal(t, temporary, err)
+		assert.Equal(t, 1, calls)
+	})
+}
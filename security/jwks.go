@@ -0,0 +1,155 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+type (
+	// jwks is a JSON Web Key Set as defined in RFC 7517.
+	jwks struct {
+		Keys []*jwk `json:"keys"`
+	}
+
+	// jwk is a JSON Web Key as defined in RFC 7517 and RFC 8037.
+	jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		Crv string `json:"crv"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+		K   string `json:"k"`
+	}
+)
+
+// LoadJWKS reads the JSON Web Key Set file with the given path and returns its
+// keys, see ParseJWKS.
+func LoadJWKS(path string) ([]*JWTKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// ParseJWKS parses the given JSON Web Key Set and returns its signature
+// verification keys. ParseJWKS supports the "RSA", "EC" (P-256, P-384 and
+// P-521 curves), "OKP" (Ed25519 curve) and "oct" key types. The keys of other
+// types and the keys whose "use" is not "sig" are ignored.
+func ParseJWKS(data []byte) ([]*JWTKey, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+	var keys []*JWTKey
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.key()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key %d: %w", i, err)
+		}
+		if key == nil {
+			continue
+		}
+		keys = append(keys, &JWTKey{ID: k.Kid, Algorithm: k.Alg, Key: key})
+	}
+	return keys, nil
+}
+
+// key returns the verification key described by k, nil if the key type is not
+// supported.
+func (k *jwk) key() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N, "n")
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E, "e")
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeInt(k.X, "x")
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y, "y")
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		if _, err := pub.ECDH(); err != nil {
+			return nil, fmt.Errorf("invalid EC public key: %w", err)
+		}
+		return pub, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBytes(k.X, "x")
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key size %d", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		return decodeBytes(k.K, "k")
+	}
+	return nil, nil
+}
+
+// decodeInt decodes the given base64url encoded big-endian integer parameter.
+func decodeInt(val, name string) (*big.Int, error) {
+	bs, err := decodeBytes(val, name)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(bs), nil
+}
+
+// decodeBytes decodes the given base64url encoded parameter.
+func decodeBytes(val, name string) ([]byte, error) {
+	if val == "" {
+		return nil, fmt.Errorf("missing %q parameter", name)
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q parameter: %w", name, err)
+	}
+	return bs, nil
+}
@@ -0,0 +1,373 @@
+package security
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // register SHA-256 hash
+	_ "crypto/sha512" // register SHA-384 and SHA-512 hashes
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// JWTConfig configures the verification of JWTs by JWTVerifier.
+	JWTConfig struct {
+		// Keys lists the keys used to verify the token signatures.
+		Keys []*JWTKey
+		// JWKSFile is the path to a JSON Web Key Set file that lists
+		// additional keys. The file is loaded on the first verification.
+		JWKSFile string
+		// Issuer is the expected value of the "iss" claim. The claim is
+		// not checked if Issuer is empty.
+		Issuer string
+		// Audience lists the accepted values of the "aud" claim. The
+		// claim is not checked if Audience is empty.
+		Audience []string
+		// Leeway is the tolerated clock skew when checking the "exp",
+		// "nbf" and "iat" claims.
+		Leeway time.Duration
+		// AllowNoExpiration accepts the tokens that do not have an
+		// "exp" claim. Such tokens never expire, by default they are
+		// rejected.
+		AllowNoExpiration bool
+		// ScopesClaim is the name of the claim that lists the token
+		// scopes, "scope" by default. The claim value may be a string
+		// of space separated scopes or an array of strings.
+		ScopesClaim string
+		// Now returns the current time, time.Now by default.
+		Now func() time.Time
+	}
+
+	// JWTKey is a key used to verify JWT signatures.
+	JWTKey struct {
+		// ID is the key identifier. If set the key is not used to verify
+		// the tokens whose "kid" header is set to a different value.
+		ID string
+		// Algorithm is the JWS algorithm the key is used with, e.g.
+		// "RS256". If empty the key is used with all the algorithms
+		// compatible with its type.
+		Algorithm string
+		// Key is the verification key: a []byte secret for the HMAC
+		// algorithms (HS256, HS384, HS512), a *rsa.PublicKey for the RSA
+		// algorithms (RS256, RS384, RS512, PS256, PS384, PS512), a
+		// *ecdsa.PublicKey for the ECDSA algorithms (ES256, ES384,
+		// ES512) or an ed25519.PublicKey for EdDSA. HMAC secrets must be
+		// at least as long as the algorithm hash output (32, 48 or 64
+		// bytes), shorter secrets such as empty ones never verify any
+		// token.
+		Key any
+	}
+
+	// JWTClaims holds the claims of a verified JWT. Numbers are decoded as
+	// json.Number values.
+	JWTClaims map[string]any
+
+	// JWTVerifier verifies the signature and the claims of JWTs.
+	JWTVerifier struct {
+		cfg JWTConfig
+		// mu protects keys.
+		mu sync.Mutex
+		// keys lists the configured keys and the keys loaded from the
+		// JWKS file, nil until the file is loaded.
+		keys []*JWTKey
+	}
+
+	// jwtHeader is the JOSE header of a JWT.
+	jwtHeader struct {
+		Alg  string   `json:"alg"`
+		Kid  string   `json:"kid"`
+		Crit []string `json:"crit"`
+	}
+
+	// claimsKey is the context key used to store the JWT claims.
+	claimsKey struct{}
+)
+
+// ErrInvalidJWT is wrapped by the errors returned when a JWT is malformed, its
+// signature does not match any key or its claims are not valid.
+var ErrInvalidJWT = errors.New("invalid JWT")
+
+// algHashes maps the JWS algorithms to their hash function.
+var algHashes = map[string]crypto.Hash{
+	"HS256": crypto.SHA256, "HS384": crypto.SHA384, "HS512": crypto.SHA512,
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"PS256": crypto.SHA256, "PS384": crypto.SHA384, "PS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+	"EdDSA": 0,
+}
+
+// algCurves maps the ECDSA algorithms to their curve.
+var algCurves = map[string]elliptic.Curve{
+	"ES256": elliptic.P256(), "ES384": elliptic.P384(), "ES512": elliptic.P521(),
+}
+
+// NewJWTVerifier returns a verifier that uses the given configuration.
+func NewJWTVerifier(cfg *JWTConfig) *JWTVerifier {
+	v := &JWTVerifier{cfg: *cfg}
+	if v.cfg.ScopesClaim == "" {
+		v.cfg.ScopesClaim = "scope"
+	}
+	if v.cfg.Now == nil {
+		v.cfg.Now = time.Now
+	}
+	return v
+}
+
+// NewJWTAuthFunc returns an AuthJWTFunc that verifies the tokens with the
+// given configuration, validates the token scopes against the scheme required
+// scopes and stores the token claims in the returned context, see
+// JWTClaimsFromContext. Invalid tokens cause a goa.ServiceError named
+// goa.Unauthenticated and tokens that lack the required scopes a
+// goa.ServiceError named goa.InsufficientScope so that the transports respond
+// with the corresponding status codes whether or not the design declares
+// AuthErrors. Other errors, e.g. failing to load the JWKS file, are returned
+// as is.
+func NewJWTAuthFunc(cfg *JWTConfig) AuthJWTFunc {
+	v := NewJWTVerifier(cfg)
+	return func(ctx context.Context, token string, s *JWTScheme) (context.Context, error) {
+		claims, err := v.Verify(token)
+		if err != nil {
+			if errors.Is(err, ErrInvalidJWT) {
+				return ctx, AuthError(err)
+			}
+			return ctx, err
+		}
+		if err := s.Validate(claims.Scopes(v.cfg.ScopesClaim)); err != nil {
+			return ctx, AuthError(err)
+		}
+		return ContextWithJWTClaims(ctx, claims), nil
+	}
+}
+
+// ContextWithJWTClaims returns a copy of ctx that holds the given claims.
+func ContextWithJWTClaims(ctx context.Context, claims JWTClaims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// JWTClaimsFromContext returns the JWT claims stored in ctx by the
+// AuthJWTFunc returned by NewJWTAuthFunc.
+func JWTClaimsFromContext(ctx context.Context) (JWTClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(JWTClaims)
+	return claims, ok
+}
+
+// Verify checks the signature of the given compact serialized JWT with the
+// configured keys, then checks its registered claims and returns its claims.
+// The returned errors wrap ErrInvalidJWT unless the JWKS file cannot be
+// loaded.
+func (v *JWTVerifier) Verify(token string) (JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token")
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalid("malformed header: %s", err)
+	}
+	if len(header.Crit) > 0 {
+		return nil, invalid("unsupported critical headers %s", strings.Join(header.Crit, ", "))
+	}
+	hash, ok := algHashes[header.Alg]
+	if !ok {
+		return nil, invalid("unsupported algorithm %q", header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature: %s", err)
+	}
+	keys, err := v.loadKeys()
+	if err != nil {
+		return nil, err
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, k := range keys {
+		if (k.ID != "" && header.Kid != "" && k.ID != header.Kid) || (k.Algorithm != "" && k.Algorithm != header.Alg) {
+			continue
+		}
+		if verifySignature(header.Alg, hash, k.Key, signed, sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, invalid("signature does not match any key")
+	}
+	var claims JWTClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalid("malformed claims: %s", err)
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Scopes returns the scopes listed in the given claim.
+func (c JWTClaims) Scopes(claim string) []string {
+	switch actual := c[claim].(type) {
+	case string:
+		return strings.Fields(actual)
+	case []any:
+		scopes := make([]string, 0, len(actual))
+		for _, s := range actual {
+			if str, ok := s.(string); ok {
+				scopes = append(scopes, str)
+			}
+		}
+		return scopes
+	}
+	return nil
+}
+
+// loadKeys returns the configured keys and the keys of the JWKS file, the
+// file is loaded on the first call that succeeds.
+func (v *JWTVerifier) loadKeys() ([]*JWTKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.keys != nil {
+		return v.keys, nil
+	}
+	keys := append([]*JWTKey{}, v.cfg.Keys...)
+	if v.cfg.JWKSFile != "" {
+		fks, err := LoadJWKS(v.cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, fks...)
+	}
+	v.keys = keys
+	return keys, nil
+}
+
+// validateClaims checks the registered claims "exp", "nbf", "iat", "iss" and
+// "aud".
+func (v *JWTVerifier) validateClaims(claims JWTClaims) error {
+	now := v.cfg.Now()
+	if _, ok := claims["exp"]; !ok && !v.cfg.AllowNoExpiration {
+		return invalid("token has no expiration")
+	}
+	for _, name := range []string{"exp", "nbf", "iat"} {
+		val, ok := claims[name]
+		if !ok {
+			continue
+		}
+		n, ok := val.(json.Number)
+		if !ok {
+			return invalid("%q claim is not a number", name)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return invalid("%q claim is not a number", name)
+		}
+		t := time.Unix(int64(f), 0)
+		switch name {
+		case "exp":
+			if !now.Before(t.Add(v.cfg.Leeway)) {
+				return invalid("token is expired")
+			}
+		case "nbf":
+			if now.Add(v.cfg.Leeway).Before(t) {
+				return invalid("token is not valid yet")
+			}
+		case "iat":
+			if now.Add(v.cfg.Leeway).Before(t) {
+				return invalid("token is issued in the future")
+			}
+		}
+	}
+	if v.cfg.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+			return invalid("unexpected issuer %q", iss)
+		}
+	}
+	if len(v.cfg.Audience) > 0 {
+		var auds []string
+		switch actual := claims["aud"].(type) {
+		case string:
+			auds = []string{actual}
+		case []any:
+			for _, a := range actual {
+				if s, ok := a.(string); ok {
+					auds = append(auds, s)
+				}
+			}
+		}
+		if !slices.ContainsFunc(auds, func(a string) bool { return slices.Contains(v.cfg.Audience, a) }) {
+			return invalid("unexpected audience")
+		}
+	}
+	return nil
+}
+
+// verifySignature returns true if sig is the signature of signed computed
+// with the given algorithm and the key matching the verification key.
+func verifySignature(alg string, hash crypto.Hash, key any, signed, sig []byte) bool {
+	var digest []byte
+	if hash != 0 {
+		h := hash.New()
+		h.Write(signed)
+		digest = h.Sum(nil)
+	}
+	switch k := key.(type) {
+	case []byte:
+		// Short secrets, e.g. read from an unset environment variable,
+		// would let anyone forge tokens.
+		if !strings.HasPrefix(alg, "HS") || len(k) < hash.Size() {
+			return false
+		}
+		mac := hmac.New(hash.New, k)
+		mac.Write(signed)
+		return hmac.Equal(sig, mac.Sum(nil))
+	case *rsa.PublicKey:
+		switch {
+		case strings.HasPrefix(alg, "RS"):
+			return rsa.VerifyPKCS1v15(k, hash, digest, sig) == nil
+		case strings.HasPrefix(alg, "PS"):
+			return rsa.VerifyPSS(k, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto}) == nil
+		}
+	case *ecdsa.PublicKey:
+		curve, ok := algCurves[alg]
+		if !ok || k.Curve != curve {
+			return false
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	case ed25519.PublicKey:
+		return alg == "EdDSA" && ed25519.Verify(k, signed, sig)
+	}
+	return false
+}
+
+// decodeSegment decodes the given base64url encoded JSON JWT segment into v.
+func decodeSegment(seg string, v any) error {
+	bs, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(strings.NewReader(string(bs)))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// invalid returns an error that wraps ErrInvalidJWT.
+func invalid(format string, v ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidJWT, fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,297 @@
+package security
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+)
+
+var (
+	testNow    = time.Unix(1700000000, 0)
+	testSecret = []byte("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+)
+
+func TestJWTVerifierSignature(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys := []*JWTKey{
+		{ID: "hmac", Key: testSecret},
+		{ID: "rsa", Key: &rsaKey.PublicKey},
+		{ID: "ec", Algorithm: "ES256", Key: &ecKey.PublicKey},
+		{Key: edPub},
+	}
+	claims := map[string]any{"sub": "user", "exp": testNow.Unix() + 60}
+
+	cases := map[string]struct {
+		Token string
+		Error string
+	}{
+		"HS256":              {signJWT(t, "HS256", "hmac", testSecret, claims), ""},
+		"HS512":              {signJWT(t, "HS512", "", testSecret, claims), ""},
+		"RS256":              {signJWT(t, "RS256", "rsa", rsaKey, claims), ""},
+		"PS384":              {signJWT(t, "PS384", "rsa", rsaKey, claims), ""},
+		"ES256":              {signJWT(t, "ES256", "ec", ecKey, claims), ""},
+		"EdDSA":              {signJWT(t, "EdDSA", "", edKey, claims), ""},
+		"wrong secret":       {signJWT(t, "HS256", "hmac", []byte("other"), claims), "invalid JWT: signature does not match any key"},
+		"wrong kid":          {signJWT(t, "RS256", "hmac", rsaKey, claims), "invalid JWT: signature does not match any key"},
+		"algorithm mismatch": {signJWT(t, "ES384", "ec", mustECKey(t, elliptic.P384()), claims), "invalid JWT: signature does not match any key"},
+		"none":               {encodeSegment(t, map[string]any{"alg": "none"}) + "." + encodeSegment(t, claims) + ".", `invalid JWT: unsupported algorithm "none"`},
+		"critical":           {encodeSegment(t, map[string]any{"alg": "HS256", "crit": []string{"b64"}}) + "." + encodeSegment(t, claims) + ".", "invalid JWT: unsupported critical headers b64"},
+		"malformed":          {"abc.def", "invalid JWT: malformed token"},
+	}
+	v := NewJWTVerifier(&JWTConfig{Keys: keys, Now: func() time.Time { return testNow }})
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := v.Verify(c.Token)
+			if c.Error != "" {
+				assert.EqualError(t, err, c.Error)
+				assert.True(t, errors.Is(err, ErrInvalidJWT))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user", got["sub"])
+		})
+	}
+}
+
+func TestJWTVerifierShortSecret(t *testing.T) {
+	claims := map[string]any{"sub": "user", "exp": testNow.Unix() + 60}
+	cases := map[string]struct {
+		Alg    string
+		Secret []byte
+	}{
+		"empty":       {"HS256", []byte{}},
+		"nil":         {"HS256", nil},
+		"short HS256": {"HS256", testSecret[:31]},
+		"short HS512": {"HS512", testSecret[:48]},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			v := NewJWTVerifier(&JWTConfig{Keys: []*JWTKey{{Key: c.Secret}}, Now: func() time.Time { return testNow }})
+			_, err := v.Verify(signJWT(t, c.Alg, "", c.Secret, claims))
+			assert.EqualError(t, err, "invalid JWT: signature does not match any key")
+		})
+	}
+}
+
+func TestJWTVerifierClaims(t *testing.T) {
+	exp := testNow.Unix() + 60
+	cases := map[string]struct {
+		Config *JWTConfig
+		Claims map[string]any
+		Error  string
+	}{
+		"valid":            {&JWTConfig{}, map[string]any{"exp": testNow.Unix() + 60, "nbf": testNow.Unix(), "iat": testNow.Unix()}, ""},
+		"expired":          {&JWTConfig{}, map[string]any{"exp": testNow.Unix()}, "invalid JWT: token is expired"},
+		"no expiration":    {&JWTConfig{}, map[string]any{"sub": "user"}, "invalid JWT: token has no expiration"},
+		"allowed no exp":   {&JWTConfig{AllowNoExpiration: true}, map[string]any{"sub": "user"}, ""},
+		"expired leeway":   {&JWTConfig{Leeway: time.Minute}, map[string]any{"exp": testNow.Unix() - 30}, ""},
+		"not valid yet":    {&JWTConfig{}, map[string]any{"exp": exp, "nbf": testNow.Unix() + 30}, "invalid JWT: token is not valid yet"},
+		"issued in future": {&JWTConfig{Leeway: 10 * time.Second}, map[string]any{"exp": exp, "iat": testNow.Unix() + 30}, "invalid JWT: token is issued in the future"},
+		"invalid exp":      {&JWTConfig{}, map[string]any{"exp": "tomorrow"}, `invalid JWT: "exp" claim is not a number`},
+		"issuer":           {&JWTConfig{Issuer: "goa"}, map[string]any{"exp": exp, "iss": "goa"}, ""},
+		"wrong issuer":     {&JWTConfig{Issuer: "goa"}, map[string]any{"exp": exp, "iss": "other"}, `invalid JWT: unexpected issuer "other"`},
+		"audience":         {&JWTConfig{Audience: []string{"api", "cli"}}, map[string]any{"exp": exp, "aud": "cli"}, ""},
+		"audience array":   {&JWTConfig{Audience: []string{"api"}}, map[string]any{"exp": exp, "aud": []string{"web", "api"}}, ""},
+		"wrong audience":   {&JWTConfig{Audience: []string{"api"}}, map[string]any{"exp": exp, "aud": []string{"web"}}, "invalid JWT: unexpected audience"},
+		"missing audience": {&JWTConfig{Audience: []string{"api"}}, map[string]any{"exp": exp}, "invalid JWT: unexpected audience"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			c.Config.Keys = []*JWTKey{{Key: testSecret}}
+			c.Config.Now = func() time.Time { return testNow }
+			_, err := NewJWTVerifier(c.Config).Verify(signJWT(t, "HS256", "", testSecret, c.Claims))
+			if c.Error == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, c.Error)
+			}
+		})
+	}
+}
+
+func TestNewJWTAuthFunc(t *testing.T) {
+	scheme := &JWTScheme{Name: "jwt", Scopes: []string{"api:read", "api:write"}, RequiredScopes: []string{"api:write"}}
+	cases := map[string]struct {
+		ScopesClaim string
+		Claims      map[string]any
+		Error       string
+	}{
+		"space separated": {"", map[string]any{"scope": "api:read api:write"}, ""},
+		"array":           {"scopes", map[string]any{"scopes": []any{"api:write"}}, ""},
+		"missing scope":   {"", map[string]any{"scope": "api:read"}, "missing scopes: api:write"},
+		"wrong claim":     {"", map[string]any{"scopes": []string{"api:write"}}, "missing scopes: api:write"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			auth := NewJWTAuthFunc(&JWTConfig{Keys: []*JWTKey{{Key: testSecret}}, ScopesClaim: c.ScopesClaim, AllowNoExpiration: true})
+			ctx, err := auth(context.Background(), signJWT(t, "HS256", "", testSecret, c.Claims), scheme)
+			claims, ok := JWTClaimsFromContext(ctx)
+			if c.Error != "" {
+				assert.EqualError(t, err, c.Error)
+				assert.False(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, JWTClaims(c.Claims), claims)
+		})
+	}
+}
+
+func TestNewJWTAuthFuncStatus(t *testing.T) {
+	scheme := &JWTScheme{Name: "jwt", Scopes: []string{"api:write"}, RequiredScopes: []string{"api:write"}}
+	cases := map[string]struct {
+		Token  string
+		Name   string
+		Status int
+	}{
+		"expired":       {signJWT(t, "HS256", "", testSecret, map[string]any{"exp": testNow.Unix() - 60, "scope": "api:write"}), goa.Unauthenticated, http.StatusUnauthorized},
+		"forged":        {signJWT(t, "HS256", "", []byte("fedcba9876543210fedcba9876543210"), map[string]any{"exp": testNow.Unix() + 60}), goa.Unauthenticated, http.StatusUnauthorized},
+		"missing scope": {signJWT(t, "HS256", "", testSecret, map[string]any{"exp": testNow.Unix() + 60}), goa.InsufficientScope, http.StatusForbidden},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			auth := NewJWTAuthFunc(&JWTConfig{Keys: []*JWTKey{{Key: testSecret}}, Now: func() time.Time { return testNow }})
+			_, err := auth(context.Background(), c.Token, scheme)
+			var serr *goa.ServiceError
+			require.True(t, errors.As(err, &serr))
+			assert.Equal(t, c.Name, serr.Name)
+			assert.False(t, serr.Fault)
+			resp := goahttp.NewErrorResponse(context.Background(), err)
+			assert.Equal(t, c.Status, resp.StatusCode())
+		})
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey := mustECKey(t, elliptic.P384())
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	b64 := func(bs []byte) string { return base64.RawURLEncoding.EncodeToString(bs) }
+	set := map[string]any{"keys": []map[string]any{
+		{"kty": "RSA", "kid": "rsa", "alg": "RS256", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec", "crv": "P-384", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": b64(edPub)},
+		{"kty": "oct", "kid": "hmac", "k": b64(testSecret)},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": b64(rsaKey.N.Bytes()), "e": "AQAB"},
+		{"kty": "unknown", "kid": "unknown"},
+	}}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	keys, err := LoadJWKS(path)
+	require.NoError(t, err)
+	require.Len(t, keys, 4)
+	assert.Equal(t, "RS256", keys[0].Algorithm)
+
+	v := NewJWTVerifier(&JWTConfig{JWKSFile: path, AllowNoExpiration: true})
+	for _, tok := range []string{
+		signJWT(t, "RS256", "rsa", rsaKey, nil),
+		signJWT(t, "ES384", "ec", ecKey, nil),
+		signJWT(t, "EdDSA", "ed", edKey, nil),
+		signJWT(t, "HS256", "hmac", testSecret, nil),
+	} {
+		_, err := v.Verify(tok)
+		assert.NoError(t, err)
+	}
+	_, err = v.Verify(signJWT(t, "RS256", "enc", rsaKey, nil))
+	assert.ErrorIs(t, err, ErrInvalidJWT)
+
+	_, err = ParseJWKS([]byte(`{"keys":[{"kty":"EC","crv":"P-256","x":"AQ","y":"AQ"}]}`))
+	assert.ErrorContains(t, err, "invalid JWKS key 0: invalid EC public key")
+	_, err = ParseJWKS([]byte(`{"keys":[{"kty":"RSA","e":"AQAB"}]}`))
+	assert.EqualError(t, err, `invalid JWKS key 0: missing "n" parameter`)
+}
+
+// signJWT returns a JWT with the given claims signed with key using the given
+// algorithm.
+func signJWT(t *testing.T, alg, kid string, key any, claims map[string]any) string {
+	t.Helper()
+	header := map[string]any{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		header["kid"] = kid
+	}
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	hash := algHashes[alg]
+	var digest []byte
+	if hash != 0 {
+		h := hash.New()
+		h.Write([]byte(signed))
+		digest = h.Sum(nil)
+	}
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		var err error
+		if alg[0] == 'P' {
+			sig, err = rsa.SignPSS(rand.Reader, k, hash, digest, nil)
+		} else {
+			sig, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+		}
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		require.NoError(t, err)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+	case ed25519.PrivateKey:
+		var err error
+		sig, err = k.Sign(rand.Reader, []byte(signed), crypto.Hash(0))
+		require.NoError(t, err)
+	default:
+		t.Fatalf("unsupported key type %T", key)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// encodeSegment returns the base64url encoded JSON representation of v.
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+	bs, err := json.Marshal(v)
+	require.NoError(t, err, fmt.Sprintf("%v", v))
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+// mustECKey generates an ECDSA key on the given curve.
+func mustECKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return k
+}
@@ -6,13 +6,27 @@ secure goa endpoint. It supports the following security schemes:
   - API key security using keys.
   - JWT security using JWT tokens.
   - OAuth2 security using OAuth2 tokens.
+  - Mutual TLS security using client certificates.
+
+NewJWTAuthFunc builds an AuthJWTFunc that verifies the JWT signatures with
+HMAC, RSA, ECDSA or EdDSA keys, including keys loaded from JSON Web Key Set
+files, checks the token claims and scopes and stores the claims in the
+request context.
+
+AuthError computes the error returned by the generated endpoint code when a
+request satisfies none of the method security requirements: a goa.ServiceError
+named goa.InsufficientScope if an auth function reported missing scopes with a
+ScopeError and goa.Unauthenticated otherwise.
 */
 package security
 
 import (
 	"context"
-	"fmt"
+	"crypto/x509"
+	"errors"
 	"strings"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -65,6 +79,14 @@ type (
 		Flows []*OAuthFlow
 	}
 
+	// MutualTLSScheme represents the mutual TLS security scheme. It
+	// authenticates clients using the certificates they present during the
+	// TLS handshake.
+	MutualTLSScheme struct {
+		// Name is the scheme name defined in the design.
+		Name string
+	}
+
 	// OAuthFlow represents the OAuth2 flow defined by the scheme.
 	OAuthFlow struct {
 		// Type is the type of grant.
@@ -92,6 +114,25 @@ type (
 	// AuthJWTFunc is the function type that implements the JWT
 	// scheme of using a JWT token.
 	AuthJWTFunc func(ctx context.Context, token string, s *JWTScheme) (context.Context, error)
+
+	// AuthMutualTLSFunc is the function type that implements the mutual
+	// TLS scheme of using client certificates. certs is the client
+	// certificate chain verified during the TLS handshake, leaf first. It
+	// is empty if the client did not present a verified certificate.
+	AuthMutualTLSFunc func(ctx context.Context, certs []*x509.Certificate, s *MutualTLSScheme) (context.Context, error)
+
+	// AuthMTLSFunc is an alias of AuthMutualTLSFunc.
+	AuthMTLSFunc = AuthMutualTLSFunc
+
+	// ScopeError is the error returned by the scheme Validate methods when
+	// the validated scopes do not include all the required scopes.
+	ScopeError struct {
+		// Missing lists the required scopes that are missing.
+		Missing []string
+	}
+
+	// certsKey is the context key used to store the peer certificates.
+	certsKey struct{}
 )
 
 // Validate returns a non-nil error if scopes does not contain all of
@@ -135,5 +176,50 @@ func validateScopes(expected, actual []string) error {
 	if len(missing) == 0 {
 		return nil
 	}
-	return fmt.Errorf("missing scopes: %s", strings.Join(missing, ", "))
+	return &ScopeError{Missing: missing}
+}
+
+// Error returns the error message.
+func (e *ScopeError) Error() string {
+	return "missing scopes: " + strings.Join(e.Missing, ", ")
+}
+
+// ContextWithPeerCertificates returns a copy of ctx that holds the given
+// client certificates. The generated transport code calls it with the
+// certificate chain verified by the server during the TLS handshake.
+func ContextWithPeerCertificates(ctx context.Context, certs []*x509.Certificate) context.Context {
+	return context.WithValue(ctx, certsKey{}, certs)
+}
+
+// PeerCertificatesFromContext returns the client certificates stored in ctx
+// by ContextWithPeerCertificates, nil if there are none.
+func PeerCertificatesFromContext(ctx context.Context) []*x509.Certificate {
+	certs, _ := ctx.Value(certsKey{}).([]*x509.Certificate)
+	return certs
+}
+
+// AuthError returns the error returned by the generated endpoint code when
+// a request satisfies none of the method security requirements given the
+// errors returned by the auth functions, one per requirement. AuthError
+// returns the first error that has a name, e.g. a goa.ServiceError or an
+// error generated from the design, as is. Otherwise it returns a
+// goa.ServiceError named goa.InsufficientScope wrapping the first ScopeError
+// if any, or named goa.Unauthenticated wrapping the last error.
+func AuthError(errs ...error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	for _, err := range errs {
+		var named interface{ GoaErrorName() string }
+		if errors.As(err, &named) {
+			return err
+		}
+	}
+	for _, err := range errs {
+		var serr *ScopeError
+		if errors.As(err, &serr) {
+			return goa.NewServiceError(err, goa.InsufficientScope, false, false, false)
+		}
+	}
+	return goa.NewServiceError(errs[len(errs)-1], goa.Unauthenticated, false, false, false)
 }
@@ -0,0 +1,64 @@
+package security
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestValidateScopes(t *testing.T) {
+	s := &JWTScheme{RequiredScopes: []string{"api:read", "api:write"}}
+	assert.NoError(t, s.Validate([]string{"api:write", "api:read"}))
+	err := s.Validate([]string{"api:read"})
+	var serr *ScopeError
+	require.True(t, errors.As(err, &serr))
+	assert.Equal(t, []string{"api:write"}, serr.Missing)
+	assert.EqualError(t, err, "missing scopes: api:write")
+}
+
+func TestAuthError(t *testing.T) {
+	invalid := errors.New("invalid token")
+	scope := (&OAuth2Scheme{RequiredScopes: []string{"api:write"}}).Validate(nil)
+	designed := goa.PermanentError("unauthorized", "denied")
+	cases := map[string]struct {
+		Errors  []error
+		Name    string
+		Message string
+	}{
+		"unauthenticated":     {[]error{invalid}, goa.Unauthenticated, "invalid token"},
+		"last error":          {[]error{errors.New("no certificate"), invalid}, goa.Unauthenticated, "invalid token"},
+		"insufficient scope":  {[]error{invalid, scope}, goa.InsufficientScope, "missing scopes: api:write"},
+		"wrapped scope error": {[]error{invalid, &wrapError{scope}}, goa.InsufficientScope, "wrapped: missing scopes: api:write"},
+		"designed":            {[]error{scope, designed}, "unauthorized", "denied"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			err := AuthError(c.Errors...)
+			var gerr *goa.ServiceError
+			require.True(t, errors.As(err, &gerr))
+			assert.Equal(t, c.Name, gerr.Name)
+			assert.Equal(t, c.Message, gerr.Message)
+			assert.False(t, gerr.Fault)
+		})
+	}
+	assert.NoError(t, AuthError())
+}
+
+func TestPeerCertificatesFromContext(t *testing.T) {
+	assert.Nil(t, PeerCertificatesFromContext(context.Background()))
+	certs := []*x509.Certificate{{}}
+	ctx := ContextWithPeerCertificates(context.Background(), certs)
+	assert.Equal(t, certs, PeerCertificatesFromContext(ctx))
+}
+
+// wrapError wraps an error.
+type wrapError struct{ err error }
+
+func (e *wrapError) Error() string { return "wrapped: " + e.err.Error() }
+func (e *wrapError) Unwrap() error { return e.err }